@@ -16,12 +16,15 @@ package v1
 	#KindGlobalRoleBinding |
 	#KindGlobalVariable |
 	#KindGlobalSecret |
+	#KindGroup |
 	#KindProject |
 	#KindRole |
 	#KindRoleBinding |
 	#KindSecret |
+	#KindServiceAccount |
 	#KindUser |
-	#KindVariable
+	#KindVariable |
+	#KindWebhook
 
 #KindDashboard:          #Kind & "Dashboard"
 #KindDatasource:         #Kind & "Datasource"
@@ -32,9 +35,12 @@ package v1
 #KindGlobalRoleBinding:  #Kind & "GlobalRoleBinding"
 #KindGlobalVariable:     #Kind & "GlobalVariable"
 #KindGlobalSecret:       #Kind & "GlobalSecret"
+#KindGroup:              #Kind & "Group"
 #KindProject:            #Kind & "Project"
 #KindRole:               #Kind & "Role"
 #KindRoleBinding:        #Kind & "RoleBinding"
 #KindSecret:             #Kind & "Secret"
+#KindServiceAccount:     #Kind & "ServiceAccount"
 #KindUser:               #Kind & "User"
 #KindVariable:           #Kind & "Variable"
+#KindWebhook:            #Kind & "Webhook"
@@ -27,6 +27,8 @@ import (
 	createdAt: time.Time @go(CreatedAt)
 	updatedAt: time.Time @go(UpdatedAt)
 	version:   uint64    @go(Version)
+	labels?: {[string]: string} @go(Labels,map[string]string)
+	annotations?: {[string]: string} @go(Annotations,map[string]string)
 	// Placeholder values required to pass the CUE evaluation, as those
 	// attributes are flagged as mandatory in the (Go) datamodel but
 	// populated by the server in the end.
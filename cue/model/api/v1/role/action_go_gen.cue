@@ -11,10 +11,15 @@ package role
 	#CreateAction |
 	#UpdateAction |
 	#DeleteAction |
+	#QueryAction |
 	#WildcardAction
 
 #ReadAction:     #Action & "read"
 #CreateAction:   #Action & "create"
 #UpdateAction:   #Action & "update"
 #DeleteAction:   #Action & "delete"
+
+// QueryAction allows sending queries to a datasource through the proxy.
+// It is distinct from ReadAction, which only gives access to the definition of the datasource.
+#QueryAction:    #Action & "query"
 #WildcardAction: #Action & "*"
@@ -7,6 +7,7 @@ package role
 #Scope: _ // #enumScope
 
 #enumScope:
+	#AuditScope |
 	#DashboardScope |
 	#DatasourceScope |
 	#EphemeralDashboardScope |
@@ -20,10 +21,13 @@ package role
 	#RoleScope |
 	#RoleBindingScope |
 	#SecretScope |
+	#ServiceAccountScope |
 	#UserScope |
 	#VariableScope |
+	#WebhookScope |
 	#WildcardScope
 
+#AuditScope:              #Scope & "Audit"
 #DashboardScope:          #Scope & "Dashboard"
 #DatasourceScope:         #Scope & "Datasource"
 #EphemeralDashboardScope: #Scope & "EphemeralDashboard"
@@ -37,6 +41,8 @@ package role
 #RoleScope:               #Scope & "Role"
 #RoleBindingScope:        #Scope & "RoleBinding"
 #SecretScope:             #Scope & "Secret"
+#ServiceAccountScope:     #Scope & "ServiceAccount"
 #UserScope:               #Scope & "User"
 #VariableScope:           #Scope & "Variable"
+#WebhookScope:            #Scope & "Webhook"
 #WildcardScope:           #Scope & "*"
@@ -21,6 +21,10 @@ package v1
 	lastName?:       string          @go(LastName)
 	nativeProvider?: #NativeProvider @go(NativeProvider)
 	oauthProviders?: [...#OAuthProvider] @go(OauthProviders,[]OAuthProvider)
+
+	// Groups is the list of groups the user belongs to. It is synced from the claims of the OAuth / OIDC provider
+	// the user logged in with, and it cannot be set through the API.
+	groups?: [...string] @go(Groups,[]string)
 }
 
 #User: _
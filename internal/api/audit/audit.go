@@ -0,0 +1,160 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+// Sink is where the audit events are stored.
+type Sink interface {
+	Write(event *v1.AuditEvent) error
+	// Query returns the events matching the query, the latest first.
+	Query(query *databaseModel.AuditQuery) ([]*v1.AuditEvent, error)
+}
+
+type Auditor interface {
+	// IsEnabled returns true if the audit log is enabled, false otherwise.
+	IsEnabled() bool
+	// Record completes the event with the user found in the context and with the outcome of the change, then stores it in every sink.
+	// ctx can be nil when the change is not coming from an HTTP request.
+	// A failure is only logged: the audit log must not prevent Perses from working.
+	Record(ctx echo.Context, event *v1.AuditEvent, err error)
+	// Query returns the events matching the query, the latest first.
+	Query(query *databaseModel.AuditQuery) ([]*v1.AuditEvent, error)
+}
+
+func New(conf config.AuditConfig, dao databaseModel.DAO, authz authorization.Authorization) Auditor {
+	if !conf.Enable {
+		return &disabledImpl{}
+	}
+	var sinks []Sink
+	// The database comes first, so it is used to answer the queries when it is available.
+	if !conf.Database.Disable {
+		sinks = append(sinks, &databaseSink{dao: dao})
+	}
+	if conf.File != nil {
+		sinks = append(sinks, &fileSink{path: conf.File.Path})
+	}
+	return &auditor{
+		sinks: sinks,
+		authz: authz,
+	}
+}
+
+// NewEvent returns the event describing the action made on the given resource.
+// The project is empty for a global resource. For a project, it is the name of the project itself,
+// so the events about a project can be found with the events about the resources it contains.
+func NewEvent(origin v1.AuditOrigin, action role.Action, kind v1.Kind, project string, name string) *v1.AuditEvent {
+	if kind == v1.KindProject {
+		project = name
+	}
+	return &v1.AuditEvent{
+		Origin:  origin,
+		Action:  action,
+		Kind:    kind,
+		Project: project,
+		Name:    name,
+	}
+}
+
+// GetVersion returns the metadata.version of the entity.
+func GetVersion(entity api.Entity) *uint64 {
+	version := utils.GetMetadataVersion(entity.GetMetadata())
+	return &version
+}
+
+// SetUpdatedVersion sets the versions of the event from the entity resulting of an update.
+func SetUpdatedVersion(event *v1.AuditEvent, entity api.Entity) {
+	event.NewVersion = GetVersion(entity)
+	if *event.NewVersion > 0 {
+		oldVersion := *event.NewVersion - 1
+		event.OldVersion = &oldVersion
+	}
+}
+
+type auditor struct {
+	sinks []Sink
+	authz authorization.Authorization
+}
+
+func (a *auditor) IsEnabled() bool {
+	return true
+}
+
+func (a *auditor) Record(ctx echo.Context, event *v1.AuditEvent, err error) {
+	event.Timestamp = time.Now().UTC()
+	event.Outcome = v1.AuditOutcomeSuccess
+	if err != nil {
+		event.Outcome = v1.AuditOutcomeFailure
+		event.Error = err.Error()
+	}
+	if ctx != nil {
+		a.setUser(ctx, event)
+	}
+	for _, sink := range a.sinks {
+		if writeErr := sink.Write(event); writeErr != nil {
+			logrus.WithError(writeErr).Errorf("unable to record the audit event about the %s of the %s %q", event.Action, event.Kind, event.Name)
+		}
+	}
+}
+
+func (a *auditor) setUser(ctx echo.Context, event *v1.AuditEvent) {
+	username, err := a.authz.GetUsername(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("unable to retrieve the user for the audit event")
+		return
+	}
+	event.User = username
+	providerInfo, err := a.authz.GetProviderInfo(ctx)
+	if err != nil {
+		logrus.WithError(err).Debug("unable to retrieve the provider for the audit event")
+		return
+	}
+	if len(providerInfo.ProviderKind) > 0 {
+		event.Provider = &v1.AuditProvider{
+			Kind: providerInfo.ProviderKind,
+			ID:   providerInfo.ProviderID,
+		}
+	}
+}
+
+func (a *auditor) Query(query *databaseModel.AuditQuery) ([]*v1.AuditEvent, error) {
+	return a.sinks[0].Query(query)
+}
+
+type disabledImpl struct {
+	Auditor
+}
+
+func (d *disabledImpl) IsEnabled() bool {
+	return false
+}
+
+func (d *disabledImpl) Record(_ echo.Context, _ *v1.AuditEvent, _ error) {
+}
+
+func (d *disabledImpl) Query(_ *databaseModel.AuditQuery) ([]*v1.AuditEvent, error) {
+	return []*v1.AuditEvent{}, nil
+}
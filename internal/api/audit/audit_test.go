@@ -0,0 +1,61 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent(v1.AuditOriginAPI, role.CreateAction, v1.KindProject, "", "perses")
+	assert.Equal(t, "perses", event.Project)
+	event = NewEvent(v1.AuditOriginAPI, role.CreateAction, v1.KindGlobalDatasource, "", "prometheus")
+	assert.Empty(t, event.Project)
+
+	SetUpdatedVersion(event, &v1.GlobalDatasource{Metadata: v1.Metadata{Name: "prometheus", Version: 3}})
+	if assert.NotNil(t, event.OldVersion) && assert.NotNil(t, event.NewVersion) {
+		assert.Equal(t, uint64(2), *event.OldVersion)
+		assert.Equal(t, uint64(3), *event.NewVersion)
+	}
+}
+
+func TestAuditor_Record(t *testing.T) {
+	a := New(config.AuditConfig{
+		Enable:   true,
+		Database: config.AuditDatabase{Disable: true},
+		File:     &config.AuditFile{Path: filepath.Join(t.TempDir(), "audit.jsonl")},
+	}, nil, nil)
+	assert.True(t, a.IsEnabled())
+
+	a.Record(nil, NewEvent(v1.AuditOriginProvisioning, role.CreateAction, v1.KindDashboard, "perses", "demo"), nil)
+	a.Record(nil, NewEvent(v1.AuditOriginProvisioning, role.UpdateAction, v1.KindDashboard, "perses", "demo"), errors.New("conflict"))
+
+	events, err := a.Query(&databaseModel.AuditQuery{Project: "perses"})
+	assert.NoError(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, role.UpdateAction, events[0].Action)
+		assert.Equal(t, v1.AuditOutcomeFailure, events[0].Outcome)
+		assert.Equal(t, "conflict", events[0].Error)
+		assert.Equal(t, v1.AuditOutcomeSuccess, events[1].Outcome)
+		assert.False(t, events[1].Timestamp.IsZero())
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"sync"
+
+	databasefile "github.com/perses/perses/internal/api/database/file"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// databaseSink stores the events in the Perses database.
+type databaseSink struct {
+	dao databaseModel.DAO
+}
+
+func (d *databaseSink) Write(event *v1.AuditEvent) error {
+	return d.dao.CreateAuditEvent(event)
+}
+
+func (d *databaseSink) Query(query *databaseModel.AuditQuery) ([]*v1.AuditEvent, error) {
+	return d.dao.QueryAuditEvents(query)
+}
+
+// fileSink appends the events in a file, one JSON document per line.
+type fileSink struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func (f *fileSink) Write(event *v1.AuditEvent) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return databasefile.AppendAuditEvent(f.path, event)
+}
+
+func (f *fileSink) Query(query *databaseModel.AuditQuery) ([]*v1.AuditEvent, error) {
+	return databasefile.ReadAuditEvents(f.path, query)
+}
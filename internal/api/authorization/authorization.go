@@ -18,10 +18,13 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/perses/perses/internal/api/authorization/native"
 	"github.com/perses/perses/internal/api/crypto"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/pkg/model/api/config"
 	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
@@ -41,19 +44,29 @@ type Authorization interface {
 	GetUsername(ctx echo.Context) (string, error)
 	// GetProviderInfo return some information about the provider used to authenticate the user.
 	GetProviderInfo(ctx echo.Context) (crypto.ProviderInfo, error)
+	// CheckCredentials returns an error when the credentials that authenticated the request are not valid anymore,
+	// like an expired JWT, a revoked session or a revoked access token. It is meant for the long-lived requests,
+	// as the middleware only checks the credentials when the request starts.
+	CheckCredentials(ctx echo.Context) error
 	// Middleware returns the middleware function to be used in the echo server.
 	// This middleware is responsible for finding the token in the request, validating it and extracting it in the context.
 	// In case the token is not valid, it will prevent the request from being processed and return an error.
 	// The middleware should be used before any other middleware that requires the user information to be set in the context.
 	Middleware(skipper middleware.Skipper) echo.MiddlewareFunc
 	// GetUserProjects returns the list of the project the user has access to in the context of the role and the scope requested.
+	// It includes the projects where the permission is restricted to some resource names,
+	// so every resource must then be checked with HasResourcePermission.
 	// Be aware that this function cannot be called from an anonymous endpoint.
 	// In case the user information is not found in the context, the implementation should return an error.
 	GetUserProjects(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) ([]string, error)
 	// HasPermission checks if the user has the permission to perform the action on the project with the given scope.
 	// In case the endpoint is anonymous, or the context is empty, it will return true.
 	// In case the user information is not found in the context, the implementation should return false.
+	// The permissions restricted to some resource names are ignored, as they don't grant the permission on every resource of the scope.
 	HasPermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool
+	// HasResourcePermission checks if the user has the permission to perform the action on the resource with the given name,
+	// in the project with the given scope. Unlike HasPermission, it considers the permissions restricted to some resource names.
+	HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, requestName string) bool
 	// GetPermissions returns the permissions of the user found in the context.
 	// Be aware that this function cannot be called from an anonymous endpoint.
 	// In case the user information is not found in the context, the implementation should return an error.
@@ -66,10 +79,18 @@ type Authorization interface {
 	RefreshPermissions() error
 }
 
-func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
+func New(jwtService crypto.JWT, userDAO user.DAO, serviceAccountDAO serviceaccount.DAO, accessTokenDAO accesstoken.DAO, sessionDAO session.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
 	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config) (Authorization, error) {
 	if !conf.Security.EnableAuth {
 		return &disabledImpl{}, nil
 	}
-	return native.New(userDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, conf)
+	return native.New(jwtService, userDAO, serviceAccountDAO, accessTokenDAO, sessionDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, conf)
+}
+
+// SetUserSynchronizer gives the authorization the way to create or update the users authenticated by a trusted reverse proxy.
+// It can't be given to New, as the synchronizer records audit events and the auditor depends on the authorization.
+func SetUserSynchronizer(authz Authorization, synchronizer user.Synchronizer) {
+	if n, ok := authz.(interface{ SetUserSynchronizer(user.Synchronizer) }); ok {
+		n.SetUserSynchronizer(synchronizer)
+	}
 }
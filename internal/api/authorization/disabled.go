@@ -40,6 +40,10 @@ func (r *disabledImpl) GetProviderInfo(_ echo.Context) (crypto.ProviderInfo, err
 	return crypto.ProviderInfo{}, nil
 }
 
+func (r *disabledImpl) CheckCredentials(_ echo.Context) error {
+	return nil
+}
+
 func (r *disabledImpl) Middleware(_ middleware.Skipper) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
@@ -56,6 +60,10 @@ func (r *disabledImpl) HasPermission(_ echo.Context, _ v1Role.Action, _ string,
 	return true
 }
 
+func (r *disabledImpl) HasResourcePermission(_ echo.Context, _ v1Role.Action, _ string, _ v1Role.Scope, _ string) bool {
+	return true
+}
+
 func (r *disabledImpl) GetPermissions(_ echo.Context) (map[string][]*v1Role.Permission, error) {
 	return nil, nil
 }
@@ -0,0 +1,43 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorization
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/internal/api/interface/v1/role"
+	"github.com/perses/perses/pkg/model/api/v1/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// MigrateDefaultRoles updates the default roles stored in the database, when their definition changed since they have
+// been created along with their project.
+// It must be called before the permissions are loaded.
+func MigrateDefaultRoles(roleDAO role.DAO) error {
+	roles, err := roleDAO.List(&role.Query{})
+	if err != nil {
+		return fmt.Errorf("unable to list the roles: %w", err)
+	}
+	for _, entity := range roles {
+		if !utils.MigrateDefaultViewerRole(entity) {
+			continue
+		}
+		entity.Metadata.Update(entity.Metadata)
+		if updateErr := roleDAO.Update(entity); updateErr != nil {
+			return fmt.Errorf("unable to migrate the role %q of the project %q: %w", entity.Metadata.Name, entity.Metadata.Project, updateErr)
+		}
+		logrus.Infof("the role %q of the project %q is now allowed to query the datasources", entity.Metadata.Name, entity.Metadata.Project)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package native
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+// lastUsedRefreshInterval is the minimum time between two updates of the last use of an access token.
+// It avoids writing in the database at every request.
+const lastUsedRefreshInterval = time.Minute
+
+// accessTokenVerifier checks the access tokens sent as Bearer tokens.
+type accessTokenVerifier struct {
+	dao accesstoken.DAO
+	// verified contains the sha256 of the secrets already checked against their bcrypt hash, by token ID.
+	// bcrypt is slow by design, and it would otherwise be paid at every request.
+	verified map[string][sha256.Size]byte
+	mutex    sync.RWMutex
+}
+
+func newAccessTokenVerifier(dao accesstoken.DAO) *accessTokenVerifier {
+	return &accessTokenVerifier{
+		dao:      dao,
+		verified: make(map[string][sha256.Size]byte),
+	}
+}
+
+// verify returns the access token matching the given token, as long as it is not revoked or expired.
+func (v *accessTokenVerifier) verify(token string) (*v1.AccessToken, error) {
+	invalidErr := apiInterface.HandleUnauthorizedError("invalid or expired access token")
+	id, secret, ok := crypto.ParseAccessToken(token)
+	if !ok {
+		return nil, invalidErr
+	}
+	// The token is always read from the database, so a revoked token is rejected right away.
+	stored, err := v.dao.Get(id)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			logrus.WithError(err).Error("unable to read the access token")
+		}
+		return nil, invalidErr
+	}
+	now := time.Now().UTC()
+	if stored.IsExpired(now) {
+		return nil, invalidErr
+	}
+	if !v.checkSecret(stored, secret) {
+		return nil, invalidErr
+	}
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedRefreshInterval {
+		stored.LastUsedAt = &now
+		if updateErr := v.dao.Update(stored); updateErr != nil {
+			logrus.WithError(updateErr).Warningf("unable to record the last use of the access token %q", id)
+		}
+	}
+	return &stored.AccessToken, nil
+}
+
+// check returns an error when the access token with the given ID has been revoked or has expired since it was verified.
+func (v *accessTokenVerifier) check(id string) error {
+	stored, err := v.dao.Get(id)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			logrus.WithError(err).Error("unable to read the access token")
+		}
+		return apiInterface.HandleUnauthorizedError("invalid or expired access token")
+	}
+	if stored.IsExpired(time.Now().UTC()) {
+		return apiInterface.HandleUnauthorizedError("invalid or expired access token")
+	}
+	return nil
+}
+
+func (v *accessTokenVerifier) checkSecret(stored *databaseModel.AccessToken, secret string) bool {
+	sum := sha256.Sum256([]byte(secret))
+	v.mutex.RLock()
+	verifiedSum, ok := v.verified[stored.ID]
+	v.mutex.RUnlock()
+	if ok && verifiedSum == sum {
+		return true
+	}
+	if !crypto.ComparePasswords(stored.Hash, secret) {
+		return false
+	}
+	v.mutex.Lock()
+	v.verified[stored.ID] = sum
+	v.mutex.Unlock()
+	return true
+}
+
+// accessTokenUsername returns the username the owner of an access token is known by in the permission cache.
+func accessTokenUsername(subject v1.Subject) string {
+	if subject.Kind == v1.KindServiceAccount {
+		return v1.ServiceAccountUsername(subject.Name)
+	}
+	return subject.Name
+}
+
+// accessTokenAllows returns false when the request is authenticated with an access token whose permissions don't
+// include the one requested. A token without permissions has all the permissions of its owner.
+func accessTokenAllows(ctx echo.Context, check permissionsCheck) bool {
+	if ctx == nil {
+		return true
+	}
+	token, ok := ctx.Get(utils.ContextKeyAccessToken).(*v1.AccessToken)
+	if !ok || len(token.Permissions) == 0 {
+		return true
+	}
+	permissions := make([]*v1Role.Permission, 0, len(token.Permissions))
+	for i := range token.Permissions {
+		permissions = append(permissions, &token.Permissions[i])
+	}
+	return check(permissions)
+}
@@ -14,22 +14,27 @@
 package native
 
 import (
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api/config"
@@ -38,31 +43,37 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
+func New(jwtService crypto.JWT, userDAO user.DAO, serviceAccountDAO serviceaccount.DAO, accessTokenDAO accesstoken.DAO, sessionDAO session.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
 	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config) (*native, error) {
-	key, err := hex.DecodeString(string(conf.Security.EncryptionKey))
-	if err != nil {
-		return nil, err
-	}
 	return &native{
 		cache:                &cache{},
+		accessTokens:         newAccessTokenVerifier(accessTokenDAO),
 		userDAO:              userDAO,
+		serviceAccountDAO:    serviceAccountDAO,
+		sessionDAO:           sessionDAO,
 		roleDAO:              roleDAO,
 		roleBindingDAO:       roleBindingDAO,
 		globalRoleDAO:        globalRoleDAO,
 		globalRoleBindingDAO: globalRoleBindingDAO,
 		guestPermissions:     conf.Security.Authorization.GuestPermissions,
-		accessKey:            key,
-	}, err
+		jwt:                  jwtService,
+		trustedHeader:        newTrustedHeaderAuthenticator(conf.Security.Authentication.Providers.TrustedHeader),
+	}, nil
 }
 
 // native is expecting a JWT token to extract the user information and validate its permissions.
 type native struct {
-	// The key used to sign the JWT token, it is expected to be the same as the one used in the crypto package.
-	accessKey []byte
+	// jwt verifies the JWT tokens.
+	jwt crypto.JWT
 	// cache is used to store in memory the permissions of all users.
-	cache                *cache
+	cache *cache
+	// accessTokens verifies the access tokens, that are accepted as well as the JWT.
+	accessTokens *accessTokenVerifier
+	// trustedHeader authenticates the users with the headers of a trusted reverse proxy. It is nil when it is not configured.
+	trustedHeader        *trustedHeaderAuthenticator
 	userDAO              user.DAO
+	serviceAccountDAO    serviceaccount.DAO
+	sessionDAO           session.DAO
 	roleDAO              role.DAO
 	roleBindingDAO       rolebinding.DAO
 	globalRoleDAO        globalrole.DAO
@@ -72,6 +83,13 @@ type native struct {
 	mutex sync.RWMutex
 }
 
+// SetUserSynchronizer sets the synchronizer of the users authenticated by a trusted reverse proxy.
+func (n *native) SetUserSynchronizer(synchronizer user.Synchronizer) {
+	if n.trustedHeader != nil {
+		n.trustedHeader.synchronizer = synchronizer
+	}
+}
+
 func (n *native) IsEnabled() bool {
 	return true
 }
@@ -135,17 +153,128 @@ func (n *native) Middleware(skipper middleware.Skipper) echo.MiddlewareFunc {
 			}
 			c.Request().Header.Set("Authorization", fmt.Sprintf("Bearer %s.%s", payloadCookie.Value, signatureCookie.Value))
 		},
-		NewClaimsFunc: func(_ echo.Context) jwt.Claims {
-			return &crypto.JWTClaims{}
+		ParseTokenFunc: func(_ echo.Context, auth string) (any, error) {
+			return n.jwt.ParseAccessToken(auth)
 		},
-		SigningMethod: jwt.SigningMethodHS512.Name,
-		SigningKey:    n.accessKey,
 	}
-	return echojwt.WithConfig(jwtMiddlewareConfig)
+	jwtMiddleware := echojwt.WithConfig(jwtMiddlewareConfig)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		jwtNext := jwtMiddleware(n.checkSession(next))
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+			// The headers of a trusted reverse proxy authenticate the user without any token.
+			if n.trustedHeader != nil {
+				usr, ok, err := n.trustedHeader.userFrom(c.Request())
+				if err != nil {
+					logrus.WithError(err).Error("unable to authenticate the user of the trusted proxy")
+					return apiInterface.HandleUnauthorizedError(err.Error())
+				}
+				if ok {
+					if err := n.trustedHeader.sync(usr); err != nil {
+						logrus.WithError(err).Errorf("unable to sync the user %q authenticated by the trusted proxy", usr.Login)
+						return apiInterface.HandleUnauthorizedError(fmt.Sprintf("unable to sync the user %q: %s", usr.Login, err))
+					}
+					c.Set("user", &jwt.Token{
+						Valid: true,
+						Claims: &crypto.JWTClaims{
+							RegisteredClaims: jwt.RegisteredClaims{Subject: usr.Login},
+							ProviderInfo:     crypto.ProviderInfo{ProviderKind: utils.AuthKindTrustedHeader},
+						},
+					})
+					return next(c)
+				}
+			}
+			// An access token is sent as a Bearer token, like the JWT. Only its prefix tells them apart.
+			token, found := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !found || !crypto.IsAccessToken(token) {
+				return jwtNext(c)
+			}
+			accessToken, err := n.accessTokens.verify(token)
+			if err != nil {
+				return err
+			}
+			c.Set("user", &jwt.Token{
+				Valid: true,
+				Claims: &crypto.JWTClaims{
+					RegisteredClaims: jwt.RegisteredClaims{Subject: accessTokenUsername(accessToken.Subject)},
+					ProviderInfo: crypto.ProviderInfo{
+						ProviderKind: utils.AuthKindAccessToken,
+						ProviderID:   accessToken.ID,
+					},
+				},
+			})
+			c.Set(utils.ContextKeyAccessToken, accessToken)
+			return next(c)
+		}
+	}
+}
+
+// checkSession rejects the JWT belonging to a revoked session. It runs once the JWT is verified.
+func (n *native) checkSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := c.Get("user").(*jwt.Token)
+		if !ok {
+			return next(c)
+		}
+		claims, ok := token.Claims.(*crypto.JWTClaims)
+		// The JWT signed before the sessions were tracked have no session. They are short-lived, so they are accepted.
+		if !ok || len(claims.SessionID) == 0 {
+			return next(c)
+		}
+		if err := n.checkSessionID(claims.SessionID); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// checkSessionID returns an error when the session doesn't exist anymore.
+func (n *native) checkSessionID(sessionID string) error {
+	if _, err := n.sessionDAO.Get(sessionID); err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return apiInterface.HandleUnauthorizedError(crypto.ErrSessionRevoked.Error())
+		}
+		logrus.WithError(err).Errorf("unable to check the session %q", sessionID)
+		return apiInterface.InternalError
+	}
+	return nil
+}
+
+func (n *native) CheckCredentials(ctx echo.Context) error {
+	token, ok := ctx.Get("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+	claims, ok := token.Claims.(*crypto.JWTClaims)
+	if !ok {
+		return nil
+	}
+	switch claims.ProviderKind {
+	case utils.AuthKindTrustedHeader:
+		// The trusted proxy authenticates every request, there is nothing to check once it is accepted.
+		return nil
+	case utils.AuthKindAccessToken:
+		return n.accessTokens.check(claims.ProviderID)
+	}
+	if claims.ExpiresAt != nil && !time.Now().Before(claims.ExpiresAt.Time) {
+		return apiInterface.HandleUnauthorizedError("the token has expired")
+	}
+	if len(claims.SessionID) > 0 {
+		return n.checkSessionID(claims.SessionID)
+	}
+	return nil
 }
 
 func (n *native) GetUserProjects(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) ([]string, error) {
-	if listHasPermission(n.guestPermissions, requestAction, requestScope) {
+	check := func(permissions []*v1Role.Permission) bool {
+		return listHasAnyPermission(permissions, requestAction, requestScope)
+	}
+	if !accessTokenAllows(ctx, check) {
+		return nil, nil
+	}
+	if check(n.guestPermissions) {
 		return []string{v1.WildcardProject}, nil
 	}
 
@@ -159,13 +288,13 @@ func (n *native) GetUserProjects(ctx echo.Context, requestAction v1Role.Action,
 		return nil, apiInterface.InternalError
 	}
 	projectPermission := n.cache.permissions[username]
-	if globalPermissions, ok := projectPermission[v1.WildcardProject]; ok && listHasPermission(globalPermissions, requestAction, requestScope) {
+	if globalPermissions, ok := projectPermission[v1.WildcardProject]; ok && check(globalPermissions) {
 		return []string{v1.WildcardProject}, nil
 	}
 
 	var projects []string
 	for project, permList := range projectPermission {
-		if project != v1.WildcardProject && listHasPermission(permList, requestAction, requestScope) {
+		if project != v1.WildcardProject && check(permList) {
 			projects = append(projects, project)
 		}
 	}
@@ -173,6 +302,14 @@ func (n *native) GetUserProjects(ctx echo.Context, requestAction v1Role.Action,
 }
 
 func (n *native) HasPermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool {
+	return n.hasPermission(ctx, requestProject, newPermissionsCheck(requestAction, requestScope))
+}
+
+func (n *native) HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, requestName string) bool {
+	return n.hasPermission(ctx, requestProject, newResourcePermissionsCheck(requestAction, requestScope, requestName))
+}
+
+func (n *native) hasPermission(ctx echo.Context, requestProject string, check permissionsCheck) bool {
 	// If the context is nil, it means the function is called internally without a request context.
 	// And in this case, we assume we want to bypass the authorization check.
 	if ctx == nil {
@@ -193,14 +330,18 @@ func (n *native) HasPermission(ctx echo.Context, requestAction v1Role.Action, re
 		logrus.Error("no username found in the context, this should not happen in a native RBAC implementation")
 		return false // No username found, cannot check permissions
 	}
+	// An access token can be restricted to fewer permissions than its owner.
+	if !accessTokenAllows(ctx, check) {
+		return false
+	}
 	// Checking default permissions
-	if ok := listHasPermission(n.guestPermissions, requestAction, requestScope); ok {
+	if ok := check(n.guestPermissions); ok {
 		return true
 	}
 	// Checking cached permissions
 	n.mutex.RLock()
 	defer n.mutex.RUnlock()
-	return n.cache.hasPermission(username, requestAction, requestProject, requestScope)
+	return n.cache.check(username, requestProject, check)
 }
 
 func (n *native) GetPermissions(ctx echo.Context) (map[string][]*v1Role.Permission, error) {
@@ -234,12 +375,17 @@ func (n *native) RefreshPermissions() error {
 	return nil
 }
 
-// loadAllPermissions is loading all permissions for all users.
+// loadAllPermissions is loading all permissions for all users and service accounts.
+// A user gets the permissions of a role binding when it is one of its subjects, directly or through one of its groups.
 func (n *native) loadAllPermissions() (usersPermissions, error) {
 	users, err := n.userDAO.List(&user.Query{})
 	if err != nil {
 		return nil, err
 	}
+	serviceAccounts, err := n.serviceAccountDAO.List(&serviceaccount.Query{})
+	if err != nil {
+		return nil, err
+	}
 	roles, err := n.roleDAO.List(&role.Query{})
 	if err != nil {
 		return nil, err
@@ -257,11 +403,20 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 		return nil, err
 	}
 
+	// username -> subjects through which the user can be bound
+	principals := make(map[string][]v1.Subject, len(users)+len(serviceAccounts))
+	for _, usr := range users {
+		principals[usr.Metadata.Name] = userSubjects(usr)
+	}
+	for _, sa := range serviceAccounts {
+		principals[v1.ServiceAccountUsername(sa.Metadata.Name)] = []v1.Subject{{Kind: v1.KindServiceAccount, Name: sa.Metadata.Name}}
+	}
+
 	// Build cache
 	permissionBuild := make(usersPermissions)
-	for _, usr := range users {
+	for username, subjects := range principals {
 		for _, globalRoleBinding := range globalRoleBindings {
-			if globalRoleBinding.Spec.Has(v1.KindUser, usr.Metadata.Name) {
+			if isBound(globalRoleBinding.Spec, subjects) {
 				globalRole := findGlobalRole(globalRoles, globalRoleBinding.Spec.Role)
 				if globalRole == nil {
 					logrus.Warningf("global role %q listed in the global role binding %q does not exist", globalRoleBinding.Spec.Role, globalRoleBinding.Metadata.Name)
@@ -269,15 +424,15 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 				}
 				globalRolePermissions := globalRole.Spec.Permissions
 				for i := range globalRolePermissions {
-					permissionBuild.addEntry(usr.Metadata.Name, v1.WildcardProject, &globalRolePermissions[i])
+					permissionBuild.addEntry(username, v1.WildcardProject, &globalRolePermissions[i])
 				}
 			}
 		}
 	}
 
-	for _, usr := range users {
+	for username, subjects := range principals {
 		for _, roleBinding := range roleBindings {
-			if roleBinding.Spec.Has(v1.KindUser, usr.Metadata.Name) {
+			if isBound(roleBinding.Spec, subjects) {
 				projectRole := findRole(roles, roleBinding.Metadata.Project, roleBinding.Spec.Role)
 				if projectRole == nil {
 					logrus.Warningf("role %q listed in the role binding %s/%s does not exist", roleBinding.Spec.Role, roleBinding.Metadata.Project, roleBinding.Metadata.Name)
@@ -285,7 +440,7 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 				}
 				rolePermissions := projectRole.Spec.Permissions
 				for i := range rolePermissions {
-					permissionBuild.addEntry(usr.Metadata.Name, roleBinding.Metadata.Project, &rolePermissions[i])
+					permissionBuild.addEntry(username, roleBinding.Metadata.Project, &rolePermissions[i])
 				}
 			}
 		}
@@ -15,8 +15,15 @@ package native
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/crypto"
+	"github.com/perses/perses/internal/api/utils"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/stretchr/testify/assert"
@@ -211,6 +218,111 @@ func TestCacheHasPermission(t *testing.T) {
 	}
 }
 
+func TestCacheHasResourcePermission(t *testing.T) {
+	permissions := make(usersPermissions)
+	permissions.addEntry("user0", "project0", &role.Permission{
+		Actions:       []role.Action{role.ReadAction, role.UpdateAction},
+		Scopes:        []role.Scope{role.DashboardScope},
+		ResourceNames: []string{"team-a-*", "shared"},
+	})
+	permissions.addEntry("user1", v1.WildcardProject, &role.Permission{
+		Actions:       []role.Action{role.ReadAction},
+		Scopes:        []role.Scope{role.GlobalDatasourceScope},
+		ResourceNames: []string{"prometheus-*"},
+	})
+	permissions.addEntry("user1", "project1", &role.Permission{
+		Actions: []role.Action{role.WildcardAction},
+		Scopes:  []role.Scope{role.DashboardScope},
+	})
+	restrictedCache := cache{permissions: permissions}
+
+	testSuites := []struct {
+		title          string
+		user           string
+		reqAction      role.Action
+		reqProject     string
+		reqScope       role.Scope
+		reqName        string
+		expectedResult bool
+	}{
+		{
+			title:          "user0 can read a dashboard matching the pattern",
+			user:           "user0",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "team-a-overview",
+			expectedResult: true,
+		},
+		{
+			title:          "user0 can update a dashboard matching exactly the name",
+			user:           "user0",
+			reqAction:      role.UpdateAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "shared",
+			expectedResult: true,
+		},
+		{
+			title:          "user0 can't read a dashboard not matching the patterns",
+			user:           "user0",
+			reqAction:      role.ReadAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "team-b-overview",
+			expectedResult: false,
+		},
+		{
+			title:          "user0 can't delete a dashboard matching the pattern",
+			user:           "user0",
+			reqAction:      role.DeleteAction,
+			reqProject:     "project0",
+			reqScope:       role.DashboardScope,
+			reqName:        "team-a-overview",
+			expectedResult: false,
+		},
+		{
+			title:          "user1 can read a global datasource matching the pattern",
+			user:           "user1",
+			reqAction:      role.ReadAction,
+			reqProject:     v1.WildcardProject,
+			reqScope:       role.GlobalDatasourceScope,
+			reqName:        "prometheus-demo",
+			expectedResult: true,
+		},
+		{
+			title:          "user1 can't read a global datasource not matching the pattern",
+			user:           "user1",
+			reqAction:      role.ReadAction,
+			reqProject:     v1.WildcardProject,
+			reqScope:       role.GlobalDatasourceScope,
+			reqName:        "tempo",
+			expectedResult: false,
+		},
+		{
+			title:          "user1 can read any dashboard with an unrestricted permission",
+			user:           "user1",
+			reqAction:      role.ReadAction,
+			reqProject:     "project1",
+			reqScope:       role.DashboardScope,
+			reqName:        "anything",
+			expectedResult: true,
+		},
+	}
+	for i := range testSuites {
+		test := testSuites[i]
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expectedResult, restrictedCache.hasResourcePermission(test.user, test.reqAction, test.reqProject, test.reqScope, test.reqName))
+		})
+	}
+
+	t.Run("restricted permissions don't grant the permission on every resource", func(t *testing.T) {
+		assert.False(t, restrictedCache.hasPermission("user0", role.ReadAction, "project0", role.DashboardScope))
+		assert.False(t, restrictedCache.hasPermission("user1", role.ReadAction, v1.WildcardProject, role.GlobalDatasourceScope))
+		assert.True(t, restrictedCache.hasPermission("user1", role.ReadAction, "project1", role.DashboardScope))
+	})
+}
+
 func BenchmarkCacheHasPermission(b *testing.B) {
 	benchSuites := []struct {
 		userCount          int
@@ -267,3 +379,74 @@ func BenchmarkCacheHasPermission(b *testing.B) {
 		})
 	}
 }
+
+func TestIsBound(t *testing.T) {
+	spec := v1.RoleBindingSpec{
+		Role: "editor",
+		Subjects: []v1.Subject{
+			{Kind: v1.KindUser, Name: "jane"},
+			{Kind: v1.KindGroup, Name: "sre"},
+			{Kind: v1.KindServiceAccount, Name: "ci"},
+		},
+	}
+	testSuites := []struct {
+		title  string
+		user   *v1.User
+		result bool
+	}{
+		{
+			title:  "user subject",
+			user:   &v1.User{Metadata: v1.Metadata{Name: "jane"}},
+			result: true,
+		},
+		{
+			title:  "member of a group subject",
+			user:   &v1.User{Metadata: v1.Metadata{Name: "john"}, Spec: v1.UserSpec{Groups: []string{"dev", "sre"}}},
+			result: true,
+		},
+		{
+			title:  "member of other groups",
+			user:   &v1.User{Metadata: v1.Metadata{Name: "john"}, Spec: v1.UserSpec{Groups: []string{"dev"}}},
+			result: false,
+		},
+		{
+			title:  "user named like a group subject",
+			user:   &v1.User{Metadata: v1.Metadata{Name: "sre"}},
+			result: false,
+		},
+		{
+			title:  "user named like a service account subject",
+			user:   &v1.User{Metadata: v1.Metadata{Name: "ci"}},
+			result: false,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.result, isBound(spec, userSubjects(test.user)))
+		})
+	}
+}
+
+func TestCheckCredentials(t *testing.T) {
+	n := &native{}
+	newContext := func(claims *crypto.JWTClaims) echo.Context {
+		ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/watch", nil), httptest.NewRecorder())
+		if claims != nil {
+			ctx.Set("user", &jwt.Token{Valid: true, Claims: claims})
+		}
+		return ctx
+	}
+	assert.NoError(t, n.CheckCredentials(newContext(nil)), "an anonymous request has no credentials to check")
+
+	valid := &crypto.JWTClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "alice", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+	assert.NoError(t, n.CheckCredentials(newContext(valid)))
+
+	expired := &crypto.JWTClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "alice", ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))}}
+	assert.ErrorContains(t, n.CheckCredentials(newContext(expired)), "the token has expired")
+
+	trusted := &crypto.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "alice"},
+		ProviderInfo:     crypto.ProviderInfo{ProviderKind: utils.AuthKindTrustedHeader},
+	}
+	assert.NoError(t, n.CheckCredentials(newContext(trusted)))
+}
@@ -39,7 +39,19 @@ type cache struct {
 	permissions usersPermissions
 }
 
+// permissionsCheck tells if a list of permissions allows the request.
+type permissionsCheck func(permissions []*v1Role.Permission) bool
+
 func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool {
+	return c.check(user, requestProject, newPermissionsCheck(requestAction, requestScope))
+}
+
+func (c *cache) hasResourcePermission(user string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, requestName string) bool {
+	return c.check(user, requestProject, newResourcePermissionsCheck(requestAction, requestScope, requestName))
+}
+
+// check runs the check on the global permissions of the user, then on the ones of the project.
+func (c *cache) check(user string, requestProject string, check permissionsCheck) bool {
 	usrPermissions, ok := c.permissions[user]
 	if !ok {
 		return false
@@ -48,7 +60,7 @@ func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestP
 	// Checking global perm first
 	if requestProject != v1.WildcardProject {
 		if globalPermissions, ok := usrPermissions[v1.WildcardProject]; ok {
-			if listHasPermission(globalPermissions, requestAction, requestScope) {
+			if check(globalPermissions) {
 				return true
 			}
 		}
@@ -58,15 +70,46 @@ func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestP
 	if !ok {
 		return false
 	}
-	return listHasPermission(projectPermissions, requestAction, requestScope)
+	return check(projectPermissions)
+}
+
+// newPermissionsCheck returns the check of the permission on every resource of the scope.
+// The permissions restricted to some resource names are ignored.
+func newPermissionsCheck(requestAction v1Role.Action, requestScope v1Role.Scope) permissionsCheck {
+	return func(permissions []*v1Role.Permission) bool {
+		return listHasPermission(permissions, requestAction, requestScope)
+	}
+}
+
+// newResourcePermissionsCheck returns the check of the permission on the resource with the given name.
+func newResourcePermissionsCheck(requestAction v1Role.Action, requestScope v1Role.Scope, requestName string) permissionsCheck {
+	return func(permissions []*v1Role.Permission) bool {
+		return findPermission(permissions, requestAction, requestScope, func(permission *v1Role.Permission) bool {
+			return permission.MatchResourceName(requestName)
+		})
+	}
 }
 
 func listHasPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) bool {
+	return findPermission(permissions, requestAction, requestScope, func(permission *v1Role.Permission) bool {
+		return !permission.IsRestricted()
+	})
+}
+
+// listHasAnyPermission returns true if the permission is granted on at least some resources of the scope.
+func listHasAnyPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) bool {
+	return findPermission(permissions, requestAction, requestScope, func(_ *v1Role.Permission) bool {
+		return true
+	})
+}
+
+// findPermission returns true if one of the permissions grants the action on the scope, and is accepted by the filter.
+func findPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope, filter func(permission *v1Role.Permission) bool) bool {
 	for _, permission := range permissions {
 		for _, action := range permission.Actions {
 			if action == requestAction || action == v1Role.WildcardAction {
 				for _, scope := range permission.Scopes {
-					if scope == requestScope || scope == v1Role.WildcardScope {
+					if (scope == requestScope || scope == v1Role.WildcardScope) && filter(permission) {
 						return true
 					}
 				}
@@ -76,6 +119,26 @@ func listHasPermission(permissions []*v1Role.Permission, requestAction v1Role.Ac
 	return false
 }
 
+// userSubjects returns the subjects a user can be bound through: the user itself and its groups.
+func userSubjects(usr *v1.User) []v1.Subject {
+	subjects := make([]v1.Subject, 0, len(usr.Spec.Groups)+1)
+	subjects = append(subjects, v1.Subject{Kind: v1.KindUser, Name: usr.Metadata.Name})
+	for _, group := range usr.Spec.Groups {
+		subjects = append(subjects, v1.Subject{Kind: v1.KindGroup, Name: group})
+	}
+	return subjects
+}
+
+// isBound is a helper to know if one of the given subjects is a subject of a role binding.
+func isBound(spec v1.RoleBindingSpec, subjects []v1.Subject) bool {
+	for _, subject := range subjects {
+		if spec.Has(subject.Kind, subject.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 // findRole is a helper to find a role in a slice
 func findRole(roles []*v1.Role, project string, name string) *v1.Role {
 	for _, rle := range roles {
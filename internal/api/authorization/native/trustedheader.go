@@ -0,0 +1,125 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package native
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+// trustedUserSyncInterval is the minimum time between two syncs of a user authenticated by a trusted proxy,
+// as long as the headers describing the user don't change. It avoids reading the database at every request.
+const trustedUserSyncInterval = time.Minute
+
+type syncedTrustedUser struct {
+	fingerprint string
+	at          time.Time
+}
+
+// trustedHeaderAuthenticator authenticates the users with the headers set by a trusted reverse proxy.
+type trustedHeaderAuthenticator struct {
+	provider     config.TrustedHeaderProvider
+	prefixes     []netip.Prefix
+	synchronizer user.Synchronizer
+	// synced contains the last sync of each user, with the fingerprint of the headers the user was synced from.
+	synced map[string]syncedTrustedUser
+	mutex  sync.Mutex
+}
+
+func newTrustedHeaderAuthenticator(provider *config.TrustedHeaderProvider) *trustedHeaderAuthenticator {
+	if provider == nil {
+		return nil
+	}
+	return &trustedHeaderAuthenticator{
+		provider: *provider,
+		prefixes: provider.AllowedPrefixes(),
+		synced:   make(map[string]syncedTrustedUser),
+	}
+}
+
+// isTrusted returns true when the request comes directly from one of the allowed networks.
+// The address of the peer is used, and not the X-Forwarded-For header that any client can set.
+func (a *trustedHeaderAuthenticator) isTrusted(r *http.Request) bool {
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range a.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// userFrom returns the user described by the headers of the request.
+// The boolean is false when the request doesn't come from a trusted proxy or when it doesn't describe any user.
+// An error is returned when the login isn't a valid name, as it couldn't be told apart from a service account.
+func (a *trustedHeaderAuthenticator) userFrom(r *http.Request) (user.TrustedUser, bool, error) {
+	if !a.isTrusted(r) {
+		return user.TrustedUser{}, false, nil
+	}
+	login := strings.TrimSpace(r.Header.Get(a.provider.UserHeader))
+	if len(login) == 0 {
+		return user.TrustedUser{}, false, nil
+	}
+	if err := common.ValidateID(login); err != nil {
+		return user.TrustedUser{}, false, fmt.Errorf("invalid login in the header %s: %w", a.provider.UserHeader, err)
+	}
+	usr := user.TrustedUser{
+		Login: login,
+		Email: strings.TrimSpace(r.Header.Get(a.provider.EmailHeader)),
+	}
+	if len(a.provider.GroupsHeader) > 0 {
+		usr.Groups = []string{}
+		for _, group := range strings.Split(r.Header.Get(a.provider.GroupsHeader), a.provider.GroupsSeparator) {
+			if group = strings.TrimSpace(group); len(group) > 0 {
+				usr.Groups = append(usr.Groups, group)
+			}
+		}
+	}
+	return usr, true, nil
+}
+
+// sync creates or updates the user, unless it has been synced recently from the same headers.
+func (a *trustedHeaderAuthenticator) sync(usr user.TrustedUser) error {
+	if a.synchronizer == nil {
+		return errors.New("no synchronizer is set to sync the users authenticated by the trusted proxy")
+	}
+	fingerprint := usr.Email + "\n" + strings.Join(usr.Groups, "\n")
+	now := time.Now()
+	a.mutex.Lock()
+	last, ok := a.synced[usr.Login]
+	a.mutex.Unlock()
+	if ok && last.fingerprint == fingerprint && now.Sub(last.at) < trustedUserSyncInterval {
+		return nil
+	}
+	if err := a.synchronizer.SyncTrustedUser(usr); err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	a.synced[usr.Login] = syncedTrustedUser{fingerprint: fingerprint, at: now}
+	a.mutex.Unlock()
+	return nil
+}
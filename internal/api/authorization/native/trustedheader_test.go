@@ -0,0 +1,146 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package native
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSynchronizer struct {
+	synced []user.TrustedUser
+	err    error
+}
+
+func (s *fakeSynchronizer) SyncTrustedUser(usr user.TrustedUser) error {
+	s.synced = append(s.synced, usr)
+	return s.err
+}
+
+func newTestTrustedHeaderAuthenticator(groupsHeader string) *trustedHeaderAuthenticator {
+	provider := &config.TrustedHeaderProvider{
+		AllowedCIDRs: []string{"10.0.0.0/8", "::1/128"},
+		GroupsHeader: groupsHeader,
+	}
+	_ = provider.Verify()
+	return newTrustedHeaderAuthenticator(provider)
+}
+
+func TestTrustedHeaderUserFrom(t *testing.T) {
+	testSuites := []struct {
+		title        string
+		remoteAddr   string
+		groupsHeader string
+		headers      map[string]string
+		expected     user.TrustedUser
+		ok           bool
+		err          bool
+	}{
+		{
+			title:      "trusted proxy",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"X-Forwarded-User": "alice", "X-Forwarded-Email": "alice@example.org"},
+			expected:   user.TrustedUser{Login: "alice", Email: "alice@example.org"},
+			ok:         true,
+		},
+		{
+			title:      "trusted proxy over IPv6",
+			remoteAddr: "[::1]:4567",
+			headers:    map[string]string{"X-Forwarded-User": "alice"},
+			expected:   user.TrustedUser{Login: "alice"},
+			ok:         true,
+		},
+		{
+			title:      "IPv4 mapped address",
+			remoteAddr: "[::ffff:10.1.2.3]:4567",
+			headers:    map[string]string{"X-Forwarded-User": "alice"},
+			expected:   user.TrustedUser{Login: "alice"},
+			ok:         true,
+		},
+		{
+			title:        "groups",
+			remoteAddr:   "10.1.2.3:4567",
+			groupsHeader: "X-Forwarded-Groups",
+			headers:      map[string]string{"X-Forwarded-User": "alice", "X-Forwarded-Groups": "admins, ,devs"},
+			expected:     user.TrustedUser{Login: "alice", Groups: []string{"admins", "devs"}},
+			ok:           true,
+		},
+		{
+			title:        "no group",
+			remoteAddr:   "10.1.2.3:4567",
+			groupsHeader: "X-Forwarded-Groups",
+			headers:      map[string]string{"X-Forwarded-User": "alice"},
+			expected:     user.TrustedUser{Login: "alice", Groups: []string{}},
+			ok:           true,
+		},
+		{
+			title:      "untrusted peer",
+			remoteAddr: "192.168.1.2:4567",
+			headers:    map[string]string{"X-Forwarded-User": "alice"},
+		},
+		{
+			title:      "no user",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"X-Forwarded-Email": "alice@example.org"},
+		},
+		{
+			title:      "invalid login",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"X-Forwarded-User": "serviceaccount:admin"},
+			err:        true,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+			r.RemoteAddr = test.remoteAddr
+			for key, value := range test.headers {
+				r.Header.Set(key, value)
+			}
+			usr, ok, err := newTestTrustedHeaderAuthenticator(test.groupsHeader).userFrom(r)
+			assert.Equal(t, test.err, err != nil)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, usr)
+		})
+	}
+}
+
+func TestTrustedHeaderSync(t *testing.T) {
+	authenticator := newTestTrustedHeaderAuthenticator("X-Forwarded-Groups")
+	assert.Error(t, authenticator.sync(user.TrustedUser{Login: "alice"}))
+
+	synchronizer := &fakeSynchronizer{}
+	authenticator.synchronizer = synchronizer
+	alice := user.TrustedUser{Login: "alice", Groups: []string{"devs"}}
+	assert.NoError(t, authenticator.sync(alice))
+	// The user is not synced again as long as the headers don't change.
+	assert.NoError(t, authenticator.sync(alice))
+	assert.Len(t, synchronizer.synced, 1)
+
+	alice.Groups = []string{"admins"}
+	assert.NoError(t, authenticator.sync(alice))
+	assert.Len(t, synchronizer.synced, 2)
+
+	// A failed sync is retried at the next request.
+	synchronizer.err = errors.New("already registered with the native provider")
+	bob := user.TrustedUser{Login: "bob"}
+	assert.Error(t, authenticator.sync(bob))
+	assert.Error(t, authenticator.sync(bob))
+	assert.Len(t, synchronizer.synced, 4)
+}
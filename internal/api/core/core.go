@@ -26,8 +26,11 @@ import (
 	"github.com/perses/perses/internal/api/dashboard"
 	"github.com/perses/perses/internal/api/dependency"
 	"github.com/perses/perses/internal/api/discovery"
+	"github.com/perses/perses/internal/api/impl/proxy"
+	"github.com/perses/perses/internal/api/impl/v1/session"
 	"github.com/perses/perses/internal/api/provisioning"
 	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/internal/api/webhook"
 	"github.com/perses/perses/pkg/model/api/config"
 	"github.com/perses/perses/ui"
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,13 +46,26 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 	if dbInitError := persesDAO.Init(); dbInitError != nil {
 		return nil, nil, fmt.Errorf("unable to initialize the database: %w", dbInitError)
 	}
+	if conf.Security.EnableAuth {
+		if migrationErr := authorization.MigrateDefaultRoles(persistenceManager.GetRole()); migrationErr != nil {
+			return nil, nil, fmt.Errorf("unable to migrate the default roles: %w", migrationErr)
+		}
+	}
 	serviceManager, err := dependency.NewServiceManager(persistenceManager, conf)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to initialize the service manager: %w", err)
 	}
-	persesAPI := NewPersesAPI(serviceManager, persistenceManager, conf)
+	sqlPools := proxy.NewSQLPools()
+	persesAPI := NewPersesAPI(serviceManager, persistenceManager, sqlPools, conf)
 	persesFrontend := ui.NewPersesFrontend(conf, serviceManager.GetPlugin())
 	runner := app.NewRunner().WithDefaultHTTPServerAndPrometheusRegisterer(utils.MetricNamespace, registry, registry).SetBanner(banner)
+	// Closes the watch streams when Perses stops.
+	runner.WithTasks(serviceManager.GetWatch())
+	// Queue the changes for the webhooks and send them.
+	runner.WithTasks(webhook.NewRecorder(serviceManager.GetWatch(), persistenceManager.GetWebhook()))
+	// Closes the idle connection pools of the SQL datasources, and all of them when Perses stops.
+	runner.WithTimerTasks(proxy.SQLPoolEvictionInterval, sqlPools)
+	runner.WithTimerTasks(webhook.SendInterval, webhook.NewSender(persistenceManager.GetWebhook(), persistenceManager.GetGlobalSecret(), serviceManager.GetCrypto()))
 
 	// enable cleanup of the ephemeral dashboards once their ttl is reached
 	if conf.EphemeralDashboard.Enable {
@@ -74,6 +90,7 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 	if conf.Security.EnableAuth {
 		rbacTask := authorization.NewPermissionRefreshCronTask(serviceManager.GetAuthorization(), persesDAO)
 		runner.WithTimerTasks(time.Duration(conf.Security.Authorization.CheckLatestUpdateInterval), rbacTask)
+		runner.WithTimerTasks(session.CleanupInterval, session.NewCleaner(persistenceManager.GetSession()))
 	}
 
 	// Extract the plugin archives and load the plugins.
@@ -95,7 +112,9 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 			// let's skip the gzip compression when using the proxy and rely on the datasource behind.
 			return strings.HasPrefix(c.Request().URL.Path, fmt.Sprintf("%s/proxy", conf.APIPrefix)) ||
 				// When serving the plugins from a dev server, we don't want to compress the response since it's already compressed by rsbuild.
-				(conf.Plugin.EnableDev && strings.HasPrefix(c.Request().URL.Path, fmt.Sprintf("%s/plugins", conf.APIPrefix)))
+				(conf.Plugin.EnableDev && strings.HasPrefix(c.Request().URL.Path, fmt.Sprintf("%s/plugins", conf.APIPrefix))) ||
+				// The events of the watch stream must be sent as soon as they occur.
+				c.Request().URL.Path == fmt.Sprintf("%s%s/%s", conf.APIPrefix, utils.APIV1Prefix, utils.PathWatch)
 		}).
 		Middleware(middleware.HandleError()).
 		Middleware(middleware.CheckProject(serviceManager.GetProject()))
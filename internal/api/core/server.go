@@ -20,8 +20,11 @@ import (
 	"github.com/perses/perses/internal/api/dependency"
 	authendpoint "github.com/perses/perses/internal/api/impl/auth"
 	configendpoint "github.com/perses/perses/internal/api/impl/config"
+	"github.com/perses/perses/internal/api/impl/jwks"
 	migrateendpoint "github.com/perses/perses/internal/api/impl/migrate"
 	"github.com/perses/perses/internal/api/impl/proxy"
+	"github.com/perses/perses/internal/api/impl/v1/accesstoken"
+	"github.com/perses/perses/internal/api/impl/v1/audit"
 	"github.com/perses/perses/internal/api/impl/v1/dashboard"
 	"github.com/perses/perses/internal/api/impl/v1/datasource"
 	"github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
@@ -34,12 +37,18 @@ import (
 	"github.com/perses/perses/internal/api/impl/v1/health"
 	"github.com/perses/perses/internal/api/impl/v1/plugin"
 	"github.com/perses/perses/internal/api/impl/v1/project"
+	"github.com/perses/perses/internal/api/impl/v1/reencrypt"
 	"github.com/perses/perses/internal/api/impl/v1/role"
 	"github.com/perses/perses/internal/api/impl/v1/rolebinding"
+	"github.com/perses/perses/internal/api/impl/v1/search"
 	"github.com/perses/perses/internal/api/impl/v1/secret"
+	"github.com/perses/perses/internal/api/impl/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/impl/v1/session"
 	"github.com/perses/perses/internal/api/impl/v1/user"
 	"github.com/perses/perses/internal/api/impl/v1/variable"
 	"github.com/perses/perses/internal/api/impl/v1/view"
+	"github.com/perses/perses/internal/api/impl/v1/watch"
+	"github.com/perses/perses/internal/api/impl/v1/webhook"
 	validateendpoint "github.com/perses/perses/internal/api/impl/validate"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/utils"
@@ -52,38 +61,53 @@ type api struct {
 	apiV1Endpoints         []route.Endpoint
 	apiEndpoints           []route.Endpoint
 	proxyEndpoint          route.Endpoint
+	jwksEndpoint           route.Endpoint
 	authorizationMiddlware echo.MiddlewareFunc
 	apiPrefix              string
 }
 
-func NewPersesAPI(serviceManager dependency.ServiceManager, persistenceManager dependency.PersistenceManager, cfg config.Config) echoUtils.Register {
+func NewPersesAPI(serviceManager dependency.ServiceManager, persistenceManager dependency.PersistenceManager, sqlPools *proxy.SQLPools, cfg config.Config) echoUtils.Register {
 	readonly := cfg.Security.Readonly
 	caseSensitive := persistenceManager.GetPersesDAO().IsCaseSensitive()
 	apiV1Endpoints := []route.Endpoint{
-		dashboard.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		datasource.NewEndpoint(cfg.Datasource, serviceManager.GetDatasource(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		ephemeraldashboard.NewEndpoint(serviceManager.GetEphemeralDashboard(), serviceManager.GetAuthorization(), readonly, caseSensitive, cfg.EphemeralDashboard.Enable),
-		folder.NewEndpoint(serviceManager.GetFolder(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globaldatasource.NewEndpoint(cfg.Datasource, serviceManager.GetGlobalDatasource(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalrole.NewEndpoint(serviceManager.GetGlobalRole(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalrolebinding.NewEndpoint(serviceManager.GetGlobalRoleBinding(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalsecret.NewEndpoint(serviceManager.GetGlobalSecret(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		globalvariable.NewEndpoint(cfg.Variable, serviceManager.GetGlobalVariable(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		audit.NewEndpoint(serviceManager.GetAuditor(), serviceManager.GetAuthorization()),
+		dashboard.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive, !cfg.Dashboard.Revision.Disable),
+		datasource.NewEndpoint(cfg.Datasource, serviceManager.GetDatasource(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		ephemeraldashboard.NewEndpoint(serviceManager.GetEphemeralDashboard(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive, cfg.EphemeralDashboard.Enable),
+		folder.NewEndpoint(serviceManager.GetFolder(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		globaldatasource.NewEndpoint(cfg.Datasource, serviceManager.GetGlobalDatasource(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		globalrole.NewEndpoint(serviceManager.GetGlobalRole(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		globalrolebinding.NewEndpoint(serviceManager.GetGlobalRoleBinding(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		globalsecret.NewEndpoint(serviceManager.GetGlobalSecret(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		globalvariable.NewEndpoint(cfg.Variable, serviceManager.GetGlobalVariable(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
 		health.NewEndpoint(serviceManager.GetHealth()),
 		plugin.NewEndpoint(serviceManager.GetPlugin(), cfg.Plugin.EnableDev),
-		project.NewEndpoint(serviceManager.GetProject(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		role.NewEndpoint(serviceManager.GetRole(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		rolebinding.NewEndpoint(serviceManager.GetRoleBinding(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		secret.NewEndpoint(serviceManager.GetSecret(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		user.NewEndpoint(serviceManager.GetUser(), serviceManager.GetAuthorization(), cfg.Security.Authentication.DisableSignUp, readonly, caseSensitive),
-		variable.NewEndpoint(cfg.Variable, serviceManager.GetVariable(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		project.NewEndpoint(serviceManager.GetProject(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		reencrypt.NewEndpoint(persistenceManager.GetSecret(), persistenceManager.GetGlobalSecret(), serviceManager.GetCrypto(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), serviceManager.GetWatch(), readonly),
+		role.NewEndpoint(serviceManager.GetRole(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		rolebinding.NewEndpoint(serviceManager.GetRoleBinding(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		search.NewEndpoint(serviceManager.GetSearch(), serviceManager.GetAuthorization()),
+		secret.NewEndpoint(serviceManager.GetSecret(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		serviceaccount.NewEndpoint(serviceManager.GetServiceAccount(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+		user.NewEndpoint(serviceManager.GetUser(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), cfg.Security.Authentication.DisableSignUp, readonly, caseSensitive),
+		variable.NewEndpoint(cfg.Variable, serviceManager.GetVariable(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
 		view.NewEndpoint(serviceManager.GetView(), serviceManager.GetAuthorization(), serviceManager.GetDashboard()),
+		watch.NewEndpoint(serviceManager.GetWatch(), serviceManager.GetAuthorization()),
+		webhook.NewEndpoint(serviceManager.GetWebhook(), serviceManager.GetAuthorization(), serviceManager.GetAuditor(), readonly, caseSensitive),
+	}
+	// The access tokens and the sessions are only meaningful when the requests are authenticated.
+	if cfg.Security.EnableAuth {
+		apiV1Endpoints = append(apiV1Endpoints,
+			accesstoken.NewEndpoint(serviceManager.GetAccessToken(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+			session.NewEndpoint(serviceManager.GetSession(), serviceManager.GetAuthorization(), caseSensitive),
+		)
 	}
 
 	authEndpoint, err := authendpoint.New(
 		persistenceManager.GetUser(),
 		serviceManager.GetJWT(),
 		serviceManager.GetAuthorization(),
+		serviceManager.GetAuditor(),
 		cfg.Security.Authentication.Providers,
 		cfg.Security.EnableAuth,
 		cfg.APIPrefix,
@@ -101,7 +125,8 @@ func NewPersesAPI(serviceManager dependency.ServiceManager, persistenceManager d
 		apiV1Endpoints: apiV1Endpoints,
 		apiEndpoints:   apiEndpoints,
 		proxyEndpoint: proxy.New(cfg.Datasource, persistenceManager.GetDashboard(), persistenceManager.GetSecret(), persistenceManager.GetGlobalSecret(),
-			persistenceManager.GetDatasource(), persistenceManager.GetGlobalDatasource(), serviceManager.GetCrypto(), serviceManager.GetAuthorization()),
+			persistenceManager.GetDatasource(), persistenceManager.GetGlobalDatasource(), serviceManager.GetCrypto(), serviceManager.GetAuthorization(), sqlPools),
+		jwksEndpoint: jwks.New(serviceManager.GetJWT()),
 		authorizationMiddlware: serviceManager.GetAuthorization().Middleware(func(_ echo.Context) bool {
 			return !cfg.Security.EnableAuth
 		}),
@@ -164,5 +189,7 @@ func (a *api) collectRoutes() []*route.Group {
 	}
 	proxyGroup := &route.Group{Path: a.apiPrefix + "/proxy"}
 	a.proxyEndpoint.CollectRoutes(proxyGroup)
-	return []*route.Group{apiGroup, apiV1Group, proxyGroup}
+	wellKnownGroup := &route.Group{Path: a.apiPrefix + utils.WellKnownPrefix}
+	a.jwksEndpoint.CollectRoutes(wellKnownGroup)
+	return []*route.Group{apiGroup, apiV1Group, proxyGroup, wellKnownGroup}
 }
@@ -0,0 +1,60 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// accessTokenSecretSize is the number of random bytes in the secret part of an access token.
+const accessTokenSecretSize = 32
+
+// NewAccessToken returns a new access token for the given ID, and its secret part.
+// The token looks like `perses_<id>_<secret>`. Only the secret must be hashed before being stored,
+// as bcrypt ignores what is after the 72nd byte.
+func NewAccessToken(id string) (string, string, error) {
+	b := make([]byte, accessTokenSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret := hex.EncodeToString(b)
+	return v1.AccessTokenPrefix + id + "_" + secret, secret, nil
+}
+
+// IsAccessToken returns true if the given bearer token looks like an access token and not like a JWT.
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, v1.AccessTokenPrefix)
+}
+
+// ParseAccessToken splits an access token into its ID and its secret.
+// The boolean is false when the token is malformed.
+func ParseAccessToken(token string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(token, v1.AccessTokenPrefix)
+	if !ok {
+		return "", "", false
+	}
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(secret) != 2*accessTokenSecretSize {
+		return "", "", false
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return "", "", false
+	}
+	return id, secret, true
+}
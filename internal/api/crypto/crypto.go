@@ -21,8 +21,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 )
@@ -32,125 +35,195 @@ import (
 type Crypto interface {
 	Encrypt(spec *modelV1.SecretSpec) error
 	Decrypt(spec *modelV1.SecretSpec) error
+	// Reencrypt encrypts again the fields of the spec that have been encrypted with a previous key, or without the KMS
+	// when it is configured. It returns false when every field is already encrypted the way Encrypt would do it,
+	// and the spec is left untouched.
+	Reencrypt(spec *modelV1.SecretSpec) (bool, error)
 }
 
-func New(security config.Security) (Crypto, JWT, error) {
+func New(security config.Security, sessionDAO session.DAO) (Crypto, JWT, error) {
 	key, err := hex.DecodeString(string(security.EncryptionKey))
 	if err != nil {
 		return nil, nil, err
 	}
-
-	aesBlock, err := aes.NewCipher(key)
+	c, err := newCrypto(security, key)
 	if err != nil {
 		return nil, nil, err
 	}
-	return &crypto{
-			key:   key,
-			block: aesBlock,
-		},
+	// Without signing keys, the tokens are signed with HS512 and keys derived from the encryption key.
+	accessKeys := newHMACKeySet(key)
+	refreshKeys := newHMACKeySet(append(key, []byte("-refresh")...))
+	if len(security.Authentication.SigningKeys) > 0 {
+		// The type of the token tells an access token and a refresh token apart, as they are signed by the same keys.
+		accessKeys, err = newAsymmetricKeySet(security.Authentication.SigningKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+		refreshKeys = accessKeys
+	}
+	return c,
 		&jwtImpl{
-			accessKey:       key,
-			refreshKey:      append(key, []byte("-refresh")...),
+			accessKeys:      accessKeys,
+			refreshKeys:     refreshKeys,
 			accessTokenTTL:  time.Duration(security.Authentication.AccessTokenTTL),
 			refreshTokenTTL: time.Duration(security.Authentication.RefreshTokenTTL),
 			cookieConfig:    security.Cookie,
+			sessionDAO:      sessionDAO,
 		}, nil
 }
 
+// keyIDSeparator separates the id of the key from the encrypted data. It can't be part of the base64 URL encoding.
+const keyIDSeparator = ":"
+
+// crypto encrypts the data with the current key, and decrypts it with the key it has been encrypted with.
+// The id of the key is stored as a prefix of the encrypted data. The data encrypted with a key without id has no prefix.
+// When a key provider is set, the data is encrypted with envelope encryption instead, and the keys are only used to
+// decrypt the data encrypted before.
 type crypto struct {
-	key   []byte
-	block cipher.Block
+	keyID       string
+	blocks      map[string]cipher.Block
+	keyProvider KeyProvider
+	// dataKeys contains the data keys unwrapped by the key provider.
+	dataKeys *lru.Cache[string, cipher.AEAD]
 }
 
-func (c *crypto) Encrypt(spec *modelV1.SecretSpec) error {
-	basicAuth := spec.BasicAuth
-	if basicAuth != nil {
-		encryptedPassword, err := c.encrypt(basicAuth.Password)
+func newCrypto(security config.Security, key []byte) (*crypto, error) {
+	c := &crypto{
+		keyID:  security.EncryptionKeyID,
+		blocks: make(map[string]cipher.Block, len(security.PreviousEncryptionKeys)+1),
+	}
+	if err := c.addKey(security.EncryptionKeyID, key); err != nil {
+		return nil, err
+	}
+	for _, previousKey := range security.PreviousEncryptionKeys {
+		decodedKey, err := hex.DecodeString(string(previousKey.Key))
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if err := c.addKey(previousKey.ID, decodedKey); err != nil {
+			return nil, err
 		}
-		basicAuth.Password = encryptedPassword
 	}
-
-	authorization := spec.Authorization
-	if authorization != nil {
-		encryptedCredentials, err := c.encrypt(authorization.Credentials)
+	if security.KMS != nil {
+		keyProvider, err := newKeyProvider(security.KMS)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		authorization.Credentials = encryptedCredentials
+		c.keyProvider = keyProvider
+		c.dataKeys = newDataKeyCache()
 	}
-	oauth := spec.OAuth
-	if oauth != nil {
-		encryptedClientID, err := c.encrypt(oauth.ClientID)
-		if err != nil {
-			return err
+	return c, nil
+}
+
+func (c *crypto) addKey(id string, key []byte) error {
+	if _, exist := c.blocks[id]; exist {
+		return fmt.Errorf("several encryption keys exist with the same id %q", id)
+	}
+	aesBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	c.blocks[id] = aesBlock
+	return nil
+}
+
+func (c *crypto) Encrypt(spec *modelV1.SecretSpec) error {
+	if c.keyProvider != nil {
+		return c.encryptEnvelope(spec)
+	}
+	return transform(spec, c.encrypt)
+}
+
+func (c *crypto) Decrypt(spec *modelV1.SecretSpec) error {
+	return transform(spec, func(value string) (string, error) {
+		if isEnvelope(value) {
+			return c.decryptEnvelope(value)
 		}
-		oauth.ClientID = encryptedClientID
+		return c.decrypt(value)
+	})
+}
 
-		encryptedClientSecret, err := c.encrypt(oauth.ClientSecret)
-		if err != nil {
-			return err
+func (c *crypto) Reencrypt(spec *modelV1.SecretSpec) (bool, error) {
+	needed := false
+	// The spec is checked first, so it is only modified when at least one field must be re-encrypted.
+	_ = transform(spec, func(value string) (string, error) {
+		if len(value) > 0 && !c.isCurrent(value) {
+			needed = true
 		}
-		oauth.ClientSecret = encryptedClientSecret
+		return value, nil
+	})
+	if !needed {
+		return false, nil
+	}
+	if err := c.Decrypt(spec); err != nil {
+		return false, err
 	}
+	return true, c.Encrypt(spec)
+}
 
-	tlsConfig := spec.TLSConfig
-	if tlsConfig != nil {
-		encryptedKey, err := c.encrypt(tlsConfig.Key)
-		if err != nil {
-			return err
-		}
-		tlsConfig.Key = encryptedKey
+// isCurrent returns true if the data is encrypted the way Encrypt would encrypt it.
+func (c *crypto) isCurrent(encrypted string) bool {
+	if c.keyProvider != nil {
+		return isEnvelope(encrypted)
 	}
-	return nil
+	return !isEnvelope(encrypted) && getKeyID(encrypted) == c.keyID
 }
 
-func (c *crypto) Decrypt(spec *modelV1.SecretSpec) error {
+// transform replaces every sensitive field of the spec by the result of the function.
+func transform(spec *modelV1.SecretSpec, f func(string) (string, error)) error {
 	basicAuth := spec.BasicAuth
 	if basicAuth != nil {
-		decryptedPassword, err := c.decrypt(basicAuth.Password)
+		password, err := f(basicAuth.Password)
 		if err != nil {
 			return err
 		}
-		basicAuth.Password = decryptedPassword
+		basicAuth.Password = password
 	}
 
 	authorization := spec.Authorization
 	if authorization != nil {
-		decryptedCredentials, err := c.decrypt(authorization.Credentials)
+		credentials, err := f(authorization.Credentials)
 		if err != nil {
 			return err
 		}
-		authorization.Credentials = decryptedCredentials
+		authorization.Credentials = credentials
 	}
 
 	oauth := spec.OAuth
 	if oauth != nil {
-		decryptedClientID, err := c.decrypt(oauth.ClientID)
+		clientID, err := f(oauth.ClientID)
 		if err != nil {
 			return err
 		}
-		oauth.ClientID = decryptedClientID
+		oauth.ClientID = clientID
 
-		decryptedClientSecret, err := c.decrypt(oauth.ClientSecret)
+		clientSecret, err := f(oauth.ClientSecret)
 		if err != nil {
 			return err
 		}
-		oauth.ClientSecret = decryptedClientSecret
+		oauth.ClientSecret = clientSecret
 	}
 
 	tlsConfig := spec.TLSConfig
 	if tlsConfig != nil {
-		decryptedKey, err := c.decrypt(tlsConfig.Key)
+		key, err := f(tlsConfig.Key)
 		if err != nil {
 			return err
 		}
-		tlsConfig.Key = decryptedKey
+		tlsConfig.Key = key
 	}
 	return nil
 }
 
+// getKeyID returns the id of the key used to encrypt the data.
+func getKeyID(encrypted string) string {
+	keyID, _, found := strings.Cut(encrypted, keyIDSeparator)
+	if !found {
+		return ""
+	}
+	return keyID
+}
+
 func (c *crypto) encrypt(stringToEncrypt string) (string, error) {
 	if len(stringToEncrypt) == 0 {
 		return "", nil
@@ -163,16 +236,28 @@ func (c *crypto) encrypt(stringToEncrypt string) (string, error) {
 	}
 
 	// TODO use AEAD instead of CFB as recommended by Go
-	stream := cipher.NewCFBEncrypter(c.block, iv) //nolint: staticcheck
+	stream := cipher.NewCFBEncrypter(c.blocks[c.keyID], iv) //nolint: staticcheck
 	stream.XORKeyStream(cipherText[aes.BlockSize:], plainText)
 
-	return base64.URLEncoding.EncodeToString(cipherText), nil
+	encoded := base64.URLEncoding.EncodeToString(cipherText)
+	if len(c.keyID) == 0 {
+		return encoded, nil
+	}
+	return c.keyID + keyIDSeparator + encoded, nil
 }
 
 func (c *crypto) decrypt(stringToDecrypt string) (string, error) {
 	if len(stringToDecrypt) == 0 {
 		return "", nil
 	}
+	keyID := getKeyID(stringToDecrypt)
+	block, ok := c.blocks[keyID]
+	if !ok {
+		return "", fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	if len(keyID) > 0 {
+		stringToDecrypt = stringToDecrypt[len(keyID)+len(keyIDSeparator):]
+	}
 	cipherText, err := base64.URLEncoding.DecodeString(stringToDecrypt)
 	if err != nil {
 		return "", err
@@ -184,7 +269,7 @@ func (c *crypto) decrypt(stringToDecrypt string) (string, error) {
 	cipherText = cipherText[aes.BlockSize:]
 
 	// TODO use AEAD instead of CFB as recommended by Go
-	stream := cipher.NewCFBDecrypter(c.block, iv) //nolint: staticcheck
+	stream := cipher.NewCFBDecrypter(block, iv) //nolint: staticcheck
 
 	// XORKeyStream can work in-place if the two arguments are the same.
 	stream.XORKeyStream(cipherText, cipherText)
@@ -0,0 +1,97 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	oldKey = "=tW$56zytgB&3jN2E%7-+qrGZE?v6LCc"
+	newKey = "e=dz;`M'5Pjvy^Sq3FVBkTC@N9?H/gua"
+)
+
+func newTestCrypto(t *testing.T, security config.Security) *crypto {
+	key, err := hex.DecodeString(string(security.EncryptionKey))
+	assert.NoError(t, err)
+	c, err := newCrypto(security, key)
+	assert.NoError(t, err)
+	return c
+}
+
+func hexKey(key string) secret.Hidden {
+	return secret.Hidden(hex.EncodeToString([]byte(key)))
+}
+
+func newSpec() *modelV1.SecretSpec {
+	return &modelV1.SecretSpec{
+		BasicAuth: &secret.BasicAuth{Username: "admin", Password: "password"},
+		TLSConfig: &secret.TLSConfig{Key: "key"},
+	}
+}
+
+func TestCrypto_KeyRotation(t *testing.T) {
+	// Data encrypted before the keys got an id.
+	legacy := newTestCrypto(t, config.Security{EncryptionKey: hexKey(oldKey)})
+	legacySpec := newSpec()
+	assert.NoError(t, legacy.Encrypt(legacySpec))
+	assert.NotContains(t, legacySpec.BasicAuth.Password, keyIDSeparator)
+
+	// Then the key is rotated a first time.
+	rotated := newTestCrypto(t, config.Security{
+		EncryptionKey:          hexKey(newKey),
+		EncryptionKeyID:        "2025",
+		PreviousEncryptionKeys: []config.EncryptionKey{{Key: hexKey(oldKey)}},
+	})
+	spec := newSpec()
+	assert.NoError(t, rotated.Encrypt(spec))
+	assert.True(t, strings.HasPrefix(spec.BasicAuth.Password, "2025:"))
+	assert.True(t, strings.HasPrefix(spec.TLSConfig.Key, "2025:"))
+	assert.NoError(t, rotated.Decrypt(spec))
+	assert.Equal(t, newSpec(), spec)
+
+	// The legacy data can still be decrypted, and is re-encrypted with the current key.
+	reencryptedSpec := newSpec()
+	assert.NoError(t, legacy.Encrypt(reencryptedSpec))
+	done, err := rotated.Reencrypt(reencryptedSpec)
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.True(t, strings.HasPrefix(reencryptedSpec.BasicAuth.Password, "2025:"))
+	encrypted := *reencryptedSpec.BasicAuth
+	done, err = rotated.Reencrypt(reencryptedSpec)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, encrypted, *reencryptedSpec.BasicAuth)
+	assert.NoError(t, rotated.Decrypt(reencryptedSpec))
+	assert.Equal(t, newSpec(), reencryptedSpec)
+
+	// Once the previous key is removed, the data it encrypted can't be decrypted anymore.
+	withoutPreviousKey := newTestCrypto(t, config.Security{EncryptionKey: hexKey(newKey), EncryptionKeyID: "2025"})
+	assert.ErrorContains(t, withoutPreviousKey.Decrypt(legacySpec), "unknown encryption key \"\"")
+}
+
+func TestNewCrypto_DuplicateKeyID(t *testing.T) {
+	_, err := newCrypto(config.Security{
+		EncryptionKeyID:        "2025",
+		PreviousEncryptionKeys: []config.EncryptionKey{{ID: "2025", Key: hexKey(oldKey)}},
+	}, []byte(newKey))
+	assert.ErrorContains(t, err, "several encryption keys exist with the same id \"2025\"")
+}
@@ -14,13 +14,20 @@
 package crypto
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -28,6 +35,11 @@ const (
 	CookieKeyJWTSignature = "jwtSignature"
 	CookieKeyRefreshToken = "jwtRefreshToken"
 	cookiePath            = "/"
+	// TokenTypeAccess is the `typ` header of the access tokens (RFC 9068).
+	// The services verifying the tokens with the published keys must check it, so they don't accept a refresh token.
+	TokenTypeAccess = "at+jwt"
+	// TokenTypeRefresh is the `typ` header of the refresh tokens.
+	TokenTypeRefresh = "refresh+jwt"
 )
 
 type ProviderInfo struct {
@@ -38,25 +50,46 @@ type ProviderInfo struct {
 type JWTClaims struct {
 	jwt.RegisteredClaims
 	ProviderInfo
+	// SessionID is the ID of the session the token belongs to. Both tokens are rejected once the session is revoked.
+	SessionID string `json:"sid,omitempty"`
 }
 
-func signedToken(login string, providerInfo ProviderInfo, notBefore time.Time, expireAt time.Time, key []byte) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512, &JWTClaims{
+// ErrSessionRevoked is returned when the refresh token belongs to a session that doesn't exist anymore.
+var ErrSessionRevoked = errors.New("the session has been revoked")
+
+func signedToken(login string, providerInfo ProviderInfo, sessionID string, notBefore time.Time, expireAt time.Time, keys *keySet, typ string) (string, error) {
+	return keys.sign(&JWTClaims{
 		ProviderInfo: providerInfo,
+		SessionID:    sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   login,
 			ExpiresAt: jwt.NewNumericDate(expireAt),
 			NotBefore: jwt.NewNumericDate(notBefore),
 		},
-	})
-	// The type of the key depends on the signature method.
-	// See https://golang-jwt.github.io/jwt/usage/signing_methods/#signing-methods-and-key-types.
-	return token.SignedString(key)
+	}, typ)
+}
+
+// parseToken verifies the token with the given keys and checks its type.
+func parseToken(token string, keys *keySet, typ string) (*jwt.Token, error) {
+	parsedToken, err := jwt.ParseWithClaims(token, &JWTClaims{}, keys.keyFunc, jwt.WithValidMethods(keys.validMethods()))
+	if err != nil {
+		return nil, err
+	}
+	tokenType, _ := parsedToken.Header["typ"].(string)
+	// The access tokens signed before the type was set are accepted until they expire, the refresh tokens are not.
+	if tokenType != typ && (typ == TokenTypeRefresh || tokenType == TokenTypeRefresh) {
+		return nil, fmt.Errorf("unexpected token type %q", tokenType)
+	}
+	return parsedToken, nil
 }
 
 type JWT interface {
-	SignedAccessToken(login string, providerInfo ProviderInfo) (string, error)
-	SignedRefreshToken(login string, providerInfo ProviderInfo) (string, error)
+	// OpenSession records a new session for the user. The ID of the session is then given to sign the tokens.
+	OpenSession(login string, providerInfo ProviderInfo, userAgent string, remoteAddress string) (*modelV1.Session, error)
+	// RevokeSession removes the session, so its tokens are rejected.
+	RevokeSession(sessionID string) error
+	SignedAccessToken(login string, providerInfo ProviderInfo, sessionID string) (string, error)
+	SignedRefreshToken(login string, providerInfo ProviderInfo, sessionID string) (string, error)
 	// CreateAccessTokenCookie will create two different cookies that contain a piece of the token.
 	// As a reminder, a JWT token has the following structure: header.payload.signature
 	// The first cookie will contain the struct header.payload that can then be manipulated by Javascript
@@ -65,25 +98,53 @@ type JWT interface {
 	DeleteAccessTokenCookie() (*http.Cookie, *http.Cookie)
 	CreateRefreshTokenCookie(refreshToken string) *http.Cookie
 	DeleteRefreshTokenCookie() *http.Cookie
+	// ParseAccessToken checks the signature, the expiry and the type of the access token.
+	ParseAccessToken(token string) (*jwt.Token, error)
+	// ValidateRefreshToken checks the signature and the expiry of the refresh token, then that its session is not revoked.
 	ValidateRefreshToken(token string) (*JWTClaims, error)
+	// JWKS returns the public keys verifying the tokens. It is empty when the tokens are signed with HS512.
+	JWKS() jose.JSONWebKeySet
 }
 
 type jwtImpl struct {
-	accessKey       []byte
-	refreshKey      []byte
+	accessKeys      *keySet
+	refreshKeys     *keySet
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 	cookieConfig    config.Cookie
+	sessionDAO      session.DAO
+}
+
+func (j *jwtImpl) OpenSession(login string, providerInfo ProviderInfo, userAgent string, remoteAddress string) (*modelV1.Session, error) {
+	now := time.Now().UTC()
+	sess := &modelV1.Session{
+		ID:            uuid.NewString(),
+		Username:      login,
+		ProviderKind:  providerInfo.ProviderKind,
+		ProviderID:    providerInfo.ProviderID,
+		UserAgent:     userAgent,
+		RemoteAddress: remoteAddress,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(j.refreshTokenTTL),
+	}
+	if err := j.sessionDAO.Create(sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
 }
 
-func (j *jwtImpl) SignedAccessToken(login string, providerInfo ProviderInfo) (string, error) {
+func (j *jwtImpl) RevokeSession(sessionID string) error {
+	return j.sessionDAO.Delete(sessionID)
+}
+
+func (j *jwtImpl) SignedAccessToken(login string, providerInfo ProviderInfo, sessionID string) (string, error) {
 	now := time.Now()
-	return signedToken(login, providerInfo, now, now.Add(j.accessTokenTTL), j.accessKey)
+	return signedToken(login, providerInfo, sessionID, now, now.Add(j.accessTokenTTL), j.accessKeys, TokenTypeAccess)
 }
 
-func (j *jwtImpl) SignedRefreshToken(login string, providerInfo ProviderInfo) (string, error) {
+func (j *jwtImpl) SignedRefreshToken(login string, providerInfo ProviderInfo, sessionID string) (string, error) {
 	now := time.Now()
-	return signedToken(login, providerInfo, now, now.Add(j.refreshTokenTTL), j.refreshKey)
+	return signedToken(login, providerInfo, sessionID, now, now.Add(j.refreshTokenTTL), j.refreshKeys, TokenTypeRefresh)
 }
 
 func (j *jwtImpl) CreateAccessTokenCookie(accessToken string) (*http.Cookie, *http.Cookie) {
@@ -157,12 +218,39 @@ func (j *jwtImpl) DeleteRefreshTokenCookie() *http.Cookie {
 	}
 }
 
+func (j *jwtImpl) ParseAccessToken(token string) (*jwt.Token, error) {
+	return parseToken(token, j.accessKeys, TokenTypeAccess)
+}
+
 func (j *jwtImpl) ValidateRefreshToken(token string) (*JWTClaims, error) {
-	parsedToken, err := jwt.ParseWithClaims(token, &JWTClaims{}, func(_ *jwt.Token) (any, error) {
-		return j.refreshKey, nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS512.Name}))
+	parsedToken, err := parseToken(token, j.refreshKeys, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	claims := parsedToken.Claims.(*JWTClaims)
+	// The refresh tokens signed before the sessions were tracked can't be revoked, so they are not accepted anymore.
+	if len(claims.SessionID) == 0 {
+		return nil, ErrSessionRevoked
+	}
+	sess, err := j.sessionDAO.Get(claims.SessionID)
 	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return nil, ErrSessionRevoked
+		}
 		return nil, err
 	}
-	return parsedToken.Claims.(*JWTClaims), nil
+	if sess.Username != claims.Subject {
+		return nil, ErrSessionRevoked
+	}
+	now := time.Now().UTC()
+	sess.LastRefreshedAt = &now
+	if updateErr := j.sessionDAO.Update(sess); updateErr != nil {
+		// It is only informative, the refresh must not fail because of it.
+		logrus.WithError(updateErr).Errorf("unable to refresh the last use of the session %q", sess.ID)
+	}
+	return claims, nil
+}
+
+func (j *jwtImpl) JWKS() jose.JSONWebKeySet {
+	return j.accessKeys.jwks()
 }
@@ -16,9 +16,13 @@ package crypto
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/internal/api/utils"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +40,68 @@ func TestJWTClaims_Serialization(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, `{"sub":"jdoe","pkd":"oidc","pid":"azure"}`, string(result))
 }
+
+// memorySessionDAO keeps the sessions in memory.
+type memorySessionDAO struct {
+	session.DAO
+	sessions map[string]*modelV1.Session
+}
+
+func (d *memorySessionDAO) Create(sess *modelV1.Session) error {
+	d.sessions[sess.ID] = sess
+	return nil
+}
+
+func (d *memorySessionDAO) Update(sess *modelV1.Session) error {
+	d.sessions[sess.ID] = sess
+	return nil
+}
+
+func (d *memorySessionDAO) Get(id string) (*modelV1.Session, error) {
+	sess, ok := d.sessions[id]
+	if !ok {
+		return nil, &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return sess, nil
+}
+
+func (d *memorySessionDAO) Delete(id string) error {
+	delete(d.sessions, id)
+	return nil
+}
+
+func TestValidateRefreshToken(t *testing.T) {
+	j := &jwtImpl{
+		accessKeys:      newHMACKeySet([]byte("access")),
+		refreshKeys:     newHMACKeySet([]byte("refresh")),
+		accessTokenTTL:  time.Minute,
+		refreshTokenTTL: time.Hour,
+		sessionDAO:      &memorySessionDAO{sessions: make(map[string]*modelV1.Session)},
+	}
+	providerInfo := ProviderInfo{ProviderKind: utils.AuthKindNative}
+	sess, err := j.OpenSession("jdoe", providerInfo, "Firefox", "127.0.0.1")
+	assert.NoError(t, err)
+	token, err := j.SignedRefreshToken("jdoe", providerInfo, sess.ID)
+	assert.NoError(t, err)
+
+	claims, err := j.ValidateRefreshToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, sess.ID, claims.SessionID)
+	assert.NotNil(t, sess.LastRefreshedAt)
+
+	// A refresh token without session can't be revoked, so it is rejected.
+	legacyToken, err := j.SignedRefreshToken("jdoe", providerInfo, "")
+	assert.NoError(t, err)
+	_, err = j.ValidateRefreshToken(legacyToken)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	// The session of another user is rejected as well.
+	otherToken, err := j.SignedRefreshToken("john", providerInfo, sess.ID)
+	assert.NoError(t, err)
+	_, err = j.ValidateRefreshToken(otherToken)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	assert.NoError(t, j.RevokeSession(sess.ID))
+	_, err = j.ValidateRefreshToken(token)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
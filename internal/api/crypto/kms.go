@@ -0,0 +1,209 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// envelopePrefix starts the data encrypted with a data key wrapped by a KeyProvider.
+// The data is stored as `kms:<wrapped data key>:<nonce and ciphertext>`, both parts being base64 URL encoded.
+const envelopePrefix = config.KMSKeyID + keyIDSeparator
+
+const (
+	dataKeySize = 32
+	// maxDataKeys is the number of unwrapped data keys kept in memory, so the secrets read often don't cost a call to
+	// the key provider each time they are decrypted.
+	maxDataKeys = 1024
+	// keyProviderTimeout is the maximum time to wrap or unwrap a data key.
+	keyProviderTimeout = 10 * time.Second
+)
+
+// KeyProvider wraps the data keys with a key encryption key (KEK) it holds, and unwraps them.
+// The KEK never leaves the provider, only the wrapped data keys are stored along with the secrets.
+type KeyProvider interface {
+	WrapKey(ctx context.Context, dataKey []byte) (string, error)
+	UnwrapKey(ctx context.Context, wrappedKey string) ([]byte, error)
+}
+
+func newKeyProvider(conf *config.KMS) (KeyProvider, error) {
+	if conf.VaultTransit != nil {
+		return newVaultTransit(conf.VaultTransit)
+	}
+	return newFileKeyProvider(conf.File)
+}
+
+// fileKeyProvider wraps the data keys with a KEK read from a file.
+type fileKeyProvider struct {
+	aead cipher.AEAD
+}
+
+func newFileKeyProvider(conf *config.FileKEK) (KeyProvider, error) {
+	kek, err := os.ReadFile(conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	return &fileKeyProvider{aead: aead}, nil
+}
+
+func (f *fileKeyProvider) WrapKey(_ context.Context, dataKey []byte) (string, error) {
+	sealed, err := seal(f.aead, dataKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (f *fileKeyProvider) UnwrapKey(_ context.Context, wrappedKey string) ([]byte, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(f.aead, sealed)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal returns the nonce followed by the encrypted data.
+func seal(aead cipher.AEAD, plainText []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plainText)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plainText, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+}
+
+func isEnvelope(encrypted string) bool {
+	return strings.HasPrefix(encrypted, envelopePrefix)
+}
+
+// encryptEnvelope encrypts the spec with a new data key, wrapped by the key provider.
+func (c *crypto) encryptEnvelope(spec *modelV1.SecretSpec) error {
+	if !hasValue(spec) {
+		// No need to ask the provider for a data key that won't be used.
+		return nil
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyProviderTimeout)
+	defer cancel()
+	wrappedKey, err := c.keyProvider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return fmt.Errorf("unable to wrap the data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+	prefix := envelopePrefix + base64.RawURLEncoding.EncodeToString([]byte(wrappedKey)) + keyIDSeparator
+	return transform(spec, func(value string) (string, error) {
+		if len(value) == 0 {
+			return "", nil
+		}
+		sealed, sealErr := seal(aead, []byte(value))
+		if sealErr != nil {
+			return "", sealErr
+		}
+		return prefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+	})
+}
+
+// newDataKeyCache returns the cache of the unwrapped data keys, by wrapped data key.
+func newDataKeyCache() *lru.Cache[string, cipher.AEAD] {
+	// The size is valid, so no error can be returned.
+	cache, _ := lru.New[string, cipher.AEAD](maxDataKeys)
+	return cache
+}
+
+// decryptEnvelope decrypts the data encrypted by encryptEnvelope.
+// The unwrapped data keys are cached, as every field of a spec is encrypted with the same data key, and the same
+// secrets are decrypted again and again.
+func (c *crypto) decryptEnvelope(encrypted string) (string, error) {
+	if c.keyProvider == nil {
+		return "", errors.New("the data is encrypted with a KMS, but no kms is configured")
+	}
+	wrappedKey, sealed, found := strings.Cut(strings.TrimPrefix(encrypted, envelopePrefix), keyIDSeparator)
+	if !found {
+		return "", errors.New("malformed envelope")
+	}
+	aead, ok := c.dataKeys.Get(wrappedKey)
+	if !ok {
+		decodedKey, err := base64.RawURLEncoding.DecodeString(wrappedKey)
+		if err != nil {
+			return "", err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), keyProviderTimeout)
+		defer cancel()
+		dataKey, err := c.keyProvider.UnwrapKey(ctx, string(decodedKey))
+		if err != nil {
+			return "", fmt.Errorf("unable to unwrap the data key: %w", err)
+		}
+		aead, err = newAEAD(dataKey)
+		if err != nil {
+			return "", err
+		}
+		c.dataKeys.Add(wrappedKey, aead)
+	}
+	decodedSealed, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", err
+	}
+	plainText, err := open(aead, decodedSealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plainText), nil
+}
+
+func hasValue(spec *modelV1.SecretSpec) bool {
+	found := false
+	_ = transform(spec, func(value string) (string, error) {
+		found = found || len(value) > 0
+		return value, nil
+	})
+	return found
+}
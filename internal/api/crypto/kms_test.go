@@ -0,0 +1,180 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeVault returns a server implementing the encrypt and decrypt endpoints of the Vault Transit secrets engine.
+// The wrapping is a plain base64 encoding, enough to check the data keys go through Vault.
+func newFakeVault(t *testing.T, token string) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		body := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/perses":
+			data = map[string]string{"ciphertext": "vault:v1:" + body["plaintext"]}
+		case "/v1/transit/decrypt/perses":
+			data = map[string]string{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newVaultSecurity(t *testing.T, address string, token string) config.Security {
+	u, err := url.Parse(address)
+	assert.NoError(t, err)
+	return config.Security{
+		EncryptionKey: hexKey(oldKey),
+		KMS: &config.KMS{VaultTransit: &config.VaultTransit{
+			Address:   common.URL{URL: u},
+			Token:     secret.Hidden(token),
+			MountPath: config.DefaultVaultTransitMountPath,
+			KeyName:   "perses",
+		}},
+	}
+}
+
+func TestCrypto_VaultTransit(t *testing.T) {
+	server, calls := newFakeVault(t, "my-token")
+	c := newTestCrypto(t, newVaultSecurity(t, server.URL, "my-token"))
+
+	spec := newSpec()
+	assert.NoError(t, c.Encrypt(spec))
+	assert.True(t, strings.HasPrefix(spec.BasicAuth.Password, envelopePrefix))
+	assert.True(t, strings.HasPrefix(spec.TLSConfig.Key, envelopePrefix))
+	// A single data key is wrapped for the whole spec.
+	assert.Equal(t, 1, *calls)
+
+	// Every spec gets its own data key.
+	otherSpec := newSpec()
+	assert.NoError(t, c.Encrypt(otherSpec))
+	assert.NotEqual(t, strings.Split(spec.BasicAuth.Password, ":")[1], strings.Split(otherSpec.BasicAuth.Password, ":")[1])
+
+	encrypted := *spec.BasicAuth
+	assert.NoError(t, c.Decrypt(spec))
+	assert.Equal(t, newSpec(), spec)
+	assert.Equal(t, 3, *calls)
+
+	// The data key is unwrapped once, then it is read from the cache.
+	again := newSpec()
+	again.TLSConfig = nil
+	again.BasicAuth.Password = encrypted.Password
+	assert.NoError(t, c.Decrypt(again))
+	assert.Equal(t, newSpec().BasicAuth, again.BasicAuth)
+	assert.Equal(t, 3, *calls)
+
+	denied := newTestCrypto(t, newVaultSecurity(t, server.URL, "wrong-token"))
+	assert.ErrorContains(t, denied.Encrypt(newSpec()), "vault returned the status 403: permission denied")
+}
+
+func TestCrypto_FileKEK(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "kek")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(newKey), 0600))
+	security := config.Security{
+		EncryptionKey: hexKey(oldKey),
+		KMS:           &config.KMS{File: &config.FileKEK{KeyFile: keyFile}},
+	}
+	c := newTestCrypto(t, security)
+
+	spec := newSpec()
+	assert.NoError(t, c.Encrypt(spec))
+	encrypted := *spec.BasicAuth
+	assert.NoError(t, c.Decrypt(spec))
+	assert.Equal(t, newSpec(), spec)
+
+	// The data is authenticated, a tampered ciphertext is rejected.
+	tampered := newSpec()
+	tampered.TLSConfig = nil
+	tamperedPassword := []byte(encrypted.Password)
+	i := len(tamperedPassword) - 10
+	if tamperedPassword[i] == 'A' {
+		tamperedPassword[i] = 'B'
+	} else {
+		tamperedPassword[i] = 'A'
+	}
+	tampered.BasicAuth.Password = string(tamperedPassword)
+	assert.Error(t, c.Decrypt(tampered))
+
+	// Without the KMS, the data can't be decrypted.
+	withoutKMS := newTestCrypto(t, config.Security{EncryptionKey: hexKey(oldKey)})
+	encryptedSpec := newSpec()
+	encryptedSpec.TLSConfig = nil
+	encryptedSpec.BasicAuth.Password = encrypted.Password
+	assert.ErrorContains(t, withoutKMS.Decrypt(encryptedSpec), "no kms is configured")
+}
+
+func TestCrypto_MigrateToKMS(t *testing.T) {
+	legacy := newTestCrypto(t, config.Security{EncryptionKey: hexKey(oldKey)})
+	spec := newSpec()
+	assert.NoError(t, legacy.Encrypt(spec))
+
+	keyFile := filepath.Join(t.TempDir(), "kek")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(newKey), 0600))
+	c := newTestCrypto(t, config.Security{
+		EncryptionKey: hexKey(oldKey),
+		KMS:           &config.KMS{File: &config.FileKEK{KeyFile: keyFile}},
+	})
+	// The data encrypted with the encryption key can still be decrypted, and is migrated by the re-encryption.
+	done, err := c.Reencrypt(spec)
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.True(t, strings.HasPrefix(spec.BasicAuth.Password, envelopePrefix))
+	done, err = c.Reencrypt(spec)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.NoError(t, c.Decrypt(spec))
+	assert.Equal(t, newSpec(), spec)
+}
+
+func TestFileKeyProvider_WrapKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "kek")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(newKey), 0600))
+	provider, err := newFileKeyProvider(&config.FileKEK{KeyFile: keyFile})
+	assert.NoError(t, err)
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	wrappedKey, err := provider.WrapKey(t.Context(), dataKey)
+	assert.NoError(t, err)
+	assert.NotContains(t, wrappedKey, base64.RawURLEncoding.EncodeToString(dataKey))
+	unwrappedKey, err := provider.UnwrapKey(t.Context(), wrappedKey)
+	assert.NoError(t, err)
+	assert.Equal(t, dataKey, unwrappedKey)
+}
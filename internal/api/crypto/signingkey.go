@@ -0,0 +1,169 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/perses/perses/pkg/model/api/config"
+)
+
+// verifyKey is a key verifying the tokens signed with a given algorithm.
+type verifyKey struct {
+	method jwt.SigningMethod
+	key    any
+}
+
+// keySet contains the keys signing and verifying a kind of token.
+type keySet struct {
+	// signingID is the kid of the key signing the new tokens. It is empty with HS512, as the key is never published.
+	signingID  string
+	signingKey any
+	verifyKeys map[string]verifyKey
+}
+
+// newHMACKeySet returns the key set signing and verifying the tokens with HS512 and the given secret.
+func newHMACKeySet(secret []byte) *keySet {
+	return &keySet{
+		signingKey: secret,
+		verifyKeys: map[string]verifyKey{"": {method: jwt.SigningMethodHS512, key: secret}},
+	}
+}
+
+// newAsymmetricKeySet returns the key set signing with the first key of the list, and verifying with all of them.
+func newAsymmetricKeySet(keys []config.JWTSigningKey) (*keySet, error) {
+	set := &keySet{verifyKeys: make(map[string]verifyKey, len(keys))}
+	for i, k := range keys {
+		method, privateKey, err := parseSigningKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: %w", k.ID, err)
+		}
+		if i == 0 {
+			set.signingID = k.ID
+			set.signingKey = privateKey
+		}
+		set.verifyKeys[k.ID] = verifyKey{method: method, key: privateKey.Public()}
+	}
+	return set, nil
+}
+
+// parseSigningKey decodes the PEM private key and checks it matches the algorithm.
+func parseSigningKey(k config.JWTSigningKey) (jwt.SigningMethod, stdcrypto.Signer, error) {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return nil, nil, errors.New("no PEM data found in the private key")
+	}
+	var privateKey any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse the private key: %w", err)
+	}
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if k.Algorithm != config.SigningAlgorithmRS256 {
+			return nil, nil, fmt.Errorf("an RSA key can't be used with %s", k.Algorithm)
+		}
+		if key.N.BitLen() < 2048 {
+			return nil, nil, errors.New("the RSA key must be at least 2048 bits long")
+		}
+		return jwt.SigningMethodRS256, key, nil
+	case *ecdsa.PrivateKey:
+		if k.Algorithm != config.SigningAlgorithmES256 {
+			return nil, nil, fmt.Errorf("an ECDSA key can't be used with %s", k.Algorithm)
+		}
+		if key.Curve != elliptic.P256() {
+			return nil, nil, errors.New("the ECDSA key must use the P-256 curve")
+		}
+		return jwt.SigningMethodES256, key, nil
+	default:
+		return nil, nil, fmt.Errorf("the key type %T is not supported", privateKey)
+	}
+}
+
+// sign returns the token signed by the current signing key, with the given type in the `typ` header.
+func (s *keySet) sign(claims jwt.Claims, typ string) (string, error) {
+	signing := s.verifyKeys[s.signingID]
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["typ"] = typ
+	if len(s.signingID) > 0 {
+		token.Header["kid"] = s.signingID
+	}
+	// The type of the key depends on the signature method.
+	// See https://golang-jwt.github.io/jwt/usage/signing_methods/#signing-methods-and-key-types.
+	return token.SignedString(s.signingKey)
+}
+
+// keyFunc returns the key verifying the token, found from its `kid` header.
+// The algorithm of the token must be the one of the key, so a public key can't be used as an HMAC secret.
+func (s *keySet) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	k, ok := s.verifyKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q", token.Method.Alg())
+	}
+	return k.key, nil
+}
+
+// validMethods returns the algorithms of the keys.
+func (s *keySet) validMethods() []string {
+	var methods []string
+	for _, k := range s.verifyKeys {
+		if !slices.Contains(methods, k.method.Alg()) {
+			methods = append(methods, k.method.Alg())
+		}
+	}
+	return methods
+}
+
+// jwks returns the public keys of the set. The HMAC secret is never published.
+func (s *keySet) jwks() jose.JSONWebKeySet {
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	// The signing key comes first, as it is the one most likely looked for.
+	var ids []string
+	for id := range s.verifyKeys {
+		if id != s.signingID {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	for _, id := range append([]string{s.signingID}, ids...) {
+		k := s.verifyKeys[id]
+		if _, isHMAC := k.key.([]byte); isHMAC {
+			continue
+		}
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: k.key, KeyID: id, Algorithm: k.method.Alg(), Use: "sig"})
+	}
+	return set
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rsaSigningKey(t *testing.T, id string) config.JWTSigningKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return config.JWTSigningKey{ID: id, Algorithm: config.SigningAlgorithmRS256, PrivateKey: secret.Hidden(data)}
+}
+
+func ecdsaSigningKey(t *testing.T, id string) config.JWTSigningKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return config.JWTSigningKey{ID: id, Algorithm: config.SigningAlgorithmES256, PrivateKey: secret.Hidden(data)}
+}
+
+func newTestJWT(keys *keySet) *jwtImpl {
+	return &jwtImpl{accessKeys: keys, refreshKeys: keys, accessTokenTTL: time.Minute, refreshTokenTTL: time.Hour}
+}
+
+func TestAsymmetricKeySet(t *testing.T) {
+	rsaKey := rsaSigningKey(t, "2025-01")
+	ecdsaKey := ecdsaSigningKey(t, "2025-02")
+	providerInfo := ProviderInfo{ProviderKind: utils.AuthKindNative}
+
+	// The RSA key signs, before the rotation.
+	before, err := newAsymmetricKeySet([]config.JWTSigningKey{rsaKey})
+	require.NoError(t, err)
+	oldToken, err := newTestJWT(before).SignedAccessToken("jdoe", providerInfo, "session")
+	require.NoError(t, err)
+
+	// The ECDSA key signs after the rotation, the RSA key still verifies the tokens it signed.
+	after, err := newAsymmetricKeySet([]config.JWTSigningKey{ecdsaKey, rsaKey})
+	require.NoError(t, err)
+	j := newTestJWT(after)
+	newToken, err := j.SignedAccessToken("jdoe", providerInfo, "session")
+	require.NoError(t, err)
+	for _, token := range []string{oldToken, newToken} {
+		parsed, parseErr := j.ParseAccessToken(token)
+		require.NoError(t, parseErr)
+		assert.Equal(t, "jdoe", parsed.Claims.(*JWTClaims).Subject)
+		assert.Equal(t, TokenTypeAccess, parsed.Header["typ"])
+	}
+	parsed, err := j.ParseAccessToken(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-02", parsed.Header["kid"])
+	assert.Equal(t, jwt.SigningMethodES256.Alg(), parsed.Method.Alg())
+
+	// Once the RSA key is removed, its tokens are rejected.
+	_, err = newTestJWT(&keySet{signingID: after.signingID, signingKey: after.signingKey, verifyKeys: map[string]verifyKey{"2025-02": after.verifyKeys["2025-02"]}}).ParseAccessToken(oldToken)
+	assert.Error(t, err)
+
+	// The refresh tokens are signed by the same keys, only their type tells them apart.
+	refreshToken, err := j.SignedRefreshToken("jdoe", providerInfo, "session")
+	require.NoError(t, err)
+	_, err = j.ParseAccessToken(refreshToken)
+	assert.ErrorContains(t, err, "unexpected token type")
+
+	// Only the public part of the keys is published, the signing key first.
+	jwks := j.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "2025-02", jwks.Keys[0].KeyID)
+	assert.Equal(t, "ES256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "2025-01", jwks.Keys[1].KeyID)
+	for _, key := range jwks.Keys {
+		assert.True(t, key.IsPublic())
+	}
+}
+
+func TestHMACKeySet(t *testing.T) {
+	j := &jwtImpl{accessKeys: newHMACKeySet([]byte("access")), refreshKeys: newHMACKeySet([]byte("refresh")), accessTokenTTL: time.Minute}
+	token, err := j.SignedAccessToken("jdoe", ProviderInfo{}, "")
+	require.NoError(t, err)
+	_, err = j.ParseAccessToken(token)
+	assert.NoError(t, err)
+	// The secret is never published.
+	assert.Empty(t, j.JWKS().Keys)
+
+	// A token signed with HS256 and the public key as secret must not be accepted by the asymmetric keys.
+	rsaKeys, err := newAsymmetricKeySet([]config.JWTSigningKey{rsaSigningKey(t, "rsa")})
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &JWTClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "admin"}})
+	forged.Header["kid"] = "rsa"
+	forgedToken, err := forged.SignedString(x509.MarshalPKCS1PublicKey(rsaKeys.verifyKeys["rsa"].key.(*rsa.PublicKey)))
+	require.NoError(t, err)
+	_, err = newTestJWT(rsaKeys).ParseAccessToken(forgedToken)
+	assert.Error(t, err)
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaKey := rsaSigningKey(t, "rsa")
+	ecdsaKey := ecdsaSigningKey(t, "ecdsa")
+	testSuites := []struct {
+		title string
+		key   config.JWTSigningKey
+		err   string
+	}{
+		{
+			title: "RSA key",
+			key:   rsaKey,
+		},
+		{
+			title: "ECDSA key",
+			key:   ecdsaKey,
+		},
+		{
+			title: "RSA key with ES256",
+			key:   config.JWTSigningKey{ID: "rsa", Algorithm: config.SigningAlgorithmES256, PrivateKey: rsaKey.PrivateKey},
+			err:   "an RSA key can't be used with ES256",
+		},
+		{
+			title: "ECDSA key with RS256",
+			key:   config.JWTSigningKey{ID: "ecdsa", Algorithm: config.SigningAlgorithmRS256, PrivateKey: ecdsaKey.PrivateKey},
+			err:   "an ECDSA key can't be used with RS256",
+		},
+		{
+			title: "not a PEM",
+			key:   config.JWTSigningKey{ID: "wrong", Algorithm: config.SigningAlgorithmRS256, PrivateKey: "not a key"},
+			err:   "no PEM data found",
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, _, err := parseSigningKey(test.key)
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
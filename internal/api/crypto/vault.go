@@ -0,0 +1,135 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/config"
+)
+
+// vaultTransit wraps the data keys with a key of the HashiCorp Vault Transit secrets engine.
+// See https://developer.hashicorp.com/vault/api-docs/secret/transit
+type vaultTransit struct {
+	client     *http.Client
+	encryptURL string
+	decryptURL string
+	token      string
+	tokenFile  string
+	namespace  string
+}
+
+func newVaultTransit(conf *config.VaultTransit) (KeyProvider, error) {
+	tlsConfig, err := conf.HTTP.TLSConfig.BuildTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the tls config of vault_transit: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	timeout := time.Duration(conf.HTTP.Timeout)
+	if timeout == 0 {
+		timeout = config.DefaultProviderTimeout
+	}
+	baseURL := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(conf.Address.String(), "/"), strings.Trim(conf.MountPath, "/"))
+	return &vaultTransit{
+		client:     &http.Client{Transport: transport, Timeout: timeout},
+		encryptURL: fmt.Sprintf("%s/encrypt/%s", baseURL, conf.KeyName),
+		decryptURL: fmt.Sprintf("%s/decrypt/%s", baseURL, conf.KeyName),
+		token:      string(conf.Token),
+		tokenFile:  conf.TokenFile,
+		namespace:  conf.Namespace,
+	}, nil
+}
+
+func (v *vaultTransit) WrapKey(ctx context.Context, dataKey []byte) (string, error) {
+	var response struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := v.post(ctx, v.encryptURL, map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}, &response); err != nil {
+		return "", err
+	}
+	// The ciphertext tells the version of the Transit key, so the key can be rotated in Vault.
+	return response.Data.Ciphertext, nil
+}
+
+func (v *vaultTransit) UnwrapKey(ctx context.Context, wrappedKey string) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := v.post(ctx, v.decryptURL, map[string]string{"ciphertext": wrappedKey}, &response); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Data.Plaintext)
+}
+
+func (v *vaultTransit) post(ctx context.Context, url string, body any, result any) error {
+	token, err := v.getToken()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", token)
+	if len(v.namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(respBody, &vaultErr)
+		return fmt.Errorf("vault returned the status %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+func (v *vaultTransit) getToken() (string, error) {
+	if len(v.tokenFile) == 0 {
+		return v.token, nil
+	}
+	data, err := os.ReadFile(v.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the vault token_file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -17,9 +17,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	databaseFile "github.com/perses/perses/internal/api/database/file"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	databaseSQL "github.com/perses/perses/internal/api/database/sql"
@@ -28,8 +34,13 @@ import (
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
+	// SQLite driver
+	_ "modernc.org/sqlite"
 )
 
+// sqliteSchemaName is the name SQLite gives to the main database file.
+const sqliteSchemaName = "main"
+
 type dao struct {
 	databaseModel.DAO
 	client databaseModel.DAO
@@ -51,6 +62,9 @@ func (d *dao) Create(entity modelAPI.Entity) error {
 func (d *dao) Upsert(entity modelAPI.Entity) error {
 	return d.client.Upsert(entity)
 }
+func (d *dao) Update(entity modelAPI.Entity) error {
+	return d.client.Update(entity)
+}
 func (d *dao) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error {
 	return d.client.Get(kind, metadata, entity)
 }
@@ -86,6 +100,78 @@ func (d *dao) GetLatestUpdateTime(kind []modelV1.Kind) (*string, error) {
 	return d.client.GetLatestUpdateTime(kind)
 }
 
+func (d *dao) CreateRevision(entity modelAPI.Entity, author string, maxRevisions int) error {
+	return d.client.CreateRevision(entity, author, maxRevisions)
+}
+
+func (d *dao) GetRevision(kind modelV1.Kind, metadata modelAPI.Metadata, version uint64) (*databaseModel.Revision, error) {
+	return d.client.GetRevision(kind, metadata, version)
+}
+
+func (d *dao) ListRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) ([]*modelV1.RevisionMetadata, error) {
+	return d.client.ListRevisions(kind, metadata)
+}
+
+func (d *dao) DeleteRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) error {
+	return d.client.DeleteRevisions(kind, metadata)
+}
+
+func (d *dao) CreateAuditEvent(event *modelV1.AuditEvent) error {
+	return d.client.CreateAuditEvent(event)
+}
+
+func (d *dao) QueryAuditEvents(query *databaseModel.AuditQuery) ([]*modelV1.AuditEvent, error) {
+	return d.client.QueryAuditEvents(query)
+}
+
+func (d *dao) CreateWebhookDelivery(delivery *modelV1.WebhookDelivery) error {
+	return d.client.CreateWebhookDelivery(delivery)
+}
+
+func (d *dao) UpdateWebhookDelivery(delivery *modelV1.WebhookDelivery) error {
+	return d.client.UpdateWebhookDelivery(delivery)
+}
+
+func (d *dao) QueryWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) ([]*modelV1.WebhookDelivery, error) {
+	return d.client.QueryWebhookDeliveries(query)
+}
+
+func (d *dao) DeleteWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) error {
+	return d.client.DeleteWebhookDeliveries(query)
+}
+
+func (d *dao) CreateAccessToken(token *databaseModel.AccessToken) error {
+	return d.client.CreateAccessToken(token)
+}
+
+func (d *dao) UpdateAccessToken(token *databaseModel.AccessToken) error {
+	return d.client.UpdateAccessToken(token)
+}
+
+func (d *dao) QueryAccessTokens(query *databaseModel.AccessTokenQuery) ([]*databaseModel.AccessToken, error) {
+	return d.client.QueryAccessTokens(query)
+}
+
+func (d *dao) DeleteAccessTokens(query *databaseModel.AccessTokenQuery) error {
+	return d.client.DeleteAccessTokens(query)
+}
+
+func (d *dao) CreateSession(session *modelV1.Session) error {
+	return d.client.CreateSession(session)
+}
+
+func (d *dao) UpdateSession(session *modelV1.Session) error {
+	return d.client.UpdateSession(session)
+}
+
+func (d *dao) QuerySessions(query *databaseModel.SessionQuery) ([]*modelV1.Session, error) {
+	return d.client.QuerySessions(query)
+}
+
+func (d *dao) DeleteSessions(query *databaseModel.SessionQuery) error {
+	return d.client.DeleteSessions(query)
+}
+
 func New(conf config.Database) (databaseModel.DAO, error) {
 	var client databaseModel.DAO
 	if conf.File != nil {
@@ -96,59 +182,145 @@ func New(conf config.Database) (databaseModel.DAO, error) {
 		}
 	} else if conf.SQL != nil {
 		c := conf.SQL
-		mysqlConfig := mysql.Config{
-			User:                     string(c.User),
-			Passwd:                   string(c.Password),
-			Net:                      c.Net,
-			Addr:                     string(c.Addr),
-			DBName:                   c.DBName,
-			Collation:                c.Collation,
-			Loc:                      c.Loc,
-			MaxAllowedPacket:         c.MaxAllowedPacket,
-			ServerPubKey:             c.ServerPubKey,
-			Timeout:                  time.Duration(c.Timeout),
-			ReadTimeout:              time.Duration(c.ReadTimeout),
-			WriteTimeout:             time.Duration(c.WriteTimeout),
-			AllowAllFiles:            c.AllowAllFiles,
-			AllowCleartextPasswords:  c.AllowCleartextPasswords,
-			AllowFallbackToPlaintext: c.AllowFallbackToPlaintext,
-			AllowNativePasswords:     c.AllowNativePasswords,
-			AllowOldPasswords:        c.AllowOldPasswords,
-			CheckConnLiveness:        c.CheckConnLiveness,
-			ClientFoundRows:          c.ClientFoundRows,
-			ColumnsWithAlias:         c.ColumnsWithAlias,
-			InterpolateParams:        c.InterpolateParams,
-			MultiStatements:          c.MultiStatements,
-			ParseTime:                c.ParseTime,
-			RejectReadOnly:           c.RejectReadOnly,
-		}
-
-		// (OPTIONAL) Configure TLS
-		if c.TLSConfig != nil {
-			tlsConfig, parseErr := c.TLSConfig.BuildTLSConfig()
-			if parseErr != nil {
-				logrus.WithError(parseErr).Error("Failed to parse TLS from configuration")
-				return nil, parseErr
-			}
-			tlsConfigName := "perses-tls"
-			if err := mysql.RegisterTLSConfig(tlsConfigName, tlsConfig); err != nil {
-				logrus.WithError(err).Error("Failed to register TLS configuration for mysql connection")
-				return nil, err
-			}
-			mysqlConfig.TLSConfig = tlsConfigName
+		var db *sql.DB
+		var schemaName string
+		var err error
+		if c.Driver == config.SQLDriverPostgres {
+			db, err = openPostgres(c)
+			schemaName = c.Postgres.Schema
+		} else {
+			db, err = openMySQL(c)
+			schemaName = c.DBName
 		}
-
-		db, err := sql.Open("mysql", mysqlConfig.FormatDSN())
 		if err != nil {
 			return nil, err
 		}
 		client = &databaseSQL.DAO{
 			DB:            db,
-			SchemaName:    c.DBName,
+			SchemaName:    schemaName,
 			CaseSensitive: c.CaseSensitive,
+			Driver:        c.Driver,
+		}
+	} else if conf.SQLite != nil {
+		db, err := openSQLite(conf.SQLite)
+		if err != nil {
+			return nil, err
+		}
+		client = &databaseSQL.DAO{
+			DB:            db,
+			SchemaName:    sqliteSchemaName,
+			CaseSensitive: conf.SQLite.CaseSensitive,
+			Driver:        config.SQLDriverSQLite,
 		}
 	} else {
 		return nil, fmt.Errorf("no dao defined")
 	}
 	return &dao{client: client}, nil
 }
+
+func openMySQL(c *config.SQL) (*sql.DB, error) {
+	mysqlConfig := mysql.Config{
+		User:                     string(c.User),
+		Passwd:                   string(c.Password),
+		Net:                      c.Net,
+		Addr:                     string(c.Addr),
+		DBName:                   c.DBName,
+		Collation:                c.Collation,
+		Loc:                      c.Loc,
+		MaxAllowedPacket:         c.MaxAllowedPacket,
+		ServerPubKey:             c.ServerPubKey,
+		Timeout:                  time.Duration(c.Timeout),
+		ReadTimeout:              time.Duration(c.ReadTimeout),
+		WriteTimeout:             time.Duration(c.WriteTimeout),
+		AllowAllFiles:            c.AllowAllFiles,
+		AllowCleartextPasswords:  c.AllowCleartextPasswords,
+		AllowFallbackToPlaintext: c.AllowFallbackToPlaintext,
+		AllowNativePasswords:     c.AllowNativePasswords,
+		AllowOldPasswords:        c.AllowOldPasswords,
+		CheckConnLiveness:        c.CheckConnLiveness,
+		ClientFoundRows:          c.ClientFoundRows,
+		ColumnsWithAlias:         c.ColumnsWithAlias,
+		InterpolateParams:        c.InterpolateParams,
+		MultiStatements:          c.MultiStatements,
+		ParseTime:                c.ParseTime,
+		RejectReadOnly:           c.RejectReadOnly,
+	}
+
+	// (OPTIONAL) Configure TLS
+	if c.TLSConfig != nil {
+		tlsConfig, parseErr := c.TLSConfig.BuildTLSConfig()
+		if parseErr != nil {
+			logrus.WithError(parseErr).Error("Failed to parse TLS from configuration")
+			return nil, parseErr
+		}
+		tlsConfigName := "perses-tls"
+		if err := mysql.RegisterTLSConfig(tlsConfigName, tlsConfig); err != nil {
+			logrus.WithError(err).Error("Failed to register TLS configuration for mysql connection")
+			return nil, err
+		}
+		mysqlConfig.TLSConfig = tlsConfigName
+	}
+
+	return sql.Open("mysql", mysqlConfig.FormatDSN())
+}
+
+func openPostgres(c *config.SQL) (*sql.DB, error) {
+	// build the postgres DSN for pgx to parse
+	u := &url.URL{
+		Scheme: "postgres",
+		Host:   string(c.Addr),
+		Path:   c.DBName,
+	}
+	if len(c.User) > 0 {
+		if len(c.Password) > 0 {
+			u.User = url.UserPassword(string(c.User), string(c.Password))
+		} else {
+			u.User = url.User(string(c.User))
+		}
+	}
+	query := u.Query()
+	if c.Postgres.SSLMode != "" {
+		query.Set("sslmode", string(c.Postgres.SSLMode))
+	}
+	if c.Timeout > 0 {
+		query.Set("connect_timeout", strconv.Itoa(int(time.Duration(c.Timeout).Seconds())))
+	}
+	u.RawQuery = query.Encode()
+
+	pgxConfig, err := pgx.ParseConfig(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// (OPTIONAL) Configure TLS
+	if c.TLSConfig != nil {
+		if c.Postgres.SSLMode == config.PostgresSSLModeDisable {
+			return nil, fmt.Errorf("tls_config cannot be used when ssl_mode is %q", config.PostgresSSLModeDisable)
+		}
+		tlsConfig, parseErr := c.TLSConfig.BuildTLSConfig()
+		if parseErr != nil {
+			logrus.WithError(parseErr).Error("Failed to parse TLS from configuration")
+			return nil, parseErr
+		}
+		pgxConfig.TLSConfig = tlsConfig
+		// The fallbacks are built from the sslmode and would not use the TLS configuration provided.
+		pgxConfig.Fallbacks = nil
+	}
+	return stdlib.OpenDB(*pgxConfig), nil
+}
+
+func openSQLite(c *config.SQLite) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return nil, fmt.Errorf("unable to create the folder of the sqlite database: %w", err)
+	}
+	query := url.Values{}
+	// Write transactions take the lock immediately, so concurrent writes wait for each other (up to the busy timeout)
+	// instead of failing when the transaction tries to upgrade its read lock.
+	query.Set("_txlock", "immediate")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	// The data are lowercased by the DAO when the database is not case-sensitive.
+	// A case-sensitive LIKE allows the prefix queries on the name to use the index.
+	query.Add("_pragma", "case_sensitive_like(1)")
+	return sql.Open("sqlite", fmt.Sprintf("file:%s?%s", c.Path, query.Encode()))
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+)
+
+// accessTokenFolder is the folder, relative to the database folder, containing the access tokens.
+// Each token is stored in the file <accessTokenFolder>/<id of the token>.json.
+const accessTokenFolder = "accesstokens"
+
+const accessTokenExtension = ".json"
+
+func (d *DAO) buildAccessTokenPath(id string) string {
+	return filepath.Join(d.Folder, accessTokenFolder, id+accessTokenExtension)
+}
+
+func (d *DAO) CreateAccessToken(token *databaseModel.AccessToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	path := d.buildAccessTokenPath(token.ID)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, statErr := os.Stat(path); statErr == nil {
+		return &databaseModel.Error{Key: token.ID, Code: databaseModel.ErrorCodeConflict}
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *DAO) UpdateAccessToken(token *databaseModel.AccessToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	path := d.buildAccessTokenPath(token.ID)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, statErr := os.Stat(path); statErr != nil {
+		if os.IsNotExist(statErr) {
+			return &databaseModel.Error{Key: token.ID, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return statErr
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *DAO) QueryAccessTokens(query *databaseModel.AccessTokenQuery) ([]*databaseModel.AccessToken, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	result, _, err := d.readAccessTokens(query)
+	return result, err
+}
+
+func (d *DAO) DeleteAccessTokens(query *databaseModel.AccessTokenQuery) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	_, paths, err := d.readAccessTokens(query)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
+			return removeErr
+		}
+	}
+	return nil
+}
+
+// readAccessTokens returns the tokens matching the query, the oldest first, and the files containing them.
+// The caller must hold the mutex.
+func (d *DAO) readAccessTokens(query *databaseModel.AccessTokenQuery) ([]*databaseModel.AccessToken, []string, error) {
+	var files []string
+	if len(query.ID) > 0 && filepath.Base(query.ID) != query.ID {
+		// The ID comes from the requests, it must not be able to designate a file outside the folder.
+		return []*databaseModel.AccessToken{}, nil, nil
+	}
+	if len(query.ID) > 0 {
+		// The token is read directly instead of reading the whole folder, as it is done for every request using a token.
+		files = []string{query.ID + accessTokenExtension}
+	} else {
+		entries, err := os.ReadDir(filepath.Join(d.Folder, accessTokenFolder))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && filepath.Ext(entry.Name()) == accessTokenExtension {
+				files = append(files, entry.Name())
+			}
+		}
+	}
+	tokens := []*databaseModel.AccessToken{}
+	pathByID := make(map[string]string)
+	for _, file := range files {
+		path := filepath.Join(d.Folder, accessTokenFolder, file)
+		data, readErr := os.ReadFile(path) //nolint: gosec
+		if readErr != nil {
+			if os.IsNotExist(readErr) {
+				continue
+			}
+			return nil, nil, readErr
+		}
+		token := &databaseModel.AccessToken{}
+		if unmarshalErr := json.Unmarshal(data, token); unmarshalErr != nil {
+			return nil, nil, fmt.Errorf("unable to read the access token %q: %w", path, unmarshalErr)
+		}
+		if query.Match(token) {
+			tokens = append(tokens, token)
+			pathByID[token.ID] = path
+		}
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if tokens[i].CreatedAt.Equal(tokens[j].CreatedAt) {
+			return tokens[i].ID < tokens[j].ID
+		}
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+	paths := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		paths = append(paths, pathByID[token.ID])
+	}
+	return tokens, paths, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// auditFile is the file, relative to the database folder, containing the audit events.
+const auditFile = "audit.jsonl"
+
+// maxAuditLineSize is the maximum size of an event in the audit file.
+const maxAuditLineSize = 1024 * 1024
+
+// AppendAuditEvent appends the event in the file as a single JSON line. The file is created if it doesn't exist.
+// The caller is responsible for preventing concurrent writes.
+func AppendAuditEvent(path string, event *modelV1.AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) //nolint: gosec
+	if err != nil {
+		return err
+	}
+	if _, writeErr := f.Write(append(data, '\n')); writeErr != nil {
+		_ = f.Close()
+		return writeErr
+	}
+	return f.Close()
+}
+
+// ReadAuditEvents returns the events of the file matching the query, the latest first.
+func ReadAuditEvents(path string, query *databaseModel.AuditQuery) ([]*modelV1.AuditEvent, error) {
+	result := []*modelV1.AuditEvent{}
+	f, err := os.Open(path) //nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+	defer f.Close() //nolint: errcheck
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxAuditLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		event := &modelV1.AuditEvent{}
+		if unmarshalErr := json.Unmarshal(scanner.Bytes(), event); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		if query.Match(event) {
+			result = append(result, event)
+		}
+	}
+	if scanErr := scanner.Err(); scanErr != nil {
+		return nil, scanErr
+	}
+	// Events are appended, so the latest ones are at the end of the file.
+	slices.Reverse(result)
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+func (d *DAO) CreateAuditEvent(event *modelV1.AuditEvent) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return AppendAuditEvent(filepath.Join(d.Folder, auditFile), event)
+}
+
+func (d *DAO) QueryAuditEvents(query *databaseModel.AuditQuery) ([]*modelV1.AuditEvent, error) {
+	return ReadAuditEvents(filepath.Join(d.Folder, auditFile), query)
+}
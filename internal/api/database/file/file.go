@@ -21,8 +21,11 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/utils"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
@@ -39,11 +42,21 @@ func generateID(kind modelV1.Kind, metadata modelAPI.Metadata) (string, error) {
 	return "", fmt.Errorf("metadata %T not managed", metadata)
 }
 
+// versionedDocument is used to read the version of a document whatever its kind is.
+type versionedDocument struct {
+	Metadata struct {
+		Version uint64 `json:"version" yaml:"version"`
+	} `json:"metadata" yaml:"metadata"`
+}
+
 type DAO struct {
 	databaseModel.DAO
 	Folder        string
 	Extension     config.FileExtension
 	CaseSensitive bool
+	// mutex is used to make the writes atomic.
+	// It only works as long as a single Perses instance is using the folder.
+	mutex sync.Mutex
 }
 
 func (d *DAO) Init() error {
@@ -69,6 +82,8 @@ func (d *DAO) Create(entity modelAPI.Entity) error {
 		return generateIDErr
 	}
 	filePath := d.buildPath(key)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 	if _, err := os.Stat(filePath); err == nil {
 		// The file exists, so we should return a conflict error.
 		return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeConflict}
@@ -81,6 +96,32 @@ func (d *DAO) Upsert(entity modelAPI.Entity) error {
 	if generateIDErr != nil {
 		return generateIDErr
 	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.upsert(key, entity)
+}
+func (d *DAO) Update(entity modelAPI.Entity) error {
+	entity.GetMetadata().Flatten(d.CaseSensitive)
+	key, generateIDErr := generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+	if generateIDErr != nil {
+		return generateIDErr
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	data, err := os.ReadFile(d.buildPath(key)) //nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return err
+	}
+	var current versionedDocument
+	if unMarshalErr := d.unmarshal(data, &current); unMarshalErr != nil {
+		return unMarshalErr
+	}
+	if current.Metadata.Version+1 != utils.GetMetadataVersion(entity.GetMetadata()) {
+		return &databaseModel.VersionConflictError{Key: key, CurrentVersion: current.Metadata.Version}
+	}
 	return d.upsert(key, entity)
 }
 func (d *DAO) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error {
@@ -115,49 +156,29 @@ func (d *DAO) RawMetadataQuery(_ databaseModel.Query, _ modelV1.Kind) ([]json.Ra
 }
 
 func (d *DAO) RawQuery(query databaseModel.Query) ([]json.RawMessage, error) {
-	folder, prefix, isExist, err := d.buildQuery(query)
+	docs, err := d.queryDocuments(query)
 	if err != nil {
-		return nil, fmt.Errorf("unable to build the query: %s", err)
-	}
-	if !isExist {
-		// There is nothing to return. So let's initialize the slice just to avoid returning a nil slice
-		return make([]json.RawMessage, 0), nil
-	}
-	// so now we have the proper folder to looking for and potentially a filter to use
-	var files []string
-	if files, err = d.visit(folder, prefix); err != nil {
 		return nil, err
 	}
-	if len(files) <= 0 {
-		// in case the result is empty, let's initialize the slice just to avoid returning a nil slice
-		return make([]json.RawMessage, 0), nil
-	}
-	result := []json.RawMessage{}
-
-	for _, file := range files {
-		// now read all files and append them to the final result
-		data, readErr := os.ReadFile(file) //nolint: gosec
-		if readErr != nil {
-			return nil, readErr
-		}
-
+	result := make([]json.RawMessage, 0, len(docs))
+	for _, doc := range docs {
 		// If it's YAML, we need to convert to JSON first
 		if d.Extension == config.YAMLExtension {
 			// Parse the YAML content
 			var yamlData any
-			if err := yaml.Unmarshal(data, &yamlData); err != nil {
-				return nil, fmt.Errorf("failed to parse YAML from %s: %w", file, err)
+			if err := yaml.Unmarshal(doc.data, &yamlData); err != nil {
+				return nil, fmt.Errorf("failed to parse YAML from %s: %w", doc.file, err)
 			}
 
 			// Convert YAML to JSON
 			jsonData, err := json.Marshal(yamlData)
 			if err != nil {
-				return nil, fmt.Errorf("failed to convert to JSON from %s: %w", file, err)
+				return nil, fmt.Errorf("failed to convert to JSON from %s: %w", doc.file, err)
 			}
 			result = append(result, json.RawMessage(jsonData))
 		} else {
 			// For JSON files, we can append directly
-			result = append(result, json.RawMessage(data))
+			result = append(result, json.RawMessage(doc.data))
 		}
 	}
 	return result, nil
@@ -183,32 +204,13 @@ func (d *DAO) Query(query databaseModel.Query, slice any) error {
 	if typeParameter.Kind() != reflect.Slice {
 		return fmt.Errorf("slice in parameter is not actually a slice but a %q", typeParameter.Kind())
 	}
-	folder, prefix, isExist, err := d.buildQuery(query)
+	docs, err := d.queryDocuments(query)
 	if err != nil {
-		return fmt.Errorf("unable to build the query: %s", err)
-	}
-	if !isExist {
-		// There is nothing to return. So let's initialize the slice just to avoid returning a nil slice
-		sliceElem = reflect.MakeSlice(typeParameter, 0, 0)
-		// And finally, reset the element of the slice to ensure we didn't disconnect the link between the pointer to the slice and the actual slice
-		result.Elem().Set(sliceElem)
-		return nil
-	}
-	// so now we have the proper folder to looking for and potentially a filter to use
-	var files []string
-	if files, err = d.visit(folder, prefix); err != nil {
 		return err
 	}
-	if len(files) <= 0 {
-		// in case the result is empty, let's initialize the slice just to avoid returning a nil slice
-		sliceElem = reflect.MakeSlice(typeParameter, 0, 0)
-	}
-	for _, file := range files {
-		// now read all files and append them to the final result
-		data, readErr := os.ReadFile(file) //nolint: gosec
-		if readErr != nil {
-			return readErr
-		}
+	// Initialize the slice just to avoid returning a nil slice when the result is empty
+	sliceElem = reflect.MakeSlice(typeParameter, 0, len(docs))
+	for _, doc := range docs {
 		// first create a pointer with the accurate type
 		var value reflect.Value
 		if typeParameter.Elem().Kind() != reflect.Ptr {
@@ -219,27 +221,84 @@ func (d *DAO) Query(query databaseModel.Query, slice any) error {
 		}
 		// then get back the actual struct behind the value.
 		obj := value.Interface()
-		if unmarshalErr := d.unmarshal(data, obj); unmarshalErr != nil {
+		if unmarshalErr := d.unmarshal(doc.data, obj); unmarshalErr != nil {
 			return unmarshalErr
 		}
 		if typeParameter.Elem().Kind() != reflect.Ptr {
 			// In case the type of the slice element is not a pointer,
 			// we should return the value of the pointer created in the previous step.
-			sliceElem.Set(reflect.Append(sliceElem, value.Elem()))
+			sliceElem = reflect.Append(sliceElem, value.Elem())
 		} else {
-			sliceElem.Set(reflect.Append(sliceElem, value))
+			sliceElem = reflect.Append(sliceElem, value)
 		}
 	}
 	// At the end reset the element of the slice to ensure we didn't disconnect the link between the pointer to the slice and the actual slice
 	result.Elem().Set(sliceElem)
 	return nil
 }
+
+// document is a file read from the disk.
+type document struct {
+	file string
+	data []byte
+	key  databaseModel.SortKey
+}
+
+// queryDocuments reads the files matching the query.
+// When the list is paginated, the documents are sorted and the token to get the next page is set in the pagination of the query.
+func (d *DAO) queryDocuments(query databaseModel.Query) ([]document, error) {
+	folder, prefix, selector, isExist, err := d.buildQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the query: %s", err)
+	}
+	if !isExist {
+		return nil, nil
+	}
+	// so now we have the proper folder to looking for and potentially a filter to use
+	var files []string
+	if files, err = d.visit(folder, prefix); err != nil {
+		return nil, err
+	}
+	pagination := query.GetPagination()
+	isPaginated := pagination != nil && pagination.IsEnabled()
+	result := make([]document, 0, len(files))
+	for _, file := range files {
+		// now read all files and append them to the final result
+		data, readErr := os.ReadFile(file) //nolint: gosec
+		if readErr != nil {
+			return nil, readErr
+		}
+		isMatching, matchErr := d.matchLabels(data, selector)
+		if matchErr != nil {
+			return nil, matchErr
+		}
+		if !isMatching {
+			continue
+		}
+		doc := document{file: file, data: data}
+		if isPaginated {
+			if doc.key, err = d.sortKey(data); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, doc)
+	}
+	if !isPaginated {
+		return result, nil
+	}
+	return databaseModel.Paginate(pagination, result, func(doc document) databaseModel.SortKey {
+		return doc.key
+	})
+}
+
 func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
 	key, generateIDErr := generateID(kind, metadata)
 	if generateIDErr != nil {
 		return generateIDErr
 	}
 	filePath := d.buildPath(key)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 	err := os.Remove(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -251,7 +310,7 @@ func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
 }
 
 func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
-	folder, prefix, isExist, err := d.buildQuery(query)
+	folder, prefix, _, isExist, err := d.buildQuery(query)
 	if err != nil {
 		return fmt.Errorf("unable to build the query: %s", err)
 	}
@@ -315,6 +374,39 @@ func (d *DAO) marshal(entity any) ([]byte, error) {
 	return yaml.Marshal(entity)
 }
 
+// matchLabels returns true if the labels of the document satisfy the selector.
+// Only the labels are decoded, so the documents not selected are not unmarshalled entirely.
+func (d *DAO) matchLabels(data []byte, selector databaseModel.LabelSelector) (bool, error) {
+	if selector.IsEmpty() {
+		return true, nil
+	}
+	doc := struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels" yaml:"labels"`
+		} `json:"metadata" yaml:"metadata"`
+	}{}
+	if err := d.unmarshal(data, &doc); err != nil {
+		return false, err
+	}
+	return selector.Matches(doc.Metadata.Labels), nil
+}
+
+// sortKey decodes only the metadata fields the list can be sorted by.
+func (d *DAO) sortKey(data []byte) (databaseModel.SortKey, error) {
+	doc := struct {
+		Metadata struct {
+			Name      string    `json:"name" yaml:"name"`
+			Project   string    `json:"project" yaml:"project"`
+			CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+			UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
+		} `json:"metadata" yaml:"metadata"`
+	}{}
+	if err := d.unmarshal(data, &doc); err != nil {
+		return databaseModel.SortKey{}, err
+	}
+	return databaseModel.NewSortKey(doc.Metadata.Project, doc.Metadata.Name, doc.Metadata.CreatedAt, doc.Metadata.UpdatedAt), nil
+}
+
 func (d *DAO) visit(rootPath string, prefix string) ([]string, error) {
 	var result []string
 	err := filepath.Walk(rootPath, func(path string, info fs.FileInfo, err error) error {
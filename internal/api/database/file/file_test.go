@@ -14,13 +14,16 @@
 package databasefile
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,6 +66,24 @@ func TestDAO_Upsert(t *testing.T) {
 	removeAllFiles(t)
 }
 
+func TestDAO_Update(t *testing.T) {
+	d := newDAO()
+	projectEntity := &modelV1.Project{
+		Kind: modelV1.KindProject,
+		Metadata: modelV1.Metadata{
+			Name: "perses",
+		},
+	}
+	assert.True(t, databaseModel.IsKeyNotFound(d.Update(projectEntity)))
+	assert.NoError(t, d.Create(projectEntity))
+	// the entity stored has the version 0, so the update must provide the version 1
+	assert.True(t, databaseModel.IsVersionConflict(d.Update(projectEntity)))
+	projectEntity.Metadata.Version = 1
+	assert.NoError(t, d.Update(projectEntity))
+	assert.True(t, databaseModel.IsVersionConflict(d.Update(projectEntity)))
+	removeAllFiles(t)
+}
+
 func TestDAO_Get(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
@@ -96,6 +117,77 @@ func TestDAO_Query(t *testing.T) {
 	removeAllFiles(t)
 }
 
+func TestDAO_QueryLabelSelector(t *testing.T) {
+	d := newDAO()
+	projects := []*modelV1.Project{
+		{Kind: modelV1.KindProject, Metadata: modelV1.Metadata{Name: "sre", Labels: map[string]string{"team": "sre", "tier": "prod"}}},
+		{Kind: modelV1.KindProject, Metadata: modelV1.Metadata{Name: "dev", Labels: map[string]string{"team": "sre", "tier": "dev"}}},
+		{Kind: modelV1.KindProject, Metadata: modelV1.Metadata{Name: "none"}},
+	}
+	for _, p := range projects {
+		assert.NoError(t, d.Create(p))
+	}
+	selector, err := databaseModel.ParseLabelSelector("team=sre,tier!=dev")
+	assert.NoError(t, err)
+	var result []*modelV1.Project
+	assert.NoError(t, d.Query(&project.Query{LabelSelector: selector}, &result))
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, projects[0].Metadata, result[0].Metadata)
+	}
+	raws, err := d.RawQuery(&project.Query{LabelSelector: selector})
+	assert.NoError(t, err)
+	assert.Len(t, raws, 1)
+
+	selector, err = databaseModel.ParseLabelSelector("team=ops")
+	assert.NoError(t, err)
+	result = nil
+	assert.NoError(t, d.Query(&project.Query{LabelSelector: selector}, &result))
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+	removeAllFiles(t)
+}
+
+func TestDAO_QueryPagination(t *testing.T) {
+	d := newDAO()
+	now := time.Now().UTC()
+	for i, name := range []string{"c", "a", "e", "b", "d"} {
+		entity := &modelV1.Project{Kind: modelV1.KindProject, Metadata: modelV1.Metadata{Name: name, CreatedAt: now.Add(time.Duration(i) * time.Second)}}
+		assert.NoError(t, d.Create(entity))
+	}
+	query := &project.Query{Pagination: databaseModel.Pagination{Limit: 2}}
+	var result []*modelV1.Project
+	assert.NoError(t, d.Query(query, &result))
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "a", result[0].Metadata.Name)
+		assert.Equal(t, "b", result[1].Metadata.Name)
+	}
+	assert.NotEmpty(t, query.Pagination.Next())
+
+	query = &project.Query{Pagination: databaseModel.Pagination{Limit: 2, Continue: query.Pagination.Next()}}
+	raws, err := d.RawQuery(query)
+	assert.NoError(t, err)
+	assert.Len(t, raws, 2)
+	assert.NotEmpty(t, query.Pagination.Next())
+
+	query = &project.Query{Pagination: databaseModel.Pagination{Limit: 2, Continue: query.Pagination.Next()}}
+	result = nil
+	assert.NoError(t, d.Query(query, &result))
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "e", result[0].Metadata.Name)
+	}
+	assert.Empty(t, query.Pagination.Next())
+
+	query = &project.Query{Pagination: databaseModel.Pagination{SortBy: databaseModel.SortByCreatedAt, Order: databaseModel.SortOrderDesc}}
+	result = nil
+	assert.NoError(t, d.Query(query, &result))
+	var names []string
+	for _, entity := range result {
+		names = append(names, entity.Metadata.Name)
+	}
+	assert.Equal(t, []string{"d", "b", "e", "a", "c"}, names)
+	removeAllFiles(t)
+}
+
 func TestDAO_Delete(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
@@ -110,3 +202,189 @@ func TestDAO_Delete(t *testing.T) {
 	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindProject, projectEntity.GetMetadata(), result)))
 	removeAllFiles(t)
 }
+
+func TestDAO_Revisions(t *testing.T) {
+	d := newDAO()
+	projectEntity := &modelV1.Project{
+		Kind: modelV1.KindProject,
+		Metadata: modelV1.Metadata{
+			Name: "perses",
+		},
+	}
+	for version := uint64(0); version < 4; version++ {
+		projectEntity.Metadata.Version = version
+		assert.NoError(t, d.CreateRevision(projectEntity, "admin", 3))
+	}
+	revisions, err := d.ListRevisions(modelV1.KindProject, projectEntity.GetMetadata())
+	assert.NoError(t, err)
+	// Only the last 3 revisions are kept, the latest first.
+	assert.Len(t, revisions, 3)
+	assert.Equal(t, uint64(3), revisions[0].Version)
+	assert.Equal(t, uint64(1), revisions[2].Version)
+	assert.Equal(t, "admin", revisions[0].Author)
+
+	revision, err := d.GetRevision(modelV1.KindProject, projectEntity.GetMetadata(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), revision.Version)
+	result := &modelV1.Project{}
+	assert.NoError(t, json.Unmarshal(revision.Entity, result))
+	assert.Equal(t, uint64(2), result.Metadata.Version)
+	_, err = d.GetRevision(modelV1.KindProject, projectEntity.GetMetadata(), 0)
+	assert.True(t, databaseModel.IsKeyNotFound(err))
+
+	assert.NoError(t, d.DeleteRevisions(modelV1.KindProject, projectEntity.GetMetadata()))
+	revisions, err = d.ListRevisions(modelV1.KindProject, projectEntity.GetMetadata())
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+	removeAllFiles(t)
+}
+
+func TestDAO_AuditEvents(t *testing.T) {
+	d := newDAO()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []*modelV1.AuditEvent{
+		{Timestamp: start, User: "alice", Action: role.CreateAction, Kind: modelV1.KindProject, Project: "perses", Name: "perses", Outcome: modelV1.AuditOutcomeSuccess},
+		{Timestamp: start.Add(time.Minute), User: "bob", Action: role.CreateAction, Kind: modelV1.KindDashboard, Project: "perses", Name: "demo", Outcome: modelV1.AuditOutcomeSuccess},
+		{Timestamp: start.Add(2 * time.Minute), User: "alice", Action: role.DeleteAction, Kind: modelV1.KindDashboard, Project: "perses", Name: "demo", Outcome: modelV1.AuditOutcomeFailure},
+	}
+	for _, event := range events {
+		assert.NoError(t, d.CreateAuditEvent(event))
+	}
+
+	result, err := d.QueryAuditEvents(&databaseModel.AuditQuery{})
+	assert.NoError(t, err)
+	// The latest events come first.
+	assert.Equal(t, []*modelV1.AuditEvent{events[2], events[1], events[0]}, result)
+
+	result, err = d.QueryAuditEvents(&databaseModel.AuditQuery{User: "alice", Kind: modelV1.KindDashboard})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.AuditEvent{events[2]}, result)
+
+	result, err = d.QueryAuditEvents(&databaseModel.AuditQuery{From: start, To: start.Add(2 * time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.AuditEvent{events[1], events[0]}, result)
+
+	result, err = d.QueryAuditEvents(&databaseModel.AuditQuery{Project: "perses", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.AuditEvent{events[2]}, result)
+	removeAllFiles(t)
+}
+
+func TestDAO_WebhookDeliveries(t *testing.T) {
+	d := newDAO()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	due := start.Add(time.Minute)
+	later := start.Add(time.Hour)
+	deliveries := []*modelV1.WebhookDelivery{
+		{ID: "1", Webhook: "ci", Action: role.CreateAction, Kind: modelV1.KindDashboard, Name: "demo", Status: modelV1.WebhookDeliveryPending, CreatedAt: start, NextAttemptAt: &due, Payload: []byte(`{"delivery":"1"}`)},
+		{ID: "2", Webhook: "chat", Action: role.UpdateAction, Kind: modelV1.KindProject, Name: "perses", Status: modelV1.WebhookDeliveryPending, CreatedAt: start.Add(time.Second), NextAttemptAt: &later, Payload: []byte(`{"delivery":"2"}`)},
+		{ID: "3", Webhook: "ci", Action: role.CreateAction, Kind: modelV1.KindDashboard, Name: "demo", Status: modelV1.WebhookDeliverySuccess, CreatedAt: start.Add(2 * time.Second), Attempts: 1, Payload: []byte(`{"delivery":"3"}`)},
+	}
+	for _, delivery := range deliveries {
+		assert.NoError(t, d.CreateWebhookDelivery(delivery))
+	}
+	assert.True(t, databaseModel.IsKeyConflict(d.CreateWebhookDelivery(deliveries[0])))
+
+	result, err := d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{})
+	assert.NoError(t, err)
+	// The oldest deliveries come first.
+	assert.Equal(t, deliveries, result)
+
+	result, err = d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Status: modelV1.WebhookDeliveryPending, DueBefore: start.Add(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.WebhookDelivery{deliveries[0]}, result)
+
+	deliveries[0].Status = modelV1.WebhookDeliveryFailed
+	deliveries[0].NextAttemptAt = nil
+	deliveries[0].Error = "connection refused"
+	assert.NoError(t, d.UpdateWebhookDelivery(deliveries[0]))
+	result, err = d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "ci", Status: modelV1.WebhookDeliveryFailed})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.WebhookDelivery{deliveries[0]}, result)
+	assert.True(t, databaseModel.IsKeyNotFound(d.UpdateWebhookDelivery(&modelV1.WebhookDelivery{ID: "4", Webhook: "ci"})))
+
+	assert.NoError(t, d.DeleteWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Status: modelV1.WebhookDeliverySuccess, CreatedBefore: start.Add(time.Hour)}))
+	assert.NoError(t, d.DeleteWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "chat"}))
+	result, err = d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.WebhookDelivery{deliveries[0]}, result)
+	removeAllFiles(t)
+}
+
+func TestDAO_AccessTokens(t *testing.T) {
+	d := newDAO()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := start.Add(24 * time.Hour)
+	tokens := []*databaseModel.AccessToken{
+		{AccessToken: modelV1.AccessToken{ID: "0f8b2c9e-4d55-4c6b-9a57-3b7f5e0f9d21", Name: "laptop", Subject: modelV1.Subject{Kind: modelV1.KindUser, Name: "jane"}, CreatedAt: start}, Hash: "hash1"},
+		{AccessToken: modelV1.AccessToken{ID: "6a1d4e57-2f3b-4d7e-8c61-0b9a3f2e5d48", Name: "ci", Subject: modelV1.Subject{Kind: modelV1.KindServiceAccount, Name: "ci"}, CreatedAt: start.Add(time.Second), ExpiresAt: &expiry}, Hash: "hash2"},
+		{AccessToken: modelV1.AccessToken{ID: "c3e9a2b1-7d64-4f0a-9b25-8e1f6d3c4a70", Name: "scripts", Subject: modelV1.Subject{Kind: modelV1.KindUser, Name: "jane"}, CreatedAt: start.Add(2 * time.Second), Permissions: []role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.DashboardScope}}}}, Hash: "hash3"},
+	}
+	for _, token := range tokens {
+		assert.NoError(t, d.CreateAccessToken(token))
+	}
+	assert.True(t, databaseModel.IsKeyConflict(d.CreateAccessToken(tokens[0])))
+
+	result, err := d.QueryAccessTokens(&databaseModel.AccessTokenQuery{})
+	assert.NoError(t, err)
+	// The oldest tokens come first.
+	assert.Equal(t, tokens, result)
+
+	result, err = d.QueryAccessTokens(&databaseModel.AccessTokenQuery{SubjectKind: modelV1.KindUser, SubjectName: "jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.AccessToken{tokens[0], tokens[2]}, result)
+
+	lastUsed := start.Add(time.Hour)
+	tokens[1].LastUsedAt = &lastUsed
+	assert.NoError(t, d.UpdateAccessToken(tokens[1]))
+	result, err = d.QueryAccessTokens(&databaseModel.AccessTokenQuery{ID: tokens[1].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.AccessToken{tokens[1]}, result)
+	assert.True(t, databaseModel.IsKeyNotFound(d.UpdateAccessToken(&databaseModel.AccessToken{AccessToken: modelV1.AccessToken{ID: "5b7c1e2d-9a83-4f6e-b0d4-2c8e7a1f3b69"}})))
+
+	assert.NoError(t, d.DeleteAccessTokens(&databaseModel.AccessTokenQuery{ID: tokens[0].ID}))
+	assert.NoError(t, d.DeleteAccessTokens(&databaseModel.AccessTokenQuery{SubjectKind: modelV1.KindServiceAccount, SubjectName: "ci"}))
+	result, err = d.QueryAccessTokens(&databaseModel.AccessTokenQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.AccessToken{tokens[2]}, result)
+	assert.NoError(t, d.DeleteAccessTokens(&databaseModel.AccessTokenQuery{}))
+}
+
+func TestDAO_Sessions(t *testing.T) {
+	d := newDAO()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions := []*modelV1.Session{
+		{ID: "2d4f6a8c-1b3e-4c5d-8e7f-9a0b1c2d3e4f", Username: "jane", ProviderKind: "native", UserAgent: "Firefox", CreatedAt: start, ExpiresAt: start.Add(time.Hour)},
+		{ID: "7e9a1c3b-5d2f-4a6e-9b8c-0d1e2f3a4b5c", Username: "john", ProviderKind: "oidc", ProviderID: "azure", CreatedAt: start.Add(time.Second), ExpiresAt: start.Add(48 * time.Hour)},
+		{ID: "b4c6d8e0-2a1f-4e3d-a5b7-c9d0e1f2a3b4", Username: "jane", ProviderKind: "native", UserAgent: "percli", CreatedAt: start.Add(2 * time.Second), ExpiresAt: start.Add(24 * time.Hour)},
+	}
+	for _, session := range sessions {
+		assert.NoError(t, d.CreateSession(session))
+	}
+	assert.True(t, databaseModel.IsKeyConflict(d.CreateSession(sessions[0])))
+
+	result, err := d.QuerySessions(&databaseModel.SessionQuery{})
+	assert.NoError(t, err)
+	// The oldest sessions come first.
+	assert.Equal(t, sessions, result)
+
+	result, err = d.QuerySessions(&databaseModel.SessionQuery{Username: "jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.Session{sessions[0], sessions[2]}, result)
+
+	lastRefreshed := start.Add(time.Minute)
+	sessions[2].LastRefreshedAt = &lastRefreshed
+	assert.NoError(t, d.UpdateSession(sessions[2]))
+	result, err = d.QuerySessions(&databaseModel.SessionQuery{ID: sessions[2].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.Session{sessions[2]}, result)
+	assert.True(t, databaseModel.IsKeyNotFound(d.UpdateSession(&modelV1.Session{ID: "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b"})))
+
+	// Only the first session is expired two hours later.
+	assert.NoError(t, d.DeleteSessions(&databaseModel.SessionQuery{ExpiredBefore: start.Add(2 * time.Hour)}))
+	assert.NoError(t, d.DeleteSessions(&databaseModel.SessionQuery{Username: "john"}))
+	result, err = d.QuerySessions(&databaseModel.SessionQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.Session{sessions[2]}, result)
+	assert.NoError(t, d.DeleteSessions(&databaseModel.SessionQuery{}))
+}
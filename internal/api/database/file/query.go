@@ -33,8 +33,10 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
@@ -63,55 +65,78 @@ func (d *DAO) generateResourceQuery(kind v1.Kind) string {
 	return filepath.Join(d.Folder, v1.PluralKindMap[kind])
 }
 
-func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix string, isExist bool, err error) {
+func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix string, selector databaseModel.LabelSelector, isExist bool, err error) {
 	switch qt := query.(type) {
 	case *dashboard.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindDashboard, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *datasource.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindDatasource, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *ephemeraldashboard.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindEphemeralDashboard, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *folder.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindFolder, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *globaldatasource.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalDatasource)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *globalrole.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalRole)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *globalrolebinding.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalRoleBinding)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *globalsecret.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalSecret)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *globalvariable.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalVariable)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *project.Query:
 		pathFolder = d.generateResourceQuery(v1.KindProject)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *role.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindRole, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *rolebinding.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindRoleBinding, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *secret.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindSecret, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
+	case *serviceaccount.Query:
+		pathFolder = d.generateResourceQuery(v1.KindServiceAccount)
+		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *user.Query:
 		pathFolder = d.generateResourceQuery(v1.KindUser)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	case *variable.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindVariable, qt.Project)
 		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
+	case *webhook.Query:
+		pathFolder = d.generateResourceQuery(v1.KindWebhook)
+		prefix = qt.NamePrefix
+		selector = qt.LabelSelector
 	default:
-		return "", "", false, fmt.Errorf("this type of query '%T' is not managed", qt)
+		return "", "", selector, false, fmt.Errorf("this type of query '%T' is not managed", qt)
 	}
 	if !d.CaseSensitive {
 		pathFolder = strings.ToLower(pathFolder)
@@ -0,0 +1,169 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/utils"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// revisionFolder is the folder, relative to the database folder, containing the revision history.
+// Each revision is stored in the file <revisionFolder>/<id of the object>/<version>.json.
+// Revisions are always stored in JSON, as the entity is kept as it was marshalled.
+const revisionFolder = "revisions"
+
+const revisionExtension = ".json"
+
+func (d *DAO) buildRevisionFolder(kind modelV1.Kind, metadata modelAPI.Metadata) (string, string, error) {
+	key, generateIDErr := generateID(kind, metadata)
+	if generateIDErr != nil {
+		return "", "", generateIDErr
+	}
+	return key, filepath.Join(d.Folder, revisionFolder, key), nil
+}
+
+func (d *DAO) CreateRevision(entity modelAPI.Entity, author string, maxRevisions int) error {
+	entity.GetMetadata().Flatten(d.CaseSensitive)
+	_, folder, err := d.buildRevisionFolder(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+	if err != nil {
+		return err
+	}
+	entityJSON, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	version := utils.GetMetadataVersion(entity.GetMetadata())
+	data, err := json.Marshal(&databaseModel.Revision{
+		RevisionMetadata: modelV1.RevisionMetadata{
+			Version:   version,
+			Author:    author,
+			CreatedAt: time.Now().UTC(),
+		},
+		Entity: entityJSON,
+	})
+	if err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if mkdirErr := os.MkdirAll(folder, 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	if writeErr := os.WriteFile(filepath.Join(folder, fmt.Sprintf("%d%s", version, revisionExtension)), data, 0600); writeErr != nil {
+		return writeErr
+	}
+	if maxRevisions <= 0 {
+		return nil
+	}
+	versions, err := listRevisionVersions(folder)
+	if err != nil {
+		return err
+	}
+	for i := maxRevisions; i < len(versions); i++ {
+		if removeErr := os.Remove(filepath.Join(folder, fmt.Sprintf("%d%s", versions[i], revisionExtension))); removeErr != nil {
+			return removeErr
+		}
+	}
+	return nil
+}
+
+func (d *DAO) GetRevision(kind modelV1.Kind, metadata modelAPI.Metadata, version uint64) (*databaseModel.Revision, error) {
+	metadata.Flatten(d.CaseSensitive)
+	key, folder, err := d.buildRevisionFolder(kind, metadata)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(folder, fmt.Sprintf("%d%s", version, revisionExtension))) //nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &databaseModel.Error{Key: fmt.Sprintf("%s/%d", key, version), Code: databaseModel.ErrorCodeNotFound}
+		}
+		return nil, err
+	}
+	revision := &databaseModel.Revision{}
+	return revision, json.Unmarshal(data, revision)
+}
+
+func (d *DAO) ListRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) ([]*modelV1.RevisionMetadata, error) {
+	metadata.Flatten(d.CaseSensitive)
+	_, folder, err := d.buildRevisionFolder(kind, metadata)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := listRevisionVersions(folder)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*modelV1.RevisionMetadata, 0, len(versions))
+	for _, version := range versions {
+		data, readErr := os.ReadFile(filepath.Join(folder, fmt.Sprintf("%d%s", version, revisionExtension))) //nolint: gosec
+		if readErr != nil {
+			return nil, readErr
+		}
+		revision := &modelV1.RevisionMetadata{}
+		if unmarshalErr := json.Unmarshal(data, revision); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		result = append(result, revision)
+	}
+	return result, nil
+}
+
+func (d *DAO) DeleteRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) error {
+	metadata.Flatten(d.CaseSensitive)
+	// When the name is empty, the folder is the one of the project, so the whole project history is removed.
+	_, folder, err := d.buildRevisionFolder(kind, metadata)
+	if err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return os.RemoveAll(folder)
+}
+
+// listRevisionVersions returns the versions stored in the folder, the latest first.
+func listRevisionVersions(folder string) ([]uint64, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []uint64{}, nil
+		}
+		return nil, err
+	}
+	versions := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, revisionExtension) {
+			continue
+		}
+		version, parseErr := strconv.ParseUint(strings.TrimSuffix(name, revisionExtension), 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+	return versions, nil
+}
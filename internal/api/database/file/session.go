@@ -0,0 +1,146 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// sessionFolder is the folder, relative to the database folder, containing the sessions.
+// Each session is stored in the file <sessionFolder>/<id of the session>.json.
+const sessionFolder = "sessions"
+
+const sessionExtension = ".json"
+
+func (d *DAO) buildSessionPath(id string) string {
+	return filepath.Join(d.Folder, sessionFolder, id+sessionExtension)
+}
+
+func (d *DAO) CreateSession(session *modelV1.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	path := d.buildSessionPath(session.ID)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, statErr := os.Stat(path); statErr == nil {
+		return &databaseModel.Error{Key: session.ID, Code: databaseModel.ErrorCodeConflict}
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *DAO) UpdateSession(session *modelV1.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	path := d.buildSessionPath(session.ID)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, statErr := os.Stat(path); statErr != nil {
+		if os.IsNotExist(statErr) {
+			return &databaseModel.Error{Key: session.ID, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return statErr
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *DAO) QuerySessions(query *databaseModel.SessionQuery) ([]*modelV1.Session, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	result, _, err := d.readSessions(query)
+	return result, err
+}
+
+func (d *DAO) DeleteSessions(query *databaseModel.SessionQuery) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	_, paths, err := d.readSessions(query)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
+			return removeErr
+		}
+	}
+	return nil
+}
+
+// readSessions returns the sessions matching the query, the oldest first, and the files containing them.
+// The caller must hold the mutex.
+func (d *DAO) readSessions(query *databaseModel.SessionQuery) ([]*modelV1.Session, []string, error) {
+	var files []string
+	if len(query.ID) > 0 && filepath.Base(query.ID) != query.ID {
+		// The ID comes from the requests, it must not be able to designate a file outside the folder.
+		return []*modelV1.Session{}, nil, nil
+	}
+	if len(query.ID) > 0 {
+		// The session is read directly instead of reading the whole folder, as it is done for every authenticated request.
+		files = []string{query.ID + sessionExtension}
+	} else {
+		entries, err := os.ReadDir(filepath.Join(d.Folder, sessionFolder))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && filepath.Ext(entry.Name()) == sessionExtension {
+				files = append(files, entry.Name())
+			}
+		}
+	}
+	sessions := []*modelV1.Session{}
+	pathByID := make(map[string]string)
+	for _, file := range files {
+		path := filepath.Join(d.Folder, sessionFolder, file)
+		data, readErr := os.ReadFile(path) //nolint: gosec
+		if readErr != nil {
+			if os.IsNotExist(readErr) {
+				continue
+			}
+			return nil, nil, readErr
+		}
+		session := &modelV1.Session{}
+		if unmarshalErr := json.Unmarshal(data, session); unmarshalErr != nil {
+			return nil, nil, fmt.Errorf("unable to read the session %q: %w", path, unmarshalErr)
+		}
+		if query.Match(session) {
+			sessions = append(sessions, session)
+			pathByID[session.ID] = path
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].ID < sessions[j].ID
+		}
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	paths := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		paths = append(paths, pathByID[session.ID])
+	}
+	return sessions, paths, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// webhookDeliveryFolder is the folder, relative to the database folder, containing the webhook deliveries.
+// Each delivery is stored in the file <webhookDeliveryFolder>/<name of the webhook>/<id of the delivery>.json.
+const webhookDeliveryFolder = "webhookdeliveries"
+
+const webhookDeliveryExtension = ".json"
+
+func (d *DAO) buildWebhookDeliveryPath(delivery *modelV1.WebhookDelivery) string {
+	return filepath.Join(d.Folder, webhookDeliveryFolder, delivery.Webhook, delivery.ID+webhookDeliveryExtension)
+}
+
+func (d *DAO) CreateWebhookDelivery(delivery *modelV1.WebhookDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	path := d.buildWebhookDeliveryPath(delivery)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, statErr := os.Stat(path); statErr == nil {
+		return &databaseModel.Error{Key: delivery.ID, Code: databaseModel.ErrorCodeConflict}
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *DAO) UpdateWebhookDelivery(delivery *modelV1.WebhookDelivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	path := d.buildWebhookDeliveryPath(delivery)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if _, statErr := os.Stat(path); statErr != nil {
+		if os.IsNotExist(statErr) {
+			return &databaseModel.Error{Key: delivery.ID, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return statErr
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *DAO) QueryWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) ([]*modelV1.WebhookDelivery, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	result, _, err := d.readWebhookDeliveries(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+func (d *DAO) DeleteWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	_, paths, err := d.readWebhookDeliveries(query)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
+			return removeErr
+		}
+	}
+	if len(query.Webhook) > 0 && len(query.Status) == 0 && query.DueBefore.IsZero() && query.CreatedBefore.IsZero() {
+		// Every delivery of the webhook has been removed, so its folder can be removed too.
+		return os.RemoveAll(filepath.Join(d.Folder, webhookDeliveryFolder, query.Webhook))
+	}
+	return nil
+}
+
+// readWebhookDeliveries returns the deliveries matching the query, the oldest first, and the files containing them.
+// The caller must hold the mutex.
+func (d *DAO) readWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) ([]*modelV1.WebhookDelivery, []string, error) {
+	root := filepath.Join(d.Folder, webhookDeliveryFolder)
+	var webhooks []string
+	if len(query.Webhook) > 0 {
+		webhooks = []string{query.Webhook}
+	} else {
+		entries, err := os.ReadDir(root)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				webhooks = append(webhooks, entry.Name())
+			}
+		}
+	}
+	deliveries := []*modelV1.WebhookDelivery{}
+	pathByID := make(map[string]string)
+	for _, webhook := range webhooks {
+		folder := filepath.Join(root, webhook)
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != webhookDeliveryExtension {
+				continue
+			}
+			path := filepath.Join(folder, entry.Name())
+			data, readErr := os.ReadFile(path) //nolint: gosec
+			if readErr != nil {
+				return nil, nil, readErr
+			}
+			delivery := &modelV1.WebhookDelivery{}
+			if unmarshalErr := json.Unmarshal(data, delivery); unmarshalErr != nil {
+				return nil, nil, fmt.Errorf("unable to read the webhook delivery %q: %w", path, unmarshalErr)
+			}
+			if query.Match(delivery) {
+				deliveries = append(deliveries, delivery)
+				pathByID[delivery.ID] = path
+			}
+		}
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].ID < deliveries[j].ID
+		}
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+	paths := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		paths = append(paths, pathByID[delivery.ID])
+	}
+	return deliveries, paths, nil
+}
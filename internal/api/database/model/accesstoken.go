@@ -0,0 +1,46 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// AccessToken is an access token as it is stored. The token itself is never stored, only its hash.
+type AccessToken struct {
+	modelV1.AccessToken `json:",inline"`
+	// Hash is the hash of the secret part of the token.
+	Hash string `json:"hash"`
+}
+
+// AccessTokenQuery is used to filter the access tokens. Every empty field is ignored.
+type AccessTokenQuery struct {
+	ID          string
+	SubjectKind modelV1.Kind
+	SubjectName string
+}
+
+// Match returns true if the token matches every filter of the query.
+func (q *AccessTokenQuery) Match(token *AccessToken) bool {
+	if len(q.ID) > 0 && q.ID != token.ID {
+		return false
+	}
+	if len(q.SubjectKind) > 0 && q.SubjectKind != token.Subject.Kind {
+		return false
+	}
+	if len(q.SubjectName) > 0 && q.SubjectName != token.Subject.Name {
+		return false
+	}
+	return true
+}
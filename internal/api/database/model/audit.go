@@ -0,0 +1,53 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// AuditQuery is used to filter the audit events. Every empty field is ignored.
+type AuditQuery struct {
+	Project string
+	User    string
+	Kind    modelV1.Kind
+	// From is the inclusive lower bound of the time range.
+	From time.Time
+	// To is the exclusive upper bound of the time range.
+	To time.Time
+	// Limit is the maximum number of events returned, the latest first. 0 means no limit.
+	Limit int
+}
+
+// Match returns true if the event matches every filter of the query.
+func (q *AuditQuery) Match(event *modelV1.AuditEvent) bool {
+	if len(q.Project) > 0 && q.Project != event.Project {
+		return false
+	}
+	if len(q.User) > 0 && q.User != event.User {
+		return false
+	}
+	if len(q.Kind) > 0 && q.Kind != event.Kind {
+		return false
+	}
+	if !q.From.IsZero() && event.Timestamp.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !event.Timestamp.Before(q.To) {
+		return false
+	}
+	return true
+}
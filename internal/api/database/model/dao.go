@@ -25,6 +25,14 @@ type Query interface {
 	GetMetadataOnlyQueryParam() bool
 	IsRawQueryAllowed() bool
 	IsRawMetadataQueryAllowed() bool
+	GetPagination() *Pagination
+}
+
+// Revision is a version of a resource kept in the revision history.
+type Revision struct {
+	modelV1.RevisionMetadata `json:",inline"`
+	// Entity is the resource as it was when the revision has been recorded.
+	Entity json.RawMessage `json:"entity"`
 }
 
 type DAO interface {
@@ -33,6 +41,10 @@ type DAO interface {
 	IsCaseSensitive() bool
 	Create(entity modelAPI.Entity) error
 	Upsert(entity modelAPI.Entity) error
+	// Update will replace an existing object. The update is atomic and only applied if the object stored is the previous
+	// version of the entity, i.e. metadata.version of the entity is the stored version + 1.
+	// Otherwise, a VersionConflictError is returned.
+	Update(entity modelAPI.Entity) error
 	// Get will find a unique object. It will depend on the implementation to generate the key based on the kind and the metadata.
 	// entity is the object that will be used by the method to set the value returned by the database.
 	Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error
@@ -45,4 +57,42 @@ type DAO interface {
 	DeleteByQuery(query Query) error
 	HealthCheck() bool
 	GetLatestUpdateTime(kind []modelV1.Kind) (*string, error)
+	// CreateRevision records the current state of the entity in the revision history, keyed by metadata.version.
+	// Only the last maxRevisions revisions of the entity are kept. A maxRevisions lower or equal to 0 means no limit.
+	CreateRevision(entity modelAPI.Entity, author string, maxRevisions int) error
+	// GetRevision returns the revision of the object matching the given version.
+	GetRevision(kind modelV1.Kind, metadata modelAPI.Metadata, version uint64) (*Revision, error)
+	// ListRevisions returns the metadata of every revision kept for the object, the latest version first.
+	ListRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) ([]*modelV1.RevisionMetadata, error)
+	// DeleteRevisions removes the revision history of the object.
+	// If metadata is a ProjectMetadata with an empty name, the history of every object of the kind in the project is removed.
+	DeleteRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) error
+	// CreateAuditEvent appends the event to the audit log. Events are never modified nor removed.
+	CreateAuditEvent(event *modelV1.AuditEvent) error
+	// QueryAuditEvents returns the audit events matching the query, the latest first.
+	QueryAuditEvents(query *AuditQuery) ([]*modelV1.AuditEvent, error)
+	// CreateWebhookDelivery adds the delivery to the queue of deliveries.
+	CreateWebhookDelivery(delivery *modelV1.WebhookDelivery) error
+	// UpdateWebhookDelivery replaces the stored delivery having the same ID.
+	UpdateWebhookDelivery(delivery *modelV1.WebhookDelivery) error
+	// QueryWebhookDeliveries returns the deliveries matching the query, the oldest first.
+	QueryWebhookDeliveries(query *WebhookDeliveryQuery) ([]*modelV1.WebhookDelivery, error)
+	// DeleteWebhookDeliveries removes the deliveries matching the query.
+	DeleteWebhookDeliveries(query *WebhookDeliveryQuery) error
+	// CreateAccessToken stores a new access token.
+	CreateAccessToken(token *AccessToken) error
+	// UpdateAccessToken replaces the stored access token having the same ID.
+	UpdateAccessToken(token *AccessToken) error
+	// QueryAccessTokens returns the access tokens matching the query, the oldest first.
+	QueryAccessTokens(query *AccessTokenQuery) ([]*AccessToken, error)
+	// DeleteAccessTokens removes the access tokens matching the query.
+	DeleteAccessTokens(query *AccessTokenQuery) error
+	// CreateSession stores a new session.
+	CreateSession(session *modelV1.Session) error
+	// UpdateSession replaces the stored session having the same ID.
+	UpdateSession(session *modelV1.Session) error
+	// QuerySessions returns the sessions matching the query, the oldest first.
+	QuerySessions(query *SessionQuery) ([]*modelV1.Session, error)
+	// DeleteSessions removes the sessions matching the query.
+	DeleteSessions(query *SessionQuery) error
 }
@@ -36,6 +36,12 @@ func IsKeyConflict(err error) bool {
 	return false
 }
 
+// IsVersionConflict returns true if the error is a VersionConflictError.
+func IsVersionConflict(err error) bool {
+	_, ok := err.(*VersionConflictError)
+	return ok
+}
+
 type Error struct {
 	Key  string
 	Code int
@@ -44,3 +50,14 @@ type Error struct {
 func (e *Error) Error() string {
 	return fmt.Sprintf("ErrorCode: %d, key: %s", e.Code, e.Key)
 }
+
+// VersionConflictError is returned when an entity cannot be updated because it has been modified in the meantime.
+// CurrentVersion is the version of the entity stored in the database.
+type VersionConflictError struct {
+	Key            string
+	CurrentVersion uint64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict, key: %s, current version: %d", e.Key, e.CurrentVersion)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// LabelSelector is used to filter the resources based on their labels.
+// It uses the Kubernetes syntax, e.g. `team=sre,tier!=dev`, `tier in (prod,staging)`, `team` or `!team`.
+// The zero value selects every resource.
+type LabelSelector struct {
+	requirements labels.Requirements
+}
+
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	result := LabelSelector{}
+	requirements, err := labels.ParseToRequirements(selector)
+	if err != nil {
+		return result, err
+	}
+	for _, requirement := range requirements {
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.NotEquals, selection.In, selection.NotIn, selection.Exists, selection.DoesNotExist:
+		default:
+			return result, fmt.Errorf("the operator %q used in the label selector is not supported", requirement.Operator())
+		}
+	}
+	result.requirements = requirements
+	return result, nil
+}
+
+// UnmarshalParam is used by echo to parse the label selector when it is passed as a query parameter.
+func (s *LabelSelector) UnmarshalParam(param string) error {
+	selector, err := ParseLabelSelector(param)
+	if err != nil {
+		return err
+	}
+	*s = selector
+	return nil
+}
+
+func (s LabelSelector) IsEmpty() bool {
+	return len(s.requirements) == 0
+}
+
+// Requirements returns every condition of the selector. A resource is selected when it matches all of them.
+func (s LabelSelector) Requirements() labels.Requirements {
+	return s.requirements
+}
+
+// Matches returns true if the given labels satisfy every condition of the selector.
+func (s LabelSelector) Matches(resourceLabels map[string]string) bool {
+	set := labels.Set(resourceLabels)
+	for _, requirement := range s.requirements {
+		if !requirement.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s LabelSelector) String() string {
+	return labels.NewSelector().Add(s.requirements...).String()
+}
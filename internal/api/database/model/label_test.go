@@ -0,0 +1,39 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := ParseLabelSelector("team=sre,tier!=dev")
+	assert.NoError(t, err)
+	assert.True(t, selector.Matches(map[string]string{"team": "sre", "tier": "prod"}))
+	assert.True(t, selector.Matches(map[string]string{"team": "sre"}))
+	assert.False(t, selector.Matches(map[string]string{"team": "sre", "tier": "dev"}))
+	assert.False(t, selector.Matches(nil))
+
+	selector, err = ParseLabelSelector("")
+	assert.NoError(t, err)
+	assert.True(t, selector.IsEmpty())
+	assert.True(t, selector.Matches(nil))
+
+	_, err = ParseLabelSelector("replicas>2")
+	assert.Error(t, err)
+	_, err = ParseLabelSelector("team=s r e")
+	assert.Error(t, err)
+}
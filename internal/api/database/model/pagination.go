@@ -0,0 +1,279 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/tidwall/gjson"
+)
+
+type SortField string
+
+const (
+	SortByName      SortField = "name"
+	SortByCreatedAt SortField = "createdAt"
+	SortByUpdatedAt SortField = "updatedAt"
+)
+
+// UnmarshalParam is used by echo to check the sort field when it is passed as a query parameter.
+func (s *SortField) UnmarshalParam(param string) error {
+	switch SortField(param) {
+	case SortByName, SortByCreatedAt, SortByUpdatedAt:
+		*s = SortField(param)
+		return nil
+	}
+	return fmt.Errorf("unable to sort by %q, valid values are %q, %q and %q", param, SortByName, SortByCreatedAt, SortByUpdatedAt)
+}
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// UnmarshalParam is used by echo to check the sort order when it is passed as a query parameter.
+func (o *SortOrder) UnmarshalParam(param string) error {
+	switch SortOrder(param) {
+	case SortOrderAsc, SortOrderDesc:
+		*o = SortOrder(param)
+		return nil
+	}
+	return fmt.Errorf("invalid sort order %q, valid values are %q and %q", param, SortOrderAsc, SortOrderDesc)
+}
+
+// Pagination is used to get a list page by page.
+// The zero value means the list is returned entirely and in no particular order.
+type Pagination struct {
+	// Limit is the maximum number of resources returned. 0 means no limit.
+	Limit int `query:"limit"`
+	// Continue is the token returned with the previous page. It is used to get the next one.
+	Continue string `query:"continue"`
+	// SortBy is the field used to sort the list. When the list is paginated, it is sorted by name by default.
+	SortBy SortField `query:"sort"`
+	Order  SortOrder `query:"order"`
+	// next is the token to get the next page. It is set by the database once the query is executed.
+	next string
+}
+
+// IsEnabled returns true when the list must be sorted, and possibly cut, by the database.
+func (p *Pagination) IsEnabled() bool {
+	return p.Limit > 0 || len(p.Continue) > 0 || len(p.SortBy) > 0
+}
+
+func (p *Pagination) Validate() error {
+	if p.Limit < 0 {
+		return fmt.Errorf("limit must be positive")
+	}
+	_, err := p.Cursor()
+	return err
+}
+
+func (p *Pagination) GetSortBy() SortField {
+	if len(p.SortBy) == 0 {
+		return SortByName
+	}
+	return p.SortBy
+}
+
+func (p *Pagination) IsDescending() bool {
+	return p.Order == SortOrderDesc
+}
+
+// Cursor decodes the continuation token. It returns nil when the first page is requested.
+func (p *Pagination) Cursor() (*Cursor, error) {
+	if len(p.Continue) == 0 {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(p.Continue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continue token")
+	}
+	cursor := &Cursor{}
+	if unmarshalErr := json.Unmarshal(data, cursor); unmarshalErr != nil {
+		return nil, fmt.Errorf("invalid continue token")
+	}
+	if cursor.SortBy != p.GetSortBy() || cursor.Descending != p.IsDescending() {
+		return nil, fmt.Errorf("the continue token has been issued for another sort, the parameters sort and order must not change from one page to another")
+	}
+	if cursor.SortBy != SortByName {
+		if _, parseErr := time.Parse(time.RFC3339Nano, cursor.Value); parseErr != nil {
+			return nil, fmt.Errorf("invalid continue token")
+		}
+	}
+	return cursor, nil
+}
+
+// SetNext stores the token to get the page after the one ending with the given key.
+func (p *Pagination) SetNext(last SortKey) {
+	cursor := Cursor{
+		SortBy:     p.GetSortBy(),
+		Descending: p.IsDescending(),
+		Value:      last.Value(p.GetSortBy()),
+		ID:         last.ID,
+	}
+	// Marshalling a struct of strings and a boolean can't fail
+	data, _ := json.Marshal(cursor)
+	p.next = base64.RawURLEncoding.EncodeToString(data)
+}
+
+// SetNextToken is used to forward the token when the query has been copied before being passed to the database.
+func (p *Pagination) SetNextToken(token string) {
+	p.next = token
+}
+
+// Next returns the token to get the next page. It is empty when the last page has been returned.
+func (p *Pagination) Next() string {
+	return p.next
+}
+
+// Cursor is the content of the continuation token. It is the position of the last resource returned.
+type Cursor struct {
+	SortBy     SortField `json:"sort"`
+	Descending bool      `json:"desc,omitempty"`
+	Value      string    `json:"value"`
+	ID         string    `json:"id"`
+}
+
+// SortKey gathers the values a list can be sorted by.
+type SortKey struct {
+	// ID identifies the resource. It is used to order the resources having the same sorting value.
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSortKey(project string, name string, createdAt time.Time, updatedAt time.Time) SortKey {
+	id := name
+	if len(project) > 0 {
+		id = fmt.Sprintf("%s|%s", project, name)
+	}
+	return SortKey{ID: id, Name: name, CreatedAt: createdAt, UpdatedAt: updatedAt}
+}
+
+func NewSortKeyFromMetadata(metadata modelAPI.Metadata) SortKey {
+	switch m := metadata.(type) {
+	case *modelV1.ProjectMetadata:
+		return NewSortKey(m.Project, m.Name, m.CreatedAt, m.UpdatedAt)
+	case *modelV1.Metadata:
+		return NewSortKey("", m.Name, m.CreatedAt, m.UpdatedAt)
+	}
+	return NewSortKey("", metadata.GetName(), time.Time{}, time.Time{})
+}
+
+// NewSortKeyFromJSON extracts the sort key from a resource marshalled in JSON.
+func NewSortKeyFromJSON(doc []byte) SortKey {
+	metadata := gjson.GetBytes(doc, "metadata")
+	return NewSortKey(
+		metadata.Get("project").String(),
+		metadata.Get("name").String(),
+		metadata.Get("createdAt").Time(),
+		metadata.Get("updatedAt").Time(),
+	)
+}
+
+// Value returns the value of the field used to sort the list as it is stored in the continuation token.
+func (k SortKey) Value(field SortField) string {
+	switch field {
+	case SortByCreatedAt:
+		return k.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case SortByUpdatedAt:
+		return k.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return k.Name
+	}
+}
+
+func (k SortKey) compare(other SortKey, field SortField) int {
+	var result int
+	switch field {
+	case SortByCreatedAt:
+		result = k.CreatedAt.Compare(other.CreatedAt)
+	case SortByUpdatedAt:
+		result = k.UpdatedAt.Compare(other.UpdatedAt)
+	default:
+		result = strings.Compare(k.Name, other.Name)
+	}
+	if result != 0 {
+		return result
+	}
+	return strings.Compare(k.ID, other.ID)
+}
+
+func (c *Cursor) key() SortKey {
+	key := SortKey{ID: c.ID}
+	switch c.SortBy {
+	case SortByCreatedAt:
+		key.CreatedAt, _ = time.Parse(time.RFC3339Nano, c.Value)
+	case SortByUpdatedAt:
+		key.UpdatedAt, _ = time.Parse(time.RFC3339Nano, c.Value)
+	default:
+		key.Name = c.Value
+	}
+	return key
+}
+
+// Paginate sorts the list in memory and returns the page requested.
+// It is used when the database can't sort and cut the list by itself.
+// The list is returned unchanged when the pagination is not enabled.
+func Paginate[T any](p *Pagination, list []T, keyOf func(T) SortKey) ([]T, error) {
+	if !p.IsEnabled() {
+		return list, nil
+	}
+	cursor, err := p.Cursor()
+	if err != nil {
+		return nil, err
+	}
+	field := p.GetSortBy()
+	descending := p.IsDescending()
+	keys := make([]SortKey, len(list))
+	index := make([]int, len(list))
+	for i, item := range list {
+		keys[i] = keyOf(item)
+		index[i] = i
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		result := keys[index[i]].compare(keys[index[j]], field)
+		if descending {
+			return result > 0
+		}
+		return result < 0
+	})
+	result := make([]T, 0, len(list))
+	var last SortKey
+	for _, i := range index {
+		if cursor != nil {
+			position := keys[i].compare(cursor.key(), field)
+			if (!descending && position <= 0) || (descending && position >= 0) {
+				continue
+			}
+		}
+		if p.Limit > 0 && len(result) == p.Limit {
+			p.SetNext(last)
+			break
+		}
+		result = append(result, list[i])
+		last = keys[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination_Validate(t *testing.T) {
+	assert.NoError(t, (&Pagination{}).Validate())
+	assert.Error(t, (&Pagination{Limit: -1}).Validate())
+	assert.Error(t, (&Pagination{Continue: "not a token"}).Validate())
+
+	p := &Pagination{SortBy: SortByUpdatedAt}
+	p.SetNext(NewSortKey("perses", "demo", time.Time{}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, (&Pagination{SortBy: SortByUpdatedAt, Continue: p.Next()}).Validate())
+	// The token can't be used with another sort.
+	assert.Error(t, (&Pagination{Continue: p.Next()}).Validate())
+	assert.Error(t, (&Pagination{SortBy: SortByUpdatedAt, Order: SortOrderDesc, Continue: p.Next()}).Validate())
+
+	var sortBy SortField
+	assert.Error(t, sortBy.UnmarshalParam("version"))
+	var order SortOrder
+	assert.Error(t, order.UnmarshalParam("random"))
+}
+
+func TestPaginate(t *testing.T) {
+	list := []string{"d", "b", "a", "e", "c"}
+	keyOf := func(name string) SortKey {
+		return NewSortKey("", name, time.Time{}, time.Time{})
+	}
+
+	result, err := Paginate(&Pagination{}, list, keyOf)
+	assert.NoError(t, err)
+	assert.Equal(t, list, result)
+
+	p := &Pagination{Limit: 2, Order: SortOrderDesc}
+	var pages [][]string
+	for {
+		result, err = Paginate(p, list, keyOf)
+		assert.NoError(t, err)
+		pages = append(pages, result)
+		if len(p.Next()) == 0 {
+			break
+		}
+		p = &Pagination{Limit: 2, Order: SortOrderDesc, Continue: p.Next()}
+	}
+	assert.Equal(t, [][]string{{"e", "d"}, {"c", "b"}, {"a"}}, pages)
+}
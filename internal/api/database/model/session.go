@@ -0,0 +1,42 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// SessionQuery is used to filter the sessions. Every empty field is ignored.
+type SessionQuery struct {
+	ID       string
+	Username string
+	// ExpiredBefore keeps only the sessions expired before this time.
+	ExpiredBefore time.Time
+}
+
+// Match returns true if the session matches every filter of the query.
+func (q *SessionQuery) Match(session *modelV1.Session) bool {
+	if len(q.ID) > 0 && q.ID != session.ID {
+		return false
+	}
+	if len(q.Username) > 0 && q.Username != session.Username {
+		return false
+	}
+	if !q.ExpiredBefore.IsZero() && !session.ExpiresAt.Before(q.ExpiredBefore) {
+		return false
+	}
+	return true
+}
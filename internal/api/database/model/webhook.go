@@ -0,0 +1,49 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// WebhookDeliveryQuery is used to filter the webhook deliveries. Every empty field is ignored.
+type WebhookDeliveryQuery struct {
+	Webhook string
+	Status  modelV1.WebhookDeliveryStatus
+	// DueBefore only keeps the deliveries having their next attempt planned at or before this time.
+	DueBefore time.Time
+	// CreatedBefore only keeps the deliveries created strictly before this time.
+	CreatedBefore time.Time
+	// Limit is the maximum number of deliveries returned. 0 means no limit.
+	Limit int
+}
+
+// Match returns true if the delivery matches every filter of the query.
+func (q *WebhookDeliveryQuery) Match(delivery *modelV1.WebhookDelivery) bool {
+	if len(q.Webhook) > 0 && q.Webhook != delivery.Webhook {
+		return false
+	}
+	if len(q.Status) > 0 && q.Status != delivery.Status {
+		return false
+	}
+	if !q.DueBefore.IsZero() && (delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(q.DueBefore)) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !delivery.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"encoding/json"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+)
+
+const (
+	// tableAccessToken contains the access tokens of the users and of the service accounts.
+	tableAccessToken = "access_token"
+
+	colSubjectKind = "subject_kind"
+	colSubjectName = "subject_name"
+)
+
+func (d *DAO) createAccessTokenTable() string {
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableAccessToken)).IfNotExists().
+		Define(colID, "VARCHAR(64)", "NOT NULL", "PRIMARY KEY").
+		Define(colSubjectKind, "VARCHAR(32)", "NOT NULL").
+		Define(colSubjectName, "VARCHAR(128)", "NOT NULL").
+		Define(colCreatedAt, "BIGINT", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
+		String()
+}
+
+func (d *DAO) CreateAccessToken(token *databaseModel.AccessToken) error {
+	rowJSONDoc, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	insertBuilder := d.flavor().NewInsertBuilder().InsertInto(d.generateCompleteTableName(tableAccessToken)).
+		Cols(colID, colSubjectKind, colSubjectName, colCreatedAt, colDoc).
+		Values(token.ID, string(token.Subject.Kind), token.Subject.Name, token.CreatedAt.UnixNano(), string(rowJSONDoc))
+	sqlQuery, args := insertBuilder.Build()
+	_, err = d.DB.Exec(sqlQuery, args...)
+	return err
+}
+
+func (d *DAO) UpdateAccessToken(token *databaseModel.AccessToken) error {
+	rowJSONDoc, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	updateBuilder := d.flavor().NewUpdateBuilder().Update(d.generateCompleteTableName(tableAccessToken))
+	updateBuilder.Set(
+		updateBuilder.Assign(colDoc, string(rowJSONDoc)),
+	).Where(updateBuilder.Equal(colID, token.ID))
+	sqlQuery, args := updateBuilder.Build()
+	result, err := d.DB.Exec(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr == nil && rows == 0 {
+		return &databaseModel.Error{Key: token.ID, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return nil
+}
+
+func (d *DAO) QueryAccessTokens(query *databaseModel.AccessTokenQuery) ([]*databaseModel.AccessToken, error) {
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colDoc).From(d.generateCompleteTableName(tableAccessToken))
+	if conditions := accessTokenConditions(query, selectBuilder.Equal); len(conditions) > 0 {
+		selectBuilder.Where(conditions...)
+	}
+	selectBuilder.OrderBy(colCreatedAt, colID).Asc()
+	sqlQuery, args := selectBuilder.Build()
+
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := []*databaseModel.AccessToken{}
+	for rows.Next() {
+		var rowJSONDoc string
+		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
+			return nil, scanErr
+		}
+		token := &databaseModel.AccessToken{}
+		if unmarshalErr := json.Unmarshal([]byte(rowJSONDoc), token); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		result = append(result, token)
+	}
+	return result, rows.Err()
+}
+
+func (d *DAO) DeleteAccessTokens(query *databaseModel.AccessTokenQuery) error {
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(d.generateCompleteTableName(tableAccessToken))
+	if conditions := accessTokenConditions(query, deleteBuilder.Equal); len(conditions) > 0 {
+		deleteBuilder.Where(conditions...)
+	}
+	sqlQuery, args := deleteBuilder.Build()
+	_, err := d.DB.Exec(sqlQuery, args...)
+	return err
+}
+
+func accessTokenConditions(query *databaseModel.AccessTokenQuery, equal func(field string, value any) string) []string {
+	var conditions []string
+	if len(query.ID) > 0 {
+		conditions = append(conditions, equal(colID, query.ID))
+	}
+	if len(query.SubjectKind) > 0 {
+		conditions = append(conditions, equal(colSubjectKind, string(query.SubjectKind)))
+	}
+	if len(query.SubjectName) > 0 {
+		conditions = append(conditions, equal(colSubjectName, query.SubjectName))
+	}
+	return conditions
+}
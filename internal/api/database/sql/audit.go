@@ -0,0 +1,101 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"encoding/json"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	// tableAudit contains the audit events. Rows are only inserted, never updated nor deleted.
+	tableAudit = "audit"
+
+	// colTimestamp is the time of the event in nanoseconds since the Unix epoch.
+	colTimestamp = "event_time"
+	// colUsername is not named "user" as it is a reserved keyword in PostgreSQL.
+	colUsername = "username"
+)
+
+func (d *DAO) createAuditTable() string {
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableAudit)).IfNotExists().
+		Define(colTimestamp, "BIGINT", "NOT NULL").
+		Define(colUsername, "VARCHAR(128)", "NOT NULL").
+		Define(colKind, "VARCHAR(64)", "NOT NULL").
+		Define(colProject, "VARCHAR(128)", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
+		String()
+}
+
+func (d *DAO) CreateAuditEvent(event *modelV1.AuditEvent) error {
+	rowJSONDoc, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	insertBuilder := d.flavor().NewInsertBuilder().InsertInto(d.generateCompleteTableName(tableAudit)).
+		Cols(colTimestamp, colUsername, colKind, colProject, colDoc).
+		Values(event.Timestamp.UnixNano(), event.User, string(event.Kind), event.Project, string(rowJSONDoc))
+	sqlQuery, args := insertBuilder.Build()
+	_, err = d.DB.Exec(sqlQuery, args...)
+	return err
+}
+
+func (d *DAO) QueryAuditEvents(query *databaseModel.AuditQuery) ([]*modelV1.AuditEvent, error) {
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colDoc).From(d.generateCompleteTableName(tableAudit))
+	var conditions []string
+	if len(query.Project) > 0 {
+		conditions = append(conditions, selectBuilder.Equal(colProject, query.Project))
+	}
+	if len(query.User) > 0 {
+		conditions = append(conditions, selectBuilder.Equal(colUsername, query.User))
+	}
+	if len(query.Kind) > 0 {
+		conditions = append(conditions, selectBuilder.Equal(colKind, string(query.Kind)))
+	}
+	if !query.From.IsZero() {
+		conditions = append(conditions, selectBuilder.GreaterEqualThan(colTimestamp, query.From.UnixNano()))
+	}
+	if !query.To.IsZero() {
+		conditions = append(conditions, selectBuilder.LessThan(colTimestamp, query.To.UnixNano()))
+	}
+	if len(conditions) > 0 {
+		selectBuilder.Where(conditions...)
+	}
+	selectBuilder.OrderBy(colTimestamp).Desc()
+	if query.Limit > 0 {
+		selectBuilder.Limit(query.Limit)
+	}
+	sqlQuery, args := selectBuilder.Build()
+
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := []*modelV1.AuditEvent{}
+	for rows.Next() {
+		var rowJSONDoc string
+		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
+			return nil, scanErr
+		}
+		event := &modelV1.AuditEvent{}
+		if unmarshalErr := json.Unmarshal([]byte(rowJSONDoc), event); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		result = append(result, event)
+	}
+	return result, rows.Err()
+}
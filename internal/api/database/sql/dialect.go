@@ -0,0 +1,108 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	// tableUpdateTime is used with PostgreSQL and SQLite.
+	// Contrary to MySQL, these databases don't expose when a table has been modified for the last time.
+	// This table is filled by triggers attached to every resource table and is used to know when the RBAC cache must be refreshed.
+	tableUpdateTime = "perses_update_time"
+
+	colTableName  = "table_name"
+	colUpdateTime = "update_time"
+)
+
+var resourceTables = []string{
+	tableDashboard,
+	tableDatasource,
+	tableEphemeralDashboard,
+	tableFolder,
+	tableGlobalDatasource,
+	tableGlobalRole,
+	tableGlobalRoleBinding,
+	tableGlobalSecret,
+	tableGlobalVariable,
+	tableProject,
+	tableRole,
+	tableRoleBinding,
+	tableSecret,
+	tableServiceAccount,
+	tableUser,
+	tableVariable,
+	tableWebhook,
+}
+
+func (d *DAO) flavor() sqlbuilder.Flavor {
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return sqlbuilder.PostgreSQL
+	case config.SQLDriverSQLite:
+		return sqlbuilder.SQLite
+	default:
+		return sqlbuilder.MySQL
+	}
+}
+
+// documentType returns the column type used to store the JSON document of a resource.
+func (d *DAO) documentType() string {
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return "JSONB"
+	case config.SQLDriverSQLite:
+		// SQLite has no JSON type, JSON documents are stored as text.
+		return "TEXT"
+	default:
+		return "JSON"
+	}
+}
+
+func (d *DAO) createUpdateTimeTable() string {
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableUpdateTime)).IfNotExists().
+		Define(colTableName, "VARCHAR(128)", "NOT NULL", "PRIMARY KEY").
+		Define(colUpdateTime, "TIMESTAMP", "NOT NULL").
+		String()
+}
+
+// getTrackedLatestUpdateTime returns the latest update time recorded in the table tableUpdateTime for the given kinds.
+// selectExpr is the expression used to select the maximum update time formatted as expected by the caller of GetLatestUpdateTime.
+func (d *DAO) getTrackedLatestUpdateTime(kinds []modelV1.Kind, selectExpr string) (*string, error) {
+	sb := d.flavor().NewSelectBuilder()
+	sb.Select(selectExpr)
+	sb.From(d.generateCompleteTableName(tableUpdateTime))
+	tableNames := make([]any, 0, len(kinds))
+	for _, kind := range kinds {
+		tableName, err := getTableName(kind)
+		if err != nil {
+			return nil, err
+		}
+		tableNames = append(tableNames, tableName)
+	}
+	sb.Where(sb.In(colTableName, tableNames...))
+	query, args := sb.Build()
+
+	var timestamp *string
+	if err := d.DB.QueryRow(query, args...).Scan(&timestamp); err != nil {
+		return nil, fmt.Errorf("failed to retrieve last update time for tables %v: %w", kinds, err)
+	}
+	// timestamp is nil when none of the tables have been modified yet.
+	return timestamp, nil
+}
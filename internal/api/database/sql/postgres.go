@@ -0,0 +1,71 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	functionTrackUpdateTime = "perses_track_update_time"
+
+	// postgresTimestampFormat is the format expected by the consumer of GetLatestUpdateTime.
+	// It is the same format as the one used by MySQL for the column information_schema.tables.UPDATE_TIME.
+	postgresTimestampFormat = "YYYY-MM-DD HH24:MI:SS"
+)
+
+func (d *DAO) initPostgresUpdateTracking() error {
+	queries := []string{
+		d.createUpdateTimeTable(),
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS TRIGGER AS $$
+BEGIN
+	INSERT INTO %s (%s, %s) VALUES (TG_TABLE_NAME, timezone('UTC', now()))
+	ON CONFLICT (%s) DO UPDATE SET %s = EXCLUDED.%s;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`,
+			d.generateCompleteTableName(functionTrackUpdateTime), d.generateCompleteTableName(tableUpdateTime), colTableName, colUpdateTime,
+			colTableName, colUpdateTime, colUpdateTime),
+	}
+	for _, tableName := range resourceTables {
+		queries = append(queries, d.createPostgresUpdateTrigger(tableName))
+	}
+	for _, query := range queries {
+		if _, err := d.DB.Exec(query); err != nil {
+			return fmt.Errorf("unable to initialize the update tracking of the tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// createPostgresUpdateTrigger returns the query creating the trigger that records the last modification of the given table.
+// CREATE OR REPLACE TRIGGER is only available since PostgreSQL 14, so the existence of the trigger is checked first.
+func (d *DAO) createPostgresUpdateTrigger(tableName string) string {
+	triggerName := fmt.Sprintf("%s_%s", tableName, colUpdateTime)
+	completeTableName := d.generateCompleteTableName(tableName)
+	return fmt.Sprintf(`DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = '%s' AND tgrelid = '%s'::regclass) THEN
+		CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s
+		FOR EACH STATEMENT EXECUTE FUNCTION %s();
+	END IF;
+END;
+$$`, triggerName, completeTableName, triggerName, completeTableName, d.generateCompleteTableName(functionTrackUpdateTime))
+}
+
+func (d *DAO) getPostgresLatestUpdateTime(kinds []modelV1.Kind) (*string, error) {
+	return d.getTrackedLatestUpdateTime(kinds, fmt.Sprintf("to_char(MAX(%s), '%s')", colUpdateTime, postgresTimestampFormat))
+}
@@ -33,22 +33,26 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
 	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
-func generateProjectResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.ProjectMetadata) (string, []any) {
-	return sqlbuilder.NewInsertBuilder().
+func (d *DAO) generateProjectResourceInsertQuery(tableName string, id string, rowJSONDoc string, metadata *modelV1.ProjectMetadata) (string, []any) {
+	return d.flavor().NewInsertBuilder().
 		InsertInto(tableName).
 		Cols(colID, colName, colProject, colDoc).
 		Values(id, metadata.Name, metadata.Project, rowJSONDoc).
 		Build()
 }
 
-func generateResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.Metadata) (string, []any) {
-	return sqlbuilder.NewInsertBuilder().
+func (d *DAO) generateResourceInsertQuery(tableName string, id string, rowJSONDoc string, metadata *modelV1.Metadata) (string, []any) {
+	return d.flavor().NewInsertBuilder().
 		InsertInto(tableName).
 		Cols(colID, colName, colDoc).
 		Values(id, metadata.Name, rowJSONDoc).
@@ -60,6 +64,7 @@ func (d *DAO) generateInsertQuery(entity modelAPI.Entity) (string, []any, error)
 	if idErr != nil {
 		return "", nil, idErr
 	}
+	// The document is passed as a string, so it is stored as text and not as a blob by the drivers making the difference.
 	rowJSONDoc, unmarshalErr := json.Marshal(entity)
 	if unmarshalErr != nil {
 		return "", nil, unmarshalErr
@@ -68,9 +73,9 @@ func (d *DAO) generateInsertQuery(entity modelAPI.Entity) (string, []any, error)
 	var args []any
 	switch m := entity.GetMetadata().(type) {
 	case *modelV1.ProjectMetadata:
-		sql, args = generateProjectResourceInsertQuery(tableName, id, rowJSONDoc, m)
+		sql, args = d.generateProjectResourceInsertQuery(tableName, id, string(rowJSONDoc), m)
 	case *modelV1.Metadata:
-		sql, args = generateResourceInsertQuery(tableName, id, rowJSONDoc, m)
+		sql, args = d.generateResourceInsertQuery(tableName, id, string(rowJSONDoc), m)
 	}
 	return sql, args, nil
 }
@@ -84,21 +89,21 @@ func (d *DAO) generateUpdateQuery(entity modelAPI.Entity) (string, []any, error)
 	if unmarshalErr != nil {
 		return "", nil, unmarshalErr
 	}
-	builder := sqlbuilder.NewUpdateBuilder().Update(tableName)
+	builder := d.flavor().NewUpdateBuilder().Update(tableName)
 	builder.Where(builder.Equal(colID, id))
-	builder.Set(builder.Assign(colDoc, rowJSONDoc))
+	builder.Set(builder.Assign(colDoc, string(rowJSONDoc)))
 	sql, args := builder.Build()
 	return sql, args, nil
 }
 
-func (d *DAO) generateSelectQuery(tableName string, project string, name string) (string, []any) {
+func (d *DAO) generateSelectQuery(tableName string, project string, name string, selector databaseModel.LabelSelector, pagination *databaseModel.Pagination) (string, []any, error) {
 	p := project
 	n := name
 	if !d.CaseSensitive {
 		p = strings.ToLower(p)
 		n = strings.ToLower(n)
 	}
-	queryBuilder := sqlbuilder.NewSelectBuilder().
+	queryBuilder := d.flavor().NewSelectBuilder().
 		Select(colDoc).
 		From(tableName)
 	if len(n) > 0 {
@@ -107,47 +112,176 @@ func (d *DAO) generateSelectQuery(tableName string, project string, name string)
 	if len(p) > 0 {
 		queryBuilder.Where(queryBuilder.Equal(colProject, p))
 	}
-	return queryBuilder.Build()
+	d.addLabelConditions(queryBuilder, selector)
+	if err := d.addPagination(queryBuilder, pagination); err != nil {
+		return "", nil, err
+	}
+	sqlQuery, args := queryBuilder.Build()
+	return sqlQuery, args, nil
+}
+
+// addPagination sorts the result and only keeps the resources after the position given by the continuation token.
+// One more row than the limit is requested, so we know if there is a next page.
+func (d *DAO) addPagination(queryBuilder *sqlbuilder.SelectBuilder, pagination *databaseModel.Pagination) error {
+	if pagination == nil || !pagination.IsEnabled() {
+		return nil
+	}
+	cursor, err := pagination.Cursor()
+	if err != nil {
+		return err
+	}
+	sortBy := pagination.GetSortBy()
+	column := d.sortExpression(sortBy)
+	if cursor != nil {
+		operator := ">"
+		if pagination.IsDescending() {
+			operator = "<"
+		}
+		value := d.sortValueExpression(sortBy, queryBuilder.Var(cursor.Value))
+		sameValue := d.sortValueExpression(sortBy, queryBuilder.Var(cursor.Value))
+		queryBuilder.Where(fmt.Sprintf("(%s %s %s OR (%s = %s AND %s %s %s))", column, operator, value, column, sameValue, colID, operator, queryBuilder.Var(cursor.ID)))
+	}
+	order := "ASC"
+	if pagination.IsDescending() {
+		order = "DESC"
+	}
+	queryBuilder.OrderBy(fmt.Sprintf("%s %s", column, order), fmt.Sprintf("%s %s", colID, order))
+	if pagination.Limit > 0 {
+		queryBuilder.Limit(pagination.Limit + 1)
+	}
+	return nil
+}
+
+// sortExpression returns the expression used to sort the resources.
+// The timestamps are converted, so they are compared as dates and not as strings.
+func (d *DAO) sortExpression(sortBy databaseModel.SortField) string {
+	var field string
+	switch sortBy {
+	case databaseModel.SortByCreatedAt:
+		field = "createdAt"
+	case databaseModel.SortByUpdatedAt:
+		field = "updatedAt"
+	default:
+		return colName
+	}
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return d.timestampExpression(fmt.Sprintf("%s->'metadata'->>'%s'", colDoc, field))
+	case config.SQLDriverSQLite:
+		return d.timestampExpression(fmt.Sprintf("json_extract(%s, '$.metadata.%s')", colDoc, field))
+	default:
+		return d.timestampExpression(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, '$.metadata.%s'))", colDoc, field))
+	}
+}
+
+// sortValueExpression converts the value coming from the continuation token the same way as the sorted column.
+func (d *DAO) sortValueExpression(sortBy databaseModel.SortField, value string) string {
+	if sortBy == databaseModel.SortByName {
+		return value
+	}
+	return d.timestampExpression(value)
+}
+
+// timestampExpression converts a RFC3339 string into a value that is ordered chronologically.
+func (d *DAO) timestampExpression(value string) string {
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return fmt.Sprintf("CAST(%s AS TIMESTAMPTZ)", value)
+	case config.SQLDriverSQLite:
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d %%H:%%M:%%f', %s)", value)
+	default:
+		// Timestamps are always stored in UTC
+		return fmt.Sprintf("CAST(REPLACE(%s, 'Z', '') AS DATETIME(6))", value)
+	}
+}
+
+// addLabelConditions translates every requirement of the label selector into a condition on the JSON document.
+// Like with Kubernetes, `!=` and `notin` also select the resources that don't have the label.
+func (d *DAO) addLabelConditions(queryBuilder *sqlbuilder.SelectBuilder, selector databaseModel.LabelSelector) {
+	for _, requirement := range selector.Requirements() {
+		label := d.labelExpression(queryBuilder, requirement.Key())
+		values := make([]string, 0, requirement.Values().Len())
+		for _, value := range requirement.Values().List() {
+			values = append(values, queryBuilder.Var(value))
+		}
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals:
+			queryBuilder.Where(fmt.Sprintf("%s = %s", label, values[0]))
+		case selection.NotEquals:
+			queryBuilder.Where(fmt.Sprintf("(%s IS NULL OR %s <> %s)", label, label, values[0]))
+		case selection.In:
+			queryBuilder.Where(fmt.Sprintf("%s IN (%s)", label, strings.Join(values, ", ")))
+		case selection.NotIn:
+			queryBuilder.Where(fmt.Sprintf("(%s IS NULL OR %s NOT IN (%s))", label, label, strings.Join(values, ", ")))
+		case selection.Exists:
+			queryBuilder.Where(fmt.Sprintf("%s IS NOT NULL", label))
+		case selection.DoesNotExist:
+			queryBuilder.Where(fmt.Sprintf("%s IS NULL", label))
+		}
+	}
+}
+
+// labelExpression returns the expression extracting the value of the label from the JSON document.
+// It is NULL when the resource doesn't have the label.
+func (d *DAO) labelExpression(queryBuilder *sqlbuilder.SelectBuilder, key string) string {
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return fmt.Sprintf("%s->'metadata'->'labels'->>CAST(%s AS TEXT)", colDoc, queryBuilder.Var(key))
+	case config.SQLDriverSQLite:
+		return fmt.Sprintf("json_extract(%s, %s)", colDoc, queryBuilder.Var(labelJSONPath(key)))
+	default:
+		return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", colDoc, queryBuilder.Var(labelJSONPath(key)))
+	}
+}
+
+// labelJSONPath returns the JSON path of the label. The key is quoted as it can contain dots and slashes.
+func labelJSONPath(key string) string {
+	return fmt.Sprintf(`$.metadata.labels."%s"`, key)
 }
 
 func (d *DAO) buildQuery(query databaseModel.Query) (string, []any, error) {
 	var sqlQuery string
 	var args []any
+	var err error
 	switch qt := query.(type) {
 	case *dashboard.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *datasource.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *ephemeraldashboard.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableEphemeralDashboard), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableEphemeralDashboard), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *folder.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableFolder), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableFolder), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *globaldatasource.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalDatasource), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalDatasource), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *globalrole.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalRole), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalRole), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *globalrolebinding.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalRoleBinding), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalRoleBinding), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *globalsecret.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalSecret), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalSecret), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *globalvariable.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalVariable), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalVariable), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *project.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableProject), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableProject), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *role.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableRole), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableRole), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *rolebinding.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableRoleBinding), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableRoleBinding), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *secret.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableSecret), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableSecret), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
+	case *serviceaccount.Query:
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableServiceAccount), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *user.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableUser), "", qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableUser), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	case *variable.Query:
-		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableVariable), qt.Project, qt.NamePrefix)
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableVariable), qt.Project, qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
+	case *webhook.Query:
+		sqlQuery, args, err = d.generateSelectQuery(d.generateCompleteTableName(tableWebhook), "", qt.NamePrefix, qt.LabelSelector, qt.GetPagination())
 	default:
 		return "", nil, fmt.Errorf("this type of query '%T' is not managed", qt)
 	}
-	return sqlQuery, args, nil
+	return sqlQuery, args, err
 }
 
 func (d *DAO) generateDeleteQuery(tableName string, project string, name string) (string, []any) {
@@ -158,7 +292,7 @@ func (d *DAO) generateDeleteQuery(tableName string, project string, name string)
 		n = strings.ToLower(n)
 	}
 
-	queryBuilder := sqlbuilder.NewDeleteBuilder().
+	queryBuilder := d.flavor().NewDeleteBuilder().
 		DeleteFrom(tableName)
 	if len(n) > 0 {
 		queryBuilder.Where(queryBuilder.Like(colName, fmt.Sprintf("%s%%", n)))
@@ -199,10 +333,14 @@ func (d *DAO) buildDeleteQuery(query databaseModel.Query) (string, []any, error)
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableRoleBinding), qt.Project, qt.NamePrefix)
 	case *secret.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableSecret), qt.Project, qt.NamePrefix)
+	case *serviceaccount.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableServiceAccount), "", qt.NamePrefix)
 	case *user.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableUser), "", qt.NamePrefix)
 	case *variable.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableVariable), qt.Project, qt.NamePrefix)
+	case *webhook.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableWebhook), "", qt.NamePrefix)
 	default:
 		return "", nil, fmt.Errorf("this type of query '%T' is not managed", qt)
 	}
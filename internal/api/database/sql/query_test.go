@@ -15,17 +15,22 @@ package databasesql
 
 import (
 	"testing"
+	"time"
 
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/pkg/model/api/config"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGenerateProjectResourceSelectQuery(t *testing.T) {
 	testSuite := []struct {
-		title    string
-		project  string
-		name     string
-		sqlQuery string
-		sqlArgs  []any
+		title      string
+		project    string
+		name       string
+		selector   string
+		pagination databaseModel.Pagination
+		sqlQuery   string
+		sqlArgs    []any
 	}{
 		{
 			title:    "no project with a prefix name",
@@ -54,14 +59,91 @@ func TestGenerateProjectResourceSelectQuery(t *testing.T) {
 			name:     "",
 			sqlQuery: "SELECT doc FROM perses.dashboard",
 		},
+		{
+			title:    "a project with a label selector",
+			project:  "foo",
+			selector: "team=sre,tier!=dev",
+			sqlQuery: "SELECT doc FROM perses.dashboard WHERE project = ? AND JSON_UNQUOTE(JSON_EXTRACT(doc, ?)) = ? AND (JSON_UNQUOTE(JSON_EXTRACT(doc, ?)) IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(doc, ?)) <> ?)",
+			sqlArgs:  []any{"foo", `$.metadata.labels."team"`, "sre", `$.metadata.labels."tier"`, `$.metadata.labels."tier"`, "dev"},
+		},
+		{
+			title:    "set based label selector",
+			selector: "perses.dev/tier in (prod,staging),!deprecated",
+			sqlQuery: "SELECT doc FROM perses.dashboard WHERE JSON_UNQUOTE(JSON_EXTRACT(doc, ?)) IS NULL AND JSON_UNQUOTE(JSON_EXTRACT(doc, ?)) IN (?, ?)",
+			sqlArgs:  []any{`$.metadata.labels."deprecated"`, `$.metadata.labels."perses.dev/tier"`, "prod", "staging"},
+		},
+		{
+			title:      "first page sorted by name",
+			project:    "foo",
+			pagination: databaseModel.Pagination{Limit: 10},
+			sqlQuery:   "SELECT doc FROM perses.dashboard WHERE project = ? ORDER BY name ASC, id ASC LIMIT ?",
+			sqlArgs:    []any{"foo", 11},
+		},
+		{
+			title:      "next page sorted by name",
+			project:    "foo",
+			pagination: databaseModel.Pagination{Limit: 10, Continue: continueToken(databaseModel.Pagination{}, databaseModel.NewSortKey("foo", "bar", time.Time{}, time.Time{}))},
+			sqlQuery:   "SELECT doc FROM perses.dashboard WHERE project = ? AND (name > ? OR (name = ? AND id > ?)) ORDER BY name ASC, id ASC LIMIT ?",
+			sqlArgs:    []any{"foo", "bar", "bar", "foo|bar", 11},
+		},
+		{
+			title: "next page sorted by update time in descending order",
+			pagination: databaseModel.Pagination{
+				SortBy:   databaseModel.SortByUpdatedAt,
+				Order:    databaseModel.SortOrderDesc,
+				Continue: continueToken(databaseModel.Pagination{SortBy: databaseModel.SortByUpdatedAt, Order: databaseModel.SortOrderDesc}, databaseModel.NewSortKey("foo", "bar", time.Time{}, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))),
+			},
+			sqlQuery: "SELECT doc FROM perses.dashboard WHERE (CAST(REPLACE(JSON_UNQUOTE(JSON_EXTRACT(doc, '$.metadata.updatedAt')), 'Z', '') AS DATETIME(6)) < CAST(REPLACE(?, 'Z', '') AS DATETIME(6)) OR (CAST(REPLACE(JSON_UNQUOTE(JSON_EXTRACT(doc, '$.metadata.updatedAt')), 'Z', '') AS DATETIME(6)) = CAST(REPLACE(?, 'Z', '') AS DATETIME(6)) AND id < ?)) ORDER BY CAST(REPLACE(JSON_UNQUOTE(JSON_EXTRACT(doc, '$.metadata.updatedAt')), 'Z', '') AS DATETIME(6)) DESC, id DESC",
+			sqlArgs:  []any{"2025-01-02T03:04:05Z", "2025-01-02T03:04:05Z", "foo|bar"},
+		},
 	}
 
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {
 			d := &DAO{}
-			sqlQuery, args := d.generateSelectQuery("perses.dashboard", test.project, test.name)
+			selector, err := databaseModel.ParseLabelSelector(test.selector)
+			assert.NoError(t, err)
+			sqlQuery, args, err := d.generateSelectQuery("perses.dashboard", test.project, test.name, selector, &test.pagination)
+			assert.NoError(t, err)
 			assert.Equal(t, test.sqlQuery, sqlQuery)
 			assert.Equal(t, test.sqlArgs, args)
 		})
 	}
 }
+
+func TestGeneratePostgresQuery(t *testing.T) {
+	d := &DAO{Driver: config.SQLDriverPostgres, SchemaName: "public"}
+	t.Run("select", func(t *testing.T) {
+		sqlQuery, args, err := d.generateSelectQuery("public.dashboard", "foo", "bar", databaseModel.LabelSelector{}, &databaseModel.Pagination{})
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT doc FROM public.dashboard WHERE name LIKE $1 AND project = $2", sqlQuery)
+		assert.Equal(t, []any{"bar%", "foo"}, args)
+	})
+	t.Run("select with a label selector", func(t *testing.T) {
+		selector, err := databaseModel.ParseLabelSelector("team=sre")
+		assert.NoError(t, err)
+		sqlQuery, args, err := d.generateSelectQuery("public.dashboard", "foo", "", selector, &databaseModel.Pagination{})
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT doc FROM public.dashboard WHERE project = $1 AND doc->'metadata'->'labels'->>CAST($2 AS TEXT) = $3", sqlQuery)
+		assert.Equal(t, []any{"foo", "team", "sre"}, args)
+	})
+	t.Run("select a page sorted by creation time", func(t *testing.T) {
+		sqlQuery, args, err := d.generateSelectQuery("public.dashboard", "foo", "", databaseModel.LabelSelector{}, &databaseModel.Pagination{Limit: 5, SortBy: databaseModel.SortByCreatedAt})
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT doc FROM public.dashboard WHERE project = $1 ORDER BY CAST(doc->'metadata'->>'createdAt' AS TIMESTAMPTZ) ASC, id ASC LIMIT $2", sqlQuery)
+		assert.Equal(t, []any{"foo", 6}, args)
+	})
+	t.Run("delete", func(t *testing.T) {
+		sqlQuery, args := d.generateDeleteQuery("public.dashboard", "foo", "")
+		assert.Equal(t, "DELETE FROM public.dashboard WHERE project = $1", sqlQuery)
+		assert.Equal(t, []any{"foo"}, args)
+	})
+	t.Run("create table", func(t *testing.T) {
+		assert.Equal(t, "CREATE TABLE IF NOT EXISTS public.dashboard (id VARCHAR(256) NOT NULL PRIMARY KEY, name VARCHAR(128) NOT NULL, project VARCHAR(128) NOT NULL, doc JSONB NOT NULL)", d.createProjectResourceTable(tableDashboard))
+	})
+}
+
+func continueToken(pagination databaseModel.Pagination, last databaseModel.SortKey) string {
+	pagination.SetNext(last)
+	return pagination.Next()
+}
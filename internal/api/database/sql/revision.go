@@ -0,0 +1,195 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/utils"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	// tableRevision contains the previous versions of the resources.
+	// The table is not tracked in tableUpdateTime as it has no impact on the permissions.
+	tableRevision = "revision"
+
+	colKind    = "kind"
+	colVersion = "version"
+)
+
+func (d *DAO) createRevisionTable() string {
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableRevision)).IfNotExists().
+		Define(colKind, "VARCHAR(64)", "NOT NULL").
+		Define(colID, "VARCHAR(256)", "NOT NULL").
+		Define(colProject, "VARCHAR(128)", "NOT NULL").
+		Define(colVersion, "BIGINT", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
+		Define(fmt.Sprintf("PRIMARY KEY (%s, %s, %s)", colKind, colID, colVersion)).
+		String()
+}
+
+func getRevisionProject(metadata modelAPI.Metadata) string {
+	if m, ok := metadata.(*modelV1.ProjectMetadata); ok {
+		return m.Project
+	}
+	return ""
+}
+
+func (d *DAO) CreateRevision(entity modelAPI.Entity, author string, maxRevisions int) error {
+	entity.GetMetadata().Flatten(d.CaseSensitive)
+	kind := entity.GetKind()
+	id, idErr := generateID(entity.GetMetadata())
+	if idErr != nil {
+		return idErr
+	}
+	entityJSON, marshalErr := json.Marshal(entity)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	version := utils.GetMetadataVersion(entity.GetMetadata())
+	rowJSONDoc, marshalErr := json.Marshal(&databaseModel.Revision{
+		RevisionMetadata: modelV1.RevisionMetadata{
+			Version:   version,
+			Author:    author,
+			CreatedAt: time.Now().UTC(),
+		},
+		Entity: entityJSON,
+	})
+	if marshalErr != nil {
+		return marshalErr
+	}
+	tableName := d.generateCompleteTableName(tableRevision)
+
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(tableName)
+	deleteBuilder.Where(deleteBuilder.Equal(colKind, kind), deleteBuilder.Equal(colID, id), deleteBuilder.Equal(colVersion, version))
+	deleteQuery, deleteArgs := deleteBuilder.Build()
+
+	insertBuilder := d.flavor().NewInsertBuilder().InsertInto(tableName).
+		Cols(colKind, colID, colProject, colVersion, colDoc).
+		Values(kind, id, getRevisionProject(entity.GetMetadata()), version, string(rowJSONDoc))
+	insertQuery, insertArgs := insertBuilder.Build()
+
+	return d.transaction(func(tx *sql.Tx) error {
+		// A revision with the same version can remain if the object has been removed and created again without cleaning its history.
+		if _, err := tx.Exec(deleteQuery, deleteArgs...); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(insertQuery, insertArgs...); err != nil {
+			return err
+		}
+		if maxRevisions <= 0 {
+			return nil
+		}
+		return d.trimRevisions(tx, kind, id, maxRevisions)
+	})
+}
+
+// trimRevisions removes the revisions of the object that are older than the last maxRevisions ones.
+func (d *DAO) trimRevisions(tx *sql.Tx, kind string, id string, maxRevisions int) error {
+	tableName := d.generateCompleteTableName(tableRevision)
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colVersion).From(tableName)
+	selectBuilder.Where(selectBuilder.Equal(colKind, kind), selectBuilder.Equal(colID, id))
+	selectBuilder.OrderBy(colVersion).Desc().Limit(1).Offset(maxRevisions - 1)
+	selectQuery, selectArgs := selectBuilder.Build()
+
+	var oldestVersion uint64
+	if err := tx.QueryRow(selectQuery, selectArgs...).Scan(&oldestVersion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// There are fewer revisions than the limit.
+			return nil
+		}
+		return err
+	}
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(tableName)
+	deleteBuilder.Where(deleteBuilder.Equal(colKind, kind), deleteBuilder.Equal(colID, id), deleteBuilder.LessThan(colVersion, oldestVersion))
+	deleteQuery, deleteArgs := deleteBuilder.Build()
+	_, err := tx.Exec(deleteQuery, deleteArgs...)
+	return err
+}
+
+func (d *DAO) GetRevision(kind modelV1.Kind, metadata modelAPI.Metadata, version uint64) (*databaseModel.Revision, error) {
+	metadata.Flatten(d.CaseSensitive)
+	id, idErr := generateID(metadata)
+	if idErr != nil {
+		return nil, idErr
+	}
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colDoc).From(d.generateCompleteTableName(tableRevision))
+	selectBuilder.Where(selectBuilder.Equal(colKind, kind), selectBuilder.Equal(colID, id), selectBuilder.Equal(colVersion, version))
+	sqlQuery, args := selectBuilder.Build()
+
+	var rowJSONDoc string
+	if err := d.DB.QueryRow(sqlQuery, args...).Scan(&rowJSONDoc); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &databaseModel.Error{Key: fmt.Sprintf("%s|%d", id, version), Code: databaseModel.ErrorCodeNotFound}
+		}
+		return nil, err
+	}
+	revision := &databaseModel.Revision{}
+	return revision, json.Unmarshal([]byte(rowJSONDoc), revision)
+}
+
+func (d *DAO) ListRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) ([]*modelV1.RevisionMetadata, error) {
+	metadata.Flatten(d.CaseSensitive)
+	id, idErr := generateID(metadata)
+	if idErr != nil {
+		return nil, idErr
+	}
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colDoc).From(d.generateCompleteTableName(tableRevision))
+	selectBuilder.Where(selectBuilder.Equal(colKind, kind), selectBuilder.Equal(colID, id))
+	selectBuilder.OrderBy(colVersion).Desc()
+	sqlQuery, args := selectBuilder.Build()
+
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := []*modelV1.RevisionMetadata{}
+	for rows.Next() {
+		var rowJSONDoc string
+		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
+			return nil, scanErr
+		}
+		revision := &modelV1.RevisionMetadata{}
+		if unmarshalErr := json.Unmarshal([]byte(rowJSONDoc), revision); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		result = append(result, revision)
+	}
+	return result, rows.Err()
+}
+
+func (d *DAO) DeleteRevisions(kind modelV1.Kind, metadata modelAPI.Metadata) error {
+	metadata.Flatten(d.CaseSensitive)
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(d.generateCompleteTableName(tableRevision))
+	if m, ok := metadata.(*modelV1.ProjectMetadata); ok && len(m.Name) == 0 {
+		deleteBuilder.Where(deleteBuilder.Equal(colKind, kind), deleteBuilder.Equal(colProject, m.Project))
+	} else {
+		id, idErr := generateID(metadata)
+		if idErr != nil {
+			return idErr
+		}
+		deleteBuilder.Where(deleteBuilder.Equal(colKind, kind), deleteBuilder.Equal(colID, id))
+	}
+	sqlQuery, args := deleteBuilder.Build()
+	_, err := d.DB.Exec(sqlQuery, args...)
+	return err
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"encoding/json"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	// tableSession contains the sessions opened by the users when they log in.
+	tableSession = "user_session"
+
+	colExpiresAt = "expires_at"
+)
+
+func (d *DAO) createSessionTable() string {
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableSession)).IfNotExists().
+		Define(colID, "VARCHAR(64)", "NOT NULL", "PRIMARY KEY").
+		Define(colUsername, "VARCHAR(128)", "NOT NULL").
+		Define(colCreatedAt, "BIGINT", "NOT NULL").
+		Define(colExpiresAt, "BIGINT", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
+		String()
+}
+
+func (d *DAO) CreateSession(session *modelV1.Session) error {
+	rowJSONDoc, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	insertBuilder := d.flavor().NewInsertBuilder().InsertInto(d.generateCompleteTableName(tableSession)).
+		Cols(colID, colUsername, colCreatedAt, colExpiresAt, colDoc).
+		Values(session.ID, session.Username, session.CreatedAt.UnixNano(), session.ExpiresAt.UnixNano(), string(rowJSONDoc))
+	sqlQuery, args := insertBuilder.Build()
+	_, err = d.DB.Exec(sqlQuery, args...)
+	return err
+}
+
+func (d *DAO) UpdateSession(session *modelV1.Session) error {
+	rowJSONDoc, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	updateBuilder := d.flavor().NewUpdateBuilder().Update(d.generateCompleteTableName(tableSession))
+	updateBuilder.Set(
+		updateBuilder.Assign(colExpiresAt, session.ExpiresAt.UnixNano()),
+		updateBuilder.Assign(colDoc, string(rowJSONDoc)),
+	).Where(updateBuilder.Equal(colID, session.ID))
+	sqlQuery, args := updateBuilder.Build()
+	result, err := d.DB.Exec(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr == nil && rows == 0 {
+		return &databaseModel.Error{Key: session.ID, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return nil
+}
+
+func (d *DAO) QuerySessions(query *databaseModel.SessionQuery) ([]*modelV1.Session, error) {
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colDoc).From(d.generateCompleteTableName(tableSession))
+	if conditions := sessionConditions(query, selectBuilder.Equal, selectBuilder.LessThan); len(conditions) > 0 {
+		selectBuilder.Where(conditions...)
+	}
+	selectBuilder.OrderBy(colCreatedAt, colID).Asc()
+	sqlQuery, args := selectBuilder.Build()
+
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := []*modelV1.Session{}
+	for rows.Next() {
+		var rowJSONDoc string
+		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
+			return nil, scanErr
+		}
+		session := &modelV1.Session{}
+		if unmarshalErr := json.Unmarshal([]byte(rowJSONDoc), session); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		result = append(result, session)
+	}
+	return result, rows.Err()
+}
+
+func (d *DAO) DeleteSessions(query *databaseModel.SessionQuery) error {
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(d.generateCompleteTableName(tableSession))
+	if conditions := sessionConditions(query, deleteBuilder.Equal, deleteBuilder.LessThan); len(conditions) > 0 {
+		deleteBuilder.Where(conditions...)
+	}
+	sqlQuery, args := deleteBuilder.Build()
+	_, err := d.DB.Exec(sqlQuery, args...)
+	return err
+}
+
+func sessionConditions(query *databaseModel.SessionQuery, equal func(field string, value any) string, lessThan func(field string, value any) string) []string {
+	var conditions []string
+	if len(query.ID) > 0 {
+		conditions = append(conditions, equal(colID, query.ID))
+	}
+	if len(query.Username) > 0 {
+		conditions = append(conditions, equal(colUsername, query.Username))
+	}
+	if !query.ExpiredBefore.IsZero() {
+		conditions = append(conditions, lessThan(colExpiresAt, query.ExpiredBefore.UnixNano()))
+	}
+	return conditions
+}
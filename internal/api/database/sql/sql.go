@@ -17,15 +17,19 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/utils"
 	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
 )
 
 const (
@@ -42,8 +46,10 @@ const (
 	tableRole               = "role"
 	tableRoleBinding        = "rolebinding"
 	tableSecret             = "secret"
+	tableServiceAccount     = "serviceaccount"
 	tableUser               = "user"
 	tableVariable           = "variable"
+	tableWebhook            = "webhook"
 
 	colID      = "id"
 	colDoc     = "doc"
@@ -79,10 +85,14 @@ func getTableName(kind modelV1.Kind) (string, error) {
 		return tableRoleBinding, nil
 	case modelV1.KindSecret:
 		return tableSecret, nil
+	case modelV1.KindServiceAccount:
+		return tableServiceAccount, nil
 	case modelV1.KindUser:
 		return tableUser, nil
 	case modelV1.KindVariable:
 		return tableVariable, nil
+	case modelV1.KindWebhook:
+		return tableWebhook, nil
 	default:
 		return "", fmt.Errorf("%q has no associated table", kind)
 	}
@@ -103,6 +113,8 @@ type DAO struct {
 	DB            *sql.DB
 	SchemaName    string
 	CaseSensitive bool
+	// Driver is the SQL dialect spoken by the database behind DB. If empty, MySQL is assumed.
+	Driver config.SQLDriver
 }
 
 func (d *DAO) Init() error {
@@ -113,7 +125,9 @@ func (d *DAO) Init() error {
 		d.createResourceTable(tableGlobalSecret),
 		d.createResourceTable(tableGlobalVariable),
 		d.createResourceTable(tableProject),
+		d.createResourceTable(tableServiceAccount),
 		d.createResourceTable(tableUser),
+		d.createResourceTable(tableWebhook),
 
 		d.createProjectResourceTable(tableDashboard),
 		d.createProjectResourceTable(tableDatasource),
@@ -123,6 +137,12 @@ func (d *DAO) Init() error {
 		d.createProjectResourceTable(tableRoleBinding),
 		d.createProjectResourceTable(tableSecret),
 		d.createProjectResourceTable(tableVariable),
+
+		d.createRevisionTable(),
+		d.createAuditTable(),
+		d.createWebhookDeliveryTable(),
+		d.createAccessTokenTable(),
+		d.createSessionTable(),
 	}
 
 	for _, table := range tables {
@@ -130,6 +150,12 @@ func (d *DAO) Init() error {
 			return err
 		}
 	}
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return d.initPostgresUpdateTracking()
+	case config.SQLDriverSQLite:
+		return d.initSQLite()
+	}
 	return nil
 }
 
@@ -138,19 +164,19 @@ func (d *DAO) IsCaseSensitive() bool {
 }
 
 func (d *DAO) createResourceTable(tableName string) string {
-	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
 		Define(colID, "VARCHAR(128)", "NOT NULL", "PRIMARY KEY").
 		Define(colName, "VARCHAR(128)", "NOT NULL").
-		Define(colDoc, "JSON", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
 		String()
 }
 
 func (d *DAO) createProjectResourceTable(tableName string) string {
-	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
 		Define(colID, "VARCHAR(256)", "NOT NULL", "PRIMARY KEY").
 		Define(colName, "VARCHAR(128)", "NOT NULL").
 		Define(colProject, "VARCHAR(128)", "NOT NULL").
-		Define(colDoc, "JSON", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
 		String()
 }
 
@@ -164,6 +190,12 @@ func (d *DAO) createTable(query string) error {
 
 // GetLatestUpdateTime queries the database to retrieve the latest update time for the specified table names.
 func (d *DAO) GetLatestUpdateTime(kinds []modelV1.Kind) (*string, error) {
+	switch d.Driver {
+	case config.SQLDriverPostgres:
+		return d.getPostgresLatestUpdateTime(kinds)
+	case config.SQLDriverSQLite:
+		return d.getSQLiteLatestUpdateTime(kinds)
+	}
 	sb := sqlbuilder.Select("UPDATE_TIME")
 	sb.From("information_schema.tables")
 	var whereConditions []string
@@ -204,53 +236,69 @@ func (d *DAO) Create(entity modelAPI.Entity) error {
 	// Also, it will avoid an issue with the permission when activated.
 	// See https://github.com/perses/perses/issues/1721 for more details.
 	entity.GetMetadata().Flatten(d.CaseSensitive)
-	id, isExist, err := d.exists(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
-	if err != nil {
-		return err
-	}
-	if isExist {
-		return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
-	}
-
 	sqlQuery, args, queryErr := d.generateInsertQuery(entity)
 	if queryErr != nil {
 		return queryErr
 	}
-
-	createQuery, createErr := d.DB.Query(sqlQuery, args...)
-	if createErr != nil {
+	return d.transaction(func(tx *sql.Tx) error {
+		id, isExist, err := d.exists(tx, modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
+		}
+		_, createErr := tx.Exec(sqlQuery, args...)
 		return createErr
-	}
-	return createQuery.Close()
+	})
 }
 
 func (d *DAO) Upsert(entity modelAPI.Entity) error {
 	entity.GetMetadata().Flatten(d.CaseSensitive)
-	_, isExist, err := d.exists(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
-	if err != nil {
-		return err
-	}
-	var sqlQuery string
-	var args []any
-	var queryGeneratorErr error
-	if !isExist {
-		sqlQuery, args, queryGeneratorErr = d.generateInsertQuery(entity)
-	} else {
-		sqlQuery, args, queryGeneratorErr = d.generateUpdateQuery(entity)
-	}
-	if queryGeneratorErr != nil {
-		return queryGeneratorErr
-	}
-	upsertQuery, upsertErr := d.DB.Query(sqlQuery, args...)
-	if upsertErr != nil {
+	return d.transaction(func(tx *sql.Tx) error {
+		_, isExist, err := d.exists(tx, modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+		if err != nil {
+			return err
+		}
+		var sqlQuery string
+		var args []any
+		var queryGeneratorErr error
+		if !isExist {
+			sqlQuery, args, queryGeneratorErr = d.generateInsertQuery(entity)
+		} else {
+			sqlQuery, args, queryGeneratorErr = d.generateUpdateQuery(entity)
+		}
+		if queryGeneratorErr != nil {
+			return queryGeneratorErr
+		}
+		_, upsertErr := tx.Exec(sqlQuery, args...)
 		return upsertErr
+	})
+}
+
+func (d *DAO) Update(entity modelAPI.Entity) error {
+	entity.GetMetadata().Flatten(d.CaseSensitive)
+	sqlQuery, args, queryErr := d.generateUpdateQuery(entity)
+	if queryErr != nil {
+		return queryErr
 	}
-	return upsertQuery.Close()
+	version := utils.GetMetadataVersion(entity.GetMetadata())
+	return d.transaction(func(tx *sql.Tx) error {
+		id, currentVersion, err := d.getVersionForUpdate(tx, modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+		if err != nil {
+			return err
+		}
+		if currentVersion+1 != version {
+			return &databaseModel.VersionConflictError{Key: id, CurrentVersion: currentVersion}
+		}
+		_, updateErr := tx.Exec(sqlQuery, args...)
+		return updateErr
+	})
 }
 
 func (d *DAO) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error {
 	metadata.Flatten(d.CaseSensitive)
-	id, query, queryErr := d.get(kind, metadata)
+	id, query, queryErr := d.get(d.DB, kind, metadata)
 	if queryErr != nil {
 		return queryErr
 	}
@@ -266,6 +314,20 @@ func (d *DAO) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI
 }
 
 func (d *DAO) RawQuery(query databaseModel.Query) ([]json.RawMessage, error) {
+	docs, err := d.queryDocuments(query)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]json.RawMessage, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, []byte(doc))
+	}
+	return result, nil
+}
+
+// queryDocuments returns the JSON documents matching the query.
+// When the list is paginated, the token to get the next page is set in the pagination of the query.
+func (d *DAO) queryDocuments(query databaseModel.Query) ([]string, error) {
 	q, args, buildQueryErr := d.buildQuery(query)
 	if buildQueryErr != nil {
 		return nil, fmt.Errorf("unable to build the query: %s", buildQueryErr)
@@ -276,14 +338,22 @@ func (d *DAO) RawQuery(query databaseModel.Query) ([]json.RawMessage, error) {
 	}
 	defer rows.Close() //nolint:errcheck
 
-	result := []json.RawMessage{}
-
+	var result []string
 	for rows.Next() {
 		var rowJSONDoc string
 		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
 			return nil, scanErr
 		}
-		result = append(result, []byte(rowJSONDoc))
+		result = append(result, rowJSONDoc)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, rowsErr
+	}
+	pagination := query.GetPagination()
+	if pagination != nil && pagination.Limit > 0 && len(result) > pagination.Limit {
+		// The database returned one more row than the limit, so there is a next page.
+		result = result[:pagination.Limit]
+		pagination.SetNext(databaseModel.NewSortKeyFromJSON([]byte(result[len(result)-1])))
 	}
 	return result, nil
 }
@@ -312,20 +382,11 @@ func (d *DAO) Query(query databaseModel.Query, slice any) error {
 	if typeParameter.Kind() != reflect.Slice {
 		return fmt.Errorf("slice in parameter is not actually a slice but a %q", typeParameter.Kind())
 	}
-	q, args, buildQueryErr := d.buildQuery(query)
-	if buildQueryErr != nil {
-		return fmt.Errorf("unable to build the query: %s", buildQueryErr)
-	}
-	rows, runQueryErr := d.DB.Query(q, args...)
-	if runQueryErr != nil {
-		return runQueryErr
+	docs, err := d.queryDocuments(query)
+	if err != nil {
+		return err
 	}
-	defer rows.Close() //nolint:errcheck
-	for rows.Next() {
-		var rowJSONDoc string
-		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
-			return scanErr
-		}
+	for _, rowJSONDoc := range docs {
 		// first create a pointer with the accurate type
 		var value reflect.Value
 		if typeParameter.Elem().Kind() != reflect.Ptr {
@@ -357,28 +418,25 @@ func (d *DAO) Query(query databaseModel.Query, slice any) error {
 }
 
 func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
-	id, isExist, err := d.exists(kind, metadata)
-	if err != nil {
-		return err
-	}
-	if !isExist {
-		return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
-	}
-
 	id, tableName, idErr := d.getIDAndTableName(kind, metadata)
 	if idErr != nil {
 		return idErr
 	}
-
-	deleteBuilder := sqlbuilder.NewDeleteBuilder().DeleteFrom(tableName)
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(tableName)
 	deleteBuilder.Where(deleteBuilder.Equal(colID, id))
 	sqlQuery, args := deleteBuilder.Build()
 
-	deleteQuery, err := d.DB.Query(sqlQuery, args...)
-	if err != nil {
-		return err
-	}
-	return deleteQuery.Close()
+	return d.transaction(func(tx *sql.Tx) error {
+		_, isExist, err := d.exists(tx, kind, metadata)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+		}
+		_, deleteErr := tx.Exec(sqlQuery, args...)
+		return deleteErr
+	})
 }
 
 func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
@@ -420,8 +478,28 @@ func (d *DAO) generateCompleteTableName(tableName string) string {
 	return fmt.Sprintf("%s.%s", d.SchemaName, tableName)
 }
 
-func (d *DAO) exists(kind modelV1.Kind, metadata modelAPI.Metadata) (string, bool, error) {
-	id, query, queryErr := d.get(kind, metadata)
+// queryer is the common interface of sql.DB and sql.Tx used to read a document inside or outside a transaction.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// transaction runs f in a single transaction. The transaction is committed if f succeeds and rolled back otherwise.
+func (d *DAO) transaction(f func(tx *sql.Tx) error) error {
+	tx, err := d.DB.Begin()
+	if err != nil {
+		return err
+	}
+	if txErr := f(tx); txErr != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logrus.WithError(rollbackErr).Error("unable to rollback the transaction")
+		}
+		return txErr
+	}
+	return tx.Commit()
+}
+
+func (d *DAO) exists(q queryer, kind modelV1.Kind, metadata modelAPI.Metadata) (string, bool, error) {
+	id, query, queryErr := d.get(q, kind, metadata)
 	if queryErr != nil {
 		return "", false, queryErr
 	}
@@ -429,18 +507,44 @@ func (d *DAO) exists(kind modelV1.Kind, metadata modelAPI.Metadata) (string, boo
 	return id, query.Next(), nil
 }
 
-func (d *DAO) get(kind modelV1.Kind, metadata modelAPI.Metadata) (string, *sql.Rows, error) {
+// getVersionForUpdate returns the version of the document stored and locks it until the end of the transaction.
+// SQLite doesn't support SELECT ... FOR UPDATE, but the whole database is already locked by the transaction.
+func (d *DAO) getVersionForUpdate(tx *sql.Tx, kind modelV1.Kind, metadata modelAPI.Metadata) (string, uint64, error) {
+	id, tableName, idErr := d.getIDAndTableName(kind, metadata)
+	if idErr != nil {
+		return "", 0, idErr
+	}
+	queryBuilder := d.flavor().NewSelectBuilder().
+		Select(colDoc).
+		From(tableName)
+	queryBuilder.Where(queryBuilder.Equal(colID, id))
+	if d.Driver != config.SQLDriverSQLite {
+		queryBuilder.ForUpdate()
+	}
+	sqlQuery, args := queryBuilder.Build()
+
+	var rowJSONDoc string
+	if err := tx.QueryRow(sqlQuery, args...).Scan(&rowJSONDoc); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+		}
+		return "", 0, err
+	}
+	return id, gjson.Get(rowJSONDoc, "metadata.version").Uint(), nil
+}
+
+func (d *DAO) get(q queryer, kind modelV1.Kind, metadata modelAPI.Metadata) (string, *sql.Rows, error) {
 	id, tableName, idErr := d.getIDAndTableName(kind, metadata)
 	if idErr != nil {
 		return "", nil, idErr
 	}
 
-	queryBuilder := sqlbuilder.NewSelectBuilder().
+	queryBuilder := d.flavor().NewSelectBuilder().
 		Select(colDoc).
 		From(tableName)
 	queryBuilder.Where(queryBuilder.Equal(colID, id))
 	sqlQuery, args := queryBuilder.Build()
 
-	rows, err := d.DB.Query(sqlQuery, args...)
+	rows, err := q.Query(sqlQuery, args...)
 	return id, rows, err
 }
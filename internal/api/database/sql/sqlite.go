@@ -0,0 +1,75 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// sqliteTimestampFormat is the format expected by the consumer of GetLatestUpdateTime.
+const sqliteTimestampFormat = "%Y-%m-%d %H:%M:%S"
+
+var sqliteTriggerEvents = []string{"INSERT", "UPDATE", "DELETE"}
+
+// initSQLite creates the indexes used by the list queries and the triggers recording the last modification of every table.
+// Note: in SQLite, the schema name is set on the index and the trigger names and not on the table they are attached to.
+func (d *DAO) initSQLite() error {
+	queries := []string{d.createUpdateTimeTable()}
+	for _, tableName := range resourceTables {
+		indexColumns := colName
+		if isProjectResourceTable(tableName) {
+			indexColumns = fmt.Sprintf("%s, %s", colProject, colName)
+		}
+		queries = append(queries, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)",
+			d.generateCompleteTableName(fmt.Sprintf("%s_lookup", tableName)), tableName, indexColumns))
+		for _, event := range sqliteTriggerEvents {
+			queries = append(queries, fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %s AFTER %s ON %s
+BEGIN
+	INSERT INTO %s (%s, %s) VALUES ('%s', strftime('%s', 'now'))
+	ON CONFLICT (%s) DO UPDATE SET %s = excluded.%s;
+END`,
+				d.generateCompleteTableName(fmt.Sprintf("%s_%s_%s", tableName, event, colUpdateTime)), event, tableName,
+				tableUpdateTime, colTableName, colUpdateTime, tableName, sqliteTimestampFormat,
+				colTableName, colUpdateTime, colUpdateTime))
+		}
+	}
+	for _, query := range queries {
+		if _, err := d.DB.Exec(query); err != nil {
+			return fmt.Errorf("unable to initialize the sqlite database: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *DAO) getSQLiteLatestUpdateTime(kinds []modelV1.Kind) (*string, error) {
+	return d.getTrackedLatestUpdateTime(kinds, fmt.Sprintf("MAX(%s)", colUpdateTime))
+}
+
+func isProjectResourceTable(tableName string) bool {
+	switch tableName {
+	case tableDashboard,
+		tableDatasource,
+		tableEphemeralDashboard,
+		tableFolder,
+		tableRole,
+		tableRoleBinding,
+		tableSecret,
+		tableVariable:
+		return true
+	default:
+		return false
+	}
+}
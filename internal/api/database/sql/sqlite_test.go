@@ -0,0 +1,424 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+)
+
+func newSQLiteDAO(t *testing.T) *DAO {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_txlock=immediate&_pragma=busy_timeout(5000)&_pragma=case_sensitive_like(1)", filepath.Join(t.TempDir(), "perses.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &DAO{
+		DB:         db,
+		SchemaName: "main",
+		Driver:     config.SQLDriverSQLite,
+	}
+	if initErr := d.Init(); initErr != nil {
+		t.Fatal(initErr)
+	}
+	// Init must be idempotent as it is called at every start.
+	if initErr := d.Init(); initErr != nil {
+		t.Fatal(initErr)
+	}
+	t.Cleanup(func() {
+		_ = d.Close()
+	})
+	return d
+}
+
+func newDashboard(project string, name string) *modelV1.Dashboard {
+	return &modelV1.Dashboard{
+		Kind: modelV1.KindDashboard,
+		Metadata: modelV1.ProjectMetadata{
+			Metadata: modelV1.Metadata{
+				Name: name,
+			},
+			ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+				Project: project,
+			},
+		},
+		Spec: modelV1.DashboardSpec{
+			Duration: common.Duration(time.Hour),
+		},
+	}
+}
+
+func TestSQLiteDAO(t *testing.T) {
+	d := newSQLiteDAO(t)
+
+	updateTime, err := d.GetLatestUpdateTime([]modelV1.Kind{modelV1.KindDashboard})
+	assert.NoError(t, err)
+	assert.Nil(t, updateTime)
+
+	assert.NoError(t, d.Create(newDashboard("perses", "Demo")))
+	assert.NoError(t, d.Create(newDashboard("perses", "demo-2")))
+	assert.NoError(t, d.Create(newDashboard("other", "demo")))
+	assert.True(t, databaseModel.IsKeyConflict(d.Create(newDashboard("perses", "demo"))))
+
+	result := &modelV1.Dashboard{}
+	assert.NoError(t, d.Get(modelV1.KindDashboard, &newDashboard("perses", "demo").Metadata, result))
+	assert.Equal(t, "demo", result.Metadata.Name)
+	assert.Equal(t, "perses", result.Metadata.Project)
+
+	var list []*modelV1.Dashboard
+	assert.NoError(t, d.Query(&dashboard.Query{Project: "perses", NamePrefix: "dem"}, &list))
+	assert.Len(t, list, 2)
+	raws, err := d.RawQuery(&dashboard.Query{NamePrefix: "demo-"})
+	assert.NoError(t, err)
+	assert.Len(t, raws, 1)
+
+	updated := newDashboard("perses", "demo")
+	updated.Spec.Duration = common.Duration(6 * time.Hour)
+	assert.NoError(t, d.Upsert(updated))
+	assert.NoError(t, d.Upsert(newDashboard("perses", "demo-3")))
+	assert.NoError(t, d.Get(modelV1.KindDashboard, &updated.Metadata, result))
+	assert.Equal(t, updated.Spec.Duration, result.Spec.Duration)
+
+	assert.True(t, databaseModel.IsVersionConflict(d.Update(updated)))
+	updated.Metadata.Version = 1
+	assert.NoError(t, d.Update(updated))
+	assert.True(t, databaseModel.IsVersionConflict(d.Update(updated)))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Update(newDashboard("perses", "unknown"))))
+
+	assert.NoError(t, d.Delete(modelV1.KindDashboard, &newDashboard("perses", "demo").Metadata))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Delete(modelV1.KindDashboard, &newDashboard("perses", "demo").Metadata)))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindDashboard, &newDashboard("perses", "demo").Metadata, result)))
+
+	assert.NoError(t, d.DeleteByQuery(&dashboard.Query{Project: "perses"}))
+	list = nil
+	assert.NoError(t, d.Query(&dashboard.Query{Project: "perses"}, &list))
+	assert.Empty(t, list)
+
+	updateTime, err = d.GetLatestUpdateTime([]modelV1.Kind{modelV1.KindDashboard, modelV1.KindProject})
+	assert.NoError(t, err)
+	if assert.NotNil(t, updateTime) {
+		_, parseErr := time.Parse("2006-01-02 15:04:05", *updateTime)
+		assert.NoError(t, parseErr)
+	}
+	updateTime, err = d.GetLatestUpdateTime([]modelV1.Kind{modelV1.KindProject})
+	assert.NoError(t, err)
+	assert.Nil(t, updateTime)
+}
+
+func TestSQLiteDAO_LabelSelector(t *testing.T) {
+	d := newSQLiteDAO(t)
+
+	sre := newDashboard("perses", "sre")
+	sre.Metadata.Labels = map[string]string{"team": "sre", "perses.dev/tier": "prod"}
+	dev := newDashboard("perses", "dev")
+	dev.Metadata.Labels = map[string]string{"team": "sre", "perses.dev/tier": "dev"}
+	assert.NoError(t, d.Create(sre))
+	assert.NoError(t, d.Create(dev))
+	assert.NoError(t, d.Create(newDashboard("perses", "none")))
+
+	testSuite := []struct {
+		selector string
+		expected []string
+	}{
+		{selector: "team=sre", expected: []string{"dev", "sre"}},
+		{selector: "team=sre,perses.dev/tier!=dev", expected: []string{"sre"}},
+		{selector: "perses.dev/tier!=dev", expected: []string{"none", "sre"}},
+		{selector: "perses.dev/tier in (dev,staging)", expected: []string{"dev"}},
+		{selector: "perses.dev/tier notin (dev,staging)", expected: []string{"none", "sre"}},
+		{selector: "team", expected: []string{"dev", "sre"}},
+		{selector: "!team", expected: []string{"none"}},
+	}
+	for _, test := range testSuite {
+		t.Run(test.selector, func(t *testing.T) {
+			selector, err := databaseModel.ParseLabelSelector(test.selector)
+			assert.NoError(t, err)
+			var list []*modelV1.Dashboard
+			assert.NoError(t, d.Query(&dashboard.Query{Project: "perses", LabelSelector: selector}, &list))
+			var names []string
+			for _, entity := range list {
+				names = append(names, entity.Metadata.Name)
+			}
+			assert.ElementsMatch(t, test.expected, names)
+		})
+	}
+}
+
+func TestSQLiteDAO_Pagination(t *testing.T) {
+	d := newSQLiteDAO(t)
+
+	updates := map[string]string{
+		"a": "2025-01-01T10:00:00Z",
+		"b": "2025-01-01T10:00:00.5Z",
+		"c": "2025-01-01T09:00:00Z",
+		"d": "2025-01-01T10:00:00.25Z",
+		"e": "2025-01-01T11:00:00Z",
+	}
+	for name, updatedAt := range updates {
+		entity := newDashboard("perses", name)
+		date, err := time.Parse(time.RFC3339Nano, updatedAt)
+		assert.NoError(t, err)
+		entity.Metadata.UpdatedAt = date
+		assert.NoError(t, d.Create(entity))
+	}
+	assert.NoError(t, d.Create(newDashboard("other", "a")))
+
+	testSuite := []struct {
+		title      string
+		pagination databaseModel.Pagination
+		expected   [][]string
+	}{
+		{
+			title:      "sorted by name",
+			pagination: databaseModel.Pagination{Limit: 2},
+			expected:   [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+		{
+			title:      "sorted by name in descending order",
+			pagination: databaseModel.Pagination{Limit: 3, Order: databaseModel.SortOrderDesc},
+			expected:   [][]string{{"e", "d", "c"}, {"b", "a"}},
+		},
+		{
+			title:      "sorted by update time",
+			pagination: databaseModel.Pagination{Limit: 2, SortBy: databaseModel.SortByUpdatedAt},
+			expected:   [][]string{{"c", "a"}, {"d", "b"}, {"e"}},
+		},
+		{
+			title:      "sorted by update time in descending order",
+			pagination: databaseModel.Pagination{Limit: 4, SortBy: databaseModel.SortByUpdatedAt, Order: databaseModel.SortOrderDesc},
+			expected:   [][]string{{"e", "b", "d", "a"}, {"c"}},
+		},
+		{
+			title:      "sorted without limit",
+			pagination: databaseModel.Pagination{SortBy: databaseModel.SortByUpdatedAt},
+			expected:   [][]string{{"c", "a", "d", "b", "e"}},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			pagination := test.pagination
+			var pages [][]string
+			for {
+				query := &dashboard.Query{Project: "perses", Pagination: pagination}
+				var list []*modelV1.Dashboard
+				assert.NoError(t, d.Query(query, &list))
+				var names []string
+				for _, entity := range list {
+					names = append(names, entity.Metadata.Name)
+				}
+				pages = append(pages, names)
+				if len(query.Pagination.Next()) == 0 || len(pages) > len(test.expected) {
+					break
+				}
+				pagination.Continue = query.Pagination.Next()
+			}
+			assert.Equal(t, test.expected, pages)
+		})
+	}
+}
+
+func TestSQLiteDAO_Revisions(t *testing.T) {
+	d := newSQLiteDAO(t)
+
+	entity := newDashboard("perses", "demo")
+	for version := uint64(0); version < 4; version++ {
+		entity.Metadata.Version = version
+		assert.NoError(t, d.CreateRevision(entity, "admin", 3))
+	}
+	assert.NoError(t, d.CreateRevision(newDashboard("other", "demo"), "", 3))
+
+	revisions, err := d.ListRevisions(modelV1.KindDashboard, &entity.Metadata)
+	assert.NoError(t, err)
+	// Only the last 3 revisions are kept, the latest first.
+	if assert.Len(t, revisions, 3) {
+		assert.Equal(t, uint64(3), revisions[0].Version)
+		assert.Equal(t, uint64(1), revisions[2].Version)
+		assert.Equal(t, "admin", revisions[0].Author)
+	}
+
+	revision, err := d.GetRevision(modelV1.KindDashboard, &entity.Metadata, 2)
+	assert.NoError(t, err)
+	result := &modelV1.Dashboard{}
+	assert.NoError(t, json.Unmarshal(revision.Entity, result))
+	assert.Equal(t, uint64(2), result.Metadata.Version)
+	_, err = d.GetRevision(modelV1.KindDashboard, &entity.Metadata, 0)
+	assert.True(t, databaseModel.IsKeyNotFound(err))
+
+	// Removing the history of a project must not affect the other projects.
+	assert.NoError(t, d.DeleteRevisions(modelV1.KindDashboard, modelV1.NewProjectMetadata("perses", "")))
+	revisions, err = d.ListRevisions(modelV1.KindDashboard, &entity.Metadata)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+	revisions, err = d.ListRevisions(modelV1.KindDashboard, modelV1.NewProjectMetadata("other", "demo"))
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+}
+
+func TestSQLiteDAO_AuditEvents(t *testing.T) {
+	d := newSQLiteDAO(t)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []*modelV1.AuditEvent{
+		{Timestamp: start, User: "alice", Action: role.CreateAction, Kind: modelV1.KindProject, Project: "perses", Name: "perses", Outcome: modelV1.AuditOutcomeSuccess},
+		{Timestamp: start.Add(time.Minute), User: "bob", Action: role.CreateAction, Kind: modelV1.KindDashboard, Project: "perses", Name: "demo", Outcome: modelV1.AuditOutcomeSuccess},
+		{Timestamp: start.Add(2 * time.Minute), User: "alice", Action: role.DeleteAction, Kind: modelV1.KindDashboard, Project: "other", Name: "demo", Outcome: modelV1.AuditOutcomeFailure, Error: "forbidden"},
+	}
+	for _, event := range events {
+		assert.NoError(t, d.CreateAuditEvent(event))
+	}
+
+	result, err := d.QueryAuditEvents(&databaseModel.AuditQuery{})
+	assert.NoError(t, err)
+	// The latest events come first.
+	assert.Equal(t, []*modelV1.AuditEvent{events[2], events[1], events[0]}, result)
+
+	result, err = d.QueryAuditEvents(&databaseModel.AuditQuery{Project: "perses", User: "alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.AuditEvent{events[0]}, result)
+
+	result, err = d.QueryAuditEvents(&databaseModel.AuditQuery{Kind: modelV1.KindDashboard, From: start.Add(time.Minute), To: start.Add(2 * time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.AuditEvent{events[1]}, result)
+
+	result, err = d.QueryAuditEvents(&databaseModel.AuditQuery{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.AuditEvent{events[2], events[1]}, result)
+}
+
+func TestSQLiteDAO_WebhookDeliveries(t *testing.T) {
+	d := newSQLiteDAO(t)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	due := start.Add(time.Minute)
+	later := start.Add(time.Hour)
+	deliveries := []*modelV1.WebhookDelivery{
+		{ID: "1", Webhook: "ci", Action: role.CreateAction, Kind: modelV1.KindDashboard, Name: "demo", Status: modelV1.WebhookDeliveryPending, CreatedAt: start, NextAttemptAt: &due, Payload: []byte(`{"delivery":"1"}`)},
+		{ID: "2", Webhook: "chat", Action: role.UpdateAction, Kind: modelV1.KindProject, Name: "perses", Status: modelV1.WebhookDeliveryPending, CreatedAt: start.Add(time.Second), NextAttemptAt: &later, Payload: []byte(`{"delivery":"2"}`)},
+		{ID: "3", Webhook: "ci", Action: role.CreateAction, Kind: modelV1.KindDashboard, Name: "demo", Status: modelV1.WebhookDeliverySuccess, CreatedAt: start.Add(2 * time.Second), Attempts: 1, Payload: []byte(`{"delivery":"3"}`)},
+	}
+	for _, delivery := range deliveries {
+		assert.NoError(t, d.CreateWebhookDelivery(delivery))
+	}
+
+	result, err := d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{})
+	assert.NoError(t, err)
+	// The oldest deliveries come first.
+	assert.Equal(t, deliveries, result)
+
+	result, err = d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Status: modelV1.WebhookDeliveryPending, DueBefore: start.Add(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.WebhookDelivery{deliveries[0]}, result)
+
+	deliveries[0].Status = modelV1.WebhookDeliveryFailed
+	deliveries[0].NextAttemptAt = nil
+	deliveries[0].Error = "connection refused"
+	assert.NoError(t, d.UpdateWebhookDelivery(deliveries[0]))
+	result, err = d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "ci", Status: modelV1.WebhookDeliveryFailed})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.WebhookDelivery{deliveries[0]}, result)
+	assert.True(t, databaseModel.IsKeyNotFound(d.UpdateWebhookDelivery(&modelV1.WebhookDelivery{ID: "4", Webhook: "ci"})))
+
+	assert.NoError(t, d.DeleteWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Status: modelV1.WebhookDeliverySuccess, CreatedBefore: start.Add(time.Hour)}))
+	assert.NoError(t, d.DeleteWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "chat"}))
+	result, err = d.QueryWebhookDeliveries(&databaseModel.WebhookDeliveryQuery{Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.WebhookDelivery{deliveries[0]}, result)
+}
+
+func TestSQLiteDAO_AccessTokens(t *testing.T) {
+	d := newSQLiteDAO(t)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := start.Add(24 * time.Hour)
+	tokens := []*databaseModel.AccessToken{
+		{AccessToken: modelV1.AccessToken{ID: "0f8b2c9e-4d55-4c6b-9a57-3b7f5e0f9d21", Name: "laptop", Subject: modelV1.Subject{Kind: modelV1.KindUser, Name: "jane"}, CreatedAt: start}, Hash: "hash1"},
+		{AccessToken: modelV1.AccessToken{ID: "6a1d4e57-2f3b-4d7e-8c61-0b9a3f2e5d48", Name: "ci", Subject: modelV1.Subject{Kind: modelV1.KindServiceAccount, Name: "ci"}, CreatedAt: start.Add(time.Second), ExpiresAt: &expiry}, Hash: "hash2"},
+		{AccessToken: modelV1.AccessToken{ID: "c3e9a2b1-7d64-4f0a-9b25-8e1f6d3c4a70", Name: "scripts", Subject: modelV1.Subject{Kind: modelV1.KindUser, Name: "jane"}, CreatedAt: start.Add(2 * time.Second), Permissions: []role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.DashboardScope}}}}, Hash: "hash3"},
+	}
+	for _, token := range tokens {
+		assert.NoError(t, d.CreateAccessToken(token))
+	}
+	assert.Error(t, d.CreateAccessToken(tokens[0]))
+
+	result, err := d.QueryAccessTokens(&databaseModel.AccessTokenQuery{})
+	assert.NoError(t, err)
+	// The oldest tokens come first.
+	assert.Equal(t, tokens, result)
+
+	result, err = d.QueryAccessTokens(&databaseModel.AccessTokenQuery{SubjectKind: modelV1.KindUser, SubjectName: "jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.AccessToken{tokens[0], tokens[2]}, result)
+
+	lastUsed := start.Add(time.Hour)
+	tokens[1].LastUsedAt = &lastUsed
+	assert.NoError(t, d.UpdateAccessToken(tokens[1]))
+	result, err = d.QueryAccessTokens(&databaseModel.AccessTokenQuery{ID: tokens[1].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.AccessToken{tokens[1]}, result)
+	assert.True(t, databaseModel.IsKeyNotFound(d.UpdateAccessToken(&databaseModel.AccessToken{AccessToken: modelV1.AccessToken{ID: "5b7c1e2d-9a83-4f6e-b0d4-2c8e7a1f3b69"}})))
+
+	assert.NoError(t, d.DeleteAccessTokens(&databaseModel.AccessTokenQuery{ID: tokens[0].ID}))
+	assert.NoError(t, d.DeleteAccessTokens(&databaseModel.AccessTokenQuery{SubjectKind: modelV1.KindServiceAccount, SubjectName: "ci"}))
+	result, err = d.QueryAccessTokens(&databaseModel.AccessTokenQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.AccessToken{tokens[2]}, result)
+}
+
+func TestSQLiteDAO_Sessions(t *testing.T) {
+	d := newSQLiteDAO(t)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions := []*modelV1.Session{
+		{ID: "2d4f6a8c-1b3e-4c5d-8e7f-9a0b1c2d3e4f", Username: "jane", ProviderKind: "native", UserAgent: "Firefox", CreatedAt: start, ExpiresAt: start.Add(time.Hour)},
+		{ID: "7e9a1c3b-5d2f-4a6e-9b8c-0d1e2f3a4b5c", Username: "john", ProviderKind: "oidc", ProviderID: "azure", CreatedAt: start.Add(time.Second), ExpiresAt: start.Add(48 * time.Hour)},
+		{ID: "b4c6d8e0-2a1f-4e3d-a5b7-c9d0e1f2a3b4", Username: "jane", ProviderKind: "native", UserAgent: "percli", CreatedAt: start.Add(2 * time.Second), ExpiresAt: start.Add(24 * time.Hour)},
+	}
+	for _, session := range sessions {
+		assert.NoError(t, d.CreateSession(session))
+	}
+	assert.Error(t, d.CreateSession(sessions[0]))
+
+	result, err := d.QuerySessions(&databaseModel.SessionQuery{})
+	assert.NoError(t, err)
+	// The oldest sessions come first.
+	assert.Equal(t, sessions, result)
+
+	result, err = d.QuerySessions(&databaseModel.SessionQuery{Username: "jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.Session{sessions[0], sessions[2]}, result)
+
+	lastRefreshed := start.Add(time.Minute)
+	sessions[2].LastRefreshedAt = &lastRefreshed
+	assert.NoError(t, d.UpdateSession(sessions[2]))
+	result, err = d.QuerySessions(&databaseModel.SessionQuery{ID: sessions[2].ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.Session{sessions[2]}, result)
+	assert.True(t, databaseModel.IsKeyNotFound(d.UpdateSession(&modelV1.Session{ID: "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b"})))
+
+	// Only the first session is expired two hours later.
+	assert.NoError(t, d.DeleteSessions(&databaseModel.SessionQuery{ExpiredBefore: start.Add(2 * time.Hour)}))
+	assert.NoError(t, d.DeleteSessions(&databaseModel.SessionQuery{Username: "john"}))
+	result, err = d.QuerySessions(&databaseModel.SessionQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*modelV1.Session{sessions[2]}, result)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"encoding/json"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	// tableWebhookDelivery is the queue of the deliveries of the webhooks.
+	tableWebhookDelivery = "webhook_delivery"
+
+	colWebhook = "webhook"
+	colStatus  = "status"
+	// colCreatedAt is the creation time of the delivery in nanoseconds since the Unix epoch.
+	colCreatedAt = "created_at"
+	// colNextAttempt is the time of the next attempt in nanoseconds since the Unix epoch, 0 if there is none.
+	colNextAttempt = "next_attempt"
+)
+
+func (d *DAO) createWebhookDeliveryTable() string {
+	return d.flavor().NewCreateTableBuilder().CreateTable(d.generateCompleteTableName(tableWebhookDelivery)).IfNotExists().
+		Define(colID, "VARCHAR(64)", "NOT NULL", "PRIMARY KEY").
+		Define(colWebhook, "VARCHAR(128)", "NOT NULL").
+		Define(colStatus, "VARCHAR(16)", "NOT NULL").
+		Define(colCreatedAt, "BIGINT", "NOT NULL").
+		Define(colNextAttempt, "BIGINT", "NOT NULL").
+		Define(colDoc, d.documentType(), "NOT NULL").
+		String()
+}
+
+func nextAttempt(delivery *modelV1.WebhookDelivery) int64 {
+	if delivery.NextAttemptAt == nil {
+		return 0
+	}
+	return delivery.NextAttemptAt.UnixNano()
+}
+
+func (d *DAO) CreateWebhookDelivery(delivery *modelV1.WebhookDelivery) error {
+	rowJSONDoc, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	insertBuilder := d.flavor().NewInsertBuilder().InsertInto(d.generateCompleteTableName(tableWebhookDelivery)).
+		Cols(colID, colWebhook, colStatus, colCreatedAt, colNextAttempt, colDoc).
+		Values(delivery.ID, delivery.Webhook, string(delivery.Status), delivery.CreatedAt.UnixNano(), nextAttempt(delivery), string(rowJSONDoc))
+	sqlQuery, args := insertBuilder.Build()
+	_, err = d.DB.Exec(sqlQuery, args...)
+	return err
+}
+
+func (d *DAO) UpdateWebhookDelivery(delivery *modelV1.WebhookDelivery) error {
+	rowJSONDoc, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	updateBuilder := d.flavor().NewUpdateBuilder().Update(d.generateCompleteTableName(tableWebhookDelivery))
+	updateBuilder.Set(
+		updateBuilder.Assign(colStatus, string(delivery.Status)),
+		updateBuilder.Assign(colNextAttempt, nextAttempt(delivery)),
+		updateBuilder.Assign(colDoc, string(rowJSONDoc)),
+	).Where(updateBuilder.Equal(colID, delivery.ID))
+	sqlQuery, args := updateBuilder.Build()
+	result, err := d.DB.Exec(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr == nil && rows == 0 {
+		return &databaseModel.Error{Key: delivery.ID, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return nil
+}
+
+func (d *DAO) QueryWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) ([]*modelV1.WebhookDelivery, error) {
+	selectBuilder := d.flavor().NewSelectBuilder().Select(colDoc).From(d.generateCompleteTableName(tableWebhookDelivery))
+	var conditions []string
+	if len(query.Webhook) > 0 {
+		conditions = append(conditions, selectBuilder.Equal(colWebhook, query.Webhook))
+	}
+	if len(query.Status) > 0 {
+		conditions = append(conditions, selectBuilder.Equal(colStatus, string(query.Status)))
+	}
+	if !query.DueBefore.IsZero() {
+		conditions = append(conditions, selectBuilder.GreaterThan(colNextAttempt, 0), selectBuilder.LessEqualThan(colNextAttempt, query.DueBefore.UnixNano()))
+	}
+	if !query.CreatedBefore.IsZero() {
+		conditions = append(conditions, selectBuilder.LessThan(colCreatedAt, query.CreatedBefore.UnixNano()))
+	}
+	if len(conditions) > 0 {
+		selectBuilder.Where(conditions...)
+	}
+	selectBuilder.OrderBy(colCreatedAt, colID).Asc()
+	if query.Limit > 0 {
+		selectBuilder.Limit(query.Limit)
+	}
+	sqlQuery, args := selectBuilder.Build()
+
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := []*modelV1.WebhookDelivery{}
+	for rows.Next() {
+		var rowJSONDoc string
+		if scanErr := rows.Scan(&rowJSONDoc); scanErr != nil {
+			return nil, scanErr
+		}
+		delivery := &modelV1.WebhookDelivery{}
+		if unmarshalErr := json.Unmarshal([]byte(rowJSONDoc), delivery); unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+		result = append(result, delivery)
+	}
+	return result, rows.Err()
+}
+
+func (d *DAO) DeleteWebhookDeliveries(query *databaseModel.WebhookDeliveryQuery) error {
+	deleteBuilder := d.flavor().NewDeleteBuilder().DeleteFrom(d.generateCompleteTableName(tableWebhookDelivery))
+	var conditions []string
+	if len(query.Webhook) > 0 {
+		conditions = append(conditions, deleteBuilder.Equal(colWebhook, query.Webhook))
+	}
+	if len(query.Status) > 0 {
+		conditions = append(conditions, deleteBuilder.Equal(colStatus, string(query.Status)))
+	}
+	if !query.DueBefore.IsZero() {
+		conditions = append(conditions, deleteBuilder.GreaterThan(colNextAttempt, 0), deleteBuilder.LessEqualThan(colNextAttempt, query.DueBefore.UnixNano()))
+	}
+	if !query.CreatedBefore.IsZero() {
+		conditions = append(conditions, deleteBuilder.LessThan(colCreatedAt, query.CreatedBefore.UnixNano()))
+	}
+	if len(conditions) > 0 {
+		deleteBuilder.Where(conditions...)
+	}
+	sqlQuery, args := deleteBuilder.Build()
+	_, err := d.DB.Exec(sqlQuery, args...)
+	return err
+}
@@ -16,6 +16,7 @@ package dependency
 import (
 	"github.com/perses/perses/internal/api/database"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
+	accessTokenImpl "github.com/perses/perses/internal/api/impl/v1/accesstoken"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
 	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
@@ -30,8 +31,12 @@ import (
 	roleImpl "github.com/perses/perses/internal/api/impl/v1/role"
 	roleBindingImpl "github.com/perses/perses/internal/api/impl/v1/rolebinding"
 	secretImpl "github.com/perses/perses/internal/api/impl/v1/secret"
+	serviceAccountImpl "github.com/perses/perses/internal/api/impl/v1/serviceaccount"
+	sessionImpl "github.com/perses/perses/internal/api/impl/v1/session"
 	userImpl "github.com/perses/perses/internal/api/impl/v1/user"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
+	webhookImpl "github.com/perses/perses/internal/api/impl/v1/webhook"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
@@ -46,12 +51,16 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
 	"github.com/perses/perses/pkg/model/api/config"
 )
 
 type PersistenceManager interface {
+	GetAccessToken() accesstoken.DAO
 	GetDashboard() dashboard.DAO
 	GetDatasource() datasource.DAO
 	GetEphemeralDashboard() ephemeraldashboard.DAO
@@ -67,12 +76,16 @@ type PersistenceManager interface {
 	GetRole() role.DAO
 	GetRoleBinding() rolebinding.DAO
 	GetSecret() secret.DAO
+	GetServiceAccount() serviceaccount.DAO
+	GetSession() session.DAO
 	GetUser() user.DAO
 	GetVariable() variable.DAO
+	GetWebhook() webhook.DAO
 }
 
 type persistence struct {
 	PersistenceManager
+	accessToken        accesstoken.DAO
 	dashboard          dashboard.DAO
 	datasource         datasource.DAO
 	ephemeralDashboard ephemeraldashboard.DAO
@@ -88,8 +101,11 @@ type persistence struct {
 	role               role.DAO
 	roleBinding        rolebinding.DAO
 	secret             secret.DAO
+	serviceAccount     serviceaccount.DAO
+	session            session.DAO
 	user               user.DAO
 	variable           variable.DAO
+	webhook            webhook.DAO
 }
 
 func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
@@ -97,6 +113,7 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	accessTokenDAO := accessTokenImpl.NewDAO(persesDAO)
 	dashboardDAO := dashboardImpl.NewDAO(persesDAO)
 	datasourceDAO := datasourceImpl.NewDAO(persesDAO)
 	ephemeralDashboardDAO := ephemeralDashboardImpl.NewDAO(persesDAO)
@@ -111,9 +128,13 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 	roleDAO := roleImpl.NewDAO(persesDAO)
 	roleBindingDAO := roleBindingImpl.NewDAO(persesDAO)
 	secretDAO := secretImpl.NewDAO(persesDAO)
+	serviceAccountDAO := serviceAccountImpl.NewDAO(persesDAO)
+	sessionDAO := sessionImpl.NewDAO(persesDAO)
 	userDAO := userImpl.NewDAO(persesDAO)
 	variableDAO := variableImpl.NewDAO(persesDAO)
+	webhookDAO := webhookImpl.NewDAO(persesDAO)
 	return &persistence{
+		accessToken:        accessTokenDAO,
 		dashboard:          dashboardDAO,
 		datasource:         datasourceDAO,
 		ephemeralDashboard: ephemeralDashboardDAO,
@@ -129,11 +150,18 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 		role:               roleDAO,
 		roleBinding:        roleBindingDAO,
 		secret:             secretDAO,
+		serviceAccount:     serviceAccountDAO,
+		session:            sessionDAO,
 		user:               userDAO,
 		variable:           variableDAO,
+		webhook:            webhookDAO,
 	}, nil
 }
 
+func (p *persistence) GetAccessToken() accesstoken.DAO {
+	return p.accessToken
+}
+
 func (p *persistence) GetDashboard() dashboard.DAO {
 	return p.dashboard
 }
@@ -194,6 +222,14 @@ func (p *persistence) GetSecret() secret.DAO {
 	return p.secret
 }
 
+func (p *persistence) GetServiceAccount() serviceaccount.DAO {
+	return p.serviceAccount
+}
+
+func (p *persistence) GetSession() session.DAO {
+	return p.session
+}
+
 func (p *persistence) GetUser() user.DAO {
 	return p.user
 }
@@ -201,3 +237,7 @@ func (p *persistence) GetUser() user.DAO {
 func (p *persistence) GetVariable() variable.DAO {
 	return p.variable
 }
+
+func (p *persistence) GetWebhook() webhook.DAO {
+	return p.webhook
+}
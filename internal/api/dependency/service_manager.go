@@ -16,8 +16,11 @@
 package dependency
 
 import (
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
+	authImpl "github.com/perses/perses/internal/api/impl/auth"
+	accessTokenImpl "github.com/perses/perses/internal/api/impl/v1/accesstoken"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
 	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
@@ -32,9 +35,13 @@ import (
 	roleImpl "github.com/perses/perses/internal/api/impl/v1/role"
 	roleBindingImpl "github.com/perses/perses/internal/api/impl/v1/rolebinding"
 	secretImpl "github.com/perses/perses/internal/api/impl/v1/secret"
+	serviceAccountImpl "github.com/perses/perses/internal/api/impl/v1/serviceaccount"
+	sessionImpl "github.com/perses/perses/internal/api/impl/v1/session"
 	userImpl "github.com/perses/perses/internal/api/impl/v1/user"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
 	viewImpl "github.com/perses/perses/internal/api/impl/v1/view"
+	webhookImpl "github.com/perses/perses/internal/api/impl/v1/webhook"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
@@ -49,16 +56,23 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/interface/v1/view"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
 	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/plugin/migrate"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/search"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api/config"
 )
 
 type ServiceManager interface {
+	GetAccessToken() accesstoken.Service
+	GetAuditor() audit.Auditor
 	GetAuthorization() authorization.Authorization
 	GetCrypto() crypto.Crypto
 	GetDashboard() dashboard.Service
@@ -76,16 +90,23 @@ type ServiceManager interface {
 	GetPlugin() plugin.Plugin
 	GetProject() project.Service
 	GetSchema() schema.Schema
+	GetSearch() search.Index
 	GetRole() role.Service
 	GetRoleBinding() rolebinding.Service
 	GetSecret() secret.Service
+	GetServiceAccount() serviceaccount.Service
+	GetSession() session.Service
 	GetUser() user.Service
 	GetVariable() variable.Service
 	GetView() view.Service
+	GetWatch() watch.Broadcaster
+	GetWebhook() webhook.Service
 }
 
 type service struct {
 	ServiceManager
+	accessToken        accesstoken.Service
+	auditor            audit.Auditor
 	authorization      authorization.Authorization
 	crypto             crypto.Crypto
 	dashboard          dashboard.Service
@@ -103,45 +124,60 @@ type service struct {
 	plugin             plugin.Plugin
 	project            project.Service
 	schema             schema.Schema
+	search             search.Index
 	role               role.Service
 	roleBinding        rolebinding.Service
 	secret             secret.Service
+	serviceAccount     serviceaccount.Service
+	session            session.Service
 	user               user.Service
 	variable           variable.Service
 	view               view.Service
+	watch              watch.Broadcaster
+	webhook            webhook.Service
 }
 
 func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManager, error) {
-	cryptoService, jwtService, err := crypto.New(conf.Security)
+	cryptoService, jwtService, err := crypto.New(conf.Security, dao.GetSession())
 	if err != nil {
 		return nil, err
 	}
-	authzService, err := authorization.New(dao.GetUser(), dao.GetRole(), dao.GetRoleBinding(), dao.GetGlobalRole(), dao.GetGlobalRoleBinding(), conf)
+	authzService, err := authorization.New(jwtService, dao.GetUser(), dao.GetServiceAccount(), dao.GetAccessToken(), dao.GetSession(), dao.GetRole(), dao.GetRoleBinding(), dao.GetGlobalRole(), dao.GetGlobalRoleBinding(), conf)
 	if err != nil {
 		return nil, err
 	}
+	accessTokenService := accessTokenImpl.NewService(dao.GetAccessToken(), dao.GetUser(), dao.GetServiceAccount())
+	auditor := audit.New(conf.Audit, dao.GetPersesDAO(), authzService)
+	authorization.SetUserSynchronizer(authzService, authImpl.NewUserSynchronizer(dao.GetUser(), authzService, auditor))
 	pluginService := plugin.New(conf.Plugin)
 	schemaService := pluginService.Schema()
 	migrateService := pluginService.Migration()
-	dashboardService := dashboardImpl.NewService(conf, dao.GetDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService)
-	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), schemaService)
-	ephemeralDashboardService := ephemeralDashboardImpl.NewService(dao.GetEphemeralDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService)
-	folderService := folderImpl.NewService(dao.GetFolder())
-	variableService := variableImpl.NewService(dao.GetVariable(), schemaService)
-	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemaService)
-	globalRole := globalRoleImpl.NewService(dao.GetGlobalRole(), authzService, schemaService)
-	globalRoleBinding := globalRoleBindingImpl.NewService(dao.GetGlobalRoleBinding(), dao.GetGlobalRole(), dao.GetUser(), authzService, schemaService)
-	globalSecret := globalSecretImpl.NewService(dao.GetGlobalSecret(), cryptoService)
-	globalVariableService := globalVariableImpl.NewService(dao.GetGlobalVariable(), schemaService)
+	searchIndex := search.New(dao.GetDashboard(), dao.GetVariable())
+	broadcaster := watch.New()
+	dashboardService := dashboardImpl.NewService(conf, dao.GetDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService, authzService, searchIndex, broadcaster)
+	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), schemaService, broadcaster)
+	ephemeralDashboardService := ephemeralDashboardImpl.NewService(dao.GetEphemeralDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService, broadcaster)
+	folderService := folderImpl.NewService(dao.GetFolder(), broadcaster)
+	variableService := variableImpl.NewService(dao.GetVariable(), schemaService, searchIndex, broadcaster)
+	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemaService, broadcaster)
+	globalRole := globalRoleImpl.NewService(dao.GetGlobalRole(), authzService, schemaService, broadcaster)
+	globalRoleBinding := globalRoleBindingImpl.NewService(dao.GetGlobalRoleBinding(), dao.GetGlobalRole(), dao.GetUser(), authzService, schemaService, broadcaster)
+	globalSecret := globalSecretImpl.NewService(dao.GetGlobalSecret(), cryptoService, broadcaster)
+	globalVariableService := globalVariableImpl.NewService(dao.GetGlobalVariable(), schemaService, broadcaster)
 	healthService := healthImpl.NewService(dao.GetHealth())
-	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetRole(), dao.GetRoleBinding(), dao.GetSecret(), dao.GetVariable(), authzService)
-	roleService := roleImpl.NewService(dao.GetRole(), authzService, schemaService)
-	roleBindingService := roleBindingImpl.NewService(dao.GetRoleBinding(), dao.GetRole(), dao.GetUser(), authzService, schemaService)
-	secretService := secretImpl.NewService(dao.GetSecret(), cryptoService)
-	userService := userImpl.NewService(dao.GetUser(), authzService)
+	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetRole(), dao.GetRoleBinding(), dao.GetSecret(), dao.GetVariable(), authzService, searchIndex, broadcaster)
+	roleService := roleImpl.NewService(dao.GetRole(), authzService, schemaService, broadcaster)
+	roleBindingService := roleBindingImpl.NewService(dao.GetRoleBinding(), dao.GetRole(), dao.GetUser(), authzService, schemaService, broadcaster)
+	secretService := secretImpl.NewService(dao.GetSecret(), cryptoService, broadcaster)
+	serviceAccountService := serviceAccountImpl.NewService(dao.GetServiceAccount(), dao.GetAccessToken(), authzService, broadcaster)
+	sessionService := sessionImpl.NewService(dao.GetSession(), dao.GetUser())
+	userService := userImpl.NewService(dao.GetUser(), dao.GetAccessToken(), dao.GetSession(), authzService, broadcaster)
 	viewService := viewImpl.NewMetricsViewService()
+	webhookService := webhookImpl.NewService(dao.GetWebhook(), broadcaster)
 
 	svc := &service{
+		accessToken:        accessTokenService,
+		auditor:            auditor,
 		authorization:      authzService,
 		crypto:             cryptoService,
 		dashboard:          dashboardService,
@@ -161,14 +197,27 @@ func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManag
 		role:               roleService,
 		roleBinding:        roleBindingService,
 		schema:             schemaService,
+		search:             searchIndex,
 		secret:             secretService,
+		serviceAccount:     serviceAccountService,
+		session:            sessionService,
 		user:               userService,
 		variable:           variableService,
 		view:               viewService,
+		watch:              broadcaster,
+		webhook:            webhookService,
 	}
 	return svc, nil
 }
 
+func (s *service) GetAccessToken() accesstoken.Service {
+	return s.accessToken
+}
+
+func (s *service) GetAuditor() audit.Auditor {
+	return s.auditor
+}
+
 func (s *service) GetAuthorization() authorization.Authorization {
 	return s.authorization
 }
@@ -237,6 +286,10 @@ func (s *service) GetSchema() schema.Schema {
 	return s.schema
 }
 
+func (s *service) GetSearch() search.Index {
+	return s.search
+}
+
 func (s *service) GetRole() role.Service {
 	return s.role
 }
@@ -249,6 +302,14 @@ func (s *service) GetSecret() secret.Service {
 	return s.secret
 }
 
+func (s *service) GetServiceAccount() serviceaccount.Service {
+	return s.serviceAccount
+}
+
+func (s *service) GetSession() session.Service {
+	return s.session
+}
+
 func (s *service) GetUser() user.Service {
 	return s.user
 }
@@ -260,3 +321,11 @@ func (s *service) GetVariable() variable.Service {
 func (s *service) GetView() view.Service {
 	return s.view
 }
+
+func (s *service) GetWatch() watch.Broadcaster {
+	return s.watch
+}
+
+func (s *service) GetWebhook() webhook.Service {
+	return s.webhook
+}
@@ -24,7 +24,7 @@ import (
 
 func New(cfg config.Config, serviceManager dependency.ServiceManager, caseSensitive bool) ([]taskhelper.Helper, error) {
 	var helpers []taskhelper.Helper
-	svc := service.New(caseSensitive, serviceManager.GetGlobalDatasource())
+	svc := service.New(caseSensitive, serviceManager.GetGlobalDatasource(), serviceManager.GetAuditor())
 	for _, c := range cfg.Datasource.Global.Discovery {
 		var helper taskhelper.Helper
 		var err error
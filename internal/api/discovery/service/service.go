@@ -14,35 +14,43 @@
 package service
 
 import (
+	"github.com/perses/perses/internal/api/audit"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/sirupsen/logrus"
 )
 
-func New(caseSensitive bool, svc globaldatasource.Service) *ApplyService {
+func New(caseSensitive bool, svc globaldatasource.Service, auditor audit.Auditor) *ApplyService {
 	return &ApplyService{
 		caseSensitive: caseSensitive,
 		svc:           svc,
+		auditor:       auditor,
 	}
 }
 
 type ApplyService struct {
 	caseSensitive bool
 	svc           globaldatasource.Service
+	auditor       audit.Auditor
 }
 
 func (a *ApplyService) Apply(entities []*v1.GlobalDatasource) {
 	for _, entity := range entities {
 		entity.GetMetadata().Flatten(a.caseSensitive)
-		_, createErr := a.svc.Create(nil, entity)
+		created, createErr := a.svc.Create(nil, entity)
 		if createErr == nil {
+			event := audit.NewEvent(v1.AuditOriginDiscovery, role.CreateAction, v1.KindGlobalDatasource, "", entity.Metadata.Name)
+			event.NewVersion = audit.GetVersion(created)
+			a.auditor.Record(nil, event, nil)
 			continue
 		}
 
 		if !databaseModel.IsKeyConflict(createErr) {
 			logrus.WithError(createErr).Errorf("unable to create the globaldatasource %q", entity.Metadata.Name)
+			a.auditor.Record(nil, audit.NewEvent(v1.AuditOriginDiscovery, role.CreateAction, v1.KindGlobalDatasource, "", entity.Metadata.Name), createErr)
 			continue
 		}
 
@@ -50,8 +58,13 @@ func (a *ApplyService) Apply(entities []*v1.GlobalDatasource) {
 			Name: entity.Metadata.Name,
 		}
 
-		if _, updateError := a.svc.Update(nil, entity, param); updateError != nil {
+		event := audit.NewEvent(v1.AuditOriginDiscovery, role.UpdateAction, v1.KindGlobalDatasource, "", entity.Metadata.Name)
+		updated, updateError := a.svc.Update(nil, entity, param)
+		if updateError != nil {
 			logrus.WithError(updateError).Errorf("unable to update the globaldatasource %q", entity.Metadata.Name)
+		} else {
+			audit.SetUpdatedVersion(event, updated)
 		}
+		a.auditor.Record(nil, event, updateError)
 	}
 }
@@ -16,18 +16,26 @@
 package api
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/perses/perses/internal/api/dependency"
 	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
 	"github.com/perses/perses/internal/api/utils"
 	v1 "github.com/perses/perses/pkg/client/api/v1"
 	"github.com/perses/perses/pkg/client/config"
 	modelAPI "github.com/perses/perses/pkg/model/api"
+	apiConfig "github.com/perses/perses/pkg/model/api/config"
 	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/perses/perses/pkg/model/api/v1/secret"
 	"github.com/stretchr/testify/assert"
@@ -490,3 +498,48 @@ func TestAuth_OIDCProvider_Token_WithLib(t *testing.T) {
 		return nil
 	})
 }
+
+func TestAuthWithSigningKeys(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	serverConfig := e2eframework.DefaultAuthConfig()
+	serverConfig.Security.Authentication.SigningKeys = []apiConfig.JWTSigningKey{
+		{
+			ID:         "key-1",
+			Algorithm:  apiConfig.SigningAlgorithmES256,
+			PrivateKey: secret.Hidden(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		},
+	}
+	e2eframework.WithServerConfig(t, serverConfig, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+		usrEntity := e2eframework.NewUser("foo", "password")
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathUser)).
+			WithJSON(usrEntity).
+			Expect().
+			Status(http.StatusOK)
+
+		accessToken := expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIPrefix, utils.PathAuthProviders, utils.AuthKindNative, utils.PathLogin)).
+			WithJSON(modelAPI.Auth{Login: "foo", Password: usrEntity.Spec.NativeProvider.Password}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Path("$.access_token").String().Raw()
+
+		var set jose.JSONWebKeySet
+		expect.GET(fmt.Sprintf("%s/%s", utils.WellKnownPrefix, utils.PathJWKS)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Decode(&set)
+		keys := set.Key("key-1")
+		assert.Len(t, keys, 1)
+		assert.True(t, keys[0].IsPublic())
+
+		// The access token can be verified with the published key only.
+		token, err := jwt.Parse(accessToken, func(_ *jwt.Token) (any, error) {
+			return keys[0].Key, nil
+		}, jwt.WithValidMethods([]string{apiConfig.SigningAlgorithmES256}))
+		assert.NoError(t, err)
+		assert.Equal(t, "at+jwt", token.Header["typ"])
+		return []modelAPI.Entity{usrEntity}
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/perses/perses/internal/api/dependency"
@@ -29,6 +30,7 @@ import (
 	"github.com/perses/perses/pkg/model/api"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,6 +67,8 @@ func TestUpdateDashboardIncreaseVersion(t *testing.T) {
 			JSON().
 			Raw())
 
+		// The spec is modified before each update, since an update that doesn't change anything keeps the version.
+		entity.Spec.Duration = common.Duration(time.Hour)
 		updatedDashboard := extractDashboardFromHTTPBody(expect.PUT(fmt.Sprintf("%s/%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, dashboard.Metadata.Project, utils.PathDashboard, dashboard.Metadata.Name)).
 			WithJSON(entity).
 			Expect().
@@ -73,6 +77,7 @@ func TestUpdateDashboardIncreaseVersion(t *testing.T) {
 			Raw())
 		assert.True(t, dashboard.Metadata.Version+1 == updatedDashboard.Metadata.Version)
 
+		entity.Spec.Duration = common.Duration(2 * time.Hour)
 		updatedDashboard = extractDashboardFromHTTPBody(expect.PUT(fmt.Sprintf("%s/%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, dashboard.Metadata.Project, utils.PathDashboard, dashboard.Metadata.Name)).
 			WithJSON(entity).
 			Expect().
@@ -85,6 +90,88 @@ func TestUpdateDashboardIncreaseVersion(t *testing.T) {
 	})
 }
 
+func TestUpdateDashboardWithSameContent(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+
+		dashboard := extractDashboardFromHTTPBody(expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, entity.Metadata.Project, utils.PathDashboard)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw())
+
+		// Applying the same dashboard again neither bumps its version nor records a revision.
+		for i := 0; i < 2; i++ {
+			updatedDashboard := extractDashboardFromHTTPBody(expect.PUT(fmt.Sprintf("%s/%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, dashboard.Metadata.Project, utils.PathDashboard, dashboard.Metadata.Name)).
+				WithJSON(entity).
+				Expect().
+				Status(http.StatusOK).
+				JSON().
+				Raw())
+			assert.Equal(t, dashboard.Metadata.Version, updatedDashboard.Metadata.Version)
+		}
+
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, dashboard.Metadata.Project, utils.PathDashboard, dashboard.Metadata.Name, utils.PathRevision)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			Length().
+			IsEqual(1)
+		return []api.Entity{project, entity}
+	})
+}
+
+func TestDiffRevisionsOfDashboardWithoutCurrentRevision(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		// The dashboard is saved directly in the database, like the ones saved before the revision history was
+		// introduced: there is no revision for its current version.
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		entity.Metadata.Version = 2
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, entity)
+
+		diffPath := fmt.Sprintf("%s/%s/%s/%s/%s/%s/diff", utils.APIV1Prefix, utils.PathProject, entity.Metadata.Project, utils.PathDashboard, entity.Metadata.Name, utils.PathRevision)
+		expect.GET(diffPath).
+			WithQuery("from", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			HasValue("to", 2).
+			Value("patch").
+			Array().
+			IsEmpty()
+
+		previous := e2eframework.NewDashboard(t, "perses", "test")
+		previous.Metadata.Version = 1
+		previous.Spec.Duration = common.Duration(time.Hour)
+		if err := manager.GetDashboard().CreateRevision(previous, "", 10); err != nil {
+			t.Fatal(err)
+		}
+		expect.GET(diffPath).
+			WithQuery("from", 1).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("patch").
+			Array().
+			NotEmpty()
+
+		expect.GET(diffPath).
+			WithQuery("from", 1).
+			WithQuery("to", 3).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{project, entity}
+	})
+}
+
 func TestListDashboardInEmptyProject(t *testing.T) {
 	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
 		demoDashboard := e2eframework.NewDashboard(t, "perses", "Demo")
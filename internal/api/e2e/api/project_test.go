@@ -25,6 +25,7 @@ import (
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/dependency"
 	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/toolbox"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,133 @@ func TestDeleteProjectWithSubResources(t *testing.T) {
 		return []api.Entity{}
 	})
 }
+
+func TestUpdateProjectWithOutdatedVersion(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		path := fmt.Sprintf("%s/%s/%s", utils.APIV1Prefix, utils.PathProject, project.Metadata.Name)
+
+		expect.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			Header("ETag").IsEqual(`"0"`)
+
+		// version 0 means the client doesn't expect a particular version
+		expect.PUT(path).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusOK).
+			Header("ETag").IsEqual(`"1"`)
+
+		// the project has been modified in the meantime, so the version 0 is outdated
+		expect.PUT(path).
+			WithHeader("If-Match", `"0"`).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusConflict)
+
+		project.Metadata.Version = 2
+		expect.PUT(path).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusConflict)
+
+		project.Metadata.Version = 1
+		expect.PUT(path).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Path("$.metadata.version").IsEqual(2)
+
+		expect.PUT(path).
+			WithHeader("If-Match", `"2"`).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusOK).
+			Header("ETag").IsEqual(`"3"`)
+
+		expect.PUT(path).
+			WithHeader("If-Match", "2").
+			WithJSON(project).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{project}
+	})
+}
+
+func TestListProjectWithLabelSelector(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		sre := e2eframework.NewProject("sre")
+		sre.Metadata.Labels = map[string]string{"team": "sre", "tier": "prod"}
+		dev := e2eframework.NewProject("dev")
+		dev.Metadata.Labels = map[string]string{"team": "sre", "tier": "dev"}
+		other := e2eframework.NewProject("other")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, sre, dev, other)
+		path := fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)
+
+		result := expect.GET(path).
+			WithQuery("label_selector", "team=sre,tier!=dev").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().IsEqual(1)
+		result.Value(0).Object().Value("metadata").Object().Value("name").IsEqual("sre")
+
+		expect.GET(path).
+			WithQuery("label_selector", "!team").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+
+		expect.GET(path).
+			WithQuery("label_selector", "tier>1").
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{sre, dev, other}
+	})
+}
+
+func TestListProjectWithPagination(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		perses := e2eframework.NewProject("perses")
+		alpha := e2eframework.NewProject("alpha")
+		zeta := e2eframework.NewProject("zeta")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, perses, alpha, zeta)
+		path := fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)
+
+		response := expect.GET(path).
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK)
+		result := response.JSON().Array()
+		result.Length().IsEqual(2)
+		result.Value(0).Object().Value("metadata").Object().Value("name").IsEqual("alpha")
+		result.Value(1).Object().Value("metadata").Object().Value("name").IsEqual("perses")
+		token := response.Header(toolbox.HeaderContinue).NotEmpty().Raw()
+
+		response = expect.GET(path).
+			WithQuery("limit", 2).
+			WithQuery("continue", token).
+			Expect().
+			Status(http.StatusOK)
+		result = response.JSON().Array()
+		result.Length().IsEqual(1)
+		result.Value(0).Object().Value("metadata").Object().Value("name").IsEqual("zeta")
+		response.Header(toolbox.HeaderContinue).IsEmpty()
+
+		// The token can't be used with another sort.
+		expect.GET(path).
+			WithQuery("limit", 2).
+			WithQuery("continue", token).
+			WithQuery("order", "desc").
+			Expect().
+			Status(http.StatusBadRequest)
+
+		expect.GET(path).
+			WithQuery("sort", "version").
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{perses, alpha, zeta}
+	})
+}
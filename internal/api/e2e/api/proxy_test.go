@@ -16,6 +16,7 @@
 package api
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,11 +30,13 @@ import (
 	"github.com/perses/perses/internal/api/utils"
 	testUtils "github.com/perses/perses/internal/test"
 	"github.com/perses/perses/pkg/model/api"
+	apiConfig "github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/perses/perses/pkg/model/api/v1/datasource"
 	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
 	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	_ "modernc.org/sqlite"
 )
 
 func newHTTPDatasourceSpec(t *testing.T) v1.DatasourceSpec {
@@ -70,17 +73,21 @@ func newHTTPDatasourceSpec(t *testing.T) v1.DatasourceSpec {
 }
 
 func newSQLDatasourceSpec(t *testing.T) v1.DatasourceSpec {
+	return newSQLDatasourceSpecWithConfig(t, datasourceSQL.Config{
+		Driver:   "postgres",
+		Host:     "localhost:5432",
+		Database: "perses",
+		Postgres: &datasourceSQL.PostgresConfig{
+			SSLMode: datasourceSQL.SSLModePreferable,
+		},
+	})
+}
+
+func newSQLDatasourceSpecWithConfig(t *testing.T, config datasourceSQL.Config) v1.DatasourceSpec {
 	pluginSpec := &datasource.Postgres{
 		Proxy: &datasourceSQL.Proxy{
 			Kind: "SQLProxy",
-			Spec: datasourceSQL.Config{
-				Driver:   "postgres",
-				Host:     "localhost:5432",
-				Database: "perses",
-				Postgres: &datasourceSQL.PostgresConfig{
-					SSLMode: datasourceSQL.SSLModePreferable,
-				},
-			},
+			Spec: config,
 		},
 	}
 
@@ -219,7 +226,50 @@ func TestSQLProxyGlobalDatasource(t *testing.T) {
 			Expect().
 			Status(http.StatusOK).
 			Body().
-			IsEqual("datname\npostgres\nperses\ntemplate1\ntemplate0\n")
+			IsEqual("datname\r\npostgres\r\nperses\r\ntemplate1\r\ntemplate0\r\n")
+		return []api.Entity{dts}
+	})
+}
+
+func TestSQLProxySQLiteGlobalDatasource(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "analytics.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec(`CREATE TABLE users (id INTEGER, name TEXT); INSERT INTO users VALUES (1, 'perses'), (2, 'it''s')`); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	conf := e2eframework.DefaultConfig()
+	conf.Datasource.SQLite = &apiConfig.SQLiteDatasourceConfig{AllowedDirectories: []string{dir}}
+	e2eframework.WithServerConfig(t, conf, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		dtsName := "mySQLiteDTS"
+		dts := &v1.GlobalDatasource{
+			Kind:     v1.KindGlobalDatasource,
+			Metadata: v1.Metadata{Name: dtsName},
+			Spec:     newSQLDatasourceSpecWithConfig(t, datasourceSQL.Config{Driver: datasourceSQL.DriverSQLite, Database: dbPath}),
+		}
+		dts.Metadata.CreateNow()
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, dts)
+
+		expect.POST(fmt.Sprintf("/proxy/%s/%s", utils.PathGlobalDatasource, dtsName)).
+			WithHeader("Accept", "application/json").
+			WithBytes([]byte(`{"query": "SELECT id, name FROM users WHERE name = :name", "parameters": {"name": {"type": "string", "value": "it's"}}}`)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			IsEqual(map[string]any{
+				"columns": []any{map[string]any{"name": "id", "type": "int"}, map[string]any{"name": "name", "type": "string"}},
+				"rows":    []any{[]any{2, "it's"}},
+			})
+
+		expect.POST(fmt.Sprintf("/proxy/%s/%s", utils.PathGlobalDatasource, dtsName)).
+			WithBytes([]byte(`{"query": "DROP TABLE users"}`)).
+			Expect().
+			Status(http.StatusForbidden)
 		return []api.Entity{dts}
 	})
 }
@@ -257,7 +307,7 @@ func TestSQLProxyProjectDatasource(t *testing.T) {
 			Expect().
 			Status(http.StatusOK).
 			Body().
-			IsEqual("datname\npostgres\nperses\ntemplate1\ntemplate0\n")
+			IsEqual("datname\r\npostgres\r\nperses\r\ntemplate1\r\ntemplate0\r\n")
 		return []api.Entity{project, dts}
 	})
 }
@@ -299,7 +349,7 @@ func TestSQLProxyLocalDatasource(t *testing.T) {
 			Expect().
 			Status(http.StatusOK).
 			Body().
-			IsEqual("datname\npostgres\nperses\ntemplate1\ntemplate0\n")
+			IsEqual("datname\r\npostgres\r\nperses\r\ntemplate1\r\ntemplate0\r\n")
 		return []api.Entity{project, dashboard}
 	})
 }
@@ -27,6 +27,8 @@ import (
 	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
 	"github.com/perses/perses/internal/api/utils"
 	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -137,3 +139,110 @@ func TestUnauthorizedEndpoints(t *testing.T) {
 		return []modelAPI.Entity{}
 	})
 }
+
+func TestResourceNamesRestriction(t *testing.T) {
+	conf := e2eframework.DefaultAuthConfig()
+	conf.Security.Authorization.GuestPermissions = []*role.Permission{
+		{
+			Actions: []role.Action{role.CreateAction},
+			Scopes:  []role.Scope{role.ProjectScope},
+		},
+	}
+	e2eframework.WithServerConfig(t, conf, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+		owner := e2eframework.NewUser("foo", "password")
+		member := e2eframework.NewUser("bar", "password")
+		for _, usr := range []*v1.User{owner, member} {
+			expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathUser)).WithJSON(usr).Expect().Status(http.StatusOK)
+		}
+		ownerAuth := fmt.Sprintf("Bearer %s", loginWithPassword(expect, owner))
+
+		projectName := "restricted"
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)).WithJSON(e2eframework.NewProject(projectName)).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+		secretPath := fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName, utils.PathSecret)
+		for _, name := range []string{"team-a-token", "team-b-token"} {
+			expect.POST(secretPath).WithJSON(e2eframework.NewSecret(projectName, name)).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+		}
+
+		teamRole := e2eframework.NewRole(projectName, "team-a")
+		teamRole.Spec.Permissions = []role.Permission{
+			{
+				Actions:       []role.Action{role.ReadAction, role.UpdateAction},
+				Scopes:        []role.Scope{role.SecretScope},
+				ResourceNames: []string{"team-a-*"},
+			},
+		}
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName, utils.PathRole)).WithJSON(teamRole).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+		teamRoleBinding := e2eframework.NewRoleBinding(projectName, "team-a")
+		teamRoleBinding.Spec = v1.RoleBindingSpec{Role: "team-a", Subjects: []v1.Subject{{Kind: v1.KindUser, Name: "bar"}}}
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName, utils.PathRoleBinding)).WithJSON(teamRoleBinding).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+
+		memberAuth := fmt.Sprintf("Bearer %s", loginWithPassword(expect, member))
+		// Only the secrets matching the pattern are reachable.
+		expect.GET(fmt.Sprintf("%s/team-a-token", secretPath)).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusOK)
+		expect.GET(fmt.Sprintf("%s/team-b-token", secretPath)).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusForbidden)
+		expect.PUT(fmt.Sprintf("%s/team-a-token", secretPath)).WithJSON(e2eframework.NewSecret(projectName, "team-a-token")).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusOK)
+		expect.PUT(fmt.Sprintf("%s/team-b-token", secretPath)).WithJSON(e2eframework.NewSecret(projectName, "team-b-token")).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusForbidden)
+		expect.DELETE(fmt.Sprintf("%s/team-a-token", secretPath)).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusForbidden)
+
+		// The lists are filtered.
+		for _, path := range []string{secretPath, fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathSecret)} {
+			list := expect.GET(path).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusOK).JSON().Array()
+			list.Length().IsEqual(1)
+			list.Value(0).Object().Value("metadata").Object().Value("name").IsEqual("team-a-token")
+		}
+		// The restricted permission doesn't grant the access to other kinds.
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName, utils.PathRole)).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusForbidden)
+
+		// The auth cookies of the last login take precedence over the Authorization header, so the owner logs in again.
+		ownerAuth = fmt.Sprintf("Bearer %s", loginWithPassword(expect, owner))
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName)).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusNoContent)
+		e2eframework.ClearAllKeys(t, manager.GetPersesDAO(), owner, member)
+		return []modelAPI.Entity{}
+	})
+}
+
+func TestDatasourceQueryPermission(t *testing.T) {
+	conf := e2eframework.DefaultAuthConfig()
+	conf.Security.Authorization.GuestPermissions = []*role.Permission{
+		{
+			Actions: []role.Action{role.CreateAction},
+			Scopes:  []role.Scope{role.ProjectScope},
+		},
+	}
+	e2eframework.WithServerConfig(t, conf, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+		owner := e2eframework.NewUser("foo", "password")
+		member := e2eframework.NewUser("bar", "password")
+		for _, usr := range []*v1.User{owner, member} {
+			expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathUser)).WithJSON(usr).Expect().Status(http.StatusOK)
+		}
+		ownerAuth := fmt.Sprintf("Bearer %s", loginWithPassword(expect, owner))
+
+		projectName := "querier"
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)).WithJSON(e2eframework.NewProject(projectName)).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+		rolePath := fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName, utils.PathRole)
+		readerRole := e2eframework.NewRole(projectName, "reader")
+		readerRole.Spec.Permissions = []role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.DatasourceScope}}}
+		expect.POST(rolePath).WithJSON(readerRole).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+		readerRoleBinding := e2eframework.NewRoleBinding(projectName, "reader")
+		readerRoleBinding.Spec = v1.RoleBindingSpec{Role: "reader", Subjects: []v1.Subject{{Kind: v1.KindUser, Name: "bar"}}}
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName, utils.PathRoleBinding)).WithJSON(readerRoleBinding).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+
+		// Reading the datasources doesn't allow querying them.
+		memberAuth := fmt.Sprintf("Bearer %s", loginWithPassword(expect, member))
+		proxyPath := fmt.Sprintf("/proxy/%s/%s/%s/prometheus/api/v1/query", utils.PathProject, projectName, utils.PathDatasource)
+		expect.GET(proxyPath).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusForbidden)
+
+		// Once the query action is granted, the request reaches the datasource, which doesn't exist.
+		ownerAuth = fmt.Sprintf("Bearer %s", loginWithPassword(expect, owner))
+		readerRole.Spec.Permissions = append(readerRole.Spec.Permissions, role.Permission{Actions: []role.Action{role.QueryAction}, Scopes: []role.Scope{role.DatasourceScope}})
+		expect.PUT(fmt.Sprintf("%s/reader", rolePath)).WithJSON(readerRole).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusOK)
+		memberAuth = fmt.Sprintf("Bearer %s", loginWithPassword(expect, member))
+		expect.GET(proxyPath).WithHeader("Authorization", memberAuth).Expect().Status(http.StatusNotFound)
+
+		// The auth cookies of the last login take precedence over the Authorization header, so the owner logs in again.
+		ownerAuth = fmt.Sprintf("Bearer %s", loginWithPassword(expect, owner))
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", utils.APIV1Prefix, utils.PathProject, projectName)).WithHeader("Authorization", ownerAuth).Expect().Status(http.StatusNoContent)
+		e2eframework.ClearAllKeys(t, manager.GetPersesDAO(), owner, member)
+		return []modelAPI.Entity{}
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+)
+
+func TestSearch(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		projectEntity := e2eframework.NewProject("perses")
+		dashboardEntity := e2eframework.NewDashboard(t, "perses", "demo")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, projectEntity, dashboardEntity)
+		path := fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathSearch)
+
+		result := expect.GET(path).
+			WithQuery("q", "legend example").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().IsEqual(1)
+		panel := result.Value(0).Object()
+		panel.Value("kind").IsEqual("Panel")
+		panel.Value("project").IsEqual("perses")
+		panel.Value("dashboard").IsEqual("demo")
+		panel.Value("name").IsEqual("legendEx")
+
+		expect.GET(path).
+			WithQuery("q", "demo").
+			WithQuery("kind", "Dashboard").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+
+		expect.GET(path).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		expect.GET(path).
+			WithQuery("q", "demo").
+			WithQuery("kind", "Folder").
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{dashboardEntity, projectEntity}
+	})
+}
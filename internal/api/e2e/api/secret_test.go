@@ -16,18 +16,24 @@
 package api
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/crypto"
 	"github.com/perses/perses/internal/api/dependency"
 	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/impl/v1/reencrypt"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api"
+	apiConfig "github.com/perses/perses/pkg/model/api/config"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,3 +85,56 @@ func TestMainScenarioSecret(t *testing.T) {
 	e2eframework.DeleteTestScenarioWithProject(t, path, creator)
 	e2eframework.NotFoundTestScenarioWithProject(t, path, creator)
 }
+
+func TestReencryptSecrets(t *testing.T) {
+	serverConfig := e2eframework.DefaultConfig()
+	// The secrets are encrypted with the default key, which is then rotated.
+	legacyCrypto, _, err := crypto.New(serverConfig.Security, nil)
+	assert.NoError(t, err)
+	serverConfig.Security.PreviousEncryptionKeys = []apiConfig.EncryptionKey{{Key: serverConfig.Security.EncryptionKey}}
+	serverConfig.Security.EncryptionKey = secret.Hidden(hex.EncodeToString([]byte("e=dz;`M'5Pjvy^Sq3FVBkTC@N9?H/gua")))
+	serverConfig.Security.EncryptionKeyID = "2025"
+	e2eframework.WithServerConfig(t, serverConfig, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		scrt := e2eframework.NewSecret(project.Metadata.Name, "mysecret")
+		globalSecret := e2eframework.NewGlobalSecret("myglobalsecret")
+		assert.NoError(t, legacyCrypto.Encrypt(&scrt.Spec))
+		assert.NoError(t, legacyCrypto.Encrypt(&globalSecret.Spec))
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, scrt, globalSecret)
+
+		reencryptAll := func() []reencrypt.Progress {
+			body := expect.POST(fmt.Sprintf("%s/%s/%s", utils.APIV1Prefix, utils.PathEncryption, utils.PathReencrypt)).
+				Expect().
+				Status(http.StatusOK).
+				Body().Raw()
+			var result []reencrypt.Progress
+			decoder := json.NewDecoder(strings.NewReader(body))
+			for decoder.More() {
+				var p reencrypt.Progress
+				assert.NoError(t, decoder.Decode(&p))
+				result = append(result, p)
+			}
+			return result
+		}
+
+		progress := reencryptAll()
+		assert.Equal(t, []reencrypt.Progress{
+			{Kind: modelV1.KindSecret, Project: "perses", Name: "mysecret", Status: reencrypt.StatusReencrypted, Done: 1, Total: 2},
+			{Kind: modelV1.KindGlobalSecret, Name: "myglobalsecret", Status: reencrypt.StatusReencrypted, Done: 2, Total: 2},
+		}, progress)
+
+		storedSecret, err := manager.GetSecret().Get("perses", "mysecret")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(storedSecret.Spec.BasicAuth.Password, "2025:"))
+		assert.Equal(t, scrt.Metadata.Version+1, storedSecret.Metadata.Version)
+		storedGlobalSecret, err := manager.GetGlobalSecret().Get("myglobalsecret")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(storedGlobalSecret.Spec.BasicAuth.Password, "2025:"))
+
+		// The secrets already encrypted with the current key are left untouched.
+		for _, p := range reencryptAll() {
+			assert.Equal(t, reencrypt.StatusSkipped, p.Status)
+		}
+		return []api.Entity{project, scrt, globalSecret}
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/google/uuid"
+	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMainScenarioServiceAccount(t *testing.T) {
+	e2eframework.MainTestScenario(t, utils.PathServiceAccount, func(name string) api.Entity {
+		return e2eframework.NewServiceAccount(name)
+	})
+}
+
+func loginWithPassword(expect *httpexpect.Expect, usr *v1.User) string {
+	return expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIPrefix, utils.PathAuthProviders, utils.AuthKindNative, utils.PathLogin)).
+		WithJSON(api.Auth{Login: usr.Metadata.Name, Password: usr.Spec.NativeProvider.Password}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("access_token").String().Raw()
+}
+
+func TestUserAccessTokens(t *testing.T) {
+	e2eframework.WithServerConfig(t, e2eframework.DefaultAuthConfig(), func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		usr := e2eframework.NewUser("foo", "password")
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathUser)).WithJSON(usr).Expect().Status(http.StatusOK)
+		defer func() {
+			assert.NoError(t, manager.GetAccessToken().DeleteAll(v1.Subject{Kind: v1.KindUser, Name: "foo"}))
+		}()
+		jwtAuth := fmt.Sprintf("Bearer %s", loginWithPassword(expect, usr))
+		tokensPath := fmt.Sprintf("%s/%s/foo/%s", utils.APIV1Prefix, utils.PathUser, utils.PathAccessToken)
+
+		created := expect.POST(tokensPath).
+			WithHeader("Authorization", jwtAuth).
+			WithJSON(v1.AccessTokenRequest{Name: "laptop"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		created.Value("name").IsEqual("laptop")
+		created.Value("subject").Object().Value("kind").IsEqual(v1.KindUser)
+		created.NotContainsKey("hash")
+		tokenID := created.Value("id").String().Raw()
+		tokenAuth := fmt.Sprintf("Bearer %s", created.Value("token").String().Raw())
+
+		// The access token is accepted as well as the JWT.
+		tokens := expect.GET(tokensPath).
+			WithHeader("Authorization", tokenAuth).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		tokens.Length().IsEqual(1)
+		tokens.Value(0).Object().NotContainsKey("token")
+		tokens.Value(0).Object().ContainsKey("lastUsedAt")
+
+		// An access token can't be used to create another one.
+		expect.POST(tokensPath).
+			WithHeader("Authorization", tokenAuth).
+			WithJSON(v1.AccessTokenRequest{Name: "other"}).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// The tokens of the other users are not reachable.
+		expect.GET(fmt.Sprintf("%s/%s/bar/%s", utils.APIV1Prefix, utils.PathUser, utils.PathAccessToken)).
+			WithHeader("Authorization", jwtAuth).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// A scoped token can't do more than what it is allowed to.
+		scopedAuth := fmt.Sprintf("Bearer %s", expect.POST(tokensPath).
+			WithHeader("Authorization", jwtAuth).
+			WithJSON(v1.AccessTokenRequest{Name: "read-only", Permissions: []role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.WildcardScope}}}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Value("token").String().Raw())
+		projectPath := fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)
+		expect.GET(projectPath).WithHeader("Authorization", scopedAuth).Expect().Status(http.StatusOK)
+		expect.POST(projectPath).WithHeader("Authorization", scopedAuth).WithJSON(e2eframework.NewProject("mysuperproject")).Expect().Status(http.StatusForbidden)
+
+		// A revoked token is rejected right away.
+		expect.DELETE(fmt.Sprintf("%s/%s", tokensPath, tokenID)).
+			WithHeader("Authorization", jwtAuth).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.GET(tokensPath).
+			WithHeader("Authorization", tokenAuth).
+			Expect().
+			Status(http.StatusUnauthorized)
+		expect.GET(tokensPath).
+			WithHeader("Authorization", "Bearer perses_not_a_token").
+			Expect().
+			Status(http.StatusUnauthorized)
+		e2eframework.ClearAllKeys(t, manager.GetPersesDAO(), usr)
+		return []api.Entity{}
+	})
+}
+
+func TestServiceAccountAccessToken(t *testing.T) {
+	e2eframework.WithServerConfig(t, e2eframework.DefaultAuthConfig(), func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		sa := e2eframework.NewServiceAccount("ci")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, sa)
+		id := uuid.NewString()
+		token, secret, err := crypto.NewAccessToken(id)
+		assert.NoError(t, err)
+		hash, err := crypto.HashAndSalt([]byte(secret))
+		assert.NoError(t, err)
+		assert.NoError(t, manager.GetAccessToken().Create(&databaseModel.AccessToken{
+			AccessToken: v1.AccessToken{ID: id, Name: "ci", Subject: v1.Subject{Kind: v1.KindServiceAccount, Name: "ci"}},
+			Hash:        string(hash),
+		}))
+		defer func() {
+			assert.NoError(t, manager.GetAccessToken().DeleteAll(v1.Subject{Kind: v1.KindServiceAccount, Name: "ci"}))
+		}()
+
+		// The service account gets the guest permissions, like any authenticated identity.
+		expect.GET(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)).
+			WithHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			Expect().
+			Status(http.StatusOK)
+		// The tokens of a service account are managed by whoever can update it.
+		expect.GET(fmt.Sprintf("%s/%s/ci/%s", utils.APIV1Prefix, utils.PathServiceAccount, utils.PathAccessToken)).
+			WithHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+		expect.DELETE(fmt.Sprintf("%s/%s/ci/%s/%s", utils.APIV1Prefix, utils.PathServiceAccount, utils.PathAccessToken, id)).
+			WithHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			Expect().
+			Status(http.StatusForbidden)
+		return []api.Entity{sa}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func loginFromDevice(expect *httpexpect.Expect, usr *v1.User, userAgent string) *httpexpect.Object {
+	return expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIPrefix, utils.PathAuthProviders, utils.AuthKindNative, utils.PathLogin)).
+		WithHeader("User-Agent", userAgent).
+		WithJSON(api.Auth{Login: usr.Metadata.Name, Password: usr.Spec.NativeProvider.Password}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+}
+
+func refresh(expect *httpexpect.Expect, refreshToken string) *httpexpect.Response {
+	return expect.POST(fmt.Sprintf("%s/%s/%s", utils.APIPrefix, utils.PathAuth, utils.PathRefresh)).
+		WithJSON(api.RefreshRequest{RefreshToken: refreshToken}).
+		Expect()
+}
+
+func TestUserSessions(t *testing.T) {
+	e2eframework.WithServerConfig(t, e2eframework.DefaultAuthConfig(), func(server *httptest.Server, _ *httpexpect.Expect, _ dependency.PersistenceManager) []api.Entity {
+		// The cookies are not kept between the requests, so each one is authenticated by its own tokens only.
+		expect := httpexpect.WithConfig(httpexpect.Config{
+			BaseURL:  server.URL,
+			Reporter: httpexpect.NewAssertReporter(t),
+			Client:   &http.Client{},
+		})
+		usr := e2eframework.NewUser("jane", "password")
+		other := e2eframework.NewUser("john", "password")
+		for _, u := range []*v1.User{usr, other} {
+			expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathUser)).WithJSON(u).Expect().Status(http.StatusOK)
+		}
+		laptop := loginFromDevice(expect, usr, "Firefox")
+		phone := loginFromDevice(expect, usr, "Safari")
+		laptopAuth := fmt.Sprintf("Bearer %s", laptop.Value("access_token").String().Raw())
+		phoneAuth := fmt.Sprintf("Bearer %s", phone.Value("access_token").String().Raw())
+		sessionsPath := fmt.Sprintf("%s/%s/jane/%s", utils.APIV1Prefix, utils.PathUser, utils.PathSession)
+
+		sessions := expect.GET(sessionsPath).
+			WithHeader("Authorization", laptopAuth).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		sessions.Length().IsEqual(2)
+		sessions.Value(0).Object().Value("userAgent").IsEqual("Firefox")
+		sessions.Value(1).Object().Value("userAgent").IsEqual("Safari")
+		phoneSessionID := sessions.Value(1).Object().Value("id").String().Raw()
+
+		// The refresh token is accepted as long as its session is not revoked.
+		refresh(expect, phone.Value("refresh_token").String().Raw()).Status(http.StatusOK)
+
+		// Another user can't revoke the sessions.
+		johnAuth := fmt.Sprintf("Bearer %s", loginFromDevice(expect, other, "curl").Value("access_token").String().Raw())
+		expect.DELETE(sessionsPath).
+			WithHeader("Authorization", johnAuth).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// Once revoked, both tokens of the session are rejected, but not the ones of the other sessions.
+		expect.DELETE(fmt.Sprintf("%s/%s", sessionsPath, phoneSessionID)).
+			WithHeader("Authorization", laptopAuth).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.GET(sessionsPath).WithHeader("Authorization", phoneAuth).Expect().Status(http.StatusUnauthorized)
+		refresh(expect, phone.Value("refresh_token").String().Raw()).Status(http.StatusUnauthorized)
+		expect.GET(sessionsPath).WithHeader("Authorization", laptopAuth).Expect().Status(http.StatusOK).JSON().Array().Length().IsEqual(1)
+
+		// Revoking every session logs the user out everywhere.
+		expect.DELETE(sessionsPath).
+			WithHeader("Authorization", laptopAuth).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.GET(sessionsPath).WithHeader("Authorization", laptopAuth).Expect().Status(http.StatusUnauthorized)
+		refresh(expect, laptop.Value("refresh_token").String().Raw()).Status(http.StatusUnauthorized)
+		return []api.Entity{usr, other}
+	})
+}
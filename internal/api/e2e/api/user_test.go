@@ -75,6 +75,25 @@ func TestMainScenarioUser(t *testing.T) {
 			return []modelAPI.Entity{entity}
 		})
 	})
+	t.Run(fmt.Sprintf("Groups cannot be updated (%s)", path), func(t *testing.T) {
+		e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
+			entity := e2eframework.NewUser("myResource", "password")
+			entity.Spec.Groups = []string{"sre"}
+			e2eframework.CreateAndWaitUntilEntityExists(t, manager, entity)
+
+			update := e2eframework.NewUser("myResource", "password")
+			update.Spec.Groups = []string{"admin"}
+			o := expect.PUT(fmt.Sprintf("%s/%s/%s", utils.APIV1Prefix, path, entity.GetMetadata().GetName())).
+				WithJSON(update).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Raw()
+
+			result := decodePublicUser(t, o)
+			assert.Equal(t, []string{"sre"}, result.Spec.Groups)
+			return []modelAPI.Entity{entity}
+		})
+	})
 	e2eframework.DeleteTestScenario(t, path, creator)
 	e2eframework.NotFoundTestScenario(t, path, creator)
 }
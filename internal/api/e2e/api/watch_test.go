@@ -0,0 +1,93 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	e2eframework.WithServer(t, func(server *httptest.Server, expect *httpexpect.Expect, _ dependency.PersistenceManager) []api.Entity {
+		path := fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathWatch)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?kind=GlobalRole", server.URL, path), nil)
+		if !assert.NoError(t, err) {
+			return []api.Entity{}
+		}
+		req.Header.Set("Accept", "application/x-ndjson")
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			return []api.Entity{}
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		entity := e2eframework.NewGlobalRole("admin")
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathGlobalRole)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK)
+		// A change on another kind must not be sent.
+		expect.POST(fmt.Sprintf("%s/%s", utils.APIV1Prefix, utils.PathProject)).
+			WithJSON(e2eframework.NewProject("perses")).
+			Expect().
+			Status(http.StatusOK)
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", utils.APIV1Prefix, utils.PathGlobalRole, entity.Metadata.Name)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		type event struct {
+			Type    v1.WatchEventType `json:"type"`
+			Kind    v1.Kind           `json:"kind"`
+			Name    string            `json:"name"`
+			Version uint64            `json:"version"`
+		}
+		var events []event
+		scanner := bufio.NewScanner(resp.Body)
+		for len(events) < 2 && scanner.Scan() {
+			var e event
+			if assert.NoError(t, json.Unmarshal(scanner.Bytes(), &e)) {
+				events = append(events, e)
+			}
+		}
+		assert.Equal(t, []event{
+			{Type: v1.WatchEventAdded, Kind: v1.KindGlobalRole, Name: "admin"},
+			{Type: v1.WatchEventDeleted, Kind: v1.KindGlobalRole, Name: "admin"},
+		}, events)
+
+		expect.GET(path).
+			WithQuery("kind", "Unknown").
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{e2eframework.NewProject("perses")}
+	})
+}
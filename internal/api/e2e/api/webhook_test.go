@@ -0,0 +1,92 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMainScenarioWebhook(t *testing.T) {
+	e2eframework.MainTestScenario(t, utils.PathWebhook, func(name string) api.Entity {
+		return e2eframework.NewWebhook(name)
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		entity := e2eframework.NewWebhook("ci")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, entity)
+		now := time.Now().UTC()
+		delivery := &v1.WebhookDelivery{
+			ID:            "delivery-1",
+			Webhook:       "ci",
+			Action:        role.CreateAction,
+			Kind:          v1.KindDashboard,
+			Project:       "perses",
+			Name:          "demo",
+			Status:        v1.WebhookDeliveryPending,
+			CreatedAt:     now,
+			NextAttemptAt: &now,
+			Payload:       []byte(`{"delivery":"delivery-1"}`),
+		}
+		assert.NoError(t, manager.GetWebhook().CreateDelivery(delivery))
+		defer func() {
+			assert.NoError(t, manager.GetWebhook().DeleteDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "ci"}))
+		}()
+
+		path := fmt.Sprintf("%s/%s/ci/%s", utils.APIV1Prefix, utils.PathWebhook, utils.PathDelivery)
+		result := expect.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		result.Length().IsEqual(1)
+		result.Value(0).Object().Value("id").IsEqual("delivery-1")
+		result.Value(0).Object().Value("status").IsEqual(v1.WebhookDeliveryPending)
+
+		expect.GET(path).
+			WithQuery("status", v1.WebhookDeliveryFailed).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			IsEmpty()
+
+		expect.GET(path).
+			WithQuery("status", "unknown").
+			Expect().
+			Status(http.StatusBadRequest)
+
+		expect.GET(fmt.Sprintf("%s/%s/unknown/%s", utils.APIV1Prefix, utils.PathWebhook, utils.PathDelivery)).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{entity}
+	})
+}
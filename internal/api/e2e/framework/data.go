@@ -47,98 +47,112 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 			return persistenceManager.GetDashboard().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetDashboard().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.Datasource:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetDatasource().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetDatasource().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.EphemeralDashboard:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetEphemeralDashboard().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetEphemeralDashboard().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.GlobalDatasource:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalDatasource().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetGlobalDatasource().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.GlobalRole:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalRole().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetGlobalRole().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.GlobalRoleBinding:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalRoleBinding().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetGlobalRoleBinding().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.GlobalSecret:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalSecret().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetGlobalSecret().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.GlobalVariable:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalVariable().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetGlobalVariable().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.Project:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetProject().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetProject().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.Role:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetRole().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetRole().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.RoleBinding:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetRoleBinding().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetRoleBinding().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.Secret:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetSecret().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetSecret().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
+		}
+	case *v1.ServiceAccount:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetServiceAccount().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.User:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetUser().Get(entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetUser().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	case *v1.Variable:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetVariable().Get(entity.Metadata.Project, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
-			return persistenceManager.GetVariable().Update(entity)
+			return persistenceManager.GetPersesDAO().Upsert(entity)
+		}
+	case *v1.Webhook:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetWebhook().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetPersesDAO().Upsert(entity)
 		}
 	default:
 		t.Fatalf("%T is not managed", object)
@@ -493,6 +507,37 @@ func NewPublicUser(name string) *v1.PublicUser {
 	return entity
 }
 
+func NewWebhook(name string) *v1.Webhook {
+	entity := &v1.Webhook{
+		Kind:     v1.KindWebhook,
+		Metadata: newMetadata(name),
+		Spec: v1.WebhookSpec{
+			URL: common.MustParseURL("https://ci.example.com/hooks/perses"),
+			Filter: v1.WebhookFilter{
+				Kinds: []v1.Kind{v1.KindDashboard},
+			},
+			RetryPolicy: v1.WebhookRetryPolicy{
+				MaxAttempts: v1.DefaultWebhookMaxAttempts,
+				Backoff:     v1.DefaultWebhookBackoff,
+			},
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewServiceAccount(name string) *v1.ServiceAccount {
+	entity := &v1.ServiceAccount{
+		Kind:     v1.KindServiceAccount,
+		Metadata: newMetadata(name),
+		Spec: v1.ServiceAccountSpec{
+			Description: "used by the CI to provision the dashboards",
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
 func NewEphemeralDashboard(t *testing.T, projectName string, name string) *v1.EphemeralDashboard {
 	// Creating a full ephemeral dashboard is quite long and to ensure the changes are still matching the dev environment,
 	// it's better to use the ephemeral dashboard written in the dev/data/ephemeraldashboard.json
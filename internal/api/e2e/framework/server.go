@@ -109,7 +109,26 @@ func defaultFileConfig() *apiConfig.File {
 }
 
 func CreateServer(t *testing.T, conf apiConfig.Config) (*httptest.Server, *httpexpect.Expect, dependency.PersistenceManager) {
-	if useSQL == "true" {
+	if useSQL == "postgres" {
+		conf.Database = apiConfig.Database{
+			SQL: &apiConfig.SQL{
+				Driver:        apiConfig.SQLDriverPostgres,
+				Postgres:      &apiConfig.SQLPostgres{Schema: "public", SSLMode: apiConfig.PostgresSSLModeDisable},
+				User:          "user",
+				Password:      "password",
+				Addr:          "localhost:5432",
+				DBName:        "perses",
+				CaseSensitive: true,
+			},
+		}
+	} else if useSQL == "sqlite" {
+		conf.Database = apiConfig.Database{
+			SQLite: &apiConfig.SQLite{
+				Path:          filepath.Join(t.TempDir(), "perses.db"),
+				CaseSensitive: true,
+			},
+		}
+	} else if useSQL == "true" {
 		conf.Database = apiConfig.Database{
 			SQL: &apiConfig.SQL{
 				User:                 "user",
@@ -157,6 +176,8 @@ func WithServerConfig(t *testing.T, config apiConfig.Config, testFunc func(*http
 	defer server.Close()
 	entities := testFunc(server, expect, persistenceManager)
 	ClearAllKeys(t, persistenceManager.GetPersesDAO(), entities...)
+	// Every login of the test opened a session.
+	assert.NoError(t, persistenceManager.GetPersesDAO().DeleteSessions(&databaseModel.SessionQuery{}))
 }
 
 // NewOAuthProviderTestServer creates a new OAuth provider server that will be used to test the OAuth login.
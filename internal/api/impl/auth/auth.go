@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	apiinterface "github.com/perses/perses/internal/api/interface"
@@ -108,7 +109,7 @@ type endpoint struct {
 	isAuthEnable    bool
 }
 
-func New(dao user.DAO, jwt crypto.JWT, authz authorization.Authorization, providers config.AuthProviders, isAuthEnable bool, apiPrefix string) (route.Endpoint, error) {
+func New(dao user.DAO, jwt crypto.JWT, authz authorization.Authorization, auditor audit.Auditor, providers config.AuthProviders, isAuthEnable bool, apiPrefix string) (route.Endpoint, error) {
 	ep := &endpoint{
 		jwt:             jwt,
 		tokenManagement: tokenManagement{jwt: jwt},
@@ -123,7 +124,7 @@ func New(dao user.DAO, jwt crypto.JWT, authz authorization.Authorization, provid
 
 	// Register the OIDC providers if any
 	for _, provider := range providers.OIDC {
-		oidcEp, err := newOIDCEndpoint(provider, jwt, dao, authz, apiPrefix)
+		oidcEp, err := newOIDCEndpoint(provider, jwt, dao, authz, auditor, apiPrefix)
 		if err != nil {
 			return nil, err
 		}
@@ -132,12 +133,26 @@ func New(dao user.DAO, jwt crypto.JWT, authz authorization.Authorization, provid
 
 	// Register the OAuth providers if any
 	for _, provider := range providers.OAuth {
-		oauthEp, err := newOAuthEndpoint(provider, jwt, dao, authz, apiPrefix)
+		oauthEp, err := newOAuthEndpoint(provider, jwt, dao, authz, auditor, apiPrefix)
 		if err != nil {
 			return nil, err
 		}
 		ep.endpoints = append(ep.endpoints, oauthEp)
 	}
+
+	// Register the LDAP providers if any
+	for _, provider := range providers.LDAP {
+		ldapEp, err := newLDAPEndpoint(provider, jwt, dao, authz, auditor)
+		if err != nil {
+			return nil, err
+		}
+		ep.endpoints = append(ep.endpoints, ldapEp)
+	}
+
+	// Register the session endpoint of the trusted proxy if enabled
+	if providers.TrustedHeader != nil {
+		ep.endpoints = append(ep.endpoints, newTrustedHeaderEndpoint(jwt, authz))
+	}
 	return ep, nil
 }
 
@@ -172,9 +187,12 @@ func (e *endpoint) refresh(ctx echo.Context) error {
 	}
 	claims, err := e.jwt.ValidateRefreshToken(refreshToken)
 	if err != nil {
+		if errors.Is(err, crypto.ErrSessionRevoked) {
+			return apiinterface.HandleUnauthorizedError(err.Error())
+		}
 		return apiinterface.HandleBadRequestError(err.Error())
 	}
-	accessToken, err := e.tokenManagement.accessToken(claims.Subject, claims.ProviderInfo, ctx.SetCookie)
+	accessToken, err := e.tokenManagement.accessToken(claims.Subject, claims.ProviderInfo, claims.SessionID, ctx.SetCookie)
 	if err != nil {
 		return err
 	}
@@ -196,6 +214,14 @@ func (e *endpoint) logout(ctx echo.Context) error {
 		logrus.WithError(err).Error("error while retrieving provider info from session")
 		return apiinterface.InternalError
 	}
+	// The session is revoked, so its tokens can't be used anymore, even if they were copied before the logout.
+	if usr, userErr := e.authz.GetUser(ctx); userErr == nil {
+		if claims, ok := usr.(*crypto.JWTClaims); ok && len(claims.SessionID) > 0 {
+			if revokeErr := e.jwt.RevokeSession(claims.SessionID); revokeErr != nil {
+				logrus.WithError(revokeErr).Errorf("unable to revoke the session %q", claims.SessionID)
+			}
+		}
+	}
 
 	for _, ep := range e.endpoints {
 		if ep.GetAuthKind() == providerInfo.ProviderKind && ep.GetSlugID() == providerInfo.ProviderID {
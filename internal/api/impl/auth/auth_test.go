@@ -67,3 +67,21 @@ func TestDecodeOAuthState(t *testing.T) {
 	state = "short--"
 	assert.Equal(t, "", decodeOAuthState(state))
 }
+
+func TestLocalRedirectPath(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"", "/"},
+		{"/projects/perses?tab=dashboards", "/projects/perses?tab=dashboards"},
+		{"https://example.org", "/"},
+		{"//example.org", "/"},
+		{"/\\example.org", "/"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.want, localRedirectPath(tc.path))
+		})
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
+	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/crypto"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+)
+
+var errLDAPWrongCredentials = apiinterface.HandleBadRequestError("wrong login or password")
+
+type ldapUserInfo struct {
+	externalUserInfoProfile
+	login string
+	dn    string
+	// groups is nil when the provider is not configured to sync the groups.
+	groups []string
+	issuer string
+}
+
+// GetLogin implements [externalUserInfo]
+func (u *ldapUserInfo) GetLogin() string {
+	return u.login
+}
+
+// GetProfile implements [externalUserInfo]
+func (u *ldapUserInfo) GetProfile() externalUserInfoProfile {
+	return u.externalUserInfoProfile
+}
+
+// GetGroups implements [externalUserInfo]
+func (u *ldapUserInfo) GetGroups() ([]string, bool) {
+	return u.groups, u.groups != nil
+}
+
+// GetProviderContext implements [externalUserInfo]
+func (u *ldapUserInfo) GetProviderContext() v1.OAuthProvider {
+	return v1.OAuthProvider{
+		Issuer:  u.issuer,
+		Email:   u.Email,
+		Subject: u.dn,
+	}
+}
+
+type ldapEndpoint struct {
+	provider        config.LDAPProvider
+	tlsConfig       *tls.Config
+	tokenManagement tokenManagement
+	svc             service
+}
+
+func newLDAPEndpoint(provider config.LDAPProvider, jwt crypto.JWT, dao user.DAO, authz authorization.Authorization, auditor audit.Auditor) (authEndpoint, error) {
+	tlsConfig, err := provider.TLSConfig.BuildTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the TLS config of the LDAP provider %q: %w", provider.SlugID, err)
+	}
+	// StartTLS doesn't deduce the server name from the address, unlike the ldaps connection.
+	if len(tlsConfig.ServerName) == 0 {
+		tlsConfig.ServerName = provider.URL.Hostname()
+	}
+	return &ldapEndpoint{
+		provider:        provider,
+		tlsConfig:       tlsConfig,
+		tokenManagement: tokenManagement{jwt: jwt},
+		svc:             service{dao: dao, authz: authz, auditor: auditor, provider: crypto.ProviderInfo{ProviderKind: utils.AuthKindLDAP, ProviderID: provider.SlugID}},
+	}, nil
+}
+
+func (e *ldapEndpoint) GetExtraProviderLogoutHandler() echo.HandlerFunc {
+	return nil // The LDAP directory doesn't keep any session
+}
+
+func (e *ldapEndpoint) GetAuthKind() string {
+	return utils.AuthKindLDAP
+}
+
+func (e *ldapEndpoint) GetSlugID() string {
+	return e.provider.SlugID
+}
+
+func (e *ldapEndpoint) CollectRoutes(g *route.Group) {
+	g.POST(fmt.Sprintf("/%s/%s/%s", utils.AuthKindLDAP, e.provider.SlugID, utils.PathLogin), e.auth, true)
+}
+
+func (e *ldapEndpoint) logWithError(err error) *logrus.Entry {
+	return logrus.WithError(err).WithField("provider", e.provider.SlugID)
+}
+
+func (e *ldapEndpoint) auth(ctx echo.Context) error {
+	body := &api.Auth{}
+	if err := ctx.Bind(body); err != nil {
+		return apiinterface.HandleBadRequestError(err.Error())
+	}
+	// An empty password would be an unauthenticated bind, that most of the directories accept.
+	if len(body.Login) == 0 || len(body.Password) == 0 {
+		return errLDAPWrongCredentials
+	}
+	userInfo, err := e.authenticate(body.Login, body.Password)
+	if err != nil {
+		return err
+	}
+	usr, err := e.svc.syncUser(userInfo)
+	if err != nil {
+		e.logWithError(err).Error("Failed to sync user in database.")
+		return apiinterface.HandleBadRequestError(err.Error())
+	}
+
+	login := usr.GetMetadata().GetName()
+	providerInfo := crypto.ProviderInfo{
+		ProviderKind: utils.AuthKindLDAP,
+		ProviderID:   e.provider.SlugID,
+	}
+	sessionID, err := e.tokenManagement.openSession(ctx, login, providerInfo)
+	if err != nil {
+		return err
+	}
+	accessToken, err := e.tokenManagement.accessToken(login, providerInfo, sessionID, ctx.SetCookie)
+	if err != nil {
+		return err
+	}
+	refreshToken, err := e.tokenManagement.refreshToken(login, providerInfo, sessionID, ctx.SetCookie)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, oauth2.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    oidc.BearerToken,
+	})
+}
+
+// authenticate finds the entry of the user in the directory and binds with it to check the password.
+// It returns the user information to sync in the database.
+func (e *ldapEndpoint) authenticate(login, password string) (*ldapUserInfo, error) {
+	conn, err := e.dial()
+	if err != nil {
+		e.logWithError(err).Error("Failed to connect to the LDAP directory.")
+		return nil, apiinterface.InternalError
+	}
+	defer conn.Close()
+
+	if bindErr := e.serviceBind(conn); bindErr != nil {
+		e.logWithError(bindErr).Error("Failed to bind with the service account.")
+		return nil, apiinterface.InternalError
+	}
+	entry, err := e.searchUser(conn, login)
+	if err != nil {
+		return nil, err
+	}
+	if bindErr := conn.Bind(entry.DN, password); bindErr != nil {
+		if ldap.IsErrorWithCode(bindErr, ldap.LDAPResultInvalidCredentials) {
+			return nil, errLDAPWrongCredentials
+		}
+		e.logWithError(bindErr).Errorf("Failed to bind with the user %q.", entry.DN)
+		return nil, apiinterface.InternalError
+	}
+
+	userInfo := &ldapUserInfo{
+		externalUserInfoProfile: externalUserInfoProfile{
+			GivenName:  entry.GetAttributeValue(e.provider.Attributes.FirstName),
+			FamilyName: entry.GetAttributeValue(e.provider.Attributes.LastName),
+			Email:      entry.GetAttributeValue(e.provider.Attributes.Email),
+		},
+		login:  entry.GetAttributeValue(e.provider.Attributes.Login),
+		dn:     entry.DN,
+		issuer: e.provider.URL.String(),
+	}
+	if len(userInfo.login) == 0 {
+		userInfo.login = login
+	}
+	if e.provider.GroupSearch != nil {
+		// The groups are searched with the service account, as the user may not be allowed to read them.
+		if bindErr := e.serviceBind(conn); bindErr != nil {
+			e.logWithError(bindErr).Error("Failed to bind with the service account.")
+			return nil, apiinterface.InternalError
+		}
+		if userInfo.groups, err = e.searchGroups(conn, entry.DN, userInfo.login); err != nil {
+			e.logWithError(err).Errorf("Failed to search the groups of the user %q.", entry.DN)
+			return nil, apiinterface.InternalError
+		}
+	}
+	return userInfo, nil
+}
+
+func (e *ldapEndpoint) dial() (*ldap.Conn, error) {
+	timeout := time.Duration(e.provider.Timeout)
+	conn, err := ldap.DialURL(e.provider.URL.String(),
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(e.tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+	if e.provider.StartTLS {
+		if tlsErr := conn.StartTLS(e.tlsConfig); tlsErr != nil {
+			conn.Close()
+			return nil, tlsErr
+		}
+	}
+	return conn, nil
+}
+
+// serviceBind binds with the service account, or anonymously when there is none.
+func (e *ldapEndpoint) serviceBind(conn *ldap.Conn) error {
+	if len(e.provider.BindDN) == 0 {
+		return conn.UnauthenticatedBind("")
+	}
+	return conn.Bind(e.provider.BindDN, string(e.provider.BindPassword))
+}
+
+func (e *ldapEndpoint) searchUser(conn *ldap.Conn, login string) (*ldap.Entry, error) {
+	attributes := e.provider.Attributes
+	filter := strings.ReplaceAll(e.provider.UserSearch.Filter, config.LDAPLoginPlaceholder, ldap.EscapeFilter(login))
+	request := ldap.NewSearchRequest(e.provider.UserSearch.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{attributes.Login, attributes.Email, attributes.FirstName, attributes.LastName}, nil)
+	result, err := conn.Search(request)
+	if err != nil {
+		e.logWithError(err).Errorf("Failed to search the user with the filter %q.", filter)
+		return nil, apiinterface.InternalError
+	}
+	switch len(result.Entries) {
+	case 0:
+		return nil, errLDAPWrongCredentials
+	case 1:
+		return result.Entries[0], nil
+	default:
+		logrus.WithField("provider", e.provider.SlugID).Warningf("The filter %q matches several users, none of them can login.", filter)
+		return nil, errLDAPWrongCredentials
+	}
+}
+
+func (e *ldapEndpoint) searchGroups(conn *ldap.Conn, dn, login string) ([]string, error) {
+	groupSearch := e.provider.GroupSearch
+	filter := strings.NewReplacer(
+		config.LDAPDNPlaceholder, ldap.EscapeFilter(dn),
+		config.LDAPLoginPlaceholder, ldap.EscapeFilter(login),
+	).Replace(groupSearch.Filter)
+	request := ldap.NewSearchRequest(groupSearch.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{groupSearch.NameAttribute}, nil)
+	result, err := conn.Search(request)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]string, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		if name := entry.GetAttributeValue(groupSearch.NameAttribute); len(name) > 0 {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
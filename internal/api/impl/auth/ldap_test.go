@@ -0,0 +1,183 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jimlambrt/gldap"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ldapServiceDN       = "cn=perses,dc=example,dc=org"
+	ldapServicePassword = "service"
+	ldapAliceDN         = "uid=alice,ou=people,dc=example,dc=org"
+)
+
+// ldapTestEntry is an entry of the test directory, returned when the search filter is exactly the given one.
+type ldapTestEntry struct {
+	filter     string
+	dn         string
+	attributes map[string][]string
+}
+
+// startLDAPServer starts an in-process LDAP directory knowing alice and the service account.
+// Alice is a member of the groups dev and sre.
+func startLDAPServer(t *testing.T) string {
+	passwords := map[string]string{
+		ldapServiceDN: ldapServicePassword,
+		ldapAliceDN:   "wonderland",
+	}
+	entries := []ldapTestEntry{
+		{
+			filter: "(uid=alice)",
+			dn:     ldapAliceDN,
+			attributes: map[string][]string{
+				"uid":       {"alice"},
+				"mail":      {"alice@example.org"},
+				"givenName": {"Alice"},
+				"sn":        {"Liddell"},
+			},
+		},
+		// A presence filter matching every user, that an unescaped `*` login would build.
+		{filter: "(uid=*)", dn: ldapAliceDN, attributes: map[string][]string{"uid": {"alice"}}},
+		{filter: fmt.Sprintf("(member=%s)", ldapAliceDN), dn: "cn=sre,ou=groups,dc=example,dc=org", attributes: map[string][]string{"cn": {"sre"}}},
+		{filter: fmt.Sprintf("(member=%s)", ldapAliceDN), dn: "cn=dev,ou=groups,dc=example,dc=org", attributes: map[string][]string{"cn": {"dev"}}},
+	}
+
+	mux, err := gldap.NewMux()
+	require.NoError(t, err)
+	require.NoError(t, mux.Bind(func(w *gldap.ResponseWriter, r *gldap.Request) {
+		resp := r.NewBindResponse(gldap.WithResponseCode(gldap.ResultInvalidCredentials))
+		defer func() { _ = w.Write(resp) }()
+		m, msgErr := r.GetSimpleBindMessage()
+		if msgErr != nil {
+			return
+		}
+		if password, ok := passwords[m.UserName]; (ok && password == string(m.Password)) || (m.UserName == "" && m.Password == "") {
+			resp.SetResultCode(gldap.ResultSuccess)
+		}
+	}))
+	require.NoError(t, mux.Search(func(w *gldap.ResponseWriter, r *gldap.Request) {
+		resp := r.NewSearchDoneResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+		defer func() { _ = w.Write(resp) }()
+		m, msgErr := r.GetSearchMessage()
+		if msgErr != nil {
+			resp.SetResultCode(gldap.ResultOperationsError)
+			return
+		}
+		for _, entry := range entries {
+			if entry.filter != m.Filter {
+				continue
+			}
+			result := r.NewSearchResponseEntry(entry.dn)
+			for name, values := range entry.attributes {
+				result.AddAttribute(name, values)
+			}
+			_ = w.Write(result)
+		}
+	}))
+
+	server, err := gldap.NewServer()
+	require.NoError(t, err)
+	require.NoError(t, server.Router(mux))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	go func() { _ = server.Run(addr) }()
+	t.Cleanup(func() { _ = server.Stop() })
+	require.Eventually(t, server.Ready, 5*time.Second, 10*time.Millisecond)
+	return fmt.Sprintf("ldap://%s", addr)
+}
+
+func newTestLDAPEndpoint(t *testing.T, rawURL string, modify func(provider *config.LDAPProvider)) *ldapEndpoint {
+	provider := config.LDAPProvider{
+		SlugID:       "corporate",
+		Name:         "Corporate",
+		URL:          *common.MustParseURL(rawURL),
+		BindDN:       ldapServiceDN,
+		BindPassword: ldapServicePassword,
+		UserSearch:   config.LDAPUserSearch{BaseDN: "ou=people,dc=example,dc=org"},
+		GroupSearch:  &config.LDAPGroupSearch{BaseDN: "ou=groups,dc=example,dc=org"},
+	}
+	if modify != nil {
+		modify(&provider)
+	}
+	require.NoError(t, provider.Verify())
+	require.NoError(t, provider.UserSearch.Verify())
+	require.NoError(t, provider.Attributes.Verify())
+	if provider.GroupSearch != nil {
+		require.NoError(t, provider.GroupSearch.Verify())
+	}
+	ep, err := newLDAPEndpoint(provider, nil, nil, nil, nil)
+	require.NoError(t, err)
+	return ep.(*ldapEndpoint)
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	rawURL := startLDAPServer(t)
+
+	ep := newTestLDAPEndpoint(t, rawURL, nil)
+	userInfo, err := ep.authenticate("alice", "wonderland")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", userInfo.GetLogin())
+	assert.Equal(t, externalUserInfoProfile{GivenName: "Alice", FamilyName: "Liddell", Email: "alice@example.org"}, userInfo.GetProfile())
+	assert.Equal(t, ldapAliceDN, userInfo.GetProviderContext().Subject)
+	assert.Equal(t, rawURL, userInfo.GetProviderContext().Issuer)
+	groups, syncGroups := userInfo.GetGroups()
+	assert.True(t, syncGroups)
+	assert.ElementsMatch(t, []string{"dev", "sre"}, groups)
+
+	_, err = ep.authenticate("alice", "wrong")
+	assert.Equal(t, errLDAPWrongCredentials, err)
+
+	_, err = ep.authenticate("bob", "wonderland")
+	assert.Equal(t, errLDAPWrongCredentials, err)
+
+	// The login is escaped, so it can't turn the filter into a presence filter matching alice.
+	_, err = ep.authenticate("*", "wonderland")
+	assert.Equal(t, errLDAPWrongCredentials, err)
+}
+
+func TestLDAPAuthenticateWithoutGroupSearch(t *testing.T) {
+	rawURL := startLDAPServer(t)
+
+	ep := newTestLDAPEndpoint(t, rawURL, func(provider *config.LDAPProvider) {
+		provider.GroupSearch = nil
+	})
+	userInfo, err := ep.authenticate("alice", "wonderland")
+	require.NoError(t, err)
+	groups, syncGroups := userInfo.GetGroups()
+	assert.False(t, syncGroups)
+	assert.Nil(t, groups)
+}
+
+func TestLDAPAuthenticateWrongServiceAccount(t *testing.T) {
+	rawURL := startLDAPServer(t)
+
+	ep := newTestLDAPEndpoint(t, rawURL, func(provider *config.LDAPProvider) {
+		provider.BindPassword = "wrong"
+	})
+	_, err := ep.authenticate("alice", "wonderland")
+	assert.Equal(t, apiinterface.InternalError, err)
+}
@@ -80,11 +80,15 @@ func (e *nativeEndpoint) auth(ctx echo.Context) error {
 		ProviderKind: utils.AuthKindNative,
 		ProviderID:   "", // no provider ID needed for native auth
 	}
-	accessToken, err := e.tokenManagement.accessToken(login, providerInfo, ctx.SetCookie)
+	sessionID, err := e.tokenManagement.openSession(ctx, login, providerInfo)
 	if err != nil {
 		return err
 	}
-	refreshToken, err := e.tokenManagement.refreshToken(login, providerInfo, ctx.SetCookie)
+	accessToken, err := e.tokenManagement.accessToken(login, providerInfo, sessionID, ctx.SetCookie)
+	if err != nil {
+		return err
+	}
+	refreshToken, err := e.tokenManagement.refreshToken(login, providerInfo, sessionID, ctx.SetCookie)
 	if err != nil {
 		return err
 	}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/gorilla/securecookie"
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	apiinterface "github.com/perses/perses/internal/api/interface"
@@ -89,6 +90,7 @@ type oauthUserInfo struct {
 	externalUserInfoProfile
 	RawProperties map[string]any
 	loginKeys     []string
+	groupsClaim   string
 	authURL       url.URL
 }
 
@@ -115,6 +117,14 @@ func (u *oauthUserInfo) GetProfile() externalUserInfoProfile {
 	return u.externalUserInfoProfile
 }
 
+// GetGroups implements [externalUserInfo]
+func (u *oauthUserInfo) GetGroups() ([]string, bool) {
+	if len(u.groupsClaim) == 0 {
+		return nil, false
+	}
+	return getClaimValues(u.RawProperties, u.groupsClaim), true
+}
+
 // GetProviderContext implements [externalUserInfo]
 func (u *oauthUserInfo) GetProviderContext() v1.OAuthProvider {
 	return v1.OAuthProvider{
@@ -138,6 +148,7 @@ type oAuthEndpoint struct {
 	authURL         url.URL
 	svc             service
 	loginProps      []string
+	groupsClaim     string
 	apiPrefix       string
 }
 
@@ -153,7 +164,7 @@ func (e *oAuthEndpoint) GetSlugID() string {
 	return e.slugID
 }
 
-func newOAuthEndpoint(provider config.OAuthProvider, jwt crypto.JWT, dao user.DAO, authz authorization.Authorization, apiPrefix string) (authEndpoint, error) {
+func newOAuthEndpoint(provider config.OAuthProvider, jwt crypto.JWT, dao user.DAO, authz authorization.Authorization, auditor audit.Auditor, apiPrefix string) (authEndpoint, error) {
 	// As the cookie is used only at login time, we don't need a persistent value here.
 	// (same reason as newOIDCEndpoint)
 	key := securecookie.GenerateRandomKey(16)
@@ -190,8 +201,9 @@ func newOAuthEndpoint(provider config.OAuthProvider, jwt crypto.JWT, dao user.DA
 		slugID:          provider.SlugID,
 		userInfoURL:     provider.UserInfosURL.String(),
 		authURL:         *provider.AuthURL.URL,
-		svc:             service{dao: dao, authz: authz},
+		svc:             service{dao: dao, authz: authz, auditor: auditor, provider: crypto.ProviderInfo{ProviderKind: utils.AuthKindOAuth, ProviderID: provider.SlugID}},
 		loginProps:      loginProps,
+		groupsClaim:     provider.GroupsClaim,
 		apiPrefix:       apiPrefix,
 	}, nil
 }
@@ -373,7 +385,7 @@ func (e *oAuthEndpoint) codeExchangeHandler(ctx echo.Context) error {
 		return err
 	}
 
-	_, err = e.performUserSync(uInfo, ctx.SetCookie)
+	_, err = e.performUserSync(ctx, uInfo, ctx.SetCookie)
 	if err != nil {
 		return err
 	}
@@ -443,7 +455,7 @@ func (e *oAuthEndpoint) tokenHandler(ctx echo.Context) error {
 		return err
 	}
 
-	resp, err := e.performUserSync(uInfo, ctx.SetCookie)
+	resp, err := e.performUserSync(ctx, uInfo, ctx.SetCookie)
 	if err != nil {
 		return err
 	}
@@ -451,7 +463,7 @@ func (e *oAuthEndpoint) tokenHandler(ctx echo.Context) error {
 }
 
 // performUserSync performs user synchronization and generates access and refresh tokens.
-func (e *oAuthEndpoint) performUserSync(userInfo externalUserInfo, setCookie func(cookie *http.Cookie)) (*oauth2.Token, error) {
+func (e *oAuthEndpoint) performUserSync(ctx echo.Context, userInfo externalUserInfo, setCookie func(cookie *http.Cookie)) (*oauth2.Token, error) {
 	usr, err := e.svc.syncUser(userInfo)
 	if err != nil {
 		e.logWithError(err).Error("Failed to sync user in database.")
@@ -464,12 +476,17 @@ func (e *oAuthEndpoint) performUserSync(userInfo externalUserInfo, setCookie fun
 		ProviderKind: utils.AuthKindOAuth,
 		ProviderID:   e.slugID,
 	}
-	accessToken, err := e.tokenManagement.accessToken(username, providerInfo, setCookie)
+	sessionID, err := e.tokenManagement.openSession(ctx, username, providerInfo)
+	if err != nil {
+		e.logWithError(err).Error("Failed to open a session.")
+		return nil, err
+	}
+	accessToken, err := e.tokenManagement.accessToken(username, providerInfo, sessionID, setCookie)
 	if err != nil {
 		e.logWithError(err).Error("Failed to generate and save access token.")
 		return nil, err
 	}
-	refreshToken, err := e.tokenManagement.refreshToken(username, providerInfo, setCookie)
+	refreshToken, err := e.tokenManagement.refreshToken(username, providerInfo, sessionID, setCookie)
 	if err != nil {
 		e.logWithError(err).Error("Failed to generate and save refresh token.")
 		return nil, err
@@ -583,8 +600,9 @@ func (e *oAuthEndpoint) requestUserInfo(ctx context.Context, token *oauth2.Token
 	}
 
 	userInfos := oauthUserInfo{
-		authURL:   e.authURL,
-		loginKeys: e.loginProps,
+		authURL:     e.authURL,
+		loginKeys:   e.loginProps,
+		groupsClaim: e.groupsClaim,
 	}
 
 	if err = json.Unmarshal(body, &userInfos); err != nil {
@@ -15,6 +15,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/gorilla/securecookie"
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	apiinterface "github.com/perses/perses/internal/api/interface"
@@ -46,6 +48,19 @@ type oidcUserInfo struct {
 	Subject string `json:"sub,omitempty"`
 	// issuer is not supposed to be taken from json, but instead it must be set right before the db sync.
 	issuer string
+	// groupsClaim is not supposed to be taken from json either, it is set right before the db sync.
+	groupsClaim string
+	// rawClaims contains all the claims of the user info, so the groups can be read from any of them.
+	rawClaims map[string]any
+}
+
+// UnmarshalJSON keeps all the claims of the user info in addition to the known ones.
+func (u *oidcUserInfo) UnmarshalJSON(data []byte) error {
+	type plain oidcUserInfo
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &u.rawClaims)
 }
 
 // GetSubject implements [rp.SubjectGetter]
@@ -68,6 +83,14 @@ func (u *oidcUserInfo) GetProfile() externalUserInfoProfile {
 	return u.externalUserInfoProfile
 }
 
+// GetGroups implements [externalUserInfo]
+func (u *oidcUserInfo) GetGroups() ([]string, bool) {
+	if len(u.groupsClaim) == 0 {
+		return nil, false
+	}
+	return getClaimValues(u.rawClaims, u.groupsClaim), true
+}
+
 // GetProviderContext implements [externalUserInfo]
 func (u *oidcUserInfo) GetProviderContext() v1.OAuthProvider {
 	return v1.OAuthProvider{
@@ -151,6 +174,7 @@ type oIDCEndpoint struct {
 	slugID                 string
 	urlParams              map[string]string
 	issuer                 string
+	groupsClaim            string
 	svc                    service
 	extraLogoutHandler     echo.HandlerFunc
 	apiPrefix              string
@@ -175,7 +199,7 @@ func newOIDCExtraLogoutHandler(provider config.OIDCProvider, rp *RelyingPartyWit
 	}, nil
 }
 
-func newOIDCEndpoint(provider config.OIDCProvider, jwt crypto.JWT, dao user.DAO, authz authorization.Authorization, apiPrefix string) (authEndpoint, error) {
+func newOIDCEndpoint(provider config.OIDCProvider, jwt crypto.JWT, dao user.DAO, authz authorization.Authorization, auditor audit.Auditor, apiPrefix string) (authEndpoint, error) {
 	relyingParty, err := newRelyingParty(provider, nil)
 	if err != nil {
 		return nil, err
@@ -209,7 +233,8 @@ func newOIDCEndpoint(provider config.OIDCProvider, jwt crypto.JWT, dao user.DAO,
 		slugID:                 provider.SlugID,
 		urlParams:              provider.URLParams,
 		issuer:                 provider.Issuer.String(),
-		svc:                    service{dao: dao, authz: authz},
+		groupsClaim:            provider.GroupsClaim,
+		svc:                    service{dao: dao, authz: authz, auditor: auditor, provider: crypto.ProviderInfo{ProviderKind: utils.AuthKindOIDC, ProviderID: provider.SlugID}},
 		extraLogoutHandler:     extraLogoutHandler,
 		apiPrefix:              apiPrefix,
 	}, nil
@@ -276,7 +301,7 @@ func (e *oIDCEndpoint) codeExchange(ctx echo.Context) error {
 			http.SetCookie(w, cookie)
 		}
 
-		if _, err := e.performUserSync(info, setCookie); err != nil {
+		if _, err := e.performUserSync(ctx, info, setCookie); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			writeResponse(w, []byte(apiinterface.InternalError.Error()))
 			return
@@ -368,7 +393,7 @@ func (e *oIDCEndpoint) token(ctx echo.Context) error {
 		return oidc.ErrUnsupportedGrantType()
 	}
 
-	resp, err := e.performUserSync(uInfo, ctx.SetCookie)
+	resp, err := e.performUserSync(ctx, uInfo, ctx.SetCookie)
 	if err != nil {
 		return err
 	}
@@ -376,9 +401,10 @@ func (e *oIDCEndpoint) token(ctx echo.Context) error {
 }
 
 // performUserSync performs user synchronization and generates access and refresh tokens.
-func (e *oIDCEndpoint) performUserSync(userInfo *oidcUserInfo, setCookie func(cookie *http.Cookie)) (*oauth2.Token, error) {
-	// We don´t forget to set the issuer before making any sync in the database.
+func (e *oIDCEndpoint) performUserSync(ctx echo.Context, userInfo *oidcUserInfo, setCookie func(cookie *http.Cookie)) (*oauth2.Token, error) {
+	// We don´t forget to set the issuer and the groups claim before making any sync in the database.
 	userInfo.issuer = e.issuer
+	userInfo.groupsClaim = e.groupsClaim
 
 	usr, err := e.svc.syncUser(userInfo)
 	if err != nil {
@@ -392,12 +418,17 @@ func (e *oIDCEndpoint) performUserSync(userInfo *oidcUserInfo, setCookie func(co
 		ProviderKind: utils.AuthKindOIDC,
 		ProviderID:   e.slugID,
 	}
-	accessToken, err := e.tokenManagement.accessToken(username, providerInfo, setCookie)
+	sessionID, err := e.tokenManagement.openSession(ctx, username, providerInfo)
+	if err != nil {
+		e.logWithError(err).Error("Failed to open a session.")
+		return nil, err
+	}
+	accessToken, err := e.tokenManagement.accessToken(username, providerInfo, sessionID, setCookie)
 	if err != nil {
 		e.logWithError(err).Error("Failed to generate and save access token.")
 		return nil, err
 	}
-	refreshToken, err := e.tokenManagement.refreshToken(username, providerInfo, setCookie)
+	refreshToken, err := e.tokenManagement.refreshToken(username, providerInfo, sessionID, setCookie)
 	if err != nil {
 		e.logWithError(err).Error("Failed to generate and save refresh token.")
 		return nil, err
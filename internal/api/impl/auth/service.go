@@ -16,11 +16,16 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/crypto"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/sirupsen/logrus"
 )
 
@@ -68,15 +73,41 @@ func saveProviderInfo(old v1.UserSpec, uInfoProvider v1.OAuthProvider) (v1.UserS
 	return old, !foundPerfectMatch, nil
 }
 
-func newSpecIfChanged(old v1.UserSpec, uInfo externalUserInfo) (v1.UserSpec, bool, error) {
+// saveGroups replaces the groups of the user with the ones coming from the provider, when the provider is configured
+// to sync them.
+// Return a boolean saying if the result is different from old value.
+func saveGroups(old v1.UserSpec, uInfo externalUserInfo) (v1.UserSpec, bool) {
+	groups, ok := uInfo.GetGroups()
+	if !ok {
+		return old, false
+	}
+	if len(groups) == 0 {
+		groups = nil
+	} else {
+		groups = slices.Clone(groups)
+		slices.Sort(groups)
+		groups = slices.Compact(groups)
+	}
+	changed := !slices.Equal(old.Groups, groups)
+	old.Groups = groups
+	return old, changed
+}
+
+// newSpecIfChanged returns the new spec of the user and two booleans.
+// The first one says if the spec has changed, the second one if the groups have changed.
+func newSpecIfChanged(old v1.UserSpec, uInfo externalUserInfo) (v1.UserSpec, bool, bool, error) {
 	specWithProfile, profileChanged := saveProfileInfo(old, uInfo.GetProfile())
-	newSpec, providerChanged, err := saveProviderInfo(specWithProfile, uInfo.GetProviderContext())
-	return newSpec, profileChanged || providerChanged, err
+	specWithGroups, groupsChanged := saveGroups(specWithProfile, uInfo)
+	newSpec, providerChanged, err := saveProviderInfo(specWithGroups, uInfo.GetProviderContext())
+	return newSpec, profileChanged || groupsChanged || providerChanged, groupsChanged, err
 }
 
 type service struct {
-	dao   user.DAO
-	authz authorization.Authorization
+	dao     user.DAO
+	authz   authorization.Authorization
+	auditor audit.Auditor
+	// provider is the provider used to sync the users. It is recorded in the audit events.
+	provider crypto.ProviderInfo
 }
 
 func (s *service) getOrPrepareUserEntity(login string) (*v1.User, bool, error) {
@@ -96,13 +127,17 @@ func (s *service) syncUser(uInfo externalUserInfo) (*v1.User, error) {
 	if len(login) == 0 {
 		return nil, errors.New("the user login cannot be empty")
 	}
-	entity, isNew, err := s.getOrPrepareUserEntity(uInfo.GetLogin())
+	// The login must be a valid name, so a user can't be mistaken for a service account.
+	if err := common.ValidateID(login); err != nil {
+		return nil, fmt.Errorf("invalid user login: %w", err)
+	}
+	entity, isNew, err := s.getOrPrepareUserEntity(login)
 	if err != nil {
 		return nil, err
 	}
 
-	var specHasChanged bool
-	entity.Spec, specHasChanged, err = newSpecIfChanged(entity.Spec, uInfo)
+	var specHasChanged, groupsHaveChanged bool
+	entity.Spec, specHasChanged, groupsHaveChanged, err = newSpecIfChanged(entity.Spec, uInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +145,11 @@ func (s *service) syncUser(uInfo externalUserInfo) (*v1.User, error) {
 	if isNew {
 		entity.Metadata.CreateNow()
 		err = s.dao.Create(entity)
+		event := s.newAuditEvent(role.CreateAction, login)
+		if err == nil {
+			event.NewVersion = audit.GetVersion(entity)
+		}
+		s.auditor.Record(nil, event, err)
 		if err != nil {
 			return nil, err
 		}
@@ -120,10 +160,33 @@ func (s *service) syncUser(uInfo externalUserInfo) (*v1.User, error) {
 	} else if specHasChanged {
 		entity.Metadata.Update(entity.Metadata)
 		err = s.dao.Update(entity)
+		event := s.newAuditEvent(role.UpdateAction, login)
+		if err == nil {
+			audit.SetUpdatedVersion(event, entity)
+		}
+		s.auditor.Record(nil, event, err)
 		if err != nil {
 			return nil, err
 		}
+		// The groups of the user grant permissions through the role bindings, so the RBAC cache must follow them.
+		if groupsHaveChanged {
+			if err := s.authz.RefreshPermissions(); err != nil {
+				logrus.WithError(err).Error("failed to refresh RBAC cache")
+			}
+		}
 	}
 	return entity, nil
 
 }
+
+// newAuditEvent returns the audit event about the synchronization of the user.
+// As the user is not yet logged in, it is the user itself that is recorded as the author of the change.
+func (s *service) newAuditEvent(action role.Action, login string) *v1.AuditEvent {
+	event := audit.NewEvent(v1.AuditOriginAPI, action, v1.KindUser, "", login)
+	event.User = login
+	event.Provider = &v1.AuditProvider{
+		Kind: s.provider.ProviderKind,
+		ID:   s.provider.ProviderID,
+	}
+	return event
+}
@@ -14,8 +14,10 @@
 package auth
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/perses/perses/internal/api/interface/v1/user"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -62,3 +64,89 @@ func TestSaveProviderInfo(t *testing.T) {
 	assert.False(t, changed3)
 	assert.Error(t, err3)
 }
+
+func TestSyncUserInvalidLogin(t *testing.T) {
+	s := &service{}
+	for _, login := range []string{"", "serviceaccount:admin", "alice/bob"} {
+		_, err := s.syncUser(&trustedUserInfo{TrustedUser: user.TrustedUser{Login: login}})
+		assert.Error(t, err, login)
+	}
+}
+
+func TestSaveGroups(t *testing.T) {
+	claims := map[string]any{
+		"groups": []any{"sre", "dev", "sre"},
+		"realm_access": map[string]any{
+			"roles": []any{"admin"},
+		},
+		"team": "ops",
+	}
+	testSuites := []struct {
+		title       string
+		groupsClaim string
+		oldGroups   []string
+		result      []string
+		changed     bool
+	}{
+		{
+			title:       "groups not synced",
+			groupsClaim: "",
+			oldGroups:   []string{"dev"},
+			result:      []string{"dev"},
+			changed:     false,
+		},
+		{
+			title:       "list claim",
+			groupsClaim: "groups",
+			result:      []string{"dev", "sre"},
+			changed:     true,
+		},
+		{
+			title:       "list claim unchanged",
+			groupsClaim: "groups",
+			oldGroups:   []string{"dev", "sre"},
+			result:      []string{"dev", "sre"},
+			changed:     false,
+		},
+		{
+			title:       "nested claim",
+			groupsClaim: "realm_access.roles",
+			oldGroups:   []string{"dev"},
+			result:      []string{"admin"},
+			changed:     true,
+		},
+		{
+			title:       "single value claim",
+			groupsClaim: "team",
+			result:      []string{"ops"},
+			changed:     true,
+		},
+		{
+			title:       "missing claim removes the groups",
+			groupsClaim: "realm_access.missing",
+			oldGroups:   []string{"dev"},
+			result:      nil,
+			changed:     true,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			uInfo := &oidcUserInfo{Subject: "subject", groupsClaim: test.groupsClaim, rawClaims: claims}
+			result, changed := saveGroups(v1.UserSpec{Groups: test.oldGroups}, uInfo)
+			assert.Equal(t, test.result, result.Groups)
+			assert.Equal(t, test.changed, changed)
+		})
+	}
+}
+
+func TestUnmarshalOIDCUserInfo(t *testing.T) {
+	data := []byte(`{"sub": "subject", "email": "jane@example.com", "groups": ["sre"]}`)
+	uInfo := &oidcUserInfo{}
+	assert.NoError(t, json.Unmarshal(data, uInfo))
+	assert.Equal(t, "subject", uInfo.Subject)
+	assert.Equal(t, "jane", uInfo.GetLogin())
+	uInfo.groupsClaim = "groups"
+	groups, ok := uInfo.GetGroups()
+	assert.True(t, ok)
+	assert.Equal(t, []string{"sre"}, groups)
+}
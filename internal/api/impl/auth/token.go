@@ -16,6 +16,7 @@ package auth
 import (
 	"net/http"
 
+	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/crypto"
 	"github.com/perses/perses/internal/api/interface"
 	"github.com/sirupsen/logrus"
@@ -25,8 +26,19 @@ type tokenManagement struct {
 	jwt crypto.JWT
 }
 
-func (tm *tokenManagement) accessToken(login string, providerInfo crypto.ProviderInfo, setCookie func(cookie *http.Cookie)) (string, error) {
-	accessToken, err := tm.jwt.SignedAccessToken(login, providerInfo)
+// openSession records the session the tokens of the user will belong to.
+// The user agent and the address of the login request are kept, so the user can recognize its devices.
+func (tm *tokenManagement) openSession(ctx echo.Context, login string, providerInfo crypto.ProviderInfo) (string, error) {
+	sess, err := tm.jwt.OpenSession(login, providerInfo, ctx.Request().UserAgent(), ctx.RealIP())
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to open a session")
+		return "", apiinterface.InternalError
+	}
+	return sess.ID, nil
+}
+
+func (tm *tokenManagement) accessToken(login string, providerInfo crypto.ProviderInfo, sessionID string, setCookie func(cookie *http.Cookie)) (string, error) {
+	accessToken, err := tm.jwt.SignedAccessToken(login, providerInfo, sessionID)
 	if err != nil {
 		logrus.WithError(err).Errorf("unable to generate the access token")
 		return "", apiinterface.InternalError
@@ -37,8 +49,8 @@ func (tm *tokenManagement) accessToken(login string, providerInfo crypto.Provide
 	return accessToken, nil
 }
 
-func (tm *tokenManagement) refreshToken(login string, providerInfo crypto.ProviderInfo, setCookie func(cookie *http.Cookie)) (string, error) {
-	refreshToken, err := tm.jwt.SignedRefreshToken(login, providerInfo)
+func (tm *tokenManagement) refreshToken(login string, providerInfo crypto.ProviderInfo, sessionID string, setCookie func(cookie *http.Cookie)) (string, error) {
+	refreshToken, err := tm.jwt.SignedRefreshToken(login, providerInfo, sessionID)
 	if err != nil {
 		logrus.WithError(err).Errorf("unable to generate the refresh token")
 		return "", apiinterface.InternalError
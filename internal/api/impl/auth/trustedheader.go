@@ -0,0 +1,146 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
+	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/crypto"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type trustedUserInfo struct {
+	user.TrustedUser
+}
+
+// GetLogin implements [externalUserInfo]
+func (u *trustedUserInfo) GetLogin() string {
+	return u.Login
+}
+
+// GetProfile implements [externalUserInfo]
+func (u *trustedUserInfo) GetProfile() externalUserInfoProfile {
+	return externalUserInfoProfile{Email: u.Email}
+}
+
+// GetGroups implements [externalUserInfo]
+func (u *trustedUserInfo) GetGroups() ([]string, bool) {
+	return u.Groups, u.Groups != nil
+}
+
+// GetProviderContext implements [externalUserInfo]
+func (u *trustedUserInfo) GetProviderContext() v1.OAuthProvider {
+	return v1.OAuthProvider{
+		Issuer:  utils.AuthKindTrustedHeader,
+		Email:   u.Email,
+		Subject: u.Login,
+	}
+}
+
+type trustedUserSynchronizer struct {
+	svc service
+}
+
+// NewUserSynchronizer returns the synchronizer of the users authenticated by a trusted reverse proxy.
+// The users are created or updated the same way as the users coming from the other external providers.
+func NewUserSynchronizer(dao user.DAO, authz authorization.Authorization, auditor audit.Auditor) user.Synchronizer {
+	return &trustedUserSynchronizer{
+		svc: service{
+			dao:      dao,
+			authz:    authz,
+			auditor:  auditor,
+			provider: crypto.ProviderInfo{ProviderKind: utils.AuthKindTrustedHeader},
+		},
+	}
+}
+
+func (s *trustedUserSynchronizer) SyncTrustedUser(usr user.TrustedUser) error {
+	_, err := s.svc.syncUser(&trustedUserInfo{TrustedUser: usr})
+	return err
+}
+
+// trustedHeaderEndpoint opens a session for the users authenticated by a trusted reverse proxy.
+// The API doesn't need it, as the authorization middleware trusts the headers of the proxy at every request,
+// but the UI relies on the cookies of a session to know who is logged in.
+type trustedHeaderEndpoint struct {
+	authz           authorization.Authorization
+	tokenManagement tokenManagement
+}
+
+func newTrustedHeaderEndpoint(jwt crypto.JWT, authz authorization.Authorization) authEndpoint {
+	return &trustedHeaderEndpoint{
+		authz:           authz,
+		tokenManagement: tokenManagement{jwt: jwt},
+	}
+}
+
+func (e *trustedHeaderEndpoint) GetExtraProviderLogoutHandler() echo.HandlerFunc {
+	return nil // The session of the proxy is not managed by Perses
+}
+
+func (e *trustedHeaderEndpoint) GetAuthKind() string {
+	return utils.AuthKindTrustedHeader
+}
+
+func (e *trustedHeaderEndpoint) GetSlugID() string {
+	return "" // There is a single trusted proxy configuration
+}
+
+func (e *trustedHeaderEndpoint) CollectRoutes(g *route.Group) {
+	// The route is not anonymous, so the authorization middleware authenticates the user with the headers of the proxy.
+	g.GET(fmt.Sprintf("/%s/%s", utils.AuthKindTrustedHeader, utils.PathLogin), e.login, false)
+}
+
+func (e *trustedHeaderEndpoint) login(ctx echo.Context) error {
+	providerInfo, err := e.authz.GetProviderInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if providerInfo.ProviderKind != utils.AuthKindTrustedHeader {
+		return apiinterface.HandleUnauthorizedError("the request has not been authenticated by a trusted proxy")
+	}
+	login, err := e.authz.GetUsername(ctx)
+	if err != nil {
+		return err
+	}
+	sessionID, err := e.tokenManagement.openSession(ctx, login, providerInfo)
+	if err != nil {
+		return err
+	}
+	if _, err = e.tokenManagement.accessToken(login, providerInfo, sessionID, ctx.SetCookie); err != nil {
+		return err
+	}
+	if _, err = e.tokenManagement.refreshToken(login, providerInfo, sessionID, ctx.SetCookie); err != nil {
+		return err
+	}
+	return ctx.Redirect(http.StatusFound, localRedirectPath(ctx.QueryParam(redirectQueryParam)))
+}
+
+// localRedirectPath returns the given path when it stays on the Perses instance, "/" otherwise.
+// It prevents the login endpoint from being used to redirect the users to any website.
+func localRedirectPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/"
+	}
+	return path
+}
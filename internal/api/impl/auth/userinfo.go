@@ -14,6 +14,7 @@
 package auth
 
 import (
+	"fmt"
 	"strings"
 
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -41,8 +42,39 @@ type externalUserInfo interface {
 	// GetProviderContext returns the provider context. It identifies the external provider used to collect this user
 	// information, as well as the identity of the user in that context.
 	GetProviderContext() v1.OAuthProvider
+	// GetGroups returns the groups the user belongs to.
+	// The boolean is false when the provider is not configured to sync the groups.
+	GetGroups() ([]string, bool)
 }
 
 func buildLoginFromEmail(email string) string {
 	return strings.Split(email, "@")[0]
 }
+
+// getClaimValues returns the values of the claim designated by the given path.
+// Nested claims are reached with a dot, like `realm_access.roles`.
+// The claim can be a list or a single value.
+func getClaimValues(claims map[string]any, path string) []string {
+	var value any = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if value, ok = object[key]; !ok {
+			return nil
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwks
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/crypto"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+)
+
+type endpoint struct {
+	jwt crypto.JWT
+}
+
+// New returns the endpoint publishing the public keys that verify the tokens issued by Perses, as a JSON Web Key Set.
+func New(jwt crypto.JWT) route.Endpoint {
+	return &endpoint{
+		jwt: jwt,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	g.GET("/"+utils.PathJWKS, e.get, true)
+}
+
+func (e *endpoint) get(ctx echo.Context) error {
+	// The verifiers are expected to cache the keys, and to fetch them again when they find an unknown kid.
+	ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return ctx.JSON(http.StatusOK, e.jwt.JWKS())
+}
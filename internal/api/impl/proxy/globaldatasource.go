@@ -25,10 +25,10 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (e *endpoint) proxyGlobalDatasource(ctx echo.Context, datasourceName string, spec v1.DatasourceSpec) error {
+func (e *endpoint) proxyGlobalDatasource(ctx echo.Context, datasourceName string, spec v1.DatasourceSpec, poolKey string) error {
 	path := ctx.Param("*")
 
-	pr, err := newProxy(datasourceName, "", spec, path, e.crypto, func(name string) (*v1.SecretSpec, error) {
+	pr, err := newProxy(datasourceName, "", spec, path, e.crypto, e.sqlPools, poolKey, e.sqliteDirectories, func(name string) (*v1.SecretSpec, error) {
 		return e.getGlobalSecret(datasourceName, name)
 	})
 	if err != nil {
@@ -43,7 +43,7 @@ func (e *endpoint) proxyUnsavedGlobalDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.CreateAction); err != nil {
+	if err := e.checkUnsavedPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope); err != nil {
 		return err
 	}
 
@@ -54,11 +54,11 @@ func (e *endpoint) proxyUnsavedGlobalDatasource(ctx echo.Context) error {
 		dtsName = body.Spec.Display.Name
 	}
 
-	return e.proxyGlobalDatasource(ctx, dtsName, body.Spec)
+	return e.proxyGlobalDatasource(ctx, dtsName, body.Spec, "")
 }
 
 func (e *endpoint) proxySavedGlobalDatasource(ctx echo.Context) error {
-	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.ReadAction); err != nil {
+	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.QueryAction, ctx.Param(utils.ParamName)); err != nil {
 		return err
 	}
 
@@ -68,7 +68,7 @@ func (e *endpoint) proxySavedGlobalDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	return e.proxyGlobalDatasource(ctx, dts.Metadata.Name, dts.Spec)
+	return e.proxyGlobalDatasource(ctx, dts.Metadata.Name, dts.Spec, fmt.Sprintf("%s/%s", utils.PathGlobalDatasource, dts.Metadata.Name))
 }
 
 func (e *endpoint) getGlobalDatasource(name string) (*v1.GlobalDatasource, error) {
@@ -25,10 +25,10 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (e *endpoint) proxyDashboardDatasource(ctx echo.Context, projectName, dtsName string, spec v1.DatasourceSpec) error {
+func (e *endpoint) proxyDashboardDatasource(ctx echo.Context, projectName, dtsName string, spec v1.DatasourceSpec, poolKey string) error {
 	path := ctx.Param("*")
 
-	pr, err := newProxy(dtsName, projectName, spec, path, e.crypto, func(name string) (*v1.SecretSpec, error) {
+	pr, err := newProxy(dtsName, projectName, spec, path, e.crypto, e.sqlPools, poolKey, e.sqliteDirectories, func(name string) (*v1.SecretSpec, error) {
 		return e.getProjectSecret(projectName, dtsName, name)
 	})
 	if err != nil {
@@ -44,7 +44,7 @@ func (e *endpoint) proxyUnsavedDashboardDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.CreateAction); err != nil {
+	if err := e.checkUnsavedPermission(ctx, projectName, role.DatasourceScope); err != nil {
 		return err
 	}
 
@@ -55,12 +55,12 @@ func (e *endpoint) proxyUnsavedDashboardDatasource(ctx echo.Context) error {
 		dtsName = body.Spec.Display.Name
 	}
 
-	return e.proxyDashboardDatasource(ctx, projectName, dtsName, body.Spec)
+	return e.proxyDashboardDatasource(ctx, projectName, dtsName, body.Spec, "")
 }
 
 func (e *endpoint) proxySavedDashboardDatasource(ctx echo.Context) error {
 	projectName := ctx.Param(utils.ParamProject)
-	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.ReadAction); err != nil {
+	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.QueryAction, ""); err != nil {
 		return err
 	}
 
@@ -72,7 +72,8 @@ func (e *endpoint) proxySavedDashboardDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	return e.proxyDashboardDatasource(ctx, projectName, dtsName, dts)
+	poolKey := fmt.Sprintf("%s/%s/%s/%s/%s/%s", utils.PathProject, projectName, utils.PathDashboard, dashboardName, utils.PathDatasource, dtsName)
+	return e.proxyDashboardDatasource(ctx, projectName, dtsName, dts, poolKey)
 }
 
 func (e *endpoint) getDashboardDatasource(projectName string, dashboardName string, name string) (v1.DatasourceSpec, error) {
@@ -25,9 +25,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (e *endpoint) proxyProjectDatasource(ctx echo.Context, projectName, dtsName string, spec v1.DatasourceSpec) error {
+func (e *endpoint) proxyProjectDatasource(ctx echo.Context, projectName, dtsName string, spec v1.DatasourceSpec, poolKey string) error {
 	path := ctx.Param("*")
-	pr, err := newProxy(dtsName, projectName, spec, path, e.crypto, func(name string) (*v1.SecretSpec, error) {
+	pr, err := newProxy(dtsName, projectName, spec, path, e.crypto, e.sqlPools, poolKey, e.sqliteDirectories, func(name string) (*v1.SecretSpec, error) {
 		return e.getProjectSecret(projectName, dtsName, name)
 	})
 	if err != nil {
@@ -43,7 +43,7 @@ func (e *endpoint) proxyUnsavedProjectDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.CreateAction); err != nil {
+	if err := e.checkUnsavedPermission(ctx, projectName, role.DatasourceScope); err != nil {
 		return err
 	}
 
@@ -54,12 +54,12 @@ func (e *endpoint) proxyUnsavedProjectDatasource(ctx echo.Context) error {
 		dtsName = body.Spec.Display.Name
 	}
 
-	return e.proxyProjectDatasource(ctx, projectName, dtsName, body.Spec)
+	return e.proxyProjectDatasource(ctx, projectName, dtsName, body.Spec, "")
 }
 
 func (e *endpoint) proxySavedProjectDatasource(ctx echo.Context) error {
 	projectName := ctx.Param(utils.ParamProject)
-	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.ReadAction); err != nil {
+	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.QueryAction, ctx.Param(utils.ParamName)); err != nil {
 		return err
 	}
 
@@ -69,7 +69,7 @@ func (e *endpoint) proxySavedProjectDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	return e.proxyProjectDatasource(ctx, projectName, dtsName, dts)
+	return e.proxyProjectDatasource(ctx, projectName, dtsName, dts, fmt.Sprintf("%s/%s/%s/%s", utils.PathProject, projectName, utils.PathDatasource, dtsName))
 }
 
 func (e *endpoint) getProjectDatasource(projectName string, name string) (v1.DatasourceSpec, error) {
@@ -15,23 +15,31 @@ package proxy
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/go-sql-driver/mysql"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/labstack/echo/v4"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	apiinterface "github.com/perses/perses/internal/api/interface"
@@ -113,10 +121,17 @@ type endpoint struct {
 	globalDTS    globaldatasource.DAO
 	crypto       crypto.Crypto
 	authz        authorization.Authorization
+	sqlPools     *SQLPools
+	// sqliteDirectories are the directories where the SQLite datasources can open their database.
+	sqliteDirectories []string
 }
 
 func New(cfg config.DatasourceConfig, dashboardDAO dashboard.DAO, secretDAO secret.DAO, globalSecretDAO globalsecret.DAO,
-	dtsDAO datasource.DAO, globalDtsDAO globaldatasource.DAO, crypto crypto.Crypto, authz authorization.Authorization) route.Endpoint {
+	dtsDAO datasource.DAO, globalDtsDAO globaldatasource.DAO, crypto crypto.Crypto, authz authorization.Authorization, sqlPools *SQLPools) route.Endpoint {
+	var sqliteDirectories []string
+	if cfg.SQLite != nil {
+		sqliteDirectories = cfg.SQLite.AllowedDirectories
+	}
 	return &endpoint{
 		cfg:          cfg,
 		dashboard:    dashboardDAO,
@@ -126,6 +141,9 @@ func New(cfg config.DatasourceConfig, dashboardDAO dashboard.DAO, secretDAO secr
 		globalDTS:    globalDtsDAO,
 		crypto:       crypto,
 		authz:        authz,
+		sqlPools:     sqlPools,
+
+		sqliteDirectories: sqliteDirectories,
 	}
 }
 
@@ -153,30 +171,50 @@ func (e *endpoint) CollectRoutes(g *route.Group) {
 	}
 }
 
-func (e *endpoint) checkPermission(ctx echo.Context, projectName string, scope role.Scope, action role.Action) error {
+// checkUnsavedPermission verifies the user can send queries through a datasource that is not saved yet.
+// As it could be saved afterward, the user must be allowed to create it as well.
+func (e *endpoint) checkUnsavedPermission(ctx echo.Context, projectName string, scope role.Scope) error {
+	if err := e.checkPermission(ctx, projectName, scope, role.CreateAction, ""); err != nil {
+		return err
+	}
+	return e.checkPermission(ctx, projectName, scope, role.QueryAction, "")
+}
+
+// checkPermission verifies the user can perform the action on the datasource with the given name.
+// When the name is empty, as for the unsaved or the dashboard datasources, the permission must apply to every datasource of the scope.
+func (e *endpoint) checkPermission(ctx echo.Context, projectName string, scope role.Scope, action role.Action, name string) error {
 	if !e.authz.IsEnabled() {
 		return nil
 	}
 
 	if role.IsGlobalScope(scope) {
-		if ok := e.authz.HasPermission(ctx, action, v1.WildcardProject, scope); !ok {
+		projectName = v1.WildcardProject
+	}
+	if !e.hasPermission(ctx, action, projectName, scope, name) {
+		if role.IsGlobalScope(scope) {
 			return apiinterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", action, scope))
 		}
-		return nil
-	}
-
-	if ok := e.authz.HasPermission(ctx, action, projectName, scope); !ok {
 		return apiinterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, projectName, scope))
 	}
 
 	return nil
 }
 
+func (e *endpoint) hasPermission(ctx echo.Context, action role.Action, projectName string, scope role.Scope, name string) bool {
+	if len(name) == 0 {
+		return e.authz.HasPermission(ctx, action, projectName, scope)
+	}
+	return e.authz.HasResourcePermission(ctx, action, projectName, scope, name)
+}
+
 type proxy interface {
 	serve(c echo.Context) error
 }
 
-func newProxy(datasourceName, projectName string, spec v1.DatasourceSpec, path string, crypto crypto.Crypto, retrieveSecret func(name string) (*v1.SecretSpec, error)) (proxy, error) {
+// newProxy returns the proxy forwarding the requests to the datasource.
+// The poolKey identifies the connection pool of an SQL datasource. It is empty when the datasource is not saved, in
+// which case the connections are closed once the query is done.
+func newProxy(datasourceName, projectName string, spec v1.DatasourceSpec, path string, crypto crypto.Crypto, pools *SQLPools, poolKey string, sqliteDirectories []string, retrieveSecret func(name string) (*v1.SecretSpec, error)) (proxy, error) {
 	cfg, kind, err := datasourcev1.ValidateAndExtract(spec.Plugin.Spec)
 	if err != nil {
 		logrus.WithError(err).Error("unable to build or find the config in the datasource")
@@ -226,6 +264,10 @@ func newProxy(datasourceName, projectName string, spec v1.DatasourceSpec, path s
 			project: projectName,
 			path:    path,
 			secret:  scrt,
+			pools:   pools,
+			poolKey: poolKey,
+
+			sqliteDirectories: sqliteDirectories,
 		}, nil
 	default:
 		return nil, errors.New("no proxy kind found")
@@ -431,7 +473,9 @@ func (h *httpProxy) prepareTLSConfig() (*tls.Config, error) {
 }
 
 type sqlQuery struct {
-	Query string `json:"query"`
+	Query      string                  `json:"query"`
+	Parameters map[string]sqlParameter `json:"parameters,omitempty"`
+	Range      *sqlTimeRange           `json:"range,omitempty"`
 }
 
 type sqlProxy struct {
@@ -442,6 +486,10 @@ type sqlProxy struct {
 	path     string
 	username string
 	password string
+	pools    *SQLPools
+	poolKey  string
+	// sqliteDirectories are the directories where the SQLite databases can be opened.
+	sqliteDirectories []string
 }
 
 func (s *sqlProxy) serve(c echo.Context) error {
@@ -453,12 +501,31 @@ func (s *sqlProxy) serve(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusMethodNotAllowed, fmt.Sprintf("you are not allowed to use this endpoint %q with the HTTP method %s", s.path, r.Method))
 	}
 
-	// TODO: validate the query is readonly
 	q := &sqlQuery{}
 	if err := json.NewDecoder(r.Body).Decode(q); err != nil {
 		logrus.WithError(err).Error("unable to decode the query body")
 		return apiinterface.HandleBadRequestError(err.Error())
 	}
+	guardrails := s.guardrails()
+	if err := checkStatement(q.Query, guardrails); err != nil {
+		return err
+	}
+	dialect := sqlDialects[s.config.Driver]
+	query, args, err := dialect.expand(q)
+	if err != nil {
+		return err
+	}
+	if *guardrails.ReadOnly && dialect.batch {
+		if query, err = dialect.readOnlyBatch(query); err != nil {
+			return err
+		}
+	}
+	if s.config.Driver == datasourceSQL.DriverSQLite {
+		if _, pathErr := s.sqlitePath(); pathErr != nil {
+			logrus.WithError(pathErr).WithFields(logrus.Fields{"project": s.project, "datasource": s.name}).Error("unable to open the sqlite database")
+			return apiinterface.HandleForbiddenError(fmt.Sprintf("the sqlite database %q is not in the directories allowed by the server", s.config.Database))
+		}
+	}
 
 	// add password if provided
 	if err := s.setupAuthentication(); err != nil {
@@ -473,38 +540,81 @@ func (s *sqlProxy) serve(c echo.Context) error {
 		return apiinterface.InternalError
 	}
 
-	// get the correct SQL driver for address and open connection
-	db, err := s.sqlOpen(tlsConfig)
+	// get the connection pool of the datasource
+	db, release, err := s.getDB(tlsConfig)
 	if err != nil {
-		logrus.WithError(err).Error("unable to open the database")
+		logrus.WithError(err).WithFields(logrus.Fields{"project": s.project, "datasource": s.name}).Error("unable to open the database")
 		return apiinterface.InternalError
 	}
-	defer func(db *sql.DB) {
-		if err = db.Close(); err != nil {
-			logrus.WithError(err).Error("unable to close the database")
-		}
-	}(db)
+	defer release()
 
-	rows, err := db.QueryContext(r.Context(), q.Query)
+	ctx, cancel := context.WithTimeout(r.Context(), guardrails.StatementTimeout)
+	defer cancel()
+	// write the SQL query result in the format asked by the client
+	format := negotiateSQLResultFormat(r.Header.Get(echo.HeaderAccept))
+	output := newSQLResultOutput(c.Response(), format, guardrails)
+	err = s.query(ctx, db, query, args, guardrails, func(rows *sql.Rows) error {
+		return writeResult(output, rows, guardrails, format)
+	})
 	if err != nil {
+		if c.Response().Committed {
+			// The beginning of the result has been sent already, so the response is interrupted for the client to
+			// know the result is incomplete.
+			logrus.WithError(err).Error("unable to send the whole result of the query")
+			panic(http.ErrAbortHandler)
+		}
+		if guardrailErr := guardrailError(ctx, err, guardrails); guardrailErr != nil {
+			return guardrailErr
+		}
 		logrus.WithError(err).Error("unable to execute the query")
 		return apiinterface.InternalError
 	}
-	defer func(rows *sql.Rows) {
-		if err = rows.Close(); err != nil {
-			logrus.WithError(err).Error("unable to close rows")
-		}
-	}(rows)
-
-	// write the SQL query result as CSV
-	if err = writeCSVResponse(c, rows); err != nil {
-		logrus.WithError(err).Error("unable to write the query result")
-		return apiinterface.InternalError
+	if !output.started {
+		return output.start()
 	}
+	return nil
+}
 
+// query executes the query with the values bound to its placeholders in a transaction, and gives its rows to the
+// consume function.
+func (s *sqlProxy) query(ctx context.Context, db *sql.DB, query string, args []any, guardrails datasourceSQL.Guardrails, consume func(rows *sql.Rows) error) error {
+	if sqlDialects[s.config.Driver].noTransaction {
+		return queryRows(ctx, db, query, args, consume)
+	}
+	tx, err := s.beginTx(ctx, db, guardrails)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Nothing is left to roll back once the transaction is committed.
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			logrus.WithError(rollbackErr).Error("unable to roll back the transaction")
+		}
+	}()
+	if err = queryRows(ctx, tx, query, args, consume); err != nil {
+		return err
+	}
+	if !*guardrails.ReadOnly {
+		return tx.Commit()
+	}
 	return nil
 }
 
+// queryRows executes the query either on the database or in a transaction, and gives its rows to the consume function.
+func queryRows(ctx context.Context, queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}, query string, args []any, consume func(rows *sql.Rows) error) error {
+	rows, err := queryer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	err = consume(rows)
+	if closeErr := rows.Close(); closeErr != nil {
+		logrus.WithError(closeErr).Error("unable to close rows")
+	}
+	return err
+}
+
 func (s *sqlProxy) setupAuthentication() error {
 	if s.secret == nil {
 		return nil
@@ -530,16 +640,99 @@ func (s *sqlProxy) prepareTLSConfig() (*tls.Config, error) {
 	return s.secret.TLSConfig.BuildTLSConfig()
 }
 
+// getDB returns the connection pool of the datasource, and the function to call once the query is done.
+func (s *sqlProxy) getDB(tlsConfig *tls.Config) (*sql.DB, func(), error) {
+	if len(s.poolKey) == 0 {
+		db, err := s.sqlOpen(tlsConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, func() {
+			if closeErr := db.Close(); closeErr != nil {
+				logrus.WithError(closeErr).Error("unable to close the database")
+			}
+		}, nil
+	}
+	fingerprint, err := s.fingerprint()
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.pools.get(s.poolKey, fingerprint, s.poolConfig().IdleTimeout, func() (*sql.DB, error) {
+		return s.sqlOpen(tlsConfig)
+	})
+}
+
+// fingerprint identifies the settings used to connect to the database, including the ones coming from the secret.
+// It changes as soon as the datasource or its secret is updated.
+func (s *sqlProxy) fingerprint() (string, error) {
+	config, err := json.Marshal(s.config)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write(config)
+	fmt.Fprintf(hash, "\n%s\n%s\n", s.username, s.password)
+	if s.secret != nil && s.secret.TLSConfig != nil {
+		tlsConfig, tlsErr := json.Marshal(s.secret.TLSConfig)
+		if tlsErr != nil {
+			return "", tlsErr
+		}
+		hash.Write(tlsConfig)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// poolConfig returns the configuration of the connection pool, completed with the default values.
+func (s *sqlProxy) poolConfig() datasourceSQL.PoolConfig {
+	pool := datasourceSQL.PoolConfig{}
+	if s.config.Pool != nil {
+		pool = *s.config.Pool
+	}
+	if pool.MaxOpenConns == 0 {
+		pool.MaxOpenConns = datasourceSQL.DefaultMaxOpenConns
+		if s.config.Postgres != nil && s.config.Postgres.MaxConns > 0 {
+			pool.MaxOpenConns = int(s.config.Postgres.MaxConns)
+		}
+	}
+	if pool.MaxIdleConns == 0 {
+		pool.MaxIdleConns = datasourceSQL.DefaultMaxIdleConns
+	}
+	if pool.ConnMaxIdleTime == 0 {
+		pool.ConnMaxIdleTime = datasourceSQL.DefaultConnMaxIdleTime
+	}
+	if pool.IdleTimeout == 0 {
+		pool.IdleTimeout = datasourceSQL.DefaultPoolIdleTimeout
+	}
+	return pool
+}
+
 // SQLOpen opens a database specified by its database driver in the address
 func (s *sqlProxy) sqlOpen(tlsConfig *tls.Config) (*sql.DB, error) {
+	var db *sql.DB
+	var err error
 	switch s.config.Driver {
 	case datasourceSQL.DriverMySQL:
-		return s.openMySQL(tlsConfig)
+		db, err = s.openMySQL(tlsConfig)
 	case datasourceSQL.DriverPostgreSQL:
-		return s.openPostgres(tlsConfig)
+		db, err = s.openPostgres(tlsConfig)
+	case datasourceSQL.DriverClickHouse:
+		db, err = s.openClickHouse(tlsConfig)
+	case datasourceSQL.DriverSQLServer:
+		db, err = s.openSQLServer(tlsConfig)
+	case datasourceSQL.DriverSQLite:
+		db, err = s.openSQLite()
 	default:
 		return nil, fmt.Errorf("unsupported database driver: %s", s.config.Driver)
 	}
+	if err != nil {
+		return nil, err
+	}
+	pool := s.poolConfig()
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	return db, nil
 }
 
 // open mySQL specific database connection
@@ -565,22 +758,14 @@ func (s *sqlProxy) openMySQL(tlsConfig *tls.Config) (*sql.DB, error) {
 		mysqlConfig.WriteTimeout = s.config.MySQL.WriteTimeout
 	}
 
-	if tlsConfig != nil {
-		if s.project == "" {
-			s.project = "global"
-		}
-		tlsConfigName := fmt.Sprintf("%s-%s-tls", s.project, s.name)
-		if err := mysql.RegisterTLSConfig(tlsConfigName, tlsConfig); err != nil {
-			return nil, err
-		}
-		mysqlConfig.TLSConfig = tlsConfigName
-	}
+	// The TLS config is given to the connector directly, rather than registered globally under a name.
+	mysqlConfig.TLS = tlsConfig
 
-	db, dbErr := sql.Open(string(datasourceSQL.DriverMySQL), mysqlConfig.FormatDSN())
-	if dbErr != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", dbErr)
+	connector, connectorErr := mysql.NewConnector(&mysqlConfig)
+	if connectorErr != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", connectorErr)
 	}
-	return db, nil
+	return sql.OpenDB(connector), nil
 }
 
 // open postgres specific database connection
@@ -603,9 +788,6 @@ func (s *sqlProxy) openPostgres(tlsConfig *tls.Config) (*sql.DB, error) {
 
 	query := url.Values{}
 
-	// set the default max connections to 100
-	query.Set("pool_max_conns", "100")
-
 	if s.config.Postgres == nil {
 		s.config.Postgres = &datasourceSQL.PostgresConfig{}
 	}
@@ -634,10 +816,6 @@ func (s *sqlProxy) openPostgres(tlsConfig *tls.Config) (*sql.DB, error) {
 		return nil, parseErr
 	}
 
-	if s.config.Postgres.MaxConns != 0 {
-		pgxConfig.MaxConns = s.config.Postgres.MaxConns
-	}
-
 	if tlsConfig != nil {
 		if s.config.Postgres.SSLMode == "" || s.config.Postgres.SSLMode == datasourceSQL.SSLModeDisable {
 			return nil, errors.New("cannot use custom TLSConfig with sslmode=disable")
@@ -650,55 +828,147 @@ func (s *sqlProxy) openPostgres(tlsConfig *tls.Config) (*sql.DB, error) {
 	return db, nil
 }
 
-func writeCSVResponse(c echo.Context, rows *sql.Rows) error {
-	cols, err := rows.Columns()
-	if err != nil {
-		logrus.WithError(err).Error("unable to get columns")
-		return apiinterface.InternalError
+// open clickhouse specific database connection
+func (s *sqlProxy) openClickHouse(tlsConfig *tls.Config) (*sql.DB, error) {
+	options := &clickhouse.Options{
+		Addr: []string{s.config.Host},
+		Auth: clickhouse.Auth{
+			Database: s.config.Database,
+			Username: s.username,
+			Password: s.password,
+		},
+		Settings: clickhouse.Settings{},
+	}
+
+	if s.config.ClickHouse != nil {
+		if s.config.ClickHouse.Protocol == datasourceSQL.ClickHouseProtocolHTTP {
+			options.Protocol = clickhouse.HTTP
+		}
+		// the TLS config is always set, while ClickHouse is usually contacted without TLS
+		if s.config.ClickHouse.Secure {
+			options.TLS = tlsConfig
+		}
+		options.DialTimeout = s.config.ClickHouse.DialTimeout
+		options.ReadTimeout = s.config.ClickHouse.ReadTimeout
+		switch s.config.ClickHouse.Compression {
+		case datasourceSQL.ClickHouseCompressionLZ4:
+			options.Compression = &clickhouse.Compression{Method: clickhouse.CompressionLZ4}
+		case datasourceSQL.ClickHouseCompressionZSTD:
+			options.Compression = &clickhouse.Compression{Method: clickhouse.CompressionZSTD}
+		}
+		for name, value := range s.config.ClickHouse.Settings {
+			options.Settings[name] = value
+		}
 	}
 
-	// Create a slice of interface{} to hold the column values
-	values := make([]any, len(cols))
+	// The guardrails are enforced by the server, as the queries don't run in transactions.
+	// They come last so the settings of the datasource can't override them.
+	guardrails := s.guardrails()
+	if *guardrails.ReadOnly {
+		// 2 rather than 1, so the settings can still be sent along with the queries
+		options.Settings["readonly"] = 2
+	}
+	options.Settings["max_execution_time"] = int(math.Ceil(guardrails.StatementTimeout.Seconds()))
+
+	return clickhouse.OpenDB(options), nil
+}
+
+// open sqlserver specific database connection
+func (s *sqlProxy) openSQLServer(tlsConfig *tls.Config) (*sql.DB, error) {
+	u := &url.URL{
+		Scheme: "sqlserver",
+		Host:   s.config.Host,
+	}
 
-	// Create a slice of sql.RawBytes to hold the raw bytes of the column values
-	scanArgs := make([]any, len(cols))
-	for i := range values {
-		scanArgs[i] = &values[i]
+	if s.username != "" {
+		u.User = url.UserPassword(s.username, s.password)
 	}
 
-	// set the Content-Type header to text/csv
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=result.csv")
+	query := url.Values{}
+	query.Set("database", s.config.Database)
 
-	// write the CSV header
-	_, err = c.Response().Write([]byte(strings.Join(cols, ",") + "\n"))
-	if err != nil {
-		logrus.WithError(err).Error("unable to csv header")
-		return apiinterface.InternalError
+	if s.config.SQLServer != nil {
+		for name, value := range s.config.SQLServer.Params {
+			query.Set(name, value)
+		}
+		if s.config.SQLServer.Encrypt != "" {
+			query.Set("encrypt", string(s.config.SQLServer.Encrypt))
+		}
+		if s.config.SQLServer.ConnectTimeout != 0 {
+			query.Set("dial timeout", strconv.Itoa(int(math.Ceil(s.config.SQLServer.ConnectTimeout.Seconds()))))
+		}
+		if s.config.SQLServer.AppName != "" {
+			query.Set("app name", s.config.SQLServer.AppName)
+		}
 	}
 
-	// add each row to the csv response
-	for rows.Next() {
-		err = rows.Scan(scanArgs...)
-		if err != nil {
-			logrus.WithError(err).Error("unable to scan row")
-			return apiinterface.InternalError
+	u.RawQuery = query.Encode()
+
+	msConfig, parseErr := msdsn.Parse(u.String())
+	if parseErr != nil {
+		logrus.WithError(parseErr).Error("failed to parse sqlserver address")
+		return nil, parseErr
+	}
+
+	// The TLS config of the secret replaces the one built by the driver, which comes from the connection parameters.
+	if s.secret != nil && s.secret.TLSConfig != nil && msConfig.TLSConfig != nil {
+		secretTLSConfig := tlsConfig.Clone()
+		if secretTLSConfig.ServerName == "" {
+			secretTLSConfig.ServerName = msConfig.TLSConfig.ServerName
 		}
+		secretTLSConfig.DynamicRecordSizingDisabled = msConfig.TLSConfig.DynamicRecordSizingDisabled
+		msConfig.TLSConfig = secretTLSConfig
+	}
 
-		record := make([]string, len(cols))
-		for i, col := range values {
-			if col == nil {
-				record[i] = ""
-			} else {
-				record[i] = fmt.Sprintf("%v", col)
-			}
+	return sql.OpenDB(mssql.NewConnectorConfig(msConfig)), nil
+}
+
+// sqlitePath returns the absolute path of the SQLite database, once verified it is in one of the allowed directories.
+// The symbolic links are resolved, so they can't point outside of these directories.
+func (s *sqlProxy) sqlitePath() (string, error) {
+	path, err := filepath.Abs(s.config.Database)
+	if err != nil {
+		return "", err
+	}
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range s.sqliteDirectories {
+		realDir, dirErr := filepath.EvalSymlinks(dir)
+		if dirErr != nil {
+			continue
 		}
-		_, err = c.Response().Write([]byte(strings.Join(record, ",") + "\n"))
-		if err != nil {
-			logrus.WithError(err).Error("unable to write response")
-			return apiinterface.InternalError
+		rel, relErr := filepath.Rel(realDir, path)
+		if relErr == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return path, nil
 		}
 	}
+	return "", fmt.Errorf("the sqlite database %q is not in the allowed directories", path)
+}
 
-	return nil
+// open sqlite specific database connection
+func (s *sqlProxy) openSQLite() (*sql.DB, error) {
+	path, err := s.sqlitePath()
+	if err != nil {
+		return nil, err
+	}
+
+	// The database is never created, and it is opened in read-only mode unless the guardrails allow the writes.
+	query := url.Values{}
+	query.Set("mode", "rw")
+	if *s.guardrails().ReadOnly {
+		query.Set("mode", "ro")
+		query.Add("_pragma", "query_only(1)")
+	}
+	if s.config.SQLite != nil && s.config.SQLite.BusyTimeout != 0 {
+		query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", s.config.SQLite.BusyTimeout.Milliseconds()))
+	}
+
+	u := &url.URL{
+		Scheme:   "file",
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
+	return sql.Open("sqlite", u.String())
 }
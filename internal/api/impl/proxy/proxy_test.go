@@ -15,16 +15,27 @@ package proxy
 
 import (
 	"crypto/tls"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
 	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 var (
-	mySQLAddress    = "localhost:3306"
-	postgresAddress = "localhost:5432"
+	mySQLAddress      = "localhost:3306"
+	postgresAddress   = "localhost:5432"
+	clickHouseAddress = "localhost:8123"
+	sqlServerAddress  = "localhost:1433"
 )
 
 func TestSQLProxy_sqlOpen(t *testing.T) {
@@ -86,6 +97,68 @@ func TestSQLProxy_sqlOpen(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "clickhouse success",
+			proxy: &sqlProxy{
+				config: &datasourceSQL.Config{
+					Driver:   datasourceSQL.DriverClickHouse,
+					Host:     clickHouseAddress,
+					Database: "default",
+					ClickHouse: &datasourceSQL.ClickHouseConfig{
+						Protocol:    datasourceSQL.ClickHouseProtocolHTTP,
+						Secure:      true,
+						Compression: datasourceSQL.ClickHouseCompressionLZ4,
+					},
+				},
+				password: "password",
+			},
+			tlsConfig:   &tls.Config{MinVersion: tls.VersionTLS12},
+			expectError: false,
+		},
+		{
+			name: "sqlserver success",
+			proxy: &sqlProxy{
+				config: &datasourceSQL.Config{
+					Driver:   datasourceSQL.DriverSQLServer,
+					Host:     sqlServerAddress,
+					Database: "reporting",
+					SQLServer: &datasourceSQL.SQLServerConfig{
+						Encrypt: datasourceSQL.SQLServerEncryptTrue,
+						AppName: "perses",
+					},
+				},
+				username: "sa",
+				password: "p@ss;word",
+			},
+			expectError: false,
+		},
+		{
+			name: "sqlserver with invalid params",
+			proxy: &sqlProxy{
+				config: &datasourceSQL.Config{
+					Driver:   datasourceSQL.DriverSQLServer,
+					Host:     sqlServerAddress,
+					Database: "reporting",
+					SQLServer: &datasourceSQL.SQLServerConfig{
+						Params: map[string]string{"packet size": "big"},
+					},
+				},
+			},
+			expectError:   true,
+			errorContains: "packet size",
+		},
+		{
+			name: "sqlite outside of the allowed directories",
+			proxy: &sqlProxy{
+				config: &datasourceSQL.Config{
+					Driver:   datasourceSQL.DriverSQLite,
+					Database: "/etc/hosts",
+				},
+				sqliteDirectories: []string{"/var/lib/perses"},
+			},
+			expectError:   true,
+			errorContains: "not in the allowed directories",
+		},
 		{
 			name: "postgres no password",
 			proxy: &sqlProxy{
@@ -112,3 +185,191 @@ func TestSQLProxy_sqlOpen(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLProxy_fingerprint(t *testing.T) {
+	newSQLProxy := func(password string) *sqlProxy {
+		return &sqlProxy{
+			config: &datasourceSQL.Config{
+				Driver:   datasourceSQL.DriverPostgreSQL,
+				Host:     postgresAddress,
+				Database: "perses",
+			},
+			username: "perses",
+			password: password,
+		}
+	}
+	fingerprint, err := newSQLProxy("password").fingerprint()
+	require.NoError(t, err)
+	same, err := newSQLProxy("password").fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, same)
+
+	// A new password in the secret requires a new connection pool.
+	updated, err := newSQLProxy("updated").fingerprint()
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, updated)
+}
+
+// newSQLiteDatabase creates a SQLite database in the directory, with a table of users.
+func newSQLiteDatabase(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "analytics.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER, name TEXT, created TIMESTAMP)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO users VALUES (1, 'perses', '2025-01-02 03:04:05'), (2, 'it''s', NULL)`)
+	require.NoError(t, err)
+	return path
+}
+
+func TestSQLProxy_sqlitePath(t *testing.T) {
+	allowedDir := t.TempDir()
+	otherDir := t.TempDir()
+	path := newSQLiteDatabase(t, allowedDir)
+	outsidePath := newSQLiteDatabase(t, otherDir)
+	link := filepath.Join(allowedDir, "link.db")
+	require.NoError(t, os.Symlink(outsidePath, link))
+
+	testSuite := []struct {
+		title    string
+		database string
+		allowed  bool
+	}{
+		{title: "in an allowed directory", database: path, allowed: true},
+		{title: "outside of the allowed directories", database: outsidePath},
+		{title: "relative path escaping the directory", database: filepath.Join(allowedDir, "..", filepath.Base(otherDir), "analytics.db")},
+		{title: "symbolic link escaping the directory", database: link},
+		{title: "allowed directory itself", database: allowedDir},
+		{title: "missing file", database: filepath.Join(allowedDir, "missing.db")},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			proxy := &sqlProxy{
+				config:            &datasourceSQL.Config{Driver: datasourceSQL.DriverSQLite, Database: test.database},
+				sqliteDirectories: []string{allowedDir},
+			}
+			_, err := proxy.sqlitePath()
+			if test.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSQLProxy_serveSQLite(t *testing.T) {
+	dir := t.TempDir()
+	path := newSQLiteDatabase(t, dir)
+
+	testSuite := []struct {
+		title       string
+		directories []string
+		body        string
+		accept      string
+		status      int
+		expected    string
+	}{
+		{
+			title:       "query with parameters as JSON",
+			directories: []string{dir},
+			body:        `{"query": "SELECT id, name, created FROM users WHERE id IN (:ids) AND name <> :name ORDER BY id", "parameters": {"ids": {"type": "list", "value": [1, 2]}, "name": {"type": "string", "value": "nobody"}}}`,
+			accept:      contentTypeJSON,
+			status:      http.StatusOK,
+			expected:    `{"columns":[{"name":"id","type":"int"},{"name":"name","type":"string"},{"name":"created","type":"time"}],"rows":[[1,"perses","2025-01-02T03:04:05Z"],[2,"it's",null]]}`,
+		},
+		{
+			title:       "query as CSV",
+			directories: []string{dir},
+			body:        `{"query": "SELECT name FROM users ORDER BY id"}`,
+			status:      http.StatusOK,
+			expected:    "name\r\nperses\r\nit's\r\n",
+		},
+		{
+			title:       "write in the read-only database",
+			directories: []string{dir},
+			body:        `{"query": "DELETE FROM users"}`,
+			status:      http.StatusForbidden,
+		},
+		{
+			title:  "database not allowed",
+			body:   `{"query": "SELECT name FROM users"}`,
+			status: http.StatusForbidden,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			proxy := &sqlProxy{
+				config:            &datasourceSQL.Config{Driver: datasourceSQL.DriverSQLite, Database: path},
+				sqliteDirectories: test.directories,
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			req.Header.Set(echo.HeaderAccept, test.accept)
+			rec := httptest.NewRecorder()
+			err := proxy.serve(echo.New().NewContext(req, rec))
+			if test.status != http.StatusOK {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, apiinterface.HandleError(err), &httpErr)
+				assert.Equal(t, test.status, httpErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rec.Body.String())
+		})
+	}
+
+	// the read-only query didn't delete anything
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
+func TestSQLProxy_serveLargeResult(t *testing.T) {
+	dir := t.TempDir()
+	path := newSQLiteDatabase(t, dir)
+	body := `{"query": "WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 10000) SELECT i FROM n"}`
+	serve := func(maxRows int) (*httptest.ResponseRecorder, error) {
+		proxy := &sqlProxy{
+			config:            &datasourceSQL.Config{Driver: datasourceSQL.DriverSQLite, Database: path, Guardrails: &datasourceSQL.Guardrails{MaxRows: maxRows}},
+			sqliteDirectories: []string{dir},
+		}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderAccept, contentTypeArrowStream)
+		rec := httptest.NewRecorder()
+		return rec, proxy.serve(echo.New().NewContext(req, rec))
+	}
+
+	t.Run("streamed in record batches", func(t *testing.T) {
+		rec, err := serve(20000)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, contentTypeArrowStream, rec.Header().Get(echo.HeaderContentType))
+		reader, readerErr := ipc.NewReader(rec.Body)
+		require.NoError(t, readerErr)
+		defer reader.Release()
+		var count int64
+		for reader.Next() {
+			count += reader.RecordBatch().NumRows()
+		}
+		require.NoError(t, reader.Err())
+		assert.EqualValues(t, 10000, count)
+	})
+
+	t.Run("limit reached before the response is started", func(t *testing.T) {
+		rec, err := serve(100)
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		assert.Equal(t, 0, rec.Body.Len())
+	})
+
+	t.Run("limit reached once the response is started", func(t *testing.T) {
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			_, _ = serve(5000)
+		})
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"strconv"
+
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+)
+
+// sqlDialect describes how a driver binds the parameters, which syntax can hide a parameter or a macro, and how the
+// queries run in transactions.
+type sqlDialect struct {
+	// placeholder returns the placeholder of the n-th argument of the query, starting from 1.
+	placeholder func(n int) string
+	// hashComment is true when # starts a comment until the end of the line.
+	hashComment bool
+	// backslashEscape is true when \ escapes the next character of a string.
+	backslashEscape bool
+	// dollarQuote is true when $tag$ delimits a string.
+	dollarQuote bool
+	// braceParameter is true when {name:Type} is a parameter bound by the driver.
+	braceParameter bool
+	// escapeString is true when E'...' is a string where the backslash escapes the next character.
+	escapeString bool
+	// noTransaction is true when the queries don't run in a transaction, the guardrails being set on the connection.
+	noTransaction bool
+	// readOnlyTransaction is true when the driver supports the read-only transactions. Otherwise, the writes of the
+	// read-only datasources are undone, as their transactions are always rolled back.
+	readOnlyTransaction bool
+	// batch is true when a query is a batch of statements that can end its own transaction. The read-only queries
+	// can't use the statements controlling the transaction, or the writes following them wouldn't be rolled back.
+	batch bool
+}
+
+func questionMark(int) string {
+	return "?"
+}
+
+var sqlDialects = map[datasourceSQL.Driver]sqlDialect{
+	datasourceSQL.DriverMySQL: {
+		placeholder:         questionMark,
+		hashComment:         true,
+		backslashEscape:     true,
+		readOnlyTransaction: true,
+	},
+	datasourceSQL.DriverPostgreSQL: {
+		placeholder:         func(n int) string { return "$" + strconv.Itoa(n) },
+		dollarQuote:         true,
+		escapeString:        true,
+		readOnlyTransaction: true,
+	},
+	datasourceSQL.DriverClickHouse: {
+		placeholder:     questionMark,
+		hashComment:     true,
+		backslashEscape: true,
+		braceParameter:  true,
+		// A rollback closes the connection, and the readonly setting already prevents the writes.
+		noTransaction: true,
+	},
+	datasourceSQL.DriverSQLServer: {
+		placeholder: func(n int) string { return "@p" + strconv.Itoa(n) },
+		// The driver refuses the read-only transactions.
+		batch: true,
+	},
+	datasourceSQL.DriverSQLite: {
+		placeholder: questionMark,
+		// The driver ignores the option, the database being opened in read-only mode instead.
+		readOnlyTransaction: true,
+	},
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"modernc.org/sqlite"
+)
+
+const (
+	// postgresReadOnlyViolation is the code of the error returned by Postgres when a read-only transaction tries to write.
+	postgresReadOnlyViolation = "25006"
+	// postgresQueryCanceled is the code of the error returned by Postgres when the statement_timeout is reached.
+	postgresQueryCanceled = "57014"
+	// mysqlReadOnlyViolation is the number of the error returned by MySQL when a read-only transaction tries to write.
+	mysqlReadOnlyViolation = 1792
+	// clickhouseTimeoutExceeded is the code of the error returned by ClickHouse when the max_execution_time is reached.
+	clickhouseTimeoutExceeded = 159
+	// clickhouseReadOnly is the code of the error returned by ClickHouse when a query tries to write in readonly mode.
+	clickhouseReadOnly = 164
+	// sqliteReadOnly is the primary code of the error returned by SQLite when a query tries to write in a read-only database.
+	sqliteReadOnly = 8
+)
+
+// transactionControlKeywords are the T-SQL keywords able to end the transaction running a query, either directly or
+// through a procedure or a dynamic query.
+var transactionControlKeywords = []string{"BEGIN", "COMMIT", "ROLLBACK", "SAVE", "EXEC", "EXECUTE", "SP_EXECUTESQL"}
+
+// errResultTooLarge is returned when the result of a query exceeds the limits of the guardrails.
+var errResultTooLarge = errors.New("the result of the query is too large")
+
+// guardrails returns the guardrails of the datasource, completed with the default values.
+func (s *sqlProxy) guardrails() datasourceSQL.Guardrails {
+	guardrails := datasourceSQL.Guardrails{}
+	if s.config.Guardrails != nil {
+		guardrails = *s.config.Guardrails
+	}
+	if guardrails.ReadOnly == nil {
+		readOnly := true
+		guardrails.ReadOnly = &readOnly
+	}
+	if guardrails.StatementTimeout == 0 {
+		guardrails.StatementTimeout = datasourceSQL.DefaultStatementTimeout
+	}
+	if guardrails.MaxRows == 0 {
+		guardrails.MaxRows = datasourceSQL.DefaultMaxRows
+	}
+	if guardrails.MaxBytes == 0 {
+		guardrails.MaxBytes = datasourceSQL.DefaultMaxBytes
+	}
+	return guardrails
+}
+
+// statementType returns the first keyword of the query, like SELECT, skipping the comments and the opening parentheses.
+func statementType(query string) string {
+	for {
+		query = strings.TrimLeftFunc(query, func(r rune) bool { return unicode.IsSpace(r) || r == '(' })
+		switch {
+		case strings.HasPrefix(query, "--") || strings.HasPrefix(query, "#"):
+			end := strings.IndexByte(query, '\n')
+			if end < 0 {
+				return ""
+			}
+			query = query[end+1:]
+		case strings.HasPrefix(query, "/*"):
+			end := strings.Index(query, "*/")
+			if end < 0 {
+				return ""
+			}
+			query = query[end+2:]
+		default:
+			end := strings.IndexFunc(query, func(r rune) bool { return !unicode.IsLetter(r) })
+			if end < 0 {
+				end = len(query)
+			}
+			return strings.ToUpper(query[:end])
+		}
+	}
+}
+
+// checkStatement verifies the type of the query is allowed by the guardrails.
+func checkStatement(query string, guardrails datasourceSQL.Guardrails) error {
+	if len(guardrails.AllowedStatements) == 0 {
+		return nil
+	}
+	statement := statementType(query)
+	if !slices.ContainsFunc(guardrails.AllowedStatements, func(allowed string) bool { return strings.EqualFold(allowed, statement) }) {
+		return apiinterface.HandleForbiddenError(fmt.Sprintf("the statement %q is not allowed on this datasource, the allowed statements are %s", statement, strings.Join(guardrails.AllowedStatements, ", ")))
+	}
+	return nil
+}
+
+// readOnlyBatch returns the batch to run on a read-only datasource whose writes are only undone by the rollback of the
+// transaction. The keywords able to end the transaction are refused anywhere in the batch, as T-SQL doesn't require
+// a semicolon between two statements, and the batch can't start with a call to a procedure.
+func (d sqlDialect) readOnlyBatch(query string) (string, error) {
+	for i := 0; i < len(query); {
+		if end := d.skip(query, i); end > i {
+			i = end
+			continue
+		}
+		if !isIdentifierChar(query[i], true) {
+			i++
+			continue
+		}
+		end := identifierEnd(query, i)
+		// skip the variables, the temporary tables and the names qualified by a schema or a table
+		if i == 0 || !strings.ContainsRune("@#.", rune(query[i-1])) {
+			keyword := strings.ToUpper(query[i:end])
+			if slices.Contains(transactionControlKeywords, keyword) {
+				return "", apiinterface.HandleForbiddenError(fmt.Sprintf("the datasource only accepts read-only queries, the statement %q can't be used", keyword))
+			}
+		}
+		i = end
+	}
+	// T-SQL only calls a procedure without EXECUTE when it is the first statement of the batch.
+	return "SET NOCOUNT ON;\n" + query, nil
+}
+
+// beginTx starts the transaction running the query, enforcing the guardrails on the database side when the driver allows it.
+func (s *sqlProxy) beginTx(ctx context.Context, db *sql.DB, guardrails datasourceSQL.Guardrails) (*sql.Tx, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: *guardrails.ReadOnly && sqlDialects[s.config.Driver].readOnlyTransaction})
+	if err != nil {
+		return nil, err
+	}
+	if s.config.Driver == datasourceSQL.DriverPostgreSQL {
+		// The query is stopped by the database itself, even if the connection stays open.
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", guardrails.StatementTimeout.Milliseconds())); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// guardrailError translates the error caused by the guardrails into the error returned to the client.
+// It returns nil when the error is not related to the guardrails.
+func guardrailError(ctx context.Context, err error, guardrails datasourceSQL.Guardrails) error {
+	timeoutErr := echo.NewHTTPError(http.StatusUnprocessableEntity, fmt.Sprintf("the query exceeded the statement timeout of %s", guardrails.StatementTimeout))
+	readOnlyErr := apiinterface.HandleForbiddenError("the datasource only accepts read-only queries")
+	if errors.Is(err, errResultTooLarge) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, fmt.Sprintf("the result of the query exceeds the limit of %d rows or %d bytes", guardrails.MaxRows, guardrails.MaxBytes))
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return timeoutErr
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case postgresQueryCanceled:
+			return timeoutErr
+		case postgresReadOnlyViolation:
+			return readOnlyErr
+		}
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlReadOnlyViolation {
+		return readOnlyErr
+	}
+	var clickhouseErr *clickhouse.Exception
+	if errors.As(err, &clickhouseErr) {
+		switch clickhouseErr.Code {
+		case clickhouseTimeoutExceeded:
+			return timeoutErr
+		case clickhouseReadOnly:
+			return readOnlyErr
+		}
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqliteReadOnly {
+		return readOnlyErr
+	}
+	return nil
+}
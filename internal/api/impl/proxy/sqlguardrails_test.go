@@ -0,0 +1,206 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementType(t *testing.T) {
+	testSuite := []struct {
+		query    string
+		expected string
+	}{
+		{query: "select * from users", expected: "SELECT"},
+		{query: "  \n\tWITH t AS (SELECT 1) SELECT * FROM t", expected: "WITH"},
+		{query: "(SELECT 1) UNION (SELECT 2)", expected: "SELECT"},
+		{query: "-- the users\nSELECT * FROM users", expected: "SELECT"},
+		{query: "/* DELETE */ delete from users", expected: "DELETE"},
+		{query: "# comment\nSHOW TABLES", expected: "SHOW"},
+		{query: "/* unterminated", expected: ""},
+		{query: "", expected: ""},
+	}
+	for _, test := range testSuite {
+		t.Run(test.query, func(t *testing.T) {
+			assert.Equal(t, test.expected, statementType(test.query))
+		})
+	}
+}
+
+func TestCheckStatement(t *testing.T) {
+	guardrails := datasourceSQL.Guardrails{AllowedStatements: []string{"select", "WITH"}}
+	assert.NoError(t, checkStatement("SELECT 1", guardrails))
+	assert.NoError(t, checkStatement("with t as (select 1) select * from t", guardrails))
+	err := checkStatement("DROP TABLE users", guardrails)
+	assert.ErrorIs(t, err, apiinterface.ForbiddenError)
+	assert.ErrorContains(t, err, `the statement "DROP" is not allowed`)
+	assert.NoError(t, checkStatement("DROP TABLE users", datasourceSQL.Guardrails{}))
+}
+
+func TestSQLDialect_readOnlyBatch(t *testing.T) {
+	dialect := sqlDialects[datasourceSQL.DriverSQLServer]
+	testSuite := []struct {
+		title   string
+		query   string
+		keyword string
+	}{
+		{
+			title: "select",
+			query: "SELECT name FROM sys.databases WHERE name = @p1",
+		},
+		{
+			title: "keywords in strings, comments and names",
+			query: "SELECT 'commit' AS [status], t.rollback, @exec FROM #begin t -- EXEC sp_who\n/* ROLLBACK */",
+		},
+		{
+			title:   "commit in the batch",
+			query:   "SELECT 1; COMMIT; DROP TABLE t",
+			keyword: "COMMIT",
+		},
+		{
+			title:   "commit without a semicolon",
+			query:   "SELECT 1 commit tran DROP TABLE t",
+			keyword: "COMMIT",
+		},
+		{
+			title:   "rollback",
+			query:   "IF @@TRANCOUNT > 0 ROLLBACK; DELETE FROM t",
+			keyword: "ROLLBACK",
+		},
+		{
+			title:   "dynamic query",
+			query:   "EXEC('COMMIT')",
+			keyword: "EXEC",
+		},
+		{
+			title:   "procedure",
+			query:   "SELECT 1 EXECUTE sp_who",
+			keyword: "EXECUTE",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			batch, err := dialect.readOnlyBatch(test.query)
+			if len(test.keyword) > 0 {
+				assert.ErrorIs(t, err, apiinterface.ForbiddenError)
+				assert.ErrorContains(t, err, fmt.Sprintf("the statement %q can't be used", test.keyword))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "SET NOCOUNT ON;\n"+test.query, batch)
+		})
+	}
+}
+
+func TestSQLProxy_guardrails(t *testing.T) {
+	proxy := &sqlProxy{config: &datasourceSQL.Config{Driver: datasourceSQL.DriverPostgreSQL}}
+	guardrails := proxy.guardrails()
+	require.NotNil(t, guardrails.ReadOnly)
+	assert.True(t, *guardrails.ReadOnly)
+	assert.Equal(t, datasourceSQL.DefaultStatementTimeout, guardrails.StatementTimeout)
+	assert.Equal(t, datasourceSQL.DefaultMaxRows, guardrails.MaxRows)
+	assert.Equal(t, int64(datasourceSQL.DefaultMaxBytes), guardrails.MaxBytes)
+
+	readOnly := false
+	proxy.config.Guardrails = &datasourceSQL.Guardrails{ReadOnly: &readOnly, MaxRows: 10}
+	guardrails = proxy.guardrails()
+	assert.False(t, *guardrails.ReadOnly)
+	assert.Equal(t, 10, guardrails.MaxRows)
+}
+
+func TestGuardrailError(t *testing.T) {
+	guardrails := datasourceSQL.Guardrails{StatementTimeout: time.Second, MaxRows: 10, MaxBytes: 100}
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	testSuite := []struct {
+		title  string
+		ctx    context.Context
+		err    error
+		status int
+	}{
+		{
+			title:  "result too large",
+			ctx:    context.Background(),
+			err:    errResultTooLarge,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			title:  "statement timeout",
+			ctx:    expired,
+			err:    context.DeadlineExceeded,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			title:  "postgres statement timeout",
+			ctx:    context.Background(),
+			err:    &pgconn.PgError{Code: postgresQueryCanceled},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			title:  "postgres read-only transaction",
+			ctx:    context.Background(),
+			err:    &pgconn.PgError{Code: postgresReadOnlyViolation},
+			status: http.StatusForbidden,
+		},
+		{
+			title:  "mysql read-only transaction",
+			ctx:    context.Background(),
+			err:    &mysql.MySQLError{Number: mysqlReadOnlyViolation},
+			status: http.StatusForbidden,
+		},
+		{
+			title:  "clickhouse execution time exceeded",
+			ctx:    context.Background(),
+			err:    &clickhouse.Exception{Code: clickhouseTimeoutExceeded},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			title:  "clickhouse readonly mode",
+			ctx:    context.Background(),
+			err:    &clickhouse.Exception{Code: clickhouseReadOnly},
+			status: http.StatusForbidden,
+		},
+		{
+			title: "other error",
+			ctx:   context.Background(),
+			err:   errors.New("connection refused"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			err := guardrailError(test.ctx, test.err, guardrails)
+			if test.status == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, apiinterface.HandleError(err), &httpErr)
+			assert.Equal(t, test.status, httpErr.Code)
+		})
+	}
+}
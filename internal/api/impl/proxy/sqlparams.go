@@ -0,0 +1,324 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apiinterface "github.com/perses/perses/internal/api/interface"
+)
+
+type sqlParameterType string
+
+const (
+	sqlParameterString sqlParameterType = "string"
+	sqlParameterNumber sqlParameterType = "number"
+	sqlParameterTime   sqlParameterType = "time"
+	sqlParameterList   sqlParameterType = "list"
+)
+
+// sqlParameter is a named value bound to the query, rather than interpolated in it.
+type sqlParameter struct {
+	Type sqlParameterType
+	// values are the values bound to the placeholders of the parameter. A list has one placeholder per item.
+	values []any
+}
+
+func (p *sqlParameter) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Type  sqlParameterType `json:"type"`
+		Value json.RawMessage  `json:"value"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	p.Type = tmp.Type
+	if tmp.Type == sqlParameterList {
+		var items []json.RawMessage
+		if err := json.Unmarshal(tmp.Value, &items); err != nil {
+			return fmt.Errorf("the value of a list parameter must be an array: %w", err)
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("the value of a list parameter can't be empty")
+		}
+		p.values = make([]any, len(items))
+		for i, item := range items {
+			// The type of the items is the type of their JSON value.
+			itemType := sqlParameterNumber
+			if bytes.HasPrefix(bytes.TrimSpace(item), []byte(`"`)) {
+				itemType = sqlParameterString
+			}
+			value, err := decodeSQLParameterValue(itemType, item)
+			if err != nil {
+				return err
+			}
+			p.values[i] = value
+		}
+		return nil
+	}
+	value, err := decodeSQLParameterValue(tmp.Type, tmp.Value)
+	if err != nil {
+		return err
+	}
+	p.values = []any{value}
+	return nil
+}
+
+// decodeSQLParameterValue decodes a scalar value. A null value is bound as NULL.
+func decodeSQLParameterValue(parameterType sqlParameterType, data json.RawMessage) (any, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	switch parameterType {
+	case sqlParameterString:
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("the value of a string parameter must be a string: %w", err)
+		}
+		return value, nil
+	case sqlParameterNumber:
+		var value json.Number
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("the value of a number parameter must be a number: %w", err)
+		}
+		if i, err := value.Int64(); err == nil {
+			return i, nil
+		}
+		return value.Float64()
+	case sqlParameterTime:
+		// A time is either an RFC 3339 string or a number of milliseconds since the epoch, like the dashboard time range.
+		var millis int64
+		if err := json.Unmarshal(data, &millis); err == nil {
+			return time.UnixMilli(millis).UTC(), nil
+		}
+		var value time.Time
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("the value of a time parameter must be an RFC 3339 string or a number of milliseconds: %w", err)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown parameter type %q, it must be one of %s, %s, %s or %s", parameterType, sqlParameterString, sqlParameterNumber, sqlParameterTime, sqlParameterList)
+	}
+}
+
+// sqlTimeRange is the time range of the dashboard, used by the time macros.
+type sqlTimeRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func isIdentifierChar(c byte, first bool) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || (!first && '0' <= c && c <= '9')
+}
+
+// identifierEnd returns the end of the identifier starting at the index i of the query.
+func identifierEnd(query string, i int) int {
+	for i < len(query) && isIdentifierChar(query[i], false) {
+		i++
+	}
+	return i
+}
+
+// expand replaces the parameters (:name) and the macros ($__name(...)) of the query by the placeholders of the driver.
+// It returns the query to execute with the values to bind, ignoring the strings and the comments of the query.
+func (d sqlDialect) expand(q *sqlQuery) (string, []any, error) {
+	var args []any
+	bind := func(values ...any) []string {
+		placeholders := make([]string, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = d.placeholder(len(args))
+		}
+		return placeholders
+	}
+	query, err := d.expandText(q.Query, q, bind)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+// expandText replaces the parameters and the macros of the given part of the query, binding their values with bind.
+func (d sqlDialect) expandText(query string, q *sqlQuery, bind func(values ...any) []string) (string, error) {
+	result := &strings.Builder{}
+	for i := 0; i < len(query); {
+		c := query[i]
+		next := i + 1
+		if end := d.skip(query, i); end > i {
+			result.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		switch {
+		case strings.HasPrefix(query[i:], "::"):
+			// cast in PostgreSQL
+			next = i + 2
+			result.WriteString("::")
+		case c == ':' && next < len(query) && isIdentifierChar(query[next], true) && (i == 0 || !isIdentifierChar(query[i-1], false)):
+			// Only the parameters sent with the query are replaced. Any other name is left as is, as it can be part
+			// of the syntax of the database, like the slice arr[1:n] of PostgreSQL.
+			next = identifierEnd(query, next)
+			parameter, ok := q.Parameters[query[i+1:next]]
+			if !ok {
+				result.WriteString(query[i:next])
+				break
+			}
+			result.WriteString(strings.Join(bind(parameter.values...), ", "))
+		case strings.HasPrefix(query[i:], "$__"):
+			var expansion string
+			var err error
+			expansion, next, err = d.expandMacro(query, i, q, bind)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(expansion)
+		default:
+			result.WriteByte(c)
+		}
+		i = next
+	}
+	return result.String(), nil
+}
+
+// skip returns the end of the string, the quoted identifier or the comment starting at the index i of the query.
+// It returns i when the query doesn't start one at this index.
+func (d sqlDialect) skip(query string, i int) int {
+	c := query[i]
+	switch {
+	case c == '\'' || c == '"' || c == '`':
+		return quotedEnd(query, i, d.backslashEscape)
+	case d.escapeString && (c == 'E' || c == 'e') && i+1 < len(query) && query[i+1] == '\'' && (i == 0 || !isIdentifierChar(query[i-1], false)):
+		// E'...' is a string where the backslash escapes the next character, like E'it\'s'.
+		return quotedEnd(query, i+1, true)
+	case strings.HasPrefix(query[i:], "--") || (d.hashComment && c == '#'):
+		if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+			return i + end
+		}
+		return len(query)
+	case strings.HasPrefix(query[i:], "/*"):
+		if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+			return i + 2 + end + 2
+		}
+		return len(query)
+	case d.braceParameter && c == '{':
+		// {name:Type} is a query parameter of ClickHouse, bound by the driver itself.
+		nameEnd := identifierEnd(query, i+1)
+		if nameEnd > i+1 && nameEnd < len(query) && query[nameEnd] == ':' {
+			if end := strings.IndexByte(query[nameEnd:], '}'); end >= 0 {
+				return nameEnd + end + 1
+			}
+		}
+	case d.dollarQuote && c == '$':
+		next := i + 1
+		tagEnd := identifierEnd(query, next)
+		if tagEnd < len(query) && query[tagEnd] == '$' && (tagEnd == next || isIdentifierChar(query[next], true)) {
+			tag := query[i : tagEnd+1]
+			if end := strings.Index(query[tagEnd+1:], tag); end >= 0 {
+				return tagEnd + 1 + end + len(tag)
+			}
+			return len(query)
+		}
+	}
+	return i
+}
+
+// quotedEnd returns the end of the string or the quoted identifier starting at the index i of the query.
+// A quote is escaped by doubling it, or with a backslash when backslashEscape is true.
+func quotedEnd(query string, i int, backslashEscape bool) int {
+	quote := query[i]
+	for j := i + 1; j < len(query); j++ {
+		switch {
+		case backslashEscape && query[j] == '\\':
+			j++
+		case query[j] == quote:
+			if j+1 < len(query) && query[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+// expandMacro expands the macro starting at the index i of the query and returns the index following it:
+//   - $__timeFilter(column) filters the column on the time range,
+//   - $__timeFrom() and $__timeTo() are the start and the end of the time range,
+//   - $__unixEpochFilter(column) filters the column, holding a number of seconds since the epoch, on the time range.
+//
+// The parameters and the macros used in the argument are expanded as well.
+func (d sqlDialect) expandMacro(query string, i int, q *sqlQuery, bind func(values ...any) []string) (string, int, error) {
+	nameEnd := identifierEnd(query, i+3)
+	name := query[i+1 : nameEnd]
+	if nameEnd >= len(query) || query[nameEnd] != '(' {
+		return "", 0, apiinterface.HandleBadRequestError(fmt.Sprintf("the macro $%s must be followed by its arguments in parentheses", name))
+	}
+	// look for the closing parenthesis, as the argument can be an expression with parentheses and strings
+	depth := 0
+	argEnd := -1
+	for j := nameEnd; j < len(query) && argEnd < 0; j++ {
+		if end := d.skip(query, j); end > j {
+			j = end - 1
+			continue
+		}
+		switch query[j] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				argEnd = j
+			}
+		}
+	}
+	if argEnd < 0 {
+		return "", 0, apiinterface.HandleBadRequestError(fmt.Sprintf("the parenthesis of the macro $%s is not closed", name))
+	}
+	next := argEnd + 1
+	timeRange := q.Range
+	if timeRange == nil {
+		return "", 0, apiinterface.HandleBadRequestError(fmt.Sprintf("the macro $%s requires the time range of the query", name))
+	}
+	arg, err := d.expandText(strings.TrimSpace(query[nameEnd+1:argEnd]), q, bind)
+	if err != nil {
+		return "", 0, err
+	}
+	switch name {
+	case "__timeFilter", "__unixEpochFilter":
+		if len(arg) == 0 {
+			return "", 0, apiinterface.HandleBadRequestError(fmt.Sprintf("the macro $%s requires a column", name))
+		}
+		var placeholders []string
+		if name == "__timeFilter" {
+			placeholders = bind(timeRange.Start, timeRange.End)
+		} else {
+			placeholders = bind(timeRange.Start.Unix(), timeRange.End.Unix())
+		}
+		return fmt.Sprintf("%s BETWEEN %s AND %s", arg, placeholders[0], placeholders[1]), next, nil
+	case "__timeFrom", "__timeTo":
+		if len(arg) > 0 {
+			return "", 0, apiinterface.HandleBadRequestError(fmt.Sprintf("the macro $%s doesn't take any argument", name))
+		}
+		if name == "__timeFrom" {
+			return bind(timeRange.Start)[0], next, nil
+		}
+		return bind(timeRange.End)[0], next, nil
+	default:
+		return "", 0, apiinterface.HandleBadRequestError(fmt.Sprintf("unknown macro $%s", name))
+	}
+}
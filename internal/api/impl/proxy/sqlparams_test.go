@@ -0,0 +1,271 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalSQLParameter(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		jason    string
+		expected []any
+		err      bool
+	}{
+		{
+			title:    "string",
+			jason:    `{"type": "string", "value": "it's"}`,
+			expected: []any{"it's"},
+		},
+		{
+			title:    "integer",
+			jason:    `{"type": "number", "value": 42}`,
+			expected: []any{int64(42)},
+		},
+		{
+			title:    "float",
+			jason:    `{"type": "number", "value": 4.2}`,
+			expected: []any{4.2},
+		},
+		{
+			title:    "null",
+			jason:    `{"type": "string", "value": null}`,
+			expected: []any{nil},
+		},
+		{
+			title:    "time as RFC 3339",
+			jason:    `{"type": "time", "value": "2025-01-02T03:04:05+01:00"}`,
+			expected: []any{time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600))},
+		},
+		{
+			title:    "time as milliseconds",
+			jason:    `{"type": "time", "value": 1735783445000}`,
+			expected: []any{time.Date(2025, 1, 2, 2, 4, 5, 0, time.UTC)},
+		},
+		{
+			title:    "list",
+			jason:    `{"type": "list", "value": ["a", 1]}`,
+			expected: []any{"a", int64(1)},
+		},
+		{
+			title: "empty list",
+			jason: `{"type": "list", "value": []}`,
+			err:   true,
+		},
+		{
+			title: "number as string",
+			jason: `{"type": "number", "value": "1; DROP TABLE users"}`,
+			err:   true,
+		},
+		{
+			title: "unknown type",
+			jason: `{"type": "duration", "value": "5m"}`,
+			err:   true,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			parameter := sqlParameter{}
+			err := json.Unmarshal([]byte(test.jason), &parameter)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(test.expected), len(parameter.values))
+			for i, value := range test.expected {
+				if expectedTime, ok := value.(time.Time); ok {
+					assert.True(t, expectedTime.Equal(parameter.values[i].(time.Time)))
+					continue
+				}
+				assert.Equal(t, value, parameter.values[i])
+			}
+		})
+	}
+}
+
+func TestSQLDialect_expand(t *testing.T) {
+	start := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	parameters := map[string]sqlParameter{
+		"name": {Type: sqlParameterString, values: []any{"perses"}},
+		"ids":  {Type: sqlParameterList, values: []any{int64(1), int64(2)}},
+	}
+	testSuite := []struct {
+		title         string
+		driver        datasourceSQL.Driver
+		query         string
+		noRange       bool
+		expectedQuery string
+		expectedArgs  []any
+		status        int
+	}{
+		{
+			title:         "parameters with MySQL",
+			driver:        datasourceSQL.DriverMySQL,
+			query:         "SELECT * FROM t WHERE name = :name AND id IN (:ids)",
+			expectedQuery: "SELECT * FROM t WHERE name = ? AND id IN (?, ?)",
+			expectedArgs:  []any{"perses", int64(1), int64(2)},
+		},
+		{
+			title:         "parameters with PostgreSQL",
+			driver:        datasourceSQL.DriverPostgreSQL,
+			query:         "SELECT * FROM t WHERE name = :name AND id IN (:ids) AND name = :name",
+			expectedQuery: "SELECT * FROM t WHERE name = $1 AND id IN ($2, $3) AND name = $4",
+			expectedArgs:  []any{"perses", int64(1), int64(2), "perses"},
+		},
+		{
+			title:         "strings, comments and casts are left as is",
+			driver:        datasourceSQL.DriverPostgreSQL,
+			query:         "SELECT ':name', \"a:b\", $$ :name $$, $tag$ it's :name $tag$, id::text /* :name */ -- :name\nFROM t WHERE name = :name",
+			expectedQuery: "SELECT ':name', \"a:b\", $$ :name $$, $tag$ it's :name $tag$, id::text /* :name */ -- :name\nFROM t WHERE name = $1",
+			expectedArgs:  []any{"perses"},
+		},
+		{
+			title:         "escaped quotes and hash comments with MySQL",
+			driver:        datasourceSQL.DriverMySQL,
+			query:         "SELECT 'it\\'s :name', 'it''s :name' # :name\nFROM t WHERE name = :name",
+			expectedQuery: "SELECT 'it\\'s :name', 'it''s :name' # :name\nFROM t WHERE name = ?",
+			expectedArgs:  []any{"perses"},
+		},
+		{
+			title:         "time macros",
+			driver:        datasourceSQL.DriverPostgreSQL,
+			query:         "SELECT $__timeFrom(), $__timeTo() FROM t WHERE $__timeFilter(date_trunc('minute', created)) AND $__unixEpochFilter( ts )",
+			expectedQuery: "SELECT $1, $2 FROM t WHERE date_trunc('minute', created) BETWEEN $3 AND $4 AND ts BETWEEN $5 AND $6",
+			expectedArgs:  []any{start, end, start, end, start.Unix(), end.Unix()},
+		},
+		{
+			title:         "escape strings with PostgreSQL",
+			driver:        datasourceSQL.DriverPostgreSQL,
+			query:         "SELECT E'it\\'s :name', e'\\\\', name FROM t WHERE name = :name",
+			expectedQuery: "SELECT E'it\\'s :name', e'\\\\', name FROM t WHERE name = $1",
+			expectedArgs:  []any{"perses"},
+		},
+		{
+			title:         "parameters in the macro arguments",
+			driver:        datasourceSQL.DriverPostgreSQL,
+			query:         "SELECT * FROM t WHERE $__timeFilter(coalesce(created, :name::timestamp)) AND $__unixEpochFilter(f(')', ts))",
+			expectedQuery: "SELECT * FROM t WHERE coalesce(created, $1::timestamp) BETWEEN $2 AND $3 AND f(')', ts) BETWEEN $4 AND $5",
+			expectedArgs:  []any{"perses", start, end, start.Unix(), end.Unix()},
+		},
+		{
+			title:         "undefined parameter in a macro argument",
+			driver:        datasourceSQL.DriverMySQL,
+			query:         "SELECT * FROM t WHERE $__timeFilter(:unknown)",
+			expectedQuery: "SELECT * FROM t WHERE :unknown BETWEEN ? AND ?",
+			expectedArgs:  []any{start, end},
+		},
+		{
+			title:         "undefined parameter",
+			driver:        datasourceSQL.DriverMySQL,
+			query:         "SELECT * FROM t WHERE name = :unknown",
+			expectedQuery: "SELECT * FROM t WHERE name = :unknown",
+		},
+		{
+			title:         "array slices with PostgreSQL",
+			driver:        datasourceSQL.DriverPostgreSQL,
+			query:         "SELECT arr[1:2], arr[:name], arr[2:name] FROM t",
+			expectedQuery: "SELECT arr[1:2], arr[$1], arr[2:name] FROM t",
+			expectedArgs:  []any{"perses"},
+		},
+		{
+			title:         "query parameters of ClickHouse",
+			driver:        datasourceSQL.DriverClickHouse,
+			query:         "SELECT * FROM t WHERE id = {id:UInt32} AND name = {name:String}",
+			expectedQuery: "SELECT * FROM t WHERE id = {id:UInt32} AND name = {name:String}",
+		},
+		{
+			title:   "macro without time range",
+			driver:  datasourceSQL.DriverMySQL,
+			query:   "SELECT * FROM t WHERE $__timeFilter(created)",
+			noRange: true,
+			status:  http.StatusBadRequest,
+		},
+		{
+			title:  "unknown macro",
+			driver: datasourceSQL.DriverMySQL,
+			query:  "SELECT $__interval() FROM t",
+			status: http.StatusBadRequest,
+		},
+		{
+			title:  "macro not closed",
+			driver: datasourceSQL.DriverMySQL,
+			query:  "SELECT * FROM t WHERE $__timeFilter(created",
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			q := &sqlQuery{Query: test.query, Parameters: parameters}
+			if !test.noRange {
+				q.Range = &sqlTimeRange{Start: start, End: end}
+			}
+			query, args, err := sqlDialects[test.driver].expand(q)
+			if test.status != 0 {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, apiinterface.HandleError(err), &httpErr)
+				assert.Equal(t, test.status, httpErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedQuery, query)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
+
+func TestSQLDialect_expandBinding(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER, name TEXT)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO users VALUES (1, 'perses'), (2, 'it''s'), (3, 'other')`)
+	require.NoError(t, err)
+
+	q := &sqlQuery{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "query": "SELECT id FROM users WHERE name = :name OR id IN (:ids) ORDER BY id",
+  "parameters": {
+    "name": {"type": "string", "value": "it's"},
+    "ids": {"type": "list", "value": [1, "1 OR 1=1"]}
+  }
+}`), q))
+	// SQLite accepts the placeholders of MySQL
+	query, args, err := sqlDialects[datasourceSQL.DriverMySQL].expand(q)
+	require.NoError(t, err)
+	rows, err := db.Query(query, args...)
+	require.NoError(t, err)
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []int64{1, 2}, ids)
+}
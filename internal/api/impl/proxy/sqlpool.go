@@ -0,0 +1,159 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/perses/common/async"
+	"github.com/sirupsen/logrus"
+)
+
+// SQLPoolEvictionInterval is the time between two searches of the idle pools to close.
+const SQLPoolEvictionInterval = time.Minute
+
+var errSQLPoolsClosed = errors.New("the connection pools are closed")
+
+type sqlPool struct {
+	db *sql.DB
+	// fingerprint identifies the version of the datasource and of its secret the pool has been opened with.
+	fingerprint string
+	idleTimeout time.Duration
+	lastUsed    time.Time
+	// users is the number of queries in progress with the pool.
+	users int
+	// retired is true once the pool has been replaced or evicted. It is closed when its last query is done.
+	retired bool
+}
+
+// SQLPools keeps a connection pool per SQL datasource, so the connections are reused from one query to another.
+// A pool is replaced as soon as the datasource or its secret is updated, and closed when it is not used anymore.
+// It is a task: the idle pools are closed each time it is executed, and all the pools once Perses stops.
+type SQLPools struct {
+	async.Task
+	mutex  sync.Mutex
+	pools  map[string]*sqlPool
+	closed bool
+}
+
+func NewSQLPools() *SQLPools {
+	return &SQLPools{pools: make(map[string]*sqlPool)}
+}
+
+func (p *SQLPools) String() string {
+	return "SQL connection pools"
+}
+
+func (p *SQLPools) Initialize() error {
+	return nil
+}
+
+func (p *SQLPools) Execute(_ context.Context, _ context.CancelFunc) error {
+	p.evictIdle(time.Now())
+	return nil
+}
+
+// Finalize closes the pools. The ones still used are closed when their last query is done.
+func (p *SQLPools) Finalize() error {
+	p.mutex.Lock()
+	p.closed = true
+	closable := make(map[string]*sql.DB)
+	for key, pool := range p.pools {
+		delete(p.pools, key)
+		if retireSQLPool(pool) {
+			closable[key] = pool.db
+		}
+	}
+	p.mutex.Unlock()
+	for key, db := range closable {
+		closeSQLPool(key, db)
+	}
+	return nil
+}
+
+// get returns the pool designated by the key, and the function to call once the query is done. The pool is opened
+// with the open function when there is none yet or when the fingerprint of the datasource changed.
+func (p *SQLPools) get(key string, fingerprint string, idleTimeout time.Duration, open func() (*sql.DB, error)) (*sql.DB, func(), error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return nil, nil, errSQLPoolsClosed
+	}
+	pool, ok := p.pools[key]
+	if ok && pool.fingerprint != fingerprint {
+		// The datasource or its secret has been updated since the pool was opened.
+		delete(p.pools, key)
+		if retireSQLPool(pool) {
+			go closeSQLPool(key, pool.db)
+		}
+		ok = false
+	}
+	if !ok {
+		db, err := open()
+		if err != nil {
+			return nil, nil, err
+		}
+		pool = &sqlPool{
+			db:          db,
+			fingerprint: fingerprint,
+			idleTimeout: idleTimeout,
+		}
+		p.pools[key] = pool
+	}
+	pool.users++
+	pool.lastUsed = time.Now()
+	return pool.db, func() { p.release(key, pool) }, nil
+}
+
+// release is called once a query using the pool is done. The pool is closed if it has been retired in the meantime.
+func (p *SQLPools) release(key string, pool *sqlPool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	pool.users--
+	pool.lastUsed = time.Now()
+	if pool.retired && pool.users == 0 {
+		go closeSQLPool(key, pool.db)
+	}
+}
+
+// evictIdle closes the pools not used for longer than their idle timeout.
+func (p *SQLPools) evictIdle(now time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for key, pool := range p.pools {
+		if pool.users == 0 && now.Sub(pool.lastUsed) > pool.idleTimeout {
+			delete(p.pools, key)
+			if retireSQLPool(pool) {
+				go closeSQLPool(key, pool.db)
+			}
+		}
+	}
+}
+
+// retireSQLPool marks the pool as retired and returns true when it can be closed right away, since no query uses it.
+// The mutex of the pools must be held by the caller.
+func retireSQLPool(pool *sqlPool) bool {
+	pool.retired = true
+	return pool.users == 0
+}
+
+func closeSQLPool(key string, db *sql.DB) {
+	if err := db.Close(); err != nil {
+		logrus.WithError(err).Errorf("unable to close the connection pool of the datasource %q", key)
+	}
+}
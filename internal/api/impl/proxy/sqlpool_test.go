@@ -0,0 +1,119 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB() (*sql.DB, error) {
+	// No connection is opened until a query is sent.
+	connector, err := mysql.NewConnector(&mysql.Config{Net: "tcp", Addr: mySQLAddress, DBName: "testdb"})
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+func isClosed(db *sql.DB) bool {
+	err := db.Ping()
+	return err != nil && err.Error() == "sql: database is closed"
+}
+
+func TestSQLPools(t *testing.T) {
+	pools := NewSQLPools()
+	first, releaseFirst, err := pools.get("globaldatasources/mysql", "v1", time.Hour, openTestDB)
+	require.NoError(t, err)
+	releaseFirst()
+
+	// The pool is reused as long as the datasource doesn't change.
+	same, releaseSame, err := pools.get("globaldatasources/mysql", "v1", time.Hour, openTestDB)
+	require.NoError(t, err)
+	assert.Same(t, first, same)
+
+	// Each datasource has its own pool.
+	other, releaseOther, err := pools.get("projects/perses/datasources/mysql", "v1", time.Hour, openTestDB)
+	require.NoError(t, err)
+	defer releaseOther()
+	assert.NotSame(t, first, other)
+
+	// The pool is replaced once the datasource or its secret is updated, but it is closed only once the query in
+	// progress is done.
+	updated, releaseUpdated, err := pools.get("globaldatasources/mysql", "v2", time.Hour, openTestDB)
+	require.NoError(t, err)
+	defer releaseUpdated()
+	assert.NotSame(t, first, updated)
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, isClosed(first))
+	releaseSame()
+	assert.Eventually(t, func() bool {
+		return isClosed(first)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSQLPoolsEvictIdle(t *testing.T) {
+	pools := NewSQLPools()
+	idle, releaseIdle, err := pools.get("globaldatasources/idle", "v1", time.Minute, openTestDB)
+	require.NoError(t, err)
+	releaseIdle()
+	inUse, releaseInUse, err := pools.get("globaldatasources/in-use", "v1", time.Minute, openTestDB)
+	require.NoError(t, err)
+	defer releaseInUse()
+	active, releaseActive, err := pools.get("globaldatasources/active", "v1", time.Hour, openTestDB)
+	require.NoError(t, err)
+	releaseActive()
+
+	pools.evictIdle(time.Now().Add(2 * time.Minute))
+	pools.mutex.Lock()
+	_, idleFound := pools.pools["globaldatasources/idle"]
+	_, inUseFound := pools.pools["globaldatasources/in-use"]
+	_, activeFound := pools.pools["globaldatasources/active"]
+	pools.mutex.Unlock()
+
+	assert.False(t, idleFound)
+	assert.True(t, inUseFound)
+	assert.True(t, activeFound)
+	assert.Eventually(t, func() bool {
+		return isClosed(idle)
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, isClosed(inUse))
+	assert.False(t, isClosed(active))
+}
+
+func TestSQLPoolsFinalize(t *testing.T) {
+	pools := NewSQLPools()
+	idle, releaseIdle, err := pools.get("globaldatasources/idle", "v1", time.Hour, openTestDB)
+	require.NoError(t, err)
+	releaseIdle()
+	inUse, releaseInUse, err := pools.get("globaldatasources/in-use", "v1", time.Hour, openTestDB)
+	require.NoError(t, err)
+
+	require.NoError(t, pools.Finalize())
+	assert.True(t, isClosed(idle))
+	assert.False(t, isClosed(inUse))
+	releaseInUse()
+	assert.Eventually(t, func() bool {
+		return isClosed(inUse)
+	}, time.Second, 10*time.Millisecond)
+
+	// No pool is opened once Perses stops.
+	_, _, err = pools.get("globaldatasources/idle", "v1", time.Hour, openTestDB)
+	assert.ErrorIs(t, err, errSQLPoolsClosed)
+}
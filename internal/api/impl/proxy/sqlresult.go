@@ -0,0 +1,757 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/labstack/echo/v4"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	contentTypeCSV         = "text/csv"
+	contentTypeJSON        = "application/json"
+	contentTypeArrowStream = "application/vnd.apache.arrow.stream"
+	// arrowBatchSize is the number of rows of each record batch of an Arrow stream.
+	arrowBatchSize = 4096
+	// sqlResultHeldRows is the number of rows read before the response is started.
+	sqlResultHeldRows = arrowBatchSize
+)
+
+// sqlColumnType is the type of column exposed to the clients, whatever the database behind.
+type sqlColumnType string
+
+const (
+	sqlColumnInt    sqlColumnType = "int"
+	sqlColumnFloat  sqlColumnType = "float"
+	sqlColumnBool   sqlColumnType = "bool"
+	sqlColumnString sqlColumnType = "string"
+	sqlColumnTime   sqlColumnType = "time"
+	sqlColumnBytes  sqlColumnType = "bytes"
+)
+
+var (
+	sqlIntTypes = []string{
+		"INT", "INTEGER", "INT2", "INT4", "INT8", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "SERIAL", "BIGSERIAL", "YEAR",
+		"INT16", "INT32", "INT64", "UINT8", "UINT16", "UINT32", "UINT64",
+	}
+	sqlFloatTypes = []string{
+		"FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL", "DECIMAL", "NUMERIC",
+		"FLOAT32", "FLOAT64", "DECIMAL32", "DECIMAL64", "DECIMAL128", "DECIMAL256", "MONEY", "SMALLMONEY",
+	}
+	sqlBoolTypes = []string{"BOOL", "BOOLEAN", "BIT"}
+	sqlTimeTypes = []string{
+		"DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ",
+		"DATE32", "DATETIME64", "DATETIME2", "DATETIMEOFFSET", "SMALLDATETIME",
+	}
+	sqlBytesTypes = []string{"BYTEA", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY"}
+	// sqlWrapperTypes are the ClickHouse types that only modify the type they wrap, like in Nullable(Int32).
+	sqlWrapperTypes = []string{"NULLABLE(", "LOWCARDINALITY("}
+	// sqlTimeLayouts are the layouts of the times returned as text by the drivers. The times without zone are in UTC.
+	sqlTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02"}
+)
+
+type sqlColumn struct {
+	Name string        `json:"name"`
+	Type sqlColumnType `json:"type"`
+}
+
+// sqlResultWriter encodes the result of a query in a format, row after row.
+type sqlResultWriter interface {
+	// writeColumns starts the result with the description of its columns.
+	writeColumns(columns []sqlColumn) error
+	// writeRow writes a row, whose values are nil, int64, float64, bool, string, time.Time or []byte according to the
+	// type of their column.
+	writeRow(row []any) error
+	// close ends the result.
+	close() error
+}
+
+// sqlResultFormat is a format the result of a query can be sent in, chosen with the Accept header of the request.
+type sqlResultFormat struct {
+	contentType string
+	newWriter   func(w io.Writer) sqlResultWriter
+}
+
+var sqlResultFormats = []sqlResultFormat{
+	{contentType: contentTypeCSV, newWriter: newCSVResultWriter},
+	{contentType: contentTypeJSON, newWriter: newJSONResultWriter},
+	{contentType: contentTypeArrowStream, newWriter: newArrowResultWriter},
+}
+
+// negotiateSQLResultFormat returns the first format of the Accept header that is supported, CSV by default.
+func negotiateSQLResultFormat(accept string) sqlResultFormat {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		for _, format := range sqlResultFormats {
+			if strings.EqualFold(strings.TrimSpace(mediaType), format.contentType) {
+				return format
+			}
+		}
+	}
+	return sqlResultFormats[0]
+}
+
+// columnTypeOf returns the type of the column according to the database. The boolean is false when it is unknown,
+// in which case the type is deduced from the values.
+func columnTypeOf(columnType *sql.ColumnType) (sqlColumnType, bool) {
+	return columnTypeOfName(columnType.DatabaseTypeName(), columnType.ScanType())
+}
+
+// columnTypeOfName returns the type of the column from the name of its type in the database, or from the Go type the
+// driver scans it into when the name is unknown.
+func columnTypeOfName(databaseTypeName string, scanType reflect.Type) (sqlColumnType, bool) {
+	name := strings.ToUpper(strings.TrimSpace(databaseTypeName))
+	for unwrapped := false; !unwrapped; {
+		unwrapped = true
+		for _, wrapper := range sqlWrapperTypes {
+			if strings.HasPrefix(name, wrapper) && strings.HasSuffix(name, ")") {
+				name, unwrapped = strings.TrimSpace(name[len(wrapper):len(name)-1]), false
+			}
+		}
+	}
+	// Remove the size, like in VARCHAR(255), and the sign, like in UNSIGNED BIGINT.
+	name, _, _ = strings.Cut(name, "(")
+	name = strings.TrimPrefix(strings.TrimSpace(name), "UNSIGNED ")
+	switch {
+	case contains(sqlIntTypes, name):
+		return sqlColumnInt, true
+	case contains(sqlFloatTypes, name):
+		return sqlColumnFloat, true
+	case contains(sqlBoolTypes, name):
+		return sqlColumnBool, true
+	case contains(sqlTimeTypes, name):
+		return sqlColumnTime, true
+	case contains(sqlBytesTypes, name):
+		return sqlColumnBytes, true
+	}
+	if scanType == nil {
+		return "", false
+	}
+	if scanType.Kind() == reflect.Pointer {
+		scanType = scanType.Elem()
+	}
+	switch scanType {
+	case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}), reflect.TypeOf(sql.NullInt16{}), reflect.TypeOf(sql.NullByte{}):
+		return sqlColumnInt, true
+	case reflect.TypeOf(sql.NullFloat64{}), reflect.TypeOf(decimal.Decimal{}):
+		return sqlColumnFloat, true
+	case reflect.TypeOf(sql.NullBool{}):
+		return sqlColumnBool, true
+	case reflect.TypeOf(sql.NullString{}), reflect.TypeOf(big.Int{}):
+		// The integers of 128 and 256 bits don't fit in an int64, they are kept as text.
+		return sqlColumnString, true
+	case reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(time.Time{}):
+		return sqlColumnTime, true
+	}
+	switch scanType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sqlColumnInt, true
+	case reflect.Float32, reflect.Float64:
+		return sqlColumnFloat, true
+	case reflect.Bool:
+		return sqlColumnBool, true
+	case reflect.String:
+		return sqlColumnString, true
+	default:
+		if len(name) > 0 {
+			// The database describes the column, but with a type that isn't a number, a boolean or a time.
+			return sqlColumnString, true
+		}
+		return "", false
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeValue converts the values of the Go types returned by some drivers (like int32, float32 or decimal.Decimal
+// with ClickHouse) to the types used by the results: int64, uint64 and float64. The big integers that don't fit in an
+// int64 are returned as text.
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case nil, int64, uint64, float64, bool, string, []byte, time.Time:
+		return value
+	case float32:
+		// The float32 is converted through its shortest representation, so 0.1 remains 0.1 and not 0.10000000149011612.
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		return f
+	case decimal.Decimal:
+		return v.InexactFloat64()
+	case big.Int:
+		return normalizeValue(&v)
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		if v.IsInt64() {
+			return v.Int64()
+		}
+		return v.String()
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflectValue.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflectValue.Uint()
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float()
+	case reflect.Bool:
+		return reflectValue.Bool()
+	case reflect.String:
+		return reflectValue.String()
+	case reflect.Pointer:
+		if reflectValue.IsNil() {
+			return nil
+		}
+		return normalizeValue(reflectValue.Elem().Interface())
+	default:
+		return value
+	}
+}
+
+// valueTypeOf returns the type of column matching the value returned by the driver.
+func valueTypeOf(value any) sqlColumnType {
+	switch normalizeValue(value).(type) {
+	case int64, uint64:
+		return sqlColumnInt
+	case float64:
+		return sqlColumnFloat
+	case bool:
+		return sqlColumnBool
+	case time.Time:
+		return sqlColumnTime
+	default:
+		return sqlColumnString
+	}
+}
+
+// convertValue converts the value returned by the driver to the type of its column.
+func convertValue(value any, columnType sqlColumnType) (any, error) {
+	value = normalizeValue(value)
+	if value == nil {
+		return nil, nil
+	}
+	text, isText := value.(string)
+	if b, ok := value.([]byte); ok {
+		text, isText = string(b), true
+	}
+	switch columnType {
+	case sqlColumnInt:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case uint64:
+			if v > math.MaxInt64 {
+				return nil, fmt.Errorf("the value %d overflows an int64", v)
+			}
+			return int64(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int64(v), nil
+			}
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+		if isText {
+			return strconv.ParseInt(text, 10, 64)
+		}
+	case sqlColumnFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case uint64:
+			return float64(v), nil
+		}
+		if isText {
+			return strconv.ParseFloat(text, 64)
+		}
+	case sqlColumnBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case uint64:
+			return v != 0, nil
+		}
+		if isText {
+			return strconv.ParseBool(text)
+		}
+	case sqlColumnTime:
+		if v, ok := value.(time.Time); ok {
+			return v, nil
+		}
+		if isText {
+			for _, layout := range sqlTimeLayouts {
+				if t, err := time.Parse(layout, text); err == nil {
+					return t, nil
+				}
+			}
+		}
+	case sqlColumnBytes:
+		if b, ok := value.([]byte); ok {
+			return b, nil
+		}
+		if isText {
+			return []byte(text), nil
+		}
+	default:
+		if isText {
+			return text, nil
+		}
+		if v, ok := value.(time.Time); ok {
+			return v.Format(time.RFC3339Nano), nil
+		}
+		return fmt.Sprint(value), nil
+	}
+	return nil, fmt.Errorf("unable to convert the value %v of type %T to %s", value, value, columnType)
+}
+
+// valueSize estimates the size of the value once encoded.
+func valueSize(value any) int64 {
+	switch v := value.(type) {
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	default:
+		return 8
+	}
+}
+
+// sqlResultReader reads the rows of a query as typed values, up to the maximum number of rows and bytes of the guardrails.
+type sqlResultReader struct {
+	rows       *sql.Rows
+	guardrails datasourceSQL.Guardrails
+	columns    []sqlColumn
+	// resolved tells whether the type of each column is known.
+	resolved []bool
+	values   []any
+	scanArgs []any
+	count    int
+	size     int64
+}
+
+func newSQLResultReader(rows *sql.Rows, guardrails datasourceSQL.Guardrails) (*sqlResultReader, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	r := &sqlResultReader{
+		rows:       rows,
+		guardrails: guardrails,
+		columns:    make([]sqlColumn, len(columnTypes)),
+		resolved:   make([]bool, len(columnTypes)),
+		values:     make([]any, len(columnTypes)),
+		scanArgs:   make([]any, len(columnTypes)),
+	}
+	for i, columnType := range columnTypes {
+		r.columns[i].Name = columnType.Name()
+		r.columns[i].Type, r.resolved[i] = columnTypeOf(columnType)
+		r.scanArgs[i] = &r.values[i]
+	}
+	return r, nil
+}
+
+// next returns the next row, or nil once all the rows have been read.
+func (r *sqlResultReader) next() ([]any, error) {
+	if !r.rows.Next() {
+		return nil, r.rows.Err()
+	}
+	if r.count >= r.guardrails.MaxRows {
+		return nil, errResultTooLarge
+	}
+	if err := r.rows.Scan(r.scanArgs...); err != nil {
+		return nil, err
+	}
+	row := make([]any, len(r.values))
+	for i, value := range r.values {
+		if !r.resolved[i] && value != nil {
+			// The first value tells the type of the columns the database doesn't describe, like the expressions in SQLite.
+			r.columns[i].Type, r.resolved[i] = valueTypeOf(value), true
+		}
+		var err error
+		if row[i], err = convertValue(value, r.columns[i].Type); err != nil {
+			return nil, fmt.Errorf("column %q: %w", r.columns[i].Name, err)
+		}
+		r.size += valueSize(row[i])
+	}
+	if r.size > r.guardrails.MaxBytes {
+		return nil, errResultTooLarge
+	}
+	r.count++
+	return row, nil
+}
+
+// resolveColumns gives the string type to the columns whose type is still unknown, since they only had NULL values.
+func (r *sqlResultReader) resolveColumns() {
+	for i := range r.columns {
+		if !r.resolved[i] {
+			r.columns[i].Type, r.resolved[i] = sqlColumnString, true
+		}
+	}
+}
+
+// sqlResultOutput counts the bytes of the result, up to the maximum of the guardrails. The bytes are held until the
+// response is started, then they are sent as soon as they are written.
+type sqlResultOutput struct {
+	response    *echo.Response
+	contentType string
+	maxBytes    int64
+	size        int64
+	held        bytes.Buffer
+	started     bool
+}
+
+func newSQLResultOutput(response *echo.Response, format sqlResultFormat, guardrails datasourceSQL.Guardrails) *sqlResultOutput {
+	return &sqlResultOutput{response: response, contentType: format.contentType, maxBytes: guardrails.MaxBytes}
+}
+
+func (o *sqlResultOutput) Write(p []byte) (int, error) {
+	o.size += int64(len(p))
+	if o.size > o.maxBytes {
+		return 0, errResultTooLarge
+	}
+	if !o.started {
+		return o.held.Write(p)
+	}
+	return o.response.Write(p)
+}
+
+// error returns errResultTooLarge when the error comes from a result exceeding the maximum number of bytes, whatever
+// the way the encoder wrapped it.
+func (o *sqlResultOutput) error(err error) error {
+	if o.size > o.maxBytes {
+		return errResultTooLarge
+	}
+	return err
+}
+
+// start sends the status, the headers and the bytes held so far.
+func (o *sqlResultOutput) start() error {
+	o.started = true
+	o.response.Header().Set(echo.HeaderContentType, o.contentType)
+	if o.contentType == contentTypeCSV {
+		o.response.Header().Set(echo.HeaderContentDisposition, "attachment; filename=result.csv")
+	}
+	o.response.WriteHeader(http.StatusOK)
+	_, err := o.response.Write(o.held.Bytes())
+	o.held = bytes.Buffer{}
+	return err
+}
+
+// writeResult reads the rows and writes them in the format to the output. The first sqlResultHeldRows rows are read
+// and encoded before the response is started: the type of the columns the database doesn't describe is deduced from
+// their values, and the client still gets an error with its status when a small result exceeds the guardrails. When
+// there are more rows, the response is started and they are sent as soon as they are read, so an error occurring then
+// can only interrupt the response. Otherwise, the caller starts the response once the query is done.
+func writeResult(output *sqlResultOutput, rows *sql.Rows, guardrails datasourceSQL.Guardrails, format sqlResultFormat) error {
+	reader, err := newSQLResultReader(rows, guardrails)
+	if err != nil {
+		return err
+	}
+	var heldRows [][]any
+	for len(heldRows) < sqlResultHeldRows {
+		row, nextErr := reader.next()
+		if nextErr != nil {
+			return nextErr
+		}
+		if row == nil {
+			break
+		}
+		heldRows = append(heldRows, row)
+	}
+	reader.resolveColumns()
+
+	writer := format.newWriter(output)
+	if err = writer.writeColumns(reader.columns); err != nil {
+		return output.error(err)
+	}
+	for _, row := range heldRows {
+		if err = writer.writeRow(row); err != nil {
+			return output.error(err)
+		}
+	}
+	if len(heldRows) == sqlResultHeldRows {
+		// There may be more rows than the ones held, they are sent as they are read.
+		if err = output.start(); err != nil {
+			return err
+		}
+		for {
+			row, nextErr := reader.next()
+			if nextErr != nil {
+				return nextErr
+			}
+			if row == nil {
+				break
+			}
+			if err = writer.writeRow(row); err != nil {
+				return output.error(err)
+			}
+		}
+	}
+	if err = writer.close(); err != nil {
+		return output.error(err)
+	}
+	return nil
+}
+
+// writeCSVField writes the field as described in RFC 4180: it is quoted when it contains a separator, a quote or a
+// line break. A NULL value is an empty field, while an empty string is an empty quoted field.
+func writeCSVField(buf *bytes.Buffer, field string, isNull bool) {
+	if isNull {
+		return
+	}
+	if len(field) > 0 && !strings.ContainsAny(field, ",\"\r\n") {
+		buf.WriteString(field)
+		return
+	}
+	buf.WriteByte('"')
+	buf.WriteString(strings.ReplaceAll(field, `"`, `""`))
+	buf.WriteByte('"')
+}
+
+func writeCSVRecord(buf *bytes.Buffer, fields []string, isNull []bool) {
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeCSVField(buf, field, isNull != nil && isNull[i])
+	}
+	buf.WriteString("\r\n")
+}
+
+func formatCSVValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type csvResultWriter struct {
+	w      io.Writer
+	buf    bytes.Buffer
+	fields []string
+	isNull []bool
+}
+
+func newCSVResultWriter(w io.Writer) sqlResultWriter {
+	return &csvResultWriter{w: w}
+}
+
+func (c *csvResultWriter) writeColumns(columns []sqlColumn) error {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	c.fields = make([]string, len(columns))
+	c.isNull = make([]bool, len(columns))
+	writeCSVRecord(&c.buf, header, nil)
+	return c.flush()
+}
+
+func (c *csvResultWriter) writeRow(row []any) error {
+	for i, value := range row {
+		c.isNull[i] = value == nil
+		c.fields[i] = formatCSVValue(value)
+	}
+	writeCSVRecord(&c.buf, c.fields, c.isNull)
+	return c.flush()
+}
+
+func (c *csvResultWriter) close() error {
+	return nil
+}
+
+func (c *csvResultWriter) flush() error {
+	_, err := c.w.Write(c.buf.Bytes())
+	c.buf.Reset()
+	return err
+}
+
+// jsonResultWriter writes the result as a JSON frame holding the columns, then the rows:
+// {"columns":[{"name":"id","type":"int"}],"rows":[[1],[2]]}
+type jsonResultWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONResultWriter(w io.Writer) sqlResultWriter {
+	return &jsonResultWriter{w: w}
+}
+
+func (j *jsonResultWriter) writeColumns(columns []sqlColumn) error {
+	data, err := json.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	return j.write([]byte(`{"columns":`), data, []byte(`,"rows":[`))
+}
+
+func (j *jsonResultWriter) writeRow(row []any) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	j.count++
+	if j.count > 1 {
+		return j.write([]byte(","), data)
+	}
+	return j.write(data)
+}
+
+func (j *jsonResultWriter) close() error {
+	return j.write([]byte("]}"))
+}
+
+func (j *jsonResultWriter) write(parts ...[]byte) error {
+	for _, part := range parts {
+		if _, err := j.w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func arrowTypeOf(columnType sqlColumnType) arrow.DataType {
+	switch columnType {
+	case sqlColumnInt:
+		return arrow.PrimitiveTypes.Int64
+	case sqlColumnFloat:
+		return arrow.PrimitiveTypes.Float64
+	case sqlColumnBool:
+		return arrow.FixedWidthTypes.Boolean
+	case sqlColumnTime:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	case sqlColumnBytes:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func appendArrowValue(builder array.Builder, value any) {
+	if value == nil {
+		builder.AppendNull()
+		return
+	}
+	switch b := builder.(type) {
+	case *array.Int64Builder:
+		b.Append(value.(int64))
+	case *array.Float64Builder:
+		b.Append(value.(float64))
+	case *array.BooleanBuilder:
+		b.Append(value.(bool))
+	case *array.TimestampBuilder:
+		b.Append(arrow.Timestamp(value.(time.Time).UnixMicro()))
+	case *array.BinaryBuilder:
+		b.Append(value.([]byte))
+	case *array.StringBuilder:
+		b.Append(value.(string))
+	}
+}
+
+// arrowResultWriter writes the result as an Arrow IPC stream, made of record batches of arrowBatchSize rows.
+type arrowResultWriter struct {
+	w       io.Writer
+	builder *array.RecordBuilder
+	writer  *ipc.Writer
+	count   int
+}
+
+func newArrowResultWriter(w io.Writer) sqlResultWriter {
+	return &arrowResultWriter{w: w}
+}
+
+func (a *arrowResultWriter) writeColumns(columns []sqlColumn) error {
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = arrow.Field{Name: column.Name, Type: arrowTypeOf(column.Type), Nullable: true}
+	}
+	schema := arrow.NewSchema(fields, nil)
+	a.builder = array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	a.writer = ipc.NewWriter(a.w, ipc.WithSchema(schema))
+	return nil
+}
+
+func (a *arrowResultWriter) writeRow(row []any) error {
+	for i, value := range row {
+		appendArrowValue(a.builder.Field(i), value)
+	}
+	a.count++
+	if a.count < arrowBatchSize {
+		return nil
+	}
+	return a.writeBatch()
+}
+
+func (a *arrowResultWriter) writeBatch() error {
+	a.count = 0
+	record := a.builder.NewRecordBatch()
+	defer record.Release()
+	return a.writer.Write(record)
+}
+
+func (a *arrowResultWriter) close() error {
+	defer a.builder.Release()
+	if a.count > 0 {
+		if err := a.writeBatch(); err != nil {
+			return err
+		}
+	}
+	return a.writer.Close()
+}
@@ -0,0 +1,334 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	datasourceSQL "github.com/perses/perses/pkg/model/api/v1/datasource/sql"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestNegotiateSQLResultFormat(t *testing.T) {
+	testSuite := []struct {
+		accept   string
+		expected string
+	}{
+		{accept: "", expected: contentTypeCSV},
+		{accept: "*/*", expected: contentTypeCSV},
+		{accept: "application/json", expected: contentTypeJSON},
+		{accept: "application/vnd.apache.arrow.stream", expected: contentTypeArrowStream},
+		{accept: "text/html, Application/JSON;q=0.9, */*;q=0.1", expected: contentTypeJSON},
+		{accept: "application/vnd.apache.arrow.stream, application/json", expected: contentTypeArrowStream},
+	}
+	for _, test := range testSuite {
+		t.Run(test.accept, func(t *testing.T) {
+			assert.Equal(t, test.expected, negotiateSQLResultFormat(test.accept).contentType)
+		})
+	}
+}
+
+func TestReadResult(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	query := "SELECT 1 AS id, 'perses' AS name UNION ALL SELECT 2, NULL"
+
+	testSuite := []struct {
+		title      string
+		guardrails datasourceSQL.Guardrails
+		expected   *testSQLResult
+		err        error
+	}{
+		{
+			title:      "within the limits",
+			guardrails: datasourceSQL.Guardrails{MaxRows: 2, MaxBytes: 100},
+			expected: &testSQLResult{
+				columns: []sqlColumn{{Name: "id", Type: sqlColumnInt}, {Name: "name", Type: sqlColumnString}},
+				rows:    [][]any{{int64(1), "perses"}, {int64(2), nil}},
+			},
+		},
+		{
+			title:      "too many rows",
+			guardrails: datasourceSQL.Guardrails{MaxRows: 1, MaxBytes: 100},
+			err:        errResultTooLarge,
+		},
+		{
+			title:      "too many bytes",
+			guardrails: datasourceSQL.Guardrails{MaxRows: 2, MaxBytes: 10},
+			err:        errResultTooLarge,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			rows, queryErr := db.Query(query)
+			require.NoError(t, queryErr)
+			defer rows.Close()
+			result, readErr := readTestResult(rows, test.guardrails)
+			if test.err != nil {
+				assert.ErrorIs(t, readErr, test.err)
+				return
+			}
+			assert.NoError(t, readErr)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestReadResult_declaredTypes(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE metrics (name VARCHAR(20), value DOUBLE, enabled BOOLEAN, created TIMESTAMP, payload BLOB)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO metrics VALUES ('up', 1, true, '2025-01-02 03:04:05', x'0102'), (NULL, NULL, NULL, NULL, NULL)`)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT * FROM metrics")
+	require.NoError(t, err)
+	defer rows.Close()
+	result, err := readTestResult(rows, datasourceSQL.Guardrails{MaxRows: 10, MaxBytes: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, []sqlColumn{
+		{Name: "name", Type: sqlColumnString},
+		{Name: "value", Type: sqlColumnFloat},
+		{Name: "enabled", Type: sqlColumnBool},
+		{Name: "created", Type: sqlColumnTime},
+		{Name: "payload", Type: sqlColumnBytes},
+	}, result.columns)
+	require.Len(t, result.rows, 2)
+	assert.Equal(t, []any{"up", float64(1), true, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), []byte{1, 2}}, result.rows[0])
+	assert.Equal(t, []any{nil, nil, nil, nil, nil}, result.rows[1])
+}
+
+func TestColumnTypeOfName(t *testing.T) {
+	testSuite := []struct {
+		databaseTypeName string
+		scanType         reflect.Type
+		expected         sqlColumnType
+		resolved         bool
+	}{
+		// ClickHouse
+		{databaseTypeName: "UInt32", scanType: reflect.TypeOf(uint32(0)), expected: sqlColumnInt, resolved: true},
+		{databaseTypeName: "Int8", scanType: reflect.TypeOf(int8(0)), expected: sqlColumnInt, resolved: true},
+		{databaseTypeName: "Nullable(Int64)", scanType: reflect.TypeOf(new(int64)), expected: sqlColumnInt, resolved: true},
+		{databaseTypeName: "Float32", scanType: reflect.TypeOf(float32(0)), expected: sqlColumnFloat, resolved: true},
+		{databaseTypeName: "Decimal(10, 2)", scanType: reflect.TypeOf(decimal.Decimal{}), expected: sqlColumnFloat, resolved: true},
+		{databaseTypeName: "Decimal64(4)", scanType: reflect.TypeOf(decimal.Decimal{}), expected: sqlColumnFloat, resolved: true},
+		{databaseTypeName: "DateTime64(3, 'UTC')", scanType: reflect.TypeOf(time.Time{}), expected: sqlColumnTime, resolved: true},
+		{databaseTypeName: "Nullable(DateTime('Europe/Paris'))", scanType: reflect.TypeOf(new(time.Time)), expected: sqlColumnTime, resolved: true},
+		{databaseTypeName: "LowCardinality(Nullable(String))", scanType: reflect.TypeOf(new(string)), expected: sqlColumnString, resolved: true},
+		{databaseTypeName: "Int128", scanType: reflect.TypeOf(new(big.Int)), expected: sqlColumnString, resolved: true},
+		{databaseTypeName: "Bool", scanType: reflect.TypeOf(false), expected: sqlColumnBool, resolved: true},
+		// SQL Server
+		{databaseTypeName: "BIT", scanType: reflect.TypeOf(false), expected: sqlColumnBool, resolved: true},
+		{databaseTypeName: "DATETIME2", scanType: reflect.TypeOf(time.Time{}), expected: sqlColumnTime, resolved: true},
+		{databaseTypeName: "DATETIMEOFFSET", scanType: reflect.TypeOf(time.Time{}), expected: sqlColumnTime, resolved: true},
+		{databaseTypeName: "MONEY", scanType: reflect.TypeOf([]byte{}), expected: sqlColumnFloat, resolved: true},
+		{databaseTypeName: "NVARCHAR", scanType: reflect.TypeOf(""), expected: sqlColumnString, resolved: true},
+		// unknown names
+		{databaseTypeName: "HUGEINT", scanType: reflect.TypeOf(int64(0)), expected: sqlColumnInt, resolved: true},
+		{databaseTypeName: "JSON", scanType: reflect.TypeOf(sql.RawBytes{}), expected: sqlColumnString, resolved: true},
+		{databaseTypeName: "", scanType: reflect.TypeOf(new(any)).Elem(), resolved: false},
+	}
+	for _, test := range testSuite {
+		t.Run(test.databaseTypeName, func(t *testing.T) {
+			columnType, resolved := columnTypeOfName(test.databaseTypeName, test.scanType)
+			assert.Equal(t, test.resolved, resolved)
+			assert.Equal(t, test.expected, columnType)
+		})
+	}
+}
+
+func TestConvertValue_driverTypes(t *testing.T) {
+	testSuite := []struct {
+		title      string
+		value      any
+		columnType sqlColumnType
+		expected   any
+	}{
+		{title: "int8", value: int8(-3), columnType: sqlColumnInt, expected: int64(-3)},
+		{title: "int16", value: int16(300), columnType: sqlColumnInt, expected: int64(300)},
+		{title: "int32", value: int32(70000), columnType: sqlColumnInt, expected: int64(70000)},
+		{title: "uint8", value: uint8(200), columnType: sqlColumnInt, expected: int64(200)},
+		{title: "uint16", value: uint16(60000), columnType: sqlColumnInt, expected: int64(60000)},
+		{title: "uint32", value: uint32(4000000000), columnType: sqlColumnInt, expected: int64(4000000000)},
+		{title: "uint64", value: uint64(42), columnType: sqlColumnInt, expected: int64(42)},
+		{title: "pointer", value: func() *int32 { v := int32(7); return &v }(), columnType: sqlColumnInt, expected: int64(7)},
+		{title: "nil pointer", value: (*int32)(nil), columnType: sqlColumnInt, expected: nil},
+		{title: "float32", value: float32(0.1), columnType: sqlColumnFloat, expected: 0.1},
+		{title: "decimal", value: decimal.RequireFromString("12.34"), columnType: sqlColumnFloat, expected: 12.34},
+		{title: "money as text", value: []byte("12.3400"), columnType: sqlColumnFloat, expected: 12.34},
+		{title: "bit", value: uint8(1), columnType: sqlColumnBool, expected: true},
+		{title: "big integer", value: new(big.Int).Lsh(big.NewInt(1), 100), columnType: sqlColumnString, expected: "1267650600228229401496703205376"},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := convertValue(test.value, test.columnType)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestValueTypeOf_driverTypes(t *testing.T) {
+	assert.Equal(t, sqlColumnInt, valueTypeOf(int32(1)))
+	assert.Equal(t, sqlColumnInt, valueTypeOf(uint8(1)))
+	assert.Equal(t, sqlColumnFloat, valueTypeOf(float32(1)))
+	assert.Equal(t, sqlColumnFloat, valueTypeOf(decimal.NewFromInt(1)))
+	assert.Equal(t, sqlColumnString, valueTypeOf("1"))
+}
+
+// testSQLResult is a result entirely read, to check the values of its rows.
+type testSQLResult struct {
+	columns []sqlColumn
+	rows    [][]any
+}
+
+func readTestResult(rows *sql.Rows, guardrails datasourceSQL.Guardrails) (*testSQLResult, error) {
+	reader, err := newSQLResultReader(rows, guardrails)
+	if err != nil {
+		return nil, err
+	}
+	result := &testSQLResult{rows: [][]any{}}
+	for {
+		row, nextErr := reader.next()
+		if nextErr != nil {
+			return nil, nextErr
+		}
+		if row == nil {
+			break
+		}
+		result.rows = append(result.rows, row)
+	}
+	reader.resolveColumns()
+	result.columns = reader.columns
+	return result, nil
+}
+
+func encodeTestResult(t *testing.T, newWriter func(w io.Writer) sqlResultWriter, result *testSQLResult) []byte {
+	buf := &bytes.Buffer{}
+	writer := newWriter(buf)
+	require.NoError(t, writer.writeColumns(result.columns))
+	for _, row := range result.rows {
+		require.NoError(t, writer.writeRow(row))
+	}
+	require.NoError(t, writer.close())
+	return buf.Bytes()
+}
+
+func newTestSQLResult() *testSQLResult {
+	return &testSQLResult{
+		columns: []sqlColumn{
+			{Name: "id", Type: sqlColumnInt},
+			{Name: "name", Type: sqlColumnString},
+			{Name: "created", Type: sqlColumnTime},
+		},
+		rows: [][]any{
+			{int64(1), "perses, \"the\"\ndashboard", time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))},
+			{int64(2), "", nil},
+			{nil, nil, time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC)},
+		},
+	}
+}
+
+func TestCSVResultWriter(t *testing.T) {
+	result := encodeTestResult(t, newCSVResultWriter, newTestSQLResult())
+	expected := "id,name,created\r\n" +
+		"1,\"perses, \"\"the\"\"\ndashboard\",2025-01-02T03:04:05+01:00\r\n" +
+		"2,\"\",\r\n" +
+		",,2025-01-02T03:04:05.000006Z\r\n"
+	assert.Equal(t, expected, string(result))
+}
+
+func TestJSONResultWriter(t *testing.T) {
+	result := encodeTestResult(t, newJSONResultWriter, newTestSQLResult())
+	expected := `{
+  "columns": [{"name": "id", "type": "int"}, {"name": "name", "type": "string"}, {"name": "created", "type": "time"}],
+  "rows": [
+    [1, "perses, \"the\"\ndashboard", "2025-01-02T03:04:05+01:00"],
+    [2, "", null],
+    [null, null, "2025-01-02T03:04:05.000006Z"]
+  ]
+}`
+	assert.JSONEq(t, expected, string(result))
+	empty := encodeTestResult(t, newJSONResultWriter, &testSQLResult{columns: []sqlColumn{{Name: "id", Type: sqlColumnInt}}})
+	assert.Equal(t, `{"columns":[{"name":"id","type":"int"}],"rows":[]}`, string(empty))
+}
+
+func TestArrowResultWriter(t *testing.T) {
+	result := encodeTestResult(t, newArrowResultWriter, newTestSQLResult())
+
+	reader, err := ipc.NewReader(bytes.NewReader(result))
+	require.NoError(t, err)
+	defer reader.Release()
+	schema := reader.Schema()
+	require.Len(t, schema.Fields(), 3)
+	assert.Equal(t, arrow.PrimitiveTypes.Int64, schema.Field(0).Type)
+	assert.Equal(t, arrow.BinaryTypes.String, schema.Field(1).Type)
+	assert.Equal(t, &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, schema.Field(2).Type)
+
+	require.True(t, reader.Next())
+	record := reader.RecordBatch()
+	require.EqualValues(t, 3, record.NumRows())
+	ids := record.Column(0).(*array.Int64)
+	names := record.Column(1).(*array.String)
+	created := record.Column(2).(*array.Timestamp)
+	assert.Equal(t, int64(2), ids.Value(1))
+	assert.True(t, ids.IsNull(2))
+	assert.Equal(t, "perses, \"the\"\ndashboard", names.Value(0))
+	assert.Equal(t, "", names.Value(1))
+	assert.True(t, names.IsValid(1))
+	assert.True(t, names.IsNull(2))
+	assert.True(t, created.IsNull(1))
+	assert.Equal(t, time.Date(2025, 1, 2, 2, 4, 5, 0, time.UTC), created.Value(0).ToTime(arrow.Microsecond))
+	assert.False(t, reader.Next())
+	assert.NoError(t, reader.Err())
+}
+
+func TestArrowResultWriter_empty(t *testing.T) {
+	result := encodeTestResult(t, newArrowResultWriter, &testSQLResult{columns: []sqlColumn{{Name: "id", Type: sqlColumnInt}}})
+	reader, err := ipc.NewReader(bytes.NewReader(result))
+	require.NoError(t, err)
+	defer reader.Release()
+	assert.Equal(t, "id", reader.Schema().Field(0).Name)
+	assert.False(t, reader.Next())
+}
+
+func TestArrowResultWriter_batches(t *testing.T) {
+	result := &testSQLResult{columns: []sqlColumn{{Name: "id", Type: sqlColumnInt}}}
+	for i := 0; i < arrowBatchSize+1; i++ {
+		result.rows = append(result.rows, []any{int64(i)})
+	}
+	reader, err := ipc.NewReader(bytes.NewReader(encodeTestResult(t, newArrowResultWriter, result)))
+	require.NoError(t, err)
+	defer reader.Release()
+	require.True(t, reader.Next())
+	assert.EqualValues(t, arrowBatchSize, reader.RecordBatch().NumRows())
+	require.True(t, reader.Next())
+	assert.EqualValues(t, 1, reader.RecordBatch().NumRows())
+	assert.False(t, reader.Next())
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+type endpoint struct {
+	service       accesstoken.Service
+	authz         authorization.Authorization
+	readonly      bool
+	caseSensitive bool
+}
+
+// NewEndpoint returns the endpoints managing the access tokens of the users and of the service accounts.
+// A user manages its own tokens only. The tokens of a service account are managed by whoever can update it.
+func NewEndpoint(service accesstoken.Service, authz authorization.Authorization, readonly bool, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		service:       service,
+		authz:         authz,
+		readonly:      readonly,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	subjectPaths := map[v1.Kind]string{
+		v1.KindUser:           utils.PathUser,
+		v1.KindServiceAccount: utils.PathServiceAccount,
+	}
+	for kind, path := range subjectPaths {
+		group := g.Group(fmt.Sprintf("/%s/:%s/%s", path, utils.ParamName, utils.PathAccessToken))
+		if !e.readonly {
+			group.POST("", e.Create(kind), false)
+			group.DELETE(fmt.Sprintf("/:%s", utils.ParamID), e.Delete(kind), false)
+		}
+		group.GET("", e.List(kind), false)
+	}
+}
+
+func (e *endpoint) Create(kind v1.Kind) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		return e.create(ctx, kind)
+	}
+}
+
+func (e *endpoint) Delete(kind v1.Kind) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		return e.delete(ctx, kind)
+	}
+}
+
+func (e *endpoint) List(kind v1.Kind) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		return e.list(ctx, kind)
+	}
+}
+
+func (e *endpoint) create(ctx echo.Context, kind v1.Kind) error {
+	subject, err := e.checkPermission(ctx, kind, role.UpdateAction)
+	if err != nil {
+		return err
+	}
+	// Otherwise, a leaked token could be used to create new ones and survive its revocation.
+	if providerInfo, providerErr := e.authz.GetProviderInfo(ctx); providerErr != nil || providerInfo.ProviderKind == utils.AuthKindAccessToken {
+		return apiInterface.HandleForbiddenError("an access token can't be used to create another access token")
+	}
+	request := &v1.AccessTokenRequest{}
+	if bindErr := ctx.Bind(request); bindErr != nil {
+		return apiInterface.HandleBadRequestError(bindErr.Error())
+	}
+	token, err := e.service.Create(subject, request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, token)
+}
+
+func (e *endpoint) delete(ctx echo.Context, kind v1.Kind) error {
+	subject, err := e.checkPermission(ctx, kind, role.UpdateAction)
+	if err != nil {
+		return err
+	}
+	if deleteErr := e.service.Delete(subject, ctx.Param(utils.ParamID)); deleteErr != nil {
+		return deleteErr
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (e *endpoint) list(ctx echo.Context, kind v1.Kind) error {
+	subject, err := e.checkPermission(ctx, kind, role.ReadAction)
+	if err != nil {
+		return err
+	}
+	tokens, err := e.service.List(subject)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, tokens)
+}
+
+// checkPermission returns the owner of the tokens designated by the path, once it is checked that the requester can
+// manage its tokens.
+func (e *endpoint) checkPermission(ctx echo.Context, kind v1.Kind, action role.Action) (v1.Subject, error) {
+	name := toolbox.ExtractParameters(ctx, e.caseSensitive).Name
+	if kind == v1.KindServiceAccount {
+		if !e.authz.HasPermission(ctx, action, v1.WildcardProject, role.ServiceAccountScope) {
+			return v1.Subject{}, apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", action, role.ServiceAccountScope))
+		}
+		return v1.Subject{Kind: v1.KindServiceAccount, Name: name}, nil
+	}
+	username, err := e.authz.GetUsername(ctx)
+	if err != nil {
+		return v1.Subject{}, apiInterface.HandleUnauthorizedError("failed to retrieve username from context")
+	}
+	if username != name {
+		return v1.Subject{}, apiInterface.HandleForbiddenError("you can only manage your own access tokens")
+	}
+	return v1.Subject{Kind: v1.KindUser, Name: name}, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type dao struct {
+	accesstoken.DAO
+	client databaseModel.DAO
+}
+
+func NewDAO(persesDAO databaseModel.DAO) accesstoken.DAO {
+	return &dao{
+		client: persesDAO,
+	}
+}
+
+func (d *dao) Create(token *databaseModel.AccessToken) error {
+	return d.client.CreateAccessToken(token)
+}
+
+func (d *dao) Update(token *databaseModel.AccessToken) error {
+	return d.client.UpdateAccessToken(token)
+}
+
+func (d *dao) Get(id string) (*databaseModel.AccessToken, error) {
+	tokens, err := d.client.QueryAccessTokens(&databaseModel.AccessTokenQuery{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return tokens[0], nil
+}
+
+func (d *dao) List(subject v1.Subject) ([]*databaseModel.AccessToken, error) {
+	return d.client.QueryAccessTokens(&databaseModel.AccessTokenQuery{SubjectKind: subject.Kind, SubjectName: subject.Name})
+}
+
+func (d *dao) Delete(id string) error {
+	return d.client.DeleteAccessTokens(&databaseModel.AccessTokenQuery{ID: id})
+}
+
+func (d *dao) DeleteAll(subject v1.Subject) error {
+	return d.client.DeleteAccessTokens(&databaseModel.AccessTokenQuery{SubjectKind: subject.Kind, SubjectName: subject.Name})
+}
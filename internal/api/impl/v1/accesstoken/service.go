@@ -0,0 +1,117 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	accesstoken.Service
+	dao               accesstoken.DAO
+	userDAO           user.DAO
+	serviceAccountDAO serviceaccount.DAO
+}
+
+func NewService(dao accesstoken.DAO, userDAO user.DAO, serviceAccountDAO serviceaccount.DAO) accesstoken.Service {
+	return &service{
+		dao:               dao,
+		userDAO:           userDAO,
+		serviceAccountDAO: serviceAccountDAO,
+	}
+}
+
+func (s *service) Create(subject v1.Subject, request *v1.AccessTokenRequest) (*v1.CreatedAccessToken, error) {
+	if err := s.checkSubject(subject); err != nil {
+		return nil, err
+	}
+	id := uuid.NewString()
+	token, secret, err := crypto.NewAccessToken(id)
+	if err != nil {
+		logrus.WithError(err).Error("unable to generate an access token")
+		return nil, apiInterface.InternalError
+	}
+	hash, err := crypto.HashAndSalt([]byte(secret))
+	if err != nil {
+		logrus.WithError(err).Error("unable to generate the hash of the access token")
+		return nil, apiInterface.InternalError
+	}
+	entity := &databaseModel.AccessToken{
+		AccessToken: v1.AccessToken{
+			ID:          id,
+			Name:        request.Name,
+			Subject:     subject,
+			Permissions: request.Permissions,
+			CreatedAt:   time.Now().UTC(),
+			ExpiresAt:   request.ExpiresAt,
+		},
+		Hash: string(hash),
+	}
+	if createErr := s.dao.Create(entity); createErr != nil {
+		return nil, createErr
+	}
+	return &v1.CreatedAccessToken{AccessToken: entity.AccessToken, Token: token}, nil
+}
+
+func (s *service) Delete(subject v1.Subject, id string) error {
+	token, err := s.dao.Get(id)
+	if err != nil {
+		return err
+	}
+	// The token of someone else is reported as missing, so its ID can't be guessed.
+	if token.Subject != subject {
+		return apiInterface.HandleNotFoundError(fmt.Sprintf("access token %q not found", id))
+	}
+	return s.dao.Delete(id)
+}
+
+func (s *service) List(subject v1.Subject) ([]*v1.AccessToken, error) {
+	if err := s.checkSubject(subject); err != nil {
+		return nil, err
+	}
+	tokens, err := s.dao.List(subject)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*v1.AccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, &token.AccessToken)
+	}
+	return result, nil
+}
+
+// checkSubject returns a 404 when the owner of the tokens doesn't exist.
+func (s *service) checkSubject(subject v1.Subject) error {
+	var err error
+	switch subject.Kind {
+	case v1.KindUser:
+		_, err = s.userDAO.Get(subject.Name)
+	case v1.KindServiceAccount:
+		_, err = s.serviceAccountDAO.Get(subject.Name)
+	default:
+		err = apiInterface.HandleBadRequestError(fmt.Sprintf("%q can't own an access token", subject.Kind))
+	}
+	return err
+}
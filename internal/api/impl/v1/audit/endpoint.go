@@ -0,0 +1,112 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const defaultLimit = 100
+
+type endpoint struct {
+	auditor audit.Auditor
+	authz   authorization.Authorization
+}
+
+func NewEndpoint(auditor audit.Auditor, authz authorization.Authorization) route.Endpoint {
+	return &endpoint{
+		auditor: auditor,
+		authz:   authz,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if !e.auditor.IsEnabled() {
+		return
+	}
+	g.GET(fmt.Sprintf("/%s", utils.PathAudit), e.List, false)
+}
+
+func (e *endpoint) List(ctx echo.Context) error {
+	if e.authz.IsEnabled() {
+		if ok := e.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, role.AuditScope); !ok {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' scope", role.ReadAction, role.AuditScope))
+		}
+	}
+	query, err := parseQuery(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := e.auditor.Query(query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func parseQuery(ctx echo.Context) (*databaseModel.AuditQuery, error) {
+	query := &databaseModel.AuditQuery{
+		Project: ctx.QueryParam("project"),
+		User:    ctx.QueryParam("user"),
+		Limit:   defaultLimit,
+	}
+	if kind := ctx.QueryParam("kind"); len(kind) > 0 {
+		k, err := v1.GetKind(kind)
+		if err != nil {
+			return nil, apiInterface.HandleBadRequestError(err.Error())
+		}
+		query.Kind = *k
+	}
+	var err error
+	if query.From, err = parseTime("from", ctx.QueryParam("from")); err != nil {
+		return nil, err
+	}
+	if query.To, err = parseTime("to", ctx.QueryParam("to")); err != nil {
+		return nil, err
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return nil, apiInterface.HandleBadRequestError("'from' must be before 'to'")
+	}
+	if limit := ctx.QueryParam("limit"); len(limit) > 0 {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit <= 0 {
+			return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("'limit' must be a positive integer, got %q", limit))
+		}
+	}
+	return query, nil
+}
+
+func parseTime(name string, value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, apiInterface.HandleBadRequestError(fmt.Sprintf("%q is not a valid RFC3339 time for the parameter '%s'", value, name))
+	}
+	return t, nil
+}
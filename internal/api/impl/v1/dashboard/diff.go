@@ -0,0 +1,138 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpRemove  = "remove"
+	patchOpReplace = "replace"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// diff returns the JSON patch (RFC 6902) transforming the JSON representation of `from` into the one of `to`.
+func diff(from any, to any) ([]v1.JSONPatchOperation, error) {
+	fromDoc, err := toGenericJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := toGenericJSON(to)
+	if err != nil {
+		return nil, err
+	}
+	patch := []v1.JSONPatchOperation{}
+	return patch, diffValue("", fromDoc, toDoc, &patch)
+}
+
+func toGenericJSON(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	return result, json.Unmarshal(data, &result)
+}
+
+func diffValue(path string, from any, to any, patch *[]v1.JSONPatchOperation) error {
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			return diffObject(path, fromValue, toValue, patch)
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			return diffArray(path, fromValue, toValue, patch)
+		}
+	}
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return appendOperation(patch, patchOpReplace, path, to)
+}
+
+func diffObject(path string, from map[string]any, to map[string]any, patch *[]v1.JSONPatchOperation) error {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	// Sorting the keys makes the patch deterministic.
+	sort.Strings(keys)
+	for _, key := range keys {
+		keyPath := fmt.Sprintf("%s/%s", path, pointerEscaper.Replace(key))
+		fromValue, isInFrom := from[key]
+		toValue, isInTo := to[key]
+		var err error
+		switch {
+		case !isInTo:
+			err = appendOperation(patch, patchOpRemove, keyPath, nil)
+		case !isInFrom:
+			err = appendOperation(patch, patchOpAdd, keyPath, toValue)
+		default:
+			err = diffValue(keyPath, fromValue, toValue, patch)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func diffArray(path string, from []any, to []any, patch *[]v1.JSONPatchOperation) error {
+	commonLength := min(len(from), len(to))
+	for i := 0; i < commonLength; i++ {
+		if err := diffValue(fmt.Sprintf("%s/%d", path, i), from[i], to[i], patch); err != nil {
+			return err
+		}
+	}
+	for i := commonLength; i < len(to); i++ {
+		if err := appendOperation(patch, patchOpAdd, fmt.Sprintf("%s/%d", path, i), to[i]); err != nil {
+			return err
+		}
+	}
+	// Elements are removed from the end, so the index of the remaining ones doesn't change while the patch is applied.
+	for i := len(from) - 1; i >= commonLength; i-- {
+		if err := appendOperation(patch, patchOpRemove, fmt.Sprintf("%s/%d", path, i), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendOperation(patch *[]v1.JSONPatchOperation, op string, path string, value any) error {
+	operation := v1.JSONPatchOperation{Op: op, Path: path}
+	if op != patchOpRemove {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		operation.Value = data
+	}
+	*patch = append(*patch, operation)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	testSuite := []struct {
+		title  string
+		from   string
+		to     string
+		result []v1.JSONPatchOperation
+	}{
+		{
+			title:  "identical documents",
+			from:   `{"a": 1, "b": [1, 2]}`,
+			to:     `{"a": 1, "b": [1, 2]}`,
+			result: []v1.JSONPatchOperation{},
+		},
+		{
+			title: "add, remove and replace a field",
+			from:  `{"a": 1, "b": "foo", "c/d": true}`,
+			to:    `{"a": 2, "c/d": true, "e~": {"f": null}}`,
+			result: []v1.JSONPatchOperation{
+				{Op: patchOpReplace, Path: "/a", Value: json.RawMessage(`2`)},
+				{Op: patchOpRemove, Path: "/b"},
+				{Op: patchOpAdd, Path: "/e~0", Value: json.RawMessage(`{"f":null}`)},
+			},
+		},
+		{
+			title: "nested changes",
+			from:  `{"spec": {"panels": {"p1": {"kind": "Panel", "title": "old"}}}}`,
+			to:    `{"spec": {"panels": {"p1": {"kind": "Panel", "title": "new"}}}}`,
+			result: []v1.JSONPatchOperation{
+				{Op: patchOpReplace, Path: "/spec/panels/p1/title", Value: json.RawMessage(`"new"`)},
+			},
+		},
+		{
+			title: "array growing",
+			from:  `{"a": [1, 2]}`,
+			to:    `{"a": [1, 3, 4, 5]}`,
+			result: []v1.JSONPatchOperation{
+				{Op: patchOpReplace, Path: "/a/1", Value: json.RawMessage(`3`)},
+				{Op: patchOpAdd, Path: "/a/2", Value: json.RawMessage(`4`)},
+				{Op: patchOpAdd, Path: "/a/3", Value: json.RawMessage(`5`)},
+			},
+		},
+		{
+			title: "array shrinking",
+			from:  `{"a": [1, 2, 3]}`,
+			to:    `{"a": [1]}`,
+			result: []v1.JSONPatchOperation{
+				{Op: patchOpRemove, Path: "/a/2"},
+				{Op: patchOpRemove, Path: "/a/1"},
+			},
+		},
+		{
+			title: "type change",
+			from:  `{"a": {"b": 1}}`,
+			to:    `{"a": [1]}`,
+			result: []v1.JSONPatchOperation{
+				{Op: patchOpReplace, Path: "/a", Value: json.RawMessage(`[1]`)},
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := diff(json.RawMessage(test.from), json.RawMessage(test.to))
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
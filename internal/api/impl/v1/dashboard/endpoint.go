@@ -16,26 +16,42 @@
 package dashboard
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/toolbox"
 	"github.com/perses/perses/internal/api/utils"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 )
 
 type endpoint struct {
-	toolbox  toolbox.Toolbox[*v1.Dashboard, *dashboard.Query]
-	readonly bool
+	toolbox           toolbox.Toolbox[*v1.Dashboard, *dashboard.Query]
+	service           dashboard.Service
+	authz             authorization.Authorization
+	auditor           audit.Auditor
+	readonly          bool
+	caseSensitive     bool
+	isRevisionEnabled bool
 }
 
-func NewEndpoint(service dashboard.Service, authz authorization.Authorization, readonly bool, caseSensitive bool) route.Endpoint {
+func NewEndpoint(service dashboard.Service, authz authorization.Authorization, auditor audit.Auditor, readonly bool, caseSensitive bool, isRevisionEnabled bool) route.Endpoint {
 	return &endpoint{
-		toolbox:  toolbox.New[*v1.Dashboard, *v1.Dashboard, *dashboard.Query](service, authz, v1.KindDashboard, caseSensitive),
-		readonly: readonly,
+		toolbox:           toolbox.New[*v1.Dashboard, *v1.Dashboard, *dashboard.Query](service, authz, auditor, v1.KindDashboard, caseSensitive),
+		service:           service,
+		authz:             authz,
+		auditor:           auditor,
+		readonly:          readonly,
+		caseSensitive:     caseSensitive,
+		isRevisionEnabled: isRevisionEnabled,
 	}
 }
 
@@ -51,6 +67,15 @@ func (e *endpoint) CollectRoutes(g *route.Group) {
 	group.GET("", e.List, false)
 	subGroup.GET("", e.List, false)
 	subGroup.GET(fmt.Sprintf("/:%s", utils.ParamName), e.Get, false)
+	if e.isRevisionEnabled {
+		revisionGroup := subGroup.Group(fmt.Sprintf("/:%s/%s", utils.ParamName, utils.PathRevision))
+		if !e.readonly {
+			revisionGroup.POST(fmt.Sprintf("/:%s/restore", utils.ParamVersion), e.RestoreRevision, false)
+		}
+		revisionGroup.GET("", e.ListRevisions, false)
+		revisionGroup.GET("/diff", e.DiffRevisions, false)
+		revisionGroup.GET(fmt.Sprintf("/:%s", utils.ParamVersion), e.GetRevision, false)
+	}
 }
 
 func (e *endpoint) Create(ctx echo.Context) error {
@@ -75,3 +100,108 @@ func (e *endpoint) List(ctx echo.Context) error {
 	q := &dashboard.Query{}
 	return e.toolbox.List(ctx, q)
 }
+
+func (e *endpoint) ListRevisions(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	if err := e.checkPermission(ctx, parameters, role.ReadAction); err != nil {
+		return err
+	}
+	revisions, err := e.service.ListRevisions(parameters)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, revisions)
+}
+
+func (e *endpoint) GetRevision(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	if err := e.checkPermission(ctx, parameters, role.ReadAction); err != nil {
+		return err
+	}
+	version, err := parseVersion(utils.ParamVersion, ctx.Param(utils.ParamVersion))
+	if err != nil {
+		return err
+	}
+	revision, err := e.service.GetRevision(parameters, version)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, revision)
+}
+
+func (e *endpoint) DiffRevisions(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	if err := e.checkPermission(ctx, parameters, role.ReadAction); err != nil {
+		return err
+	}
+	from, err := parseVersion("from", ctx.QueryParam("from"))
+	if err != nil {
+		return err
+	}
+	var to uint64
+	if toParam := ctx.QueryParam("to"); len(toParam) > 0 {
+		if to, err = parseVersion("to", toParam); err != nil {
+			return err
+		}
+	} else {
+		// By default, the revision is compared with the current version of the dashboard.
+		current, getErr := e.service.Get(parameters)
+		if getErr != nil {
+			return getErr
+		}
+		to = current.Metadata.Version
+	}
+	result, err := e.service.DiffRevisions(parameters, from, to)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *endpoint) RestoreRevision(ctx echo.Context) error {
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	if err := e.checkPermission(ctx, parameters, role.UpdateAction); err != nil {
+		return err
+	}
+	version, err := parseVersion(utils.ParamVersion, ctx.Param(utils.ParamVersion))
+	if err != nil {
+		return err
+	}
+	if parameters.Version, err = toolbox.ExtractIfMatchVersion(ctx); err != nil {
+		return err
+	}
+	entity, err := e.service.RestoreRevision(ctx, parameters, version)
+	if errors.Is(err, apiInterface.UnchangedError) {
+		// The dashboard is already in the state of the revision.
+		toolbox.SetETag(ctx, entity)
+		return ctx.JSON(http.StatusOK, entity)
+	}
+	event := audit.NewEvent(v1.AuditOriginAPI, role.UpdateAction, v1.KindDashboard, parameters.Project, parameters.Name)
+	if err == nil {
+		audit.SetUpdatedVersion(event, entity)
+	}
+	e.auditor.Record(ctx, event, err)
+	if err != nil {
+		return err
+	}
+	toolbox.SetETag(ctx, entity)
+	return ctx.JSON(http.StatusOK, entity)
+}
+
+func (e *endpoint) checkPermission(ctx echo.Context, parameters apiInterface.Parameters, action role.Action) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	if ok := e.authz.HasResourcePermission(ctx, action, parameters.Project, role.DashboardScope, parameters.Name); !ok {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, parameters.Project, role.DashboardScope))
+	}
+	return nil
+}
+
+func parseVersion(name string, value string) (uint64, error) {
+	version, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, apiInterface.HandleBadRequestError(fmt.Sprintf("invalid %s %q, it must be a positive integer", name, value))
+	}
+	return version, nil
+}
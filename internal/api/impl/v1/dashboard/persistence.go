@@ -40,7 +40,7 @@ func (d *dao) Create(entity *v1.Dashboard) error {
 }
 
 func (d *dao) Update(entity *v1.Dashboard) error {
-	return d.client.Upsert(entity)
+	return d.client.Update(entity)
 }
 
 func (d *dao) Delete(project string, name string) error {
@@ -48,7 +48,10 @@ func (d *dao) Delete(project string, name string) error {
 }
 
 func (d *dao) DeleteAll(project string) error {
-	return d.client.DeleteByQuery(&dashboard.Query{Project: project})
+	if err := d.client.DeleteByQuery(&dashboard.Query{Project: project}); err != nil {
+		return err
+	}
+	return d.client.DeleteRevisions(d.kind, v1.NewProjectMetadata(project, ""))
 }
 
 func (d *dao) Get(project string, name string) (*v1.Dashboard, error) {
@@ -79,3 +82,30 @@ func (d *dao) MetadataList(q *dashboard.Query) ([]api.Entity, error) {
 func (d *dao) RawMetadataList(q *dashboard.Query) ([]json.RawMessage, error) {
 	return d.client.RawMetadataQuery(q, d.kind)
 }
+
+func (d *dao) CreateRevision(entity *v1.Dashboard, author string, maxRevisions int) error {
+	return d.client.CreateRevision(entity, author, maxRevisions)
+}
+
+func (d *dao) GetRevision(project string, name string, version uint64) (*v1.DashboardRevision, error) {
+	revision, err := d.client.GetRevision(d.kind, v1.NewProjectMetadata(project, name), version)
+	if err != nil {
+		return nil, err
+	}
+	entity := &v1.Dashboard{}
+	if unmarshalErr := json.Unmarshal(revision.Entity, entity); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return &v1.DashboardRevision{
+		RevisionMetadata: revision.RevisionMetadata,
+		Dashboard:        entity,
+	}, nil
+}
+
+func (d *dao) ListRevisions(project string, name string) ([]*v1.RevisionMetadata, error) {
+	return d.client.ListRevisions(d.kind, v1.NewProjectMetadata(project, name))
+}
+
+func (d *dao) DeleteRevisions(project string, name string) error {
+	return d.client.DeleteRevisions(d.kind, v1.NewProjectMetadata(project, name))
+}
@@ -14,17 +14,23 @@
 package dashboard
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/search"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -40,9 +46,13 @@ type service struct {
 	isDatasourceDisable bool
 	isVariableDisable   bool
 	customRules         []*config.CustomLintRule
+	revision            config.DashboardRevision
+	authz               authorization.Authorization
+	index               search.Index
+	broadcaster         watch.Broadcaster
 }
 
-func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema) dashboard.Service {
+func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema, authz authorization.Authorization, index search.Index, broadcaster watch.Broadcaster) dashboard.Service {
 	return &service{
 		dao:                 dao,
 		globalVarDAO:        globalVarDAO,
@@ -51,18 +61,22 @@ func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariabl
 		isDatasourceDisable: cfg.Datasource.DisableLocal,
 		isVariableDisable:   cfg.Variable.DisableLocal,
 		customRules:         cfg.Dashboard.CustomLintRules,
+		revision:            cfg.Dashboard.Revision,
+		authz:               authz,
+		index:               index,
+		broadcaster:         broadcaster,
 	}
 }
 
-func (s *service) Create(_ echo.Context, entity *v1.Dashboard) (*v1.Dashboard, error) {
+func (s *service) Create(ctx echo.Context, entity *v1.Dashboard) (*v1.Dashboard, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.create(copyEntity)
+	return s.create(ctx, copyEntity)
 }
 
-func (s *service) create(entity *v1.Dashboard) (*v1.Dashboard, error) {
+func (s *service) create(ctx echo.Context, entity *v1.Dashboard) (*v1.Dashboard, error) {
 	// verify this new dashboard passes the validation
 	if err := s.Validate(entity); err != nil {
 		return nil, err
@@ -73,18 +87,25 @@ func (s *service) create(entity *v1.Dashboard) (*v1.Dashboard, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.index.Invalidate()
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
+	// A dashboard with the same name may have existed before. Its history must not be mixed with the new dashboard.
+	if err := s.dao.DeleteRevisions(entity.Metadata.Project, entity.Metadata.Name); err != nil {
+		logrus.WithError(err).Errorf("unable to remove the previous revisions of the dashboard %q", entity.Metadata.Name)
+	}
+	s.createRevision(ctx, entity)
 	return entity, nil
 }
 
-func (s *service) Update(_ echo.Context, entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
+func (s *service) Update(ctx echo.Context, entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
 	copyEntity, err := deep.Copy(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy entity: %w", err)
 	}
-	return s.update(copyEntity, parameters)
+	return s.update(ctx, copyEntity, parameters)
 }
 
-func (s *service) update(entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
+func (s *service) update(ctx echo.Context, entity *v1.Dashboard, parameters apiInterface.Parameters) (*v1.Dashboard, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in dashboard %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
@@ -106,16 +127,42 @@ func (s *service) update(entity *v1.Dashboard, parameters apiInterface.Parameter
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
+	// Applying the same dashboard again (by the provisioning for example) must neither bump its version nor record a
+	// revision, otherwise the history would be filled with identical states.
+	if unchanged, unchangedErr := isUnchanged(oldEntity, entity); unchangedErr != nil {
+		return nil, unchangedErr
+	} else if unchanged {
+		return oldEntity, apiInterface.UnchangedError
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the dashboard %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.index.Invalidate()
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
+	s.createRevision(ctx, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	// The dashboard is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.index.Invalidate()
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	if err := s.dao.DeleteRevisions(parameters.Project, parameters.Name); err != nil {
+		logrus.WithError(err).Errorf("unable to remove the revisions of the dashboard %q", parameters.Name)
+	}
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Dashboard, error) {
@@ -127,7 +174,9 @@ func (s *service) List(q *dashboard.Query, params apiInterface.Parameters) ([]*v
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.List(query)
+	list, err := s.dao.List(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawList(q *dashboard.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -135,7 +184,9 @@ func (s *service) RawList(q *dashboard.Query, params apiInterface.Parameters) ([
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawList(query)
+	list, err := s.dao.RawList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) MetadataList(q *dashboard.Query, params apiInterface.Parameters) ([]api.Entity, error) {
@@ -143,7 +194,9 @@ func (s *service) MetadataList(q *dashboard.Query, params apiInterface.Parameter
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *dashboard.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -151,7 +204,112 @@ func (s *service) RawMetadataList(q *dashboard.Query, params apiInterface.Parame
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
+}
+
+func (s *service) ListRevisions(parameters apiInterface.Parameters) ([]*v1.RevisionMetadata, error) {
+	// Ensure the dashboard exists, so a not found error is returned instead of an empty list.
+	if _, err := s.dao.Get(parameters.Project, parameters.Name); err != nil {
+		return nil, err
+	}
+	return s.dao.ListRevisions(parameters.Project, parameters.Name)
+}
+
+func (s *service) GetRevision(parameters apiInterface.Parameters, version uint64) (*v1.DashboardRevision, error) {
+	return s.dao.GetRevision(parameters.Project, parameters.Name, version)
+}
+
+func (s *service) DiffRevisions(parameters apiInterface.Parameters, from uint64, to uint64) (*v1.RevisionDiff, error) {
+	fromDashboard, err := s.getRevisionOrCurrent(parameters, from)
+	if err != nil {
+		return nil, err
+	}
+	toDashboard, err := s.getRevisionOrCurrent(parameters, to)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := diff(fromDashboard, toDashboard)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.RevisionDiff{From: from, To: to, Patch: patch}, nil
+}
+
+// getRevisionOrCurrent returns the dashboard recorded in the given revision.
+// The dashboards saved before the revision history was enabled have no revision for their current version,
+// so in this case the live dashboard is used instead.
+func (s *service) getRevisionOrCurrent(parameters apiInterface.Parameters, version uint64) (*v1.Dashboard, error) {
+	revision, err := s.dao.GetRevision(parameters.Project, parameters.Name, version)
+	if err == nil {
+		return revision.Dashboard, nil
+	}
+	if !databaseModel.IsKeyNotFound(err) {
+		return nil, err
+	}
+	current, getErr := s.dao.Get(parameters.Project, parameters.Name)
+	if getErr != nil {
+		return nil, getErr
+	}
+	if current.Metadata.Version != version {
+		return nil, err
+	}
+	return current, nil
+}
+
+func (s *service) RestoreRevision(ctx echo.Context, parameters apiInterface.Parameters, version uint64) (*v1.Dashboard, error) {
+	revision, err := s.dao.GetRevision(parameters.Project, parameters.Name, version)
+	if err != nil {
+		return nil, err
+	}
+	// The labels and the annotations are restored with the spec, the other metadata are computed by the update from
+	// the current version of the dashboard.
+	metadata := v1.NewProjectMetadata(parameters.Project, parameters.Name)
+	metadata.Labels = revision.Dashboard.Metadata.Labels
+	metadata.Annotations = revision.Dashboard.Metadata.Annotations
+	entity := &v1.Dashboard{
+		Kind:     v1.KindDashboard,
+		Metadata: *metadata,
+		Spec:     revision.Dashboard.Spec,
+	}
+	return s.update(ctx, entity, parameters)
+}
+
+// createRevision records the given state of the dashboard in its revision history.
+// A failure is only logged, the history must not prevent the dashboard from being saved.
+func (s *service) createRevision(ctx echo.Context, entity *v1.Dashboard) {
+	if s.revision.Disable {
+		return
+	}
+	var author string
+	// ctx is nil when the dashboard is modified by Perses itself (provisioning for example).
+	if ctx != nil {
+		username, err := s.authz.GetUsername(ctx)
+		if err != nil {
+			logrus.WithError(err).Debug("unable to retrieve the author of the dashboard revision")
+		}
+		author = username
+	}
+	if err := s.dao.CreateRevision(entity, author, s.revision.MaxRevisions); err != nil {
+		logrus.WithError(err).Errorf("unable to record the revision %d of the dashboard %q", entity.Metadata.Version, entity.Metadata.Name)
+	}
+}
+
+// isUnchanged returns true when the new dashboard has the same spec, labels and annotations as the stored one.
+func isUnchanged(oldEntity *v1.Dashboard, entity *v1.Dashboard) (bool, error) {
+	if !maps.Equal(oldEntity.Metadata.Labels, entity.Metadata.Labels) || !maps.Equal(oldEntity.Metadata.Annotations, entity.Metadata.Annotations) {
+		return false, nil
+	}
+	oldSpec, err := json.Marshal(oldEntity.Spec)
+	if err != nil {
+		return false, fmt.Errorf("unable to marshal the spec of the stored dashboard: %w", err)
+	}
+	newSpec, err := json.Marshal(entity.Spec)
+	if err != nil {
+		return false, fmt.Errorf("unable to marshal the spec of the dashboard: %w", err)
+	}
+	return bytes.Equal(oldSpec, newSpec), nil
 }
 
 func (s *service) Validate(entity *v1.Dashboard) error {
@@ -197,6 +355,7 @@ func (s *service) collectGlobalVariables() ([]*v1.GlobalVariable, error) {
 
 func manageQuery(q *dashboard.Query, params apiInterface.Parameters) (*dashboard.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
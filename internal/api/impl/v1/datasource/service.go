@@ -23,6 +23,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,14 +31,16 @@ import (
 
 type service struct {
 	datasource.Service
-	dao datasource.DAO
-	sch schema.Schema
+	dao         datasource.DAO
+	sch         schema.Schema
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao datasource.DAO, sch schema.Schema) datasource.Service {
+func NewService(dao datasource.DAO, sch schema.Schema, broadcaster watch.Broadcaster) datasource.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:         dao,
+		sch:         sch,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -58,6 +61,7 @@ func (s *service) create(entity *v1.Datasource) (*v1.Datasource, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	return entity, nil
 }
 
@@ -88,16 +92,29 @@ func (s *service) update(entity *v1.Datasource, parameters apiInterface.Paramete
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Datasource %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	// The Datasource is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Datasource, error) {
@@ -112,6 +129,7 @@ func (s *service) List(q *datasource.Query, params apiInterface.Parameters) ([]*
 	if err != nil {
 		return nil, err
 	}
+	q.Pagination.SetNextToken(query.Pagination.Next())
 	return v1.FilterDatasource(query.Kind, query.Default, dtsList), nil
 }
 
@@ -143,6 +161,7 @@ func (s *service) validate(entity *v1.Datasource) error {
 
 func manageQuery(q *datasource.Query, params apiInterface.Parameters) (*datasource.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
@@ -25,6 +25,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -36,14 +37,16 @@ type service struct {
 	globalVarDAO  globalvariable.DAO
 	projectVarDAO variable.DAO
 	sch           schema.Schema
+	broadcaster   watch.Broadcaster
 }
 
-func NewService(dao ephemeraldashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema) ephemeraldashboard.Service {
+func NewService(dao ephemeraldashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema, broadcaster watch.Broadcaster) ephemeraldashboard.Service {
 	return &service{
 		dao:           dao,
 		globalVarDAO:  globalVarDAO,
 		projectVarDAO: projectVarDAO,
 		sch:           sch,
+		broadcaster:   broadcaster,
 	}
 }
 
@@ -66,6 +69,7 @@ func (s *service) create(entity *v1.EphemeralDashboard) (*v1.EphemeralDashboard,
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	return entity, nil
 }
 
@@ -99,16 +103,29 @@ func (s *service) update(entity *v1.EphemeralDashboard, parameters apiInterface.
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the ephemeral dashboard %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	// The EphemeralDashboard is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.EphemeralDashboard, error) {
@@ -120,7 +137,9 @@ func (s *service) List(q *ephemeraldashboard.Query, params apiInterface.Paramete
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.List(query)
+	list, err := s.dao.List(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawList(q *ephemeraldashboard.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -128,7 +147,9 @@ func (s *service) RawList(q *ephemeraldashboard.Query, params apiInterface.Param
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawList(query)
+	list, err := s.dao.RawList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) MetadataList(q *ephemeraldashboard.Query, params apiInterface.Parameters) ([]api.Entity, error) {
@@ -136,7 +157,9 @@ func (s *service) MetadataList(q *ephemeraldashboard.Query, params apiInterface.
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *ephemeraldashboard.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -144,7 +167,9 @@ func (s *service) RawMetadataList(q *ephemeraldashboard.Query, params apiInterfa
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) Validate(entity *v1.EphemeralDashboard) error {
@@ -21,6 +21,7 @@ import (
 	"github.com/labstack/echo/v4"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -28,12 +29,14 @@ import (
 
 type service struct {
 	folder.Service
-	dao folder.DAO
+	dao         folder.DAO
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao folder.DAO) folder.Service {
+func NewService(dao folder.DAO, broadcaster watch.Broadcaster) folder.Service {
 	return &service{
-		dao: dao,
+		dao:         dao,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -51,6 +54,7 @@ func (s *service) create(entity *v1.Folder) (*v1.Folder, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	return entity, nil
 }
 
@@ -78,16 +82,29 @@ func (s *service) update(entity *v1.Folder, parameters apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Folder %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	// The Folder is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Folder, error) {
@@ -99,7 +116,9 @@ func (s *service) List(q *folder.Query, params apiInterface.Parameters) ([]*v1.F
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.List(query)
+	list, err := s.dao.List(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawList(q *folder.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -107,7 +126,9 @@ func (s *service) RawList(q *folder.Query, params apiInterface.Parameters) ([]js
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawList(query)
+	list, err := s.dao.RawList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) MetadataList(q *folder.Query, params apiInterface.Parameters) ([]api.Entity, error) {
@@ -115,7 +136,9 @@ func (s *service) MetadataList(q *folder.Query, params apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *folder.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -123,11 +146,14 @@ func (s *service) RawMetadataList(q *folder.Query, params apiInterface.Parameter
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func manageQuery(q *folder.Query, params apiInterface.Parameters) (*folder.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
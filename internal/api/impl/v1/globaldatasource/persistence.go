@@ -37,7 +37,7 @@ func (d *dao) Create(entity *v1.GlobalDatasource) error {
 }
 
 func (d *dao) Update(entity *v1.GlobalDatasource) error {
-	return d.client.Upsert(entity)
+	return d.client.Update(entity)
 }
 
 func (d *dao) Delete(name string) error {
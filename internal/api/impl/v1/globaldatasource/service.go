@@ -23,6 +23,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,14 +31,16 @@ import (
 
 type service struct {
 	globaldatasource.Service
-	dao globaldatasource.DAO
-	sch schema.Schema
+	dao         globaldatasource.DAO
+	sch         schema.Schema
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao globaldatasource.DAO, sch schema.Schema) globaldatasource.Service {
+func NewService(dao globaldatasource.DAO, sch schema.Schema, broadcaster watch.Broadcaster) globaldatasource.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:         dao,
+		sch:         sch,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -58,6 +61,7 @@ func (s *service) create(entity *v1.GlobalDatasource) (*v1.GlobalDatasource, err
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	return entity, nil
 }
 
@@ -82,16 +86,29 @@ func (s *service) update(entity *v1.GlobalDatasource, parameters apiInterface.Pa
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDatasource %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Name)
+	// The GlobalDatasource is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
@@ -23,6 +23,7 @@ import (
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,16 +31,18 @@ import (
 
 type service struct {
 	globalrole.Service
-	dao   globalrole.DAO
-	authz authorization.Authorization
-	sch   schema.Schema
+	dao         globalrole.DAO
+	authz       authorization.Authorization
+	sch         schema.Schema
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao globalrole.DAO, authz authorization.Authorization, sch schema.Schema) globalrole.Service {
+func NewService(dao globalrole.DAO, authz authorization.Authorization, sch schema.Schema, broadcaster watch.Broadcaster) globalrole.Service {
 	return &service{
-		dao:   dao,
-		authz: authz,
-		sch:   sch,
+		dao:         dao,
+		authz:       authz,
+		sch:         sch,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -57,6 +60,7 @@ func (s *service) create(entity *v1.GlobalRole) (*v1.GlobalRole, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	// Refreshing RBAC cache as the role can add or remove new permissions to users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -83,11 +87,15 @@ func (s *service) update(entity *v1.GlobalRole, parameters apiInterface.Paramete
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Globalrole %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	// Refreshing RBAC cache as the role can add or remove new permissions to users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -96,9 +104,15 @@ func (s *service) update(entity *v1.GlobalRole, parameters apiInterface.Paramete
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	// The GlobalRole is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
 	if err := s.dao.Delete(parameters.Name); err != nil {
 		return err
 	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
 	// Refreshing RBAC cache as the role can add or remove new permissions to users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -40,7 +40,7 @@ func (d *dao) Create(entity *v1.GlobalRoleBinding) error {
 }
 
 func (d *dao) Update(entity *v1.GlobalRoleBinding) error {
-	return d.client.Upsert(entity)
+	return d.client.Update(entity)
 }
 
 func (d *dao) Delete(name string) error {
@@ -25,6 +25,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -37,15 +38,17 @@ type service struct {
 	userDAO       user.DAO
 	authz         authorization.Authorization
 	sch           schema.Schema
+	broadcaster   watch.Broadcaster
 }
 
-func NewService(dao globalrolebinding.DAO, globalRoleDAO globalrole.DAO, userDAO user.DAO, authz authorization.Authorization, sch schema.Schema) globalrolebinding.Service {
+func NewService(dao globalrolebinding.DAO, globalRoleDAO globalrole.DAO, userDAO user.DAO, authz authorization.Authorization, sch schema.Schema, broadcaster watch.Broadcaster) globalrolebinding.Service {
 	return &service{
 		dao:           dao,
 		globalRoleDAO: globalRoleDAO,
 		userDAO:       userDAO,
 		authz:         authz,
 		sch:           sch,
+		broadcaster:   broadcaster,
 	}
 }
 
@@ -63,6 +66,7 @@ func (s *service) create(entity *v1.GlobalRoleBinding) (*v1.GlobalRoleBinding, e
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	// Refreshing RBAC cache as the role binding can add or remove new permissions to concerned users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -96,12 +100,15 @@ func (s *service) update(entity *v1.GlobalRoleBinding, parameters apiInterface.P
 		return nil, apiInterface.HandleBadRequestError("spec.role can't be updated")
 	}
 
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalroleBinding %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
-
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	// Refreshing RBAC cache as the role binding can add or remove new permissions to concerned users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -110,9 +117,15 @@ func (s *service) update(entity *v1.GlobalRoleBinding, parameters apiInterface.P
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	// The GlobalRoleBinding is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
 	if err := s.dao.Delete(parameters.Name); err != nil {
 		return err
 	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
 	// Refreshing RBAC cache as the role binding can add or remove new permissions to concerned users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
 	"github.com/perses/perses/internal/api/route"
@@ -32,9 +33,9 @@ type endpoint struct {
 	readonly bool
 }
 
-func NewEndpoint(service globalsecret.Service, authz authorization.Authorization, readonly bool, caseSensitive bool) route.Endpoint {
+func NewEndpoint(service globalsecret.Service, authz authorization.Authorization, auditor audit.Auditor, readonly bool, caseSensitive bool) route.Endpoint {
 	return &endpoint{
-		toolbox:  toolbox.New[*v1.GlobalSecret, *v1.PublicGlobalSecret, *globalsecret.Query](service, authz, v1.KindGlobalSecret, caseSensitive),
+		toolbox:  toolbox.New[*v1.GlobalSecret, *v1.PublicGlobalSecret, *globalsecret.Query](service, authz, auditor, v1.KindGlobalSecret, caseSensitive),
 		readonly: readonly,
 	}
 }
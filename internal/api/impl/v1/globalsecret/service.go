@@ -22,6 +22,7 @@ import (
 	"github.com/perses/perses/internal/api/crypto"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -29,14 +30,16 @@ import (
 
 type service struct {
 	globalsecret.Service
-	dao    globalsecret.DAO
-	crypto crypto.Crypto
+	dao         globalsecret.DAO
+	crypto      crypto.Crypto
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao globalsecret.DAO, crypto crypto.Crypto) globalsecret.Service {
+func NewService(dao globalsecret.DAO, crypto crypto.Crypto, broadcaster watch.Broadcaster) globalsecret.Service {
 	return &service{
-		dao:    dao,
-		crypto: crypto,
+		dao:         dao,
+		crypto:      crypto,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -58,7 +61,9 @@ func (s *service) create(entity *v1.GlobalSecret) (*v1.PublicGlobalSecret, error
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
-	return v1.NewPublicGlobalSecret(entity), nil
+	publicEntity := v1.NewPublicGlobalSecret(entity)
+	s.broadcaster.Publish(v1.WatchEventAdded, publicEntity)
+	return publicEntity, nil
 }
 
 func (s *service) Update(_ echo.Context, entity *v1.GlobalSecret, parameters apiInterface.Parameters) (*v1.PublicGlobalSecret, error) {
@@ -79,6 +84,9 @@ func (s *service) update(entity *v1.GlobalSecret, parameters apiInterface.Parame
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 
 	if encryptErr := s.crypto.Encrypt(&entity.Spec); encryptErr != nil {
@@ -89,11 +97,22 @@ func (s *service) update(entity *v1.GlobalSecret, parameters apiInterface.Parame
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalSecret %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
-	return v1.NewPublicGlobalSecret(entity), nil
+	publicEntity := v1.NewPublicGlobalSecret(entity)
+	s.broadcaster.Publish(v1.WatchEventModified, publicEntity)
+	return publicEntity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Name)
+	// The GlobalSecret is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, v1.NewPublicGlobalSecret(oldEntity))
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.PublicGlobalSecret, error) {
@@ -40,7 +40,7 @@ func (d *dao) Create(entity *v1.Project) error {
 }
 
 func (d *dao) Update(entity *v1.Project) error {
-	return d.client.Upsert(entity)
+	return d.client.Update(entity)
 }
 
 func (d *dao) Get(name string) (*v1.Project, error) {
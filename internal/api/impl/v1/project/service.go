@@ -29,6 +29,8 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/search"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/utils"
@@ -46,9 +48,11 @@ type service struct {
 	secretDAO      secret.DAO
 	variableDAO    variable.DAO
 	authz          authorization.Authorization
+	index          search.Index
+	broadcaster    watch.Broadcaster
 }
 
-func NewService(dao project.DAO, folderDAO folder.DAO, datasourceDAO datasource.DAO, dashboardDAO dashboard.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO, secretDAO secret.DAO, variableDAO variable.DAO, authz authorization.Authorization) project.Service {
+func NewService(dao project.DAO, folderDAO folder.DAO, datasourceDAO datasource.DAO, dashboardDAO dashboard.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO, secretDAO secret.DAO, variableDAO variable.DAO, authz authorization.Authorization, index search.Index, broadcaster watch.Broadcaster) project.Service {
 	return &service{
 		dao:            dao,
 		folderDAO:      folderDAO,
@@ -59,6 +63,8 @@ func NewService(dao project.DAO, folderDAO folder.DAO, datasourceDAO datasource.
 		secretDAO:      secretDAO,
 		variableDAO:    variableDAO,
 		authz:          authz,
+		index:          index,
+		broadcaster:    broadcaster,
 	}
 }
 
@@ -76,6 +82,7 @@ func (s *service) create(ctx echo.Context, entity *v1.Project) (*v1.Project, err
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 
 	// If authorization is enabled, permissions to the creator need to be given
 	if s.authz.IsEnabled() {
@@ -132,16 +139,28 @@ func (s *service) update(entity *v1.Project, parameters apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the project %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
 	projectName := parameters.Name
+	// The project is read before being deleted, so the watchers receive its last state.
+	// The resources it contains are removed without any event: the deletion of the project implies theirs.
+	oldEntity, err := s.dao.Get(projectName)
+	if err != nil {
+		return err
+	}
+	// The dashboards and the variables of the project are removed, even if the deletion fails afterward.
+	defer s.index.Invalidate()
 	if err := s.folderDAO.DeleteAll(projectName); err != nil {
 		logrus.WithError(err).Error("unable to delete all folders")
 		return err
@@ -175,7 +194,11 @@ func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) err
 			return err
 		}
 	}
-	return s.dao.Delete(parameters.Name)
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Project, error) {
@@ -0,0 +1,216 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reencrypt
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
+	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/crypto"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
+	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/internal/api/watch"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	mimeJSONLines = "application/x-ndjson"
+	// logInterval is the number of secrets processed between two progress logs.
+	logInterval = 100
+)
+
+// Status is the outcome of the re-encryption of a secret.
+type Status string
+
+const (
+	StatusReencrypted Status = "reencrypted"
+	// StatusSkipped means the secret is already encrypted with the current key.
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Progress is sent once a secret has been processed.
+type Progress struct {
+	Kind    v1.Kind `json:"kind"`
+	Project string  `json:"project,omitempty"`
+	Name    string  `json:"name"`
+	Status  Status  `json:"status"`
+	Error   string  `json:"error,omitempty"`
+	// Done is the number of secrets processed so far, this one included.
+	Done int `json:"done"`
+	// Total is the number of secrets to process.
+	Total int `json:"total"`
+}
+
+type endpoint struct {
+	secretDAO       secret.DAO
+	globalSecretDAO globalsecret.DAO
+	crypto          crypto.Crypto
+	authz           authorization.Authorization
+	auditor         audit.Auditor
+	broadcaster     watch.Broadcaster
+	readonly        bool
+}
+
+// NewEndpoint returns the endpoint re-encrypting every Secret and GlobalSecret with the current encryption key,
+// so the previous keys can be removed from the configuration.
+func NewEndpoint(secretDAO secret.DAO, globalSecretDAO globalsecret.DAO, crypto crypto.Crypto, authz authorization.Authorization, auditor audit.Auditor, broadcaster watch.Broadcaster, readonly bool) route.Endpoint {
+	return &endpoint{
+		secretDAO:       secretDAO,
+		globalSecretDAO: globalSecretDAO,
+		crypto:          crypto,
+		authz:           authz,
+		auditor:         auditor,
+		broadcaster:     broadcaster,
+		readonly:        readonly,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if e.readonly {
+		return
+	}
+	g.POST(fmt.Sprintf("/%s/%s", utils.PathEncryption, utils.PathReencrypt), e.Reencrypt, false)
+}
+
+// Reencrypt re-encrypts the secrets one by one, and streams the progress as JSON lines.
+// A secret that can't be re-encrypted is reported as failed, and doesn't stop the others from being processed.
+// As the secrets already encrypted with the current key are skipped, the request can be sent again until none fails.
+func (e *endpoint) Reencrypt(ctx echo.Context) error {
+	if err := e.checkPermission(ctx); err != nil {
+		return err
+	}
+	secrets, err := e.secretDAO.List(&secret.Query{})
+	if err != nil {
+		return err
+	}
+	globalSecrets, err := e.globalSecretDAO.List(&globalsecret.Query{})
+	if err != nil {
+		return err
+	}
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, mimeJSONLines)
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	total := len(secrets) + len(globalSecrets)
+	done := 0
+	failed := 0
+	report := func(p *Progress, processErr error) error {
+		done++
+		p.Done = done
+		p.Total = total
+		if processErr != nil {
+			failed++
+			p.Error = processErr.Error()
+			logrus.WithError(processErr).Errorf("unable to re-encrypt the %s %q", p.Kind, p.Name)
+		}
+		if done%logInterval == 0 || done == total {
+			logrus.Infof("%d/%d secrets processed by the re-encryption, %d failed", done, total, failed)
+		}
+		return writeProgress(response, p)
+	}
+	for _, scrt := range secrets {
+		p := &Progress{Kind: v1.KindSecret, Project: scrt.Metadata.Project, Name: scrt.Metadata.Name}
+		p.Status, err = e.reencryptSecret(ctx, scrt)
+		if writeErr := report(p, err); writeErr != nil {
+			// The response has already started, the error can only be logged.
+			logrus.WithError(writeErr).Debug("unable to send the re-encryption progress")
+			return nil
+		}
+	}
+	for _, scrt := range globalSecrets {
+		p := &Progress{Kind: v1.KindGlobalSecret, Name: scrt.Metadata.Name}
+		p.Status, err = e.reencryptGlobalSecret(ctx, scrt)
+		if writeErr := report(p, err); writeErr != nil {
+			logrus.WithError(writeErr).Debug("unable to send the re-encryption progress")
+			return nil
+		}
+	}
+	return nil
+}
+
+func (e *endpoint) reencryptSecret(ctx echo.Context, entity *v1.Secret) (Status, error) {
+	done, err := e.crypto.Reencrypt(&entity.Spec)
+	if err != nil {
+		return StatusFailed, err
+	}
+	if !done {
+		return StatusSkipped, nil
+	}
+	entity.Metadata.Update(entity.Metadata)
+	// The update is rejected if the secret has been modified since it has been listed.
+	err = e.secretDAO.Update(entity)
+	e.auditor.Record(ctx, audit.NewEvent(v1.AuditOriginAPI, role.UpdateAction, v1.KindSecret, entity.Metadata.Project, entity.Metadata.Name), err)
+	if err != nil {
+		return StatusFailed, err
+	}
+	e.broadcaster.Publish(v1.WatchEventModified, v1.NewPublicSecret(entity))
+	return StatusReencrypted, nil
+}
+
+func (e *endpoint) reencryptGlobalSecret(ctx echo.Context, entity *v1.GlobalSecret) (Status, error) {
+	done, err := e.crypto.Reencrypt(&entity.Spec)
+	if err != nil {
+		return StatusFailed, err
+	}
+	if !done {
+		return StatusSkipped, nil
+	}
+	entity.Metadata.Update(entity.Metadata)
+	err = e.globalSecretDAO.Update(entity)
+	e.auditor.Record(ctx, audit.NewEvent(v1.AuditOriginAPI, role.UpdateAction, v1.KindGlobalSecret, "", entity.Metadata.Name), err)
+	if err != nil {
+		return StatusFailed, err
+	}
+	e.broadcaster.Publish(v1.WatchEventModified, v1.NewPublicGlobalSecret(entity))
+	return StatusReencrypted, nil
+}
+
+// checkPermission requires the permission to update every Secret and every GlobalSecret.
+func (e *endpoint) checkPermission(ctx echo.Context) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	if !e.authz.HasPermission(ctx, role.UpdateAction, v1.WildcardProject, role.SecretScope) {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in every project for '%s' kind", role.UpdateAction, role.SecretScope))
+	}
+	if !e.authz.HasPermission(ctx, role.UpdateAction, v1.WildcardProject, role.GlobalSecretScope) {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.UpdateAction, role.GlobalSecretScope))
+	}
+	return nil
+}
+
+func writeProgress(response *echo.Response, p *Progress) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if _, err := response.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	response.Flush()
+	return nil
+}
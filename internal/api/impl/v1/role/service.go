@@ -23,6 +23,7 @@ import (
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,16 +31,18 @@ import (
 
 type service struct {
 	role.Service
-	dao   role.DAO
-	authz authorization.Authorization
-	sch   schema.Schema
+	dao         role.DAO
+	authz       authorization.Authorization
+	sch         schema.Schema
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao role.DAO, authz authorization.Authorization, sch schema.Schema) role.Service {
+func NewService(dao role.DAO, authz authorization.Authorization, sch schema.Schema, broadcaster watch.Broadcaster) role.Service {
 	return &service{
-		dao:   dao,
-		authz: authz,
-		sch:   sch,
+		dao:         dao,
+		authz:       authz,
+		sch:         sch,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -57,6 +60,7 @@ func (s *service) create(entity *v1.Role) (*v1.Role, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	// Refreshing RBAC cache as the role can add or remove new permissions to users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -89,11 +93,15 @@ func (s *service) update(entity *v1.Role, parameters apiInterface.Parameters) (*
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the role %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	// Refreshing RBAC cache as the role can add or remove new permissions to users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -102,9 +110,15 @@ func (s *service) update(entity *v1.Role, parameters apiInterface.Parameters) (*
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	// The Role is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
 	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
 		return err
 	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
 	// Refreshing RBAC cache as the role can add or remove new permissions to users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -121,7 +135,9 @@ func (s *service) List(q *role.Query, params apiInterface.Parameters) ([]*v1.Rol
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.List(query)
+	list, err := s.dao.List(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawList(q *role.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -129,7 +145,9 @@ func (s *service) RawList(q *role.Query, params apiInterface.Parameters) ([]json
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawList(query)
+	list, err := s.dao.RawList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) MetadataList(q *role.Query, params apiInterface.Parameters) ([]api.Entity, error) {
@@ -137,7 +155,9 @@ func (s *service) MetadataList(q *role.Query, params apiInterface.Parameters) ([
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *role.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -145,11 +165,14 @@ func (s *service) RawMetadataList(q *role.Query, params apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func manageQuery(q *role.Query, params apiInterface.Parameters) (*role.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
@@ -25,6 +25,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -32,20 +33,22 @@ import (
 
 type service struct {
 	rolebinding.Service
-	dao     rolebinding.DAO
-	roleDAO role.DAO
-	userDAO user.DAO
-	authz   authorization.Authorization
-	sch     schema.Schema
+	dao         rolebinding.DAO
+	roleDAO     role.DAO
+	userDAO     user.DAO
+	authz       authorization.Authorization
+	sch         schema.Schema
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao rolebinding.DAO, roleDAO role.DAO, userDAO user.DAO, authz authorization.Authorization, sch schema.Schema) rolebinding.Service {
+func NewService(dao rolebinding.DAO, roleDAO role.DAO, userDAO user.DAO, authz authorization.Authorization, sch schema.Schema, broadcaster watch.Broadcaster) rolebinding.Service {
 	return &service{
-		dao:     dao,
-		authz:   authz,
-		roleDAO: roleDAO,
-		userDAO: userDAO,
-		sch:     sch,
+		dao:         dao,
+		authz:       authz,
+		roleDAO:     roleDAO,
+		userDAO:     userDAO,
+		sch:         sch,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -63,6 +66,7 @@ func (s *service) create(entity *v1.RoleBinding) (*v1.RoleBinding, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	// Refreshing RBAC cache as the role binding can add or remove new permissions to concerned users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -102,11 +106,15 @@ func (s *service) update(entity *v1.RoleBinding, parameters apiInterface.Paramet
 		return nil, apiInterface.HandleBadRequestError("spec.role can't be updated")
 	}
 
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the roleBinding %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	// Refreshing RBAC cache as the role binding can add or remove new permissions to concerned users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -115,9 +123,15 @@ func (s *service) update(entity *v1.RoleBinding, parameters apiInterface.Paramet
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	// The RoleBinding is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
 	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
 		return err
 	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
 	// Refreshing RBAC cache as the role binding can add or remove new permissions to concerned users
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -134,7 +148,9 @@ func (s *service) List(q *rolebinding.Query, params apiInterface.Parameters) ([]
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.List(query)
+	list, err := s.dao.List(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawList(q *rolebinding.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -142,7 +158,9 @@ func (s *service) RawList(q *rolebinding.Query, params apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawList(query)
+	list, err := s.dao.RawList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) MetadataList(q *rolebinding.Query, params apiInterface.Parameters) ([]api.Entity, error) {
@@ -150,7 +168,9 @@ func (s *service) MetadataList(q *rolebinding.Query, params apiInterface.Paramet
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *rolebinding.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -158,11 +178,14 @@ func (s *service) RawMetadataList(q *rolebinding.Query, params apiInterface.Para
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func manageQuery(q *rolebinding.Query, params apiInterface.Parameters) (*rolebinding.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
@@ -0,0 +1,119 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/route"
+	searchIndex "github.com/perses/perses/internal/api/search"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const defaultLimit = 50
+
+type endpoint struct {
+	index searchIndex.Index
+	authz authorization.Authorization
+}
+
+func NewEndpoint(index searchIndex.Index, authz authorization.Authorization) route.Endpoint {
+	return &endpoint{
+		index: index,
+		authz: authz,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	g.GET(fmt.Sprintf("/%s", utils.PathSearch), e.Search, false)
+}
+
+func (e *endpoint) Search(ctx echo.Context) error {
+	query, err := parseQuery(ctx)
+	if err != nil {
+		return err
+	}
+	if e.authz.IsEnabled() {
+		isAllowed, authzErr := e.buildFilter(ctx)
+		if authzErr != nil {
+			return authzErr
+		}
+		query.IsAllowed = isAllowed
+	}
+	result, err := e.index.Search(query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// buildFilter returns the function keeping only the results in the projects where the user can read the dashboards or the variables.
+// As the permissions can be restricted to some resource names, the dashboard (or the project variable) of each result is checked as well.
+func (e *endpoint) buildFilter(ctx echo.Context) (func(result *v1.SearchResult) bool, error) {
+	dashboardProjects, err := e.authz.GetUserProjects(ctx, role.ReadAction, role.DashboardScope)
+	if err != nil {
+		return nil, err
+	}
+	variableProjects, err := e.authz.GetUserProjects(ctx, role.ReadAction, role.VariableScope)
+	if err != nil {
+		return nil, err
+	}
+	return func(result *v1.SearchResult) bool {
+		projects := dashboardProjects
+		scope := role.DashboardScope
+		name := result.Dashboard
+		if len(name) == 0 {
+			name = result.Name
+			if result.Kind == v1.SearchResultKindVariable {
+				projects = variableProjects
+				scope = role.VariableScope
+			}
+		}
+		if !slices.Contains(projects, v1.WildcardProject) && !slices.Contains(projects, result.Project) {
+			return false
+		}
+		return e.authz.HasResourcePermission(ctx, role.ReadAction, result.Project, scope, name)
+	}, nil
+}
+
+func parseQuery(ctx echo.Context) (searchIndex.Query, error) {
+	query := searchIndex.Query{
+		Text:  ctx.QueryParam("q"),
+		Limit: defaultLimit,
+	}
+	if len(query.Text) == 0 {
+		return query, apiInterface.HandleBadRequestError("the parameter 'q' is required")
+	}
+	if kind := ctx.QueryParam("kind"); len(kind) > 0 {
+		if err := query.Kind.UnmarshalParam(kind); err != nil {
+			return query, apiInterface.HandleBadRequestError(err.Error())
+		}
+	}
+	if limit := ctx.QueryParam("limit"); len(limit) > 0 {
+		var err error
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit <= 0 {
+			return query, apiInterface.HandleBadRequestError(fmt.Sprintf("'limit' must be a positive integer, got %q", limit))
+		}
+	}
+	return query, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/perses/perses/internal/api/crypto"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -29,14 +30,16 @@ import (
 
 type service struct {
 	secret.Service
-	dao    secret.DAO
-	crypto crypto.Crypto
+	dao         secret.DAO
+	crypto      crypto.Crypto
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao secret.DAO, crypto crypto.Crypto) secret.Service {
+func NewService(dao secret.DAO, crypto crypto.Crypto, broadcaster watch.Broadcaster) secret.Service {
 	return &service{
-		dao:    dao,
-		crypto: crypto,
+		dao:         dao,
+		crypto:      crypto,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -58,7 +61,9 @@ func (s *service) create(entity *v1.Secret) (*v1.PublicSecret, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
-	return v1.NewPublicSecret(entity), nil
+	publicEntity := v1.NewPublicSecret(entity)
+	s.broadcaster.Publish(v1.WatchEventAdded, publicEntity)
+	return publicEntity, nil
 }
 
 func (s *service) Update(_ echo.Context, entity *v1.Secret, parameters apiInterface.Parameters) (*v1.PublicSecret, error) {
@@ -85,6 +90,9 @@ func (s *service) update(entity *v1.Secret, parameters apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 
 	if encryptErr := s.crypto.Encrypt(&entity.Spec); encryptErr != nil {
@@ -95,11 +103,22 @@ func (s *service) update(entity *v1.Secret, parameters apiInterface.Parameters)
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Secret %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
-	return v1.NewPublicSecret(entity), nil
+	publicEntity := v1.NewPublicSecret(entity)
+	s.broadcaster.Publish(v1.WatchEventModified, publicEntity)
+	return publicEntity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	// The Secret is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, v1.NewPublicSecret(oldEntity))
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.PublicSecret, error) {
@@ -119,6 +138,7 @@ func (s *service) List(q *secret.Query, params apiInterface.Parameters) ([]*v1.P
 	if err != nil {
 		return nil, err
 	}
+	q.Pagination.SetNextToken(query.Pagination.Next())
 	result := make([]*v1.PublicSecret, 0, len(l))
 	for _, scrt := range l {
 		result = append(result, v1.NewPublicSecret(scrt))
@@ -135,7 +155,9 @@ func (s *service) MetadataList(q *secret.Query, params apiInterface.Parameters)
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *secret.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -143,11 +165,14 @@ func (s *service) RawMetadataList(q *secret.Query, params apiInterface.Parameter
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func manageQuery(q *secret.Query, params apiInterface.Parameters) (*secret.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
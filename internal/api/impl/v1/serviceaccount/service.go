@@ -0,0 +1,142 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brunoga/deep"
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
+	"github.com/perses/perses/internal/api/interface/v1/serviceaccount"
+	"github.com/perses/perses/internal/api/watch"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	serviceaccount.Service
+	dao            serviceaccount.DAO
+	accessTokenDAO accesstoken.DAO
+	authz          authorization.Authorization
+	broadcaster    watch.Broadcaster
+}
+
+func NewService(dao serviceaccount.DAO, accessTokenDAO accesstoken.DAO, authz authorization.Authorization, broadcaster watch.Broadcaster) serviceaccount.Service {
+	return &service{
+		dao:            dao,
+		accessTokenDAO: accessTokenDAO,
+		authz:          authz,
+		broadcaster:    broadcaster,
+	}
+}
+
+func (s *service) Create(_ echo.Context, entity *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.create(copyEntity)
+}
+
+func (s *service) create(entity *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
+	// Refreshing RBAC cache as the service account may already be the subject of some role bindings.
+	if err := s.authz.RefreshPermissions(); err != nil {
+		logrus.WithError(err).Error("failed to refresh RBAC cache")
+	}
+	return entity, nil
+}
+
+func (s *service) Update(_ echo.Context, entity *v1.ServiceAccount, parameters apiInterface.Parameters) (*v1.ServiceAccount, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.update(copyEntity, parameters)
+}
+
+func (s *service) update(entity *v1.ServiceAccount, parameters apiInterface.Parameters) (*v1.ServiceAccount, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in ServiceAccount %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	// find the previous version of the ServiceAccount
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the ServiceAccount %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
+	return entity, nil
+}
+
+func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	// The ServiceAccount is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
+	// The access tokens are revoked before the service account, so they can't be used by a new one having the same name.
+	// The deletion fails when they can't be revoked, as they would otherwise outlive their owner.
+	if err := s.accessTokenDAO.DeleteAll(v1.Subject{Kind: v1.KindServiceAccount, Name: parameters.Name}); err != nil {
+		logrus.WithError(err).Errorf("unable to delete the access tokens of the ServiceAccount %q", parameters.Name)
+		return apiInterface.InternalError
+	}
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	// Refreshing RBAC cache as the permissions of the service account must be dropped.
+	if err := s.authz.RefreshPermissions(); err != nil {
+		logrus.WithError(err).Error("failed to refresh RBAC cache")
+	}
+	return nil
+}
+
+func (s *service) Get(parameters apiInterface.Parameters) (*v1.ServiceAccount, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q *serviceaccount.Query, _ apiInterface.Parameters) ([]*v1.ServiceAccount, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) RawList(q *serviceaccount.Query, _ apiInterface.Parameters) ([]json.RawMessage, error) {
+	return s.dao.RawList(q)
+}
+
+func (s *service) MetadataList(q *serviceaccount.Query, _ apiInterface.Parameters) ([]api.Entity, error) {
+	return s.dao.MetadataList(q)
+}
+
+func (s *service) RawMetadataList(q *serviceaccount.Query, _ apiInterface.Parameters) ([]json.RawMessage, error) {
+	return s.dao.RawMetadataList(q)
+}
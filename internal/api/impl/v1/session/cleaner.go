@@ -0,0 +1,46 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/interface/v1/session"
+	"github.com/sirupsen/logrus"
+)
+
+// CleanupInterval is the period at which the expired sessions are removed.
+const CleanupInterval = time.Hour
+
+func NewCleaner(dao session.DAO) async.SimpleTask {
+	return &cleaner{dao: dao}
+}
+
+type cleaner struct {
+	async.SimpleTask
+	dao session.DAO
+}
+
+func (c *cleaner) String() string {
+	return "expired sessions cleaner"
+}
+
+func (c *cleaner) Execute(_ context.Context, _ context.CancelFunc) error {
+	if err := c.dao.DeleteExpired(time.Now()); err != nil {
+		logrus.WithError(err).Error("unable to delete the expired sessions")
+	}
+	return nil
+}
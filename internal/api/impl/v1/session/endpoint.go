@@ -0,0 +1,102 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/session"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+type endpoint struct {
+	service       session.Service
+	authz         authorization.Authorization
+	caseSensitive bool
+}
+
+// NewEndpoint returns the endpoints listing and revoking the sessions of a user.
+// A user manages its own sessions. The sessions of the other users are managed by whoever has the global permission
+// on the users.
+// The endpoints are not disabled by the readonly mode: the users can still log in, so the sessions must be revocable.
+func NewEndpoint(service session.Service, authz authorization.Authorization, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		service:       service,
+		authz:         authz,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	group := g.Group(fmt.Sprintf("/%s/:%s/%s", utils.PathUser, utils.ParamName, utils.PathSession))
+	group.GET("", e.List, false)
+	group.DELETE("", e.DeleteAll, false)
+	group.DELETE(fmt.Sprintf("/:%s", utils.ParamID), e.Delete, false)
+}
+
+func (e *endpoint) List(ctx echo.Context) error {
+	username, err := e.checkPermission(ctx, role.ReadAction)
+	if err != nil {
+		return err
+	}
+	sessions, err := e.service.List(username)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, sessions)
+}
+
+func (e *endpoint) Delete(ctx echo.Context) error {
+	username, err := e.checkPermission(ctx, role.UpdateAction)
+	if err != nil {
+		return err
+	}
+	if deleteErr := e.service.Delete(username, ctx.Param(utils.ParamID)); deleteErr != nil {
+		return deleteErr
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (e *endpoint) DeleteAll(ctx echo.Context) error {
+	username, err := e.checkPermission(ctx, role.UpdateAction)
+	if err != nil {
+		return err
+	}
+	if deleteErr := e.service.DeleteAll(username); deleteErr != nil {
+		return deleteErr
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// checkPermission returns the user designated by the path, once it is checked that the requester can manage its
+// sessions.
+func (e *endpoint) checkPermission(ctx echo.Context, action role.Action) (string, error) {
+	name := toolbox.ExtractParameters(ctx, e.caseSensitive).Name
+	username, err := e.authz.GetUsername(ctx)
+	if err != nil {
+		return "", apiInterface.HandleUnauthorizedError("failed to retrieve username from context")
+	}
+	if username != name && !e.authz.HasPermission(ctx, action, v1.WildcardProject, role.UserScope) {
+		return "", apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", action, role.UserScope))
+	}
+	return name, nil
+}
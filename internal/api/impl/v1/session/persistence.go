@@ -0,0 +1,72 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/session"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type dao struct {
+	session.DAO
+	client databaseModel.DAO
+}
+
+func NewDAO(persesDAO databaseModel.DAO) session.DAO {
+	return &dao{
+		client: persesDAO,
+	}
+}
+
+func (d *dao) Create(session *v1.Session) error {
+	return d.client.CreateSession(session)
+}
+
+func (d *dao) Update(session *v1.Session) error {
+	return d.client.UpdateSession(session)
+}
+
+func (d *dao) Get(id string) (*v1.Session, error) {
+	sessions, err := d.client.QuerySessions(&databaseModel.SessionQuery{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return sessions[0], nil
+}
+
+func (d *dao) List(username string) ([]*v1.Session, error) {
+	return d.client.QuerySessions(&databaseModel.SessionQuery{Username: username})
+}
+
+func (d *dao) Delete(id string) error {
+	return d.client.DeleteSessions(&databaseModel.SessionQuery{ID: id})
+}
+
+func (d *dao) DeleteAll(username string) error {
+	if len(username) == 0 {
+		// An empty username would match the sessions of every user.
+		return nil
+	}
+	return d.client.DeleteSessions(&databaseModel.SessionQuery{Username: username})
+}
+
+func (d *dao) DeleteExpired(before time.Time) error {
+	return d.client.DeleteSessions(&databaseModel.SessionQuery{ExpiredBefore: before})
+}
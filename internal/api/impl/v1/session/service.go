@@ -0,0 +1,72 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"fmt"
+	"time"
+
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/session"
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type service struct {
+	session.Service
+	dao     session.DAO
+	userDAO user.DAO
+}
+
+func NewService(dao session.DAO, userDAO user.DAO) session.Service {
+	return &service{
+		dao:     dao,
+		userDAO: userDAO,
+	}
+}
+
+func (s *service) List(username string) ([]*v1.Session, error) {
+	if _, err := s.userDAO.Get(username); err != nil {
+		return nil, err
+	}
+	sessions, err := s.dao.List(username)
+	if err != nil {
+		return nil, err
+	}
+	// The expired sessions are kept until the cleaner removes them, but they are not usable anymore.
+	now := time.Now()
+	result := make([]*v1.Session, 0, len(sessions))
+	for _, sess := range sessions {
+		if !sess.IsExpired(now) {
+			result = append(result, sess)
+		}
+	}
+	return result, nil
+}
+
+func (s *service) Delete(username string, id string) error {
+	sess, err := s.dao.Get(id)
+	if err != nil {
+		return err
+	}
+	// The session of someone else is reported as missing, so its ID can't be guessed.
+	if sess.Username != username {
+		return apiInterface.HandleNotFoundError(fmt.Sprintf("session %q not found", id))
+	}
+	return s.dao.Delete(id)
+}
+
+func (s *service) DeleteAll(username string) error {
+	return s.dao.DeleteAll(username)
+}
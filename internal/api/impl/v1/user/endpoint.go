@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/user"
@@ -37,9 +38,9 @@ type endpoint struct {
 	caseSensitive bool
 }
 
-func NewEndpoint(service user.Service, authz authorization.Authorization, disableSignUp bool, readonly bool, caseSensitive bool) route.Endpoint {
+func NewEndpoint(service user.Service, authz authorization.Authorization, auditor audit.Auditor, disableSignUp bool, readonly bool, caseSensitive bool) route.Endpoint {
 	return &endpoint{
-		toolbox:       toolbox.New[*v1.User, *v1.PublicUser, *user.Query](service, authz, v1.KindUser, caseSensitive),
+		toolbox:       toolbox.New[*v1.User, *v1.PublicUser, *user.Query](service, authz, auditor, v1.KindUser, caseSensitive),
 		authz:         authz,
 		readonly:      readonly,
 		disableSignUp: disableSignUp,
@@ -22,7 +22,10 @@ import (
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/accesstoken"
+	"github.com/perses/perses/internal/api/interface/v1/session"
 	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,14 +33,20 @@ import (
 
 type service struct {
 	user.Service
-	dao   user.DAO
-	authz authorization.Authorization
+	dao            user.DAO
+	accessTokenDAO accesstoken.DAO
+	sessionDAO     session.DAO
+	authz          authorization.Authorization
+	broadcaster    watch.Broadcaster
 }
 
-func NewService(dao user.DAO, authz authorization.Authorization) user.Service {
+func NewService(dao user.DAO, accessTokenDAO accesstoken.DAO, sessionDAO session.DAO, authz authorization.Authorization, broadcaster watch.Broadcaster) user.Service {
 	return &service{
-		dao:   dao,
-		authz: authz,
+		dao:            dao,
+		accessTokenDAO: accessTokenDAO,
+		sessionDAO:     sessionDAO,
+		authz:          authz,
+		broadcaster:    broadcaster,
 	}
 }
 
@@ -52,6 +61,8 @@ func (s *service) Create(_ echo.Context, entity *v1.User) (*v1.PublicUser, error
 func (s *service) create(entity *v1.User) (*v1.PublicUser, error) {
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
+	// The groups are synced from the OAuth / OIDC providers only. Otherwise, anyone could join any group.
+	entity.Spec.Groups = nil
 	// check that the password is correctly filled
 	if len(entity.Spec.NativeProvider.Password) == 0 {
 		return nil, fmt.Errorf("%w: password cannot be empty", apiInterface.BadRequestError)
@@ -66,11 +77,13 @@ func (s *service) create(entity *v1.User) (*v1.PublicUser, error) {
 	if createErr := s.dao.Create(entity); createErr != nil {
 		return nil, createErr
 	}
+	publicEntity := v1.NewPublicUser(entity)
+	s.broadcaster.Publish(v1.WatchEventAdded, publicEntity)
 	// Refreshing RBAC cache as the user's associated role may be updated, which can add or remove permissions.
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
 	}
-	return v1.NewPublicUser(entity), nil
+	return publicEntity, nil
 }
 
 func (s *service) Update(_ echo.Context, entity *v1.User, parameters apiInterface.Parameters) (*v1.PublicUser, error) {
@@ -91,6 +104,9 @@ func (s *service) update(entity *v1.User, parameters apiInterface.Parameters) (*
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	// in case the user updated his password, then we should hash it again, otherwise the old password should be kept
 	if len(entity.Spec.NativeProvider.Password) > 0 {
@@ -110,22 +126,40 @@ func (s *service) update(entity *v1.User, parameters apiInterface.Parameters) (*
 	if len(entity.Spec.LastName) == 0 {
 		entity.Spec.LastName = oldEntity.Spec.LastName
 	}
+	// the groups are synced from the OAuth / OIDC providers only, they cannot be changed through the API
+	entity.Spec.Groups = oldEntity.Spec.Groups
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(err).Errorf("unable to perform the update of the user %q", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	publicEntity := v1.NewPublicUser(entity)
+	s.broadcaster.Publish(v1.WatchEventModified, publicEntity)
 	// Refreshing RBAC cache as the user's associated role may be updated, which can add or remove permissions.
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
 	}
-	return v1.NewPublicUser(entity), nil
+	return publicEntity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	err := s.dao.Delete(parameters.Name)
+	// The user is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
 	if err != nil {
 		return err
 	}
+	// The access tokens are revoked before the user, so they can't be used by a new user having the same name.
+	// The deletion fails when they can't be revoked, as they would otherwise outlive their owner.
+	if err := s.accessTokenDAO.DeleteAll(v1.Subject{Kind: v1.KindUser, Name: parameters.Name}); err != nil {
+		logrus.WithError(err).Errorf("unable to delete the access tokens of the user %q", parameters.Name)
+		return apiInterface.InternalError
+	}
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	if err := s.sessionDAO.DeleteAll(parameters.Name); err != nil {
+		logrus.WithError(err).Errorf("unable to revoke the sessions of the user %q", parameters.Name)
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, v1.NewPublicUser(oldEntity))
 	// Refreshing RBAC cache as the user's associated role may be updated, which can add or remove permissions.
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
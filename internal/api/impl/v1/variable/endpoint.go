@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/route"
@@ -34,9 +35,9 @@ type endpoint struct {
 	isDisable bool
 }
 
-func NewEndpoint(cfg config.VariableConfig, service variable.Service, authz authorization.Authorization, readonly bool, caseSensitive bool) route.Endpoint {
+func NewEndpoint(cfg config.VariableConfig, service variable.Service, authz authorization.Authorization, auditor audit.Auditor, readonly bool, caseSensitive bool) route.Endpoint {
 	return &endpoint{
-		toolbox:   toolbox.New[*v1.Variable, *v1.Variable, *variable.Query](service, authz, v1.KindVariable, caseSensitive),
+		toolbox:   toolbox.New[*v1.Variable, *v1.Variable, *variable.Query](service, authz, auditor, v1.KindVariable, caseSensitive),
 		readonly:  readonly,
 		isDisable: cfg.Project.Disable,
 	}
@@ -22,7 +22,9 @@ import (
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/search"
 	"github.com/perses/perses/internal/api/validate"
+	"github.com/perses/perses/internal/api/watch"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
@@ -30,14 +32,18 @@ import (
 
 type service struct {
 	variable.Service
-	dao variable.DAO
-	sch schema.Schema
+	dao         variable.DAO
+	sch         schema.Schema
+	index       search.Index
+	broadcaster watch.Broadcaster
 }
 
-func NewService(dao variable.DAO, sch schema.Schema) variable.Service {
+func NewService(dao variable.DAO, sch schema.Schema, index search.Index, broadcaster watch.Broadcaster) variable.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:         dao,
+		sch:         sch,
+		index:       index,
+		broadcaster: broadcaster,
 	}
 }
 
@@ -58,6 +64,8 @@ func (s *service) create(entity *v1.Variable) (*v1.Variable, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	s.index.Invalidate()
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
 	return entity, nil
 }
 
@@ -90,16 +98,31 @@ func (s *service) update(entity *v1.Variable, parameters apiInterface.Parameters
 	if err != nil {
 		return nil, err
 	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Variable %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	s.index.Invalidate()
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	// The Variable is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	s.index.Invalidate()
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Variable, error) {
@@ -111,7 +134,9 @@ func (s *service) List(q *variable.Query, params apiInterface.Parameters) ([]*v1
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.List(query)
+	list, err := s.dao.List(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawList(q *variable.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -119,7 +144,9 @@ func (s *service) RawList(q *variable.Query, params apiInterface.Parameters) ([]
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawList(query)
+	list, err := s.dao.RawList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) MetadataList(q *variable.Query, params apiInterface.Parameters) ([]api.Entity, error) {
@@ -127,7 +154,9 @@ func (s *service) MetadataList(q *variable.Query, params apiInterface.Parameters
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.MetadataList(query)
+	list, err := s.dao.MetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func (s *service) RawMetadataList(q *variable.Query, params apiInterface.Parameters) ([]json.RawMessage, error) {
@@ -135,11 +164,14 @@ func (s *service) RawMetadataList(q *variable.Query, params apiInterface.Paramet
 	if err != nil {
 		return nil, err
 	}
-	return s.dao.RawMetadataList(query)
+	list, err := s.dao.RawMetadataList(query)
+	q.Pagination.SetNextToken(query.Pagination.Next())
+	return list, err
 }
 
 func manageQuery(q *variable.Query, params apiInterface.Parameters) (*variable.Query, error) {
 	// Query is copied because it can be modified by the toolbox.go: listWhenPermissionIsActivated(...) and need to `q` need to keep initial value
+	// As a consequence, the token to get the next page must be forwarded to `q` once the query is executed.
 	query, err := deep.Copy(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to copy the query: %w", err)
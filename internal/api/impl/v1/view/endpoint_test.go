@@ -64,6 +64,10 @@ func (t *testRBAC) Middleware(_ middleware.Skipper) echo.MiddlewareFunc {
 	}
 }
 
+func (t *testRBAC) CheckCredentials(_ echo.Context) error {
+	return nil
+}
+
 func (t *testRBAC) GetPermissions(_ echo.Context) (map[string][]*role.Permission, error) {
 	return map[string][]*role.Permission{}, nil
 }
@@ -72,6 +76,10 @@ func (t *testRBAC) HasPermission(_ echo.Context, _ role.Action, _ string, _ role
 	return t.allow
 }
 
+func (t *testRBAC) HasResourcePermission(_ echo.Context, _ role.Action, _ string, _ role.Scope, _ string) bool {
+	return t.allow
+}
+
 func (t *testRBAC) IsEnabled() bool {
 	return true
 }
@@ -128,6 +136,22 @@ func (*mockDashboardService) RawMetadataList(_ *dashboard.Query, _ apiInterface.
 	panic("unimplemented")
 }
 
+func (*mockDashboardService) ListRevisions(_ apiInterface.Parameters) ([]*v1.RevisionMetadata, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) GetRevision(_ apiInterface.Parameters, _ uint64) (*v1.DashboardRevision, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) DiffRevisions(_ apiInterface.Parameters, _ uint64, _ uint64) (*v1.RevisionDiff, error) {
+	panic("unimplemented")
+}
+
+func (*mockDashboardService) RestoreRevision(_ echo.Context, _ apiInterface.Parameters, _ uint64) (*v1.Dashboard, error) {
+	panic("unimplemented")
+}
+
 func TestEndpoint(t *testing.T) {
 	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{true}, &mockDashboardService{&v1.Dashboard{}}).(*endpoint)
 
@@ -0,0 +1,207 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	watchBroadcaster "github.com/perses/perses/internal/api/watch"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	mimeEventStream = "text/event-stream"
+	mimeJSONLines   = "application/x-ndjson"
+	// keepAliveInterval is the interval between two comments sent on a Server-Sent Events stream without any event,
+	// so the proxies between Perses and the client don't close the connection.
+	keepAliveInterval = 30 * time.Second
+	// credentialsCheckInterval is the interval between two checks of the credentials of the watcher. The stream ends
+	// once they are not valid anymore, like when the JWT expires or the session is revoked.
+	credentialsCheckInterval = 30 * time.Second
+)
+
+type endpoint struct {
+	broadcaster watchBroadcaster.Broadcaster
+	authz       authorization.Authorization
+}
+
+func NewEndpoint(broadcaster watchBroadcaster.Broadcaster, authz authorization.Authorization) route.Endpoint {
+	return &endpoint{
+		broadcaster: broadcaster,
+		authz:       authz,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	g.GET(fmt.Sprintf("/%s", utils.PathWatch), e.Watch, false)
+}
+
+// Watch streams the changes made on the resources, until the client closes the connection, its credentials are not
+// valid anymore or Perses stops.
+// The events are sent as Server-Sent Events, or as JSON lines when the client accepts `application/x-ndjson`.
+func (e *endpoint) Watch(ctx echo.Context) error {
+	filter, err := parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	if permErr := e.checkPermission(ctx, filter); permErr != nil {
+		return permErr
+	}
+	isJSONLines := strings.Contains(ctx.Request().Header.Get(echo.HeaderAccept), mimeJSONLines)
+	subscription := e.broadcaster.Subscribe(filter)
+	defer e.broadcaster.Unsubscribe(subscription)
+
+	response := ctx.Response()
+	if isJSONLines {
+		response.Header().Set(echo.HeaderContentType, mimeJSONLines)
+	} else {
+		response.Header().Set(echo.HeaderContentType, mimeEventStream)
+	}
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	credentialsCheck := time.NewTicker(credentialsCheckInterval)
+	defer credentialsCheck.Stop()
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return nil
+			}
+			if !e.isAllowed(ctx, event) {
+				continue
+			}
+			if writeErr := writeEvent(response, event, isJSONLines); writeErr != nil {
+				// The response has already started, the error can only be logged.
+				logrus.WithError(writeErr).Debug("unable to send the event to the watcher")
+				return nil
+			}
+		case <-keepAlive.C:
+			if isJSONLines {
+				continue
+			}
+			if _, writeErr := response.Write([]byte(": keep-alive\n\n")); writeErr != nil {
+				return nil
+			}
+			response.Flush()
+		case <-credentialsCheck.C:
+			if checkErr := e.authz.CheckCredentials(ctx); checkErr != nil {
+				// The response has already started, the stream can only be closed. The client must authenticate again.
+				logrus.WithError(checkErr).Debug("closing the stream of a watcher whose credentials are not valid anymore")
+				return nil
+			}
+		}
+	}
+}
+
+// checkPermission rejects the request when the user can't read one of the kinds explicitly requested.
+// The events are filtered afterward anyway, as the permissions of the user can change while the stream is open.
+func (e *endpoint) checkPermission(ctx echo.Context, filter watchBroadcaster.Filter) error {
+	if !e.authz.IsEnabled() {
+		return nil
+	}
+	for _, kind := range filter.Kinds {
+		scope, err := role.GetScope(string(kind))
+		if err != nil {
+			return err
+		}
+		if role.IsGlobalScope(*scope) {
+			if !e.canReadSome(ctx, v1.WildcardProject, *scope) {
+				return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, *scope))
+			}
+			continue
+		}
+		if len(filter.Project) > 0 && !e.canReadSome(ctx, filter.Project, *scope) {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, filter.Project, *scope))
+		}
+	}
+	return nil
+}
+
+// canReadSome returns true if the user can read at least some resources of the scope in the project,
+// as the permission can be restricted to some resource names.
+func (e *endpoint) canReadSome(ctx echo.Context, project string, scope role.Scope) bool {
+	if e.authz.HasPermission(ctx, role.ReadAction, project, scope) {
+		return true
+	}
+	projects, err := e.authz.GetUserProjects(ctx, role.ReadAction, scope)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(projects, v1.WildcardProject) || slices.Contains(projects, project)
+}
+
+// isAllowed returns true if the user can read the resource concerned by the event.
+func (e *endpoint) isAllowed(ctx echo.Context, event *v1.WatchEvent) bool {
+	if !e.authz.IsEnabled() {
+		return true
+	}
+	scope, err := role.GetScope(string(event.Kind))
+	if err != nil {
+		return false
+	}
+	if role.IsGlobalScope(*scope) {
+		return e.authz.HasResourcePermission(ctx, role.ReadAction, v1.WildcardProject, *scope, event.Name)
+	}
+	// For a project, event.Project is the name of the project itself.
+	return e.authz.HasResourcePermission(ctx, role.ReadAction, event.Project, *scope, event.Name)
+}
+
+func writeEvent(response *echo.Response, event *v1.WatchEvent, isJSONLines bool) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if isJSONLines {
+		data = append(data, '\n')
+	} else {
+		data = []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data))
+	}
+	if _, err := response.Write(data); err != nil {
+		return err
+	}
+	response.Flush()
+	return nil
+}
+
+func parseFilter(ctx echo.Context) (watchBroadcaster.Filter, error) {
+	filter := watchBroadcaster.Filter{
+		Project: ctx.QueryParam("project"),
+	}
+	for _, value := range ctx.QueryParams()["kind"] {
+		kind, err := v1.GetKind(value)
+		if err != nil {
+			return filter, apiInterface.HandleBadRequestError(err.Error())
+		}
+		filter.Kinds = append(filter.Kinds, *kind)
+	}
+	return filter, nil
+}
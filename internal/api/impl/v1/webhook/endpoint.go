@@ -0,0 +1,123 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const defaultDeliveryLimit = 100
+
+type endpoint struct {
+	toolbox       toolbox.Toolbox[*v1.Webhook, *webhook.Query]
+	service       webhook.Service
+	authz         authorization.Authorization
+	readonly      bool
+	caseSensitive bool
+}
+
+func NewEndpoint(service webhook.Service, authz authorization.Authorization, auditor audit.Auditor, readonly bool, caseSensitive bool) route.Endpoint {
+	return &endpoint{
+		toolbox:       toolbox.New[*v1.Webhook, *v1.Webhook, *webhook.Query](service, authz, auditor, v1.KindWebhook, caseSensitive),
+		service:       service,
+		authz:         authz,
+		readonly:      readonly,
+		caseSensitive: caseSensitive,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	group := g.Group(fmt.Sprintf("/%s", utils.PathWebhook))
+
+	if !e.readonly {
+		group.POST("", e.Create, false)
+		group.PUT(fmt.Sprintf("/:%s", utils.ParamName), e.Update, false)
+		group.DELETE(fmt.Sprintf("/:%s", utils.ParamName), e.Delete, false)
+	}
+	group.GET("", e.List, false)
+	group.GET(fmt.Sprintf("/:%s", utils.ParamName), e.Get, false)
+	group.GET(fmt.Sprintf("/:%s/%s", utils.ParamName, utils.PathDelivery), e.ListDeliveries, false)
+}
+
+func (e *endpoint) Create(ctx echo.Context) error {
+	entity := &v1.Webhook{}
+	return e.toolbox.Create(ctx, entity)
+}
+
+func (e *endpoint) Update(ctx echo.Context) error {
+	entity := &v1.Webhook{}
+	return e.toolbox.Update(ctx, entity)
+}
+
+func (e *endpoint) Delete(ctx echo.Context) error {
+	return e.toolbox.Delete(ctx)
+}
+
+func (e *endpoint) Get(ctx echo.Context) error {
+	return e.toolbox.Get(ctx)
+}
+
+func (e *endpoint) List(ctx echo.Context) error {
+	q := &webhook.Query{}
+	return e.toolbox.List(ctx, q)
+}
+
+func (e *endpoint) ListDeliveries(ctx echo.Context) error {
+	if e.authz.IsEnabled() {
+		if ok := e.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, role.WebhookScope); !ok {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, role.WebhookScope))
+		}
+	}
+	q, err := parseDeliveryQuery(ctx)
+	if err != nil {
+		return err
+	}
+	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
+	deliveries, err := e.service.ListDeliveries(parameters, q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, deliveries)
+}
+
+func parseDeliveryQuery(ctx echo.Context) (*webhook.DeliveryQuery, error) {
+	q := &webhook.DeliveryQuery{Limit: defaultDeliveryLimit}
+	switch status := v1.WebhookDeliveryStatus(ctx.QueryParam("status")); status {
+	case "", v1.WebhookDeliveryPending, v1.WebhookDeliverySuccess, v1.WebhookDeliveryFailed:
+		q.Status = status
+	default:
+		return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("unknown status %q, valid values are %q, %q and %q", status, v1.WebhookDeliveryPending, v1.WebhookDeliverySuccess, v1.WebhookDeliveryFailed))
+	}
+	if limit := ctx.QueryParam("limit"); len(limit) > 0 {
+		var err error
+		q.Limit, err = strconv.Atoi(limit)
+		if err != nil || q.Limit <= 0 {
+			return nil, apiInterface.HandleBadRequestError(fmt.Sprintf("'limit' must be a positive integer, got %q", limit))
+		}
+	}
+	return q, nil
+}
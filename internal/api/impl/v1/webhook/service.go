@@ -0,0 +1,145 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/brunoga/deep"
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
+	"github.com/perses/perses/internal/api/watch"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	webhook.Service
+	dao         webhook.DAO
+	broadcaster watch.Broadcaster
+}
+
+func NewService(dao webhook.DAO, broadcaster watch.Broadcaster) webhook.Service {
+	return &service{
+		dao:         dao,
+		broadcaster: broadcaster,
+	}
+}
+
+func (s *service) Create(_ echo.Context, entity *v1.Webhook) (*v1.Webhook, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.create(copyEntity)
+}
+
+func (s *service) create(entity *v1.Webhook) (*v1.Webhook, error) {
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	s.broadcaster.Publish(v1.WatchEventAdded, entity)
+	return entity, nil
+}
+
+func (s *service) Update(_ echo.Context, entity *v1.Webhook, parameters apiInterface.Parameters) (*v1.Webhook, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.update(copyEntity, parameters)
+}
+
+func (s *service) update(entity *v1.Webhook, parameters apiInterface.Parameters) (*v1.Webhook, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in Webhook %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	// find the previous version of the Webhook
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if versionErr := parameters.CheckVersion(oldEntity.Metadata.Version); versionErr != nil {
+		return nil, versionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the Webhook %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	s.broadcaster.Publish(v1.WatchEventModified, entity)
+	return entity, nil
+}
+
+func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	// The Webhook is read before being deleted, so the watchers receive its last state.
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	// The pending deliveries are dropped with the webhook.
+	if err := s.dao.DeleteDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: parameters.Name}); err != nil {
+		logrus.WithError(err).Errorf("unable to delete the deliveries of the Webhook %q", parameters.Name)
+	}
+	s.broadcaster.Publish(v1.WatchEventDeleted, oldEntity)
+	return nil
+}
+
+func (s *service) Get(parameters apiInterface.Parameters) (*v1.Webhook, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q *webhook.Query, _ apiInterface.Parameters) ([]*v1.Webhook, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) RawList(q *webhook.Query, _ apiInterface.Parameters) ([]json.RawMessage, error) {
+	return s.dao.RawList(q)
+}
+
+func (s *service) MetadataList(q *webhook.Query, _ apiInterface.Parameters) ([]api.Entity, error) {
+	return s.dao.MetadataList(q)
+}
+
+func (s *service) RawMetadataList(q *webhook.Query, _ apiInterface.Parameters) ([]json.RawMessage, error) {
+	return s.dao.RawMetadataList(q)
+}
+
+func (s *service) ListDeliveries(parameters apiInterface.Parameters, q *webhook.DeliveryQuery) ([]*v1.WebhookDelivery, error) {
+	// Returns a 404 when the webhook doesn't exist.
+	if _, err := s.dao.Get(parameters.Name); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.dao.QueryDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: parameters.Name, Status: q.Status})
+	if err != nil {
+		return nil, err
+	}
+	// The queue is stored the oldest first, the API shows the latest first.
+	slices.Reverse(deliveries)
+	if q.Limit > 0 && len(deliveries) > q.Limit {
+		deliveries = deliveries[:q.Limit]
+	}
+	return deliveries, nil
+}
@@ -35,10 +35,14 @@ var (
 	InternalError        = &PersesError{message: "internal server error"}
 	NotFoundError        = &PersesError{message: "document not found"}
 	ConflictError        = &PersesError{message: "document already exists"}
+	VersionConflictError = &PersesError{message: "document has been modified"}
 	BadRequestError      = &PersesError{message: "bad request"}
 	UnauthorizedError    = &PersesError{message: "unauthorized"}
 	ForbiddenError       = &PersesError{message: "forbidden access"}
 	UnsupportedMediaType = &PersesError{message: "unsupported media type"}
+	// UnchangedError is returned with the stored document when an update doesn't modify it.
+	// It is not a failure: the callers answer with the document without recording anything.
+	UnchangedError = &PersesError{message: "document is unchanged"}
 )
 
 // HandleError is translating the given error to the echo.HTTPError
@@ -53,6 +57,9 @@ func HandleError(err error) error {
 	if databaseModel.IsKeyConflict(err) {
 		return echo.NewHTTPError(http.StatusConflict, ConflictError.message)
 	}
+	if databaseModel.IsVersionConflict(err) {
+		return echo.NewHTTPError(http.StatusConflict, VersionConflictError.message)
+	}
 
 	if errors.Is(err, InternalError) {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -60,6 +67,9 @@ func HandleError(err error) error {
 	if errors.Is(err, ConflictError) {
 		return echo.NewHTTPError(http.StatusConflict, err.Error())
 	}
+	if errors.Is(err, VersionConflictError) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
 	if errors.Is(err, NotFoundError) {
 		return echo.NewHTTPError(http.StatusNotFound, err.Error())
 	}
@@ -90,6 +100,10 @@ func HandleNotFoundError(msg string) error {
 	return handleErrorMsg(msg, NotFoundError)
 }
 
+func HandleVersionConflictError(msg string) error {
+	return handleErrorMsg(msg, VersionConflictError)
+}
+
 func HandleBadRequestError(msg string) error {
 	return handleErrorMsg(msg, BadRequestError)
 }
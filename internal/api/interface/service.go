@@ -15,6 +15,7 @@ package apiinterface
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/labstack/echo/v4"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
@@ -24,6 +25,18 @@ import (
 type Parameters struct {
 	Project string
 	Name    string
+	// Version is the version of the resource the client expects to modify.
+	// When nil, the resource is modified whatever its current version is.
+	Version *uint64
+}
+
+// CheckVersion returns an error if the client expects a version of the resource different from the current one.
+// It means the resource has been modified since the client read it.
+func (p Parameters) CheckVersion(currentVersion uint64) error {
+	if p.Version != nil && *p.Version != currentVersion {
+		return HandleVersionConflictError(fmt.Sprintf("the version %d doesn't match the current version %d of the resource", *p.Version, currentVersion))
+	}
+	return nil
 }
 
 type Service[T api.Entity, K api.Entity, V databaseModel.Query] interface {
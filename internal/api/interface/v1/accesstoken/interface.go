@@ -0,0 +1,38 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type DAO interface {
+	Create(token *databaseModel.AccessToken) error
+	Update(token *databaseModel.AccessToken) error
+	// Get returns the access token having the given ID, whoever owns it.
+	Get(id string) (*databaseModel.AccessToken, error)
+	// List returns the access tokens owned by the subject, the oldest first.
+	List(subject v1.Subject) ([]*databaseModel.AccessToken, error)
+	Delete(id string) error
+	// DeleteAll removes every access token owned by the subject.
+	DeleteAll(subject v1.Subject) error
+}
+
+type Service interface {
+	Create(subject v1.Subject, request *v1.AccessTokenRequest) (*v1.CreatedAccessToken, error)
+	// Delete revokes the access token. The token must be owned by the subject.
+	Delete(subject v1.Subject, id string) error
+	List(subject v1.Subject) ([]*v1.AccessToken, error)
+}
@@ -16,6 +16,7 @@ package dashboard
 import (
 	"encoding/json"
 
+	"github.com/labstack/echo/v4"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/pkg/model/api"
@@ -27,6 +28,10 @@ type Query struct {
 	// NamePrefix is a prefix of the Dashboard.metadata.name that is used to filter the Dashboard list.
 	// It can be empty in case you want to return the full list of dashboards available.
 	NamePrefix string `query:"name"`
+	// LabelSelector is used to filter the list of the Dashboard based on their labels (e.g. `team=sre,tier!=dev`).
+	LabelSelector databaseModel.LabelSelector `query:"label_selector"`
+	// Pagination is used to get the list page by page and to sort it.
+	Pagination databaseModel.Pagination
 	// Project is the exact name of the project.
 	// The value can come from the path of the URL or from the query parameter
 	Project      string `param:"project" query:"project"`
@@ -45,6 +50,10 @@ func (q *Query) IsRawMetadataQueryAllowed() bool {
 	return true
 }
 
+func (q *Query) GetPagination() *databaseModel.Pagination {
+	return &q.Pagination
+}
+
 type DAO interface {
 	Create(entity *v1.Dashboard) error
 	Update(entity *v1.Dashboard) error
@@ -55,9 +64,20 @@ type DAO interface {
 	RawList(q *Query) ([]json.RawMessage, error)
 	MetadataList(q *Query) ([]api.Entity, error)
 	RawMetadataList(q *Query) ([]json.RawMessage, error)
+	CreateRevision(entity *v1.Dashboard, author string, maxRevisions int) error
+	GetRevision(project string, name string, version uint64) (*v1.DashboardRevision, error)
+	ListRevisions(project string, name string) ([]*v1.RevisionMetadata, error)
+	DeleteRevisions(project string, name string) error
 }
 
 type Service interface {
 	apiInterface.Service[*v1.Dashboard, *v1.Dashboard, *Query]
 	Validate(entity *v1.Dashboard) error
+	// ListRevisions returns the revisions kept for the dashboard, the latest first.
+	ListRevisions(parameters apiInterface.Parameters) ([]*v1.RevisionMetadata, error)
+	GetRevision(parameters apiInterface.Parameters, version uint64) (*v1.DashboardRevision, error)
+	// DiffRevisions returns the JSON patch to apply to the revision `from` to get the revision `to`.
+	DiffRevisions(parameters apiInterface.Parameters, from uint64, to uint64) (*v1.RevisionDiff, error)
+	// RestoreRevision replaces the current dashboard by the content of the given revision. The result is a new version of the dashboard.
+	RestoreRevision(ctx echo.Context, parameters apiInterface.Parameters, version uint64) (*v1.Dashboard, error)
 }
@@ -24,6 +24,10 @@ type Query struct {
 	// NamePrefix is a prefix of the GlobalDatasource.metadata.name that is used to filter the list of the GlobalDatasource.
 	// NamePrefix can be empty in case you want to return the full list of GlobalDatasource available.
 	NamePrefix string `query:"name"`
+	// LabelSelector is used to filter the list of the GlobalDatasource based on their labels (e.g. `team=sre,tier!=dev`).
+	LabelSelector databaseModel.LabelSelector `query:"label_selector"`
+	// Pagination is used to get the list page by page and to sort it.
+	Pagination databaseModel.Pagination
 	// Kind is the type of the datasource.
 	Kind string `query:"kind"`
 	// Default will filter the list of datasource and return only the default datasource, whatever the kind of the datasource is.
@@ -42,6 +46,10 @@ func (q *Query) IsRawMetadataQueryAllowed() bool {
 	return false
 }
 
+func (q *Query) GetPagination() *databaseModel.Pagination {
+	return &q.Pagination
+}
+
 type DAO interface {
 	Create(entity *v1.GlobalDatasource) error
 	Update(entity *v1.GlobalDatasource) error
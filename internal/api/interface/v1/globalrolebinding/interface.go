@@ -26,8 +26,12 @@ type Query struct {
 	databaseModel.Query
 	// NamePrefix is a prefix of the GlobalRoleBinding.metadata.name that is used to filter the list of the GlobalRoleBinding.
 	// NamePrefix can be empty in case you want to return the full list of GlobalRoleBinding available.
-	NamePrefix   string `query:"name"`
-	MetadataOnly bool   `query:"metadata_only"`
+	NamePrefix string `query:"name"`
+	// LabelSelector is used to filter the list of the GlobalRoleBinding based on their labels (e.g. `team=sre,tier!=dev`).
+	LabelSelector databaseModel.LabelSelector `query:"label_selector"`
+	// Pagination is used to get the list page by page and to sort it.
+	Pagination   databaseModel.Pagination
+	MetadataOnly bool `query:"metadata_only"`
 }
 
 func (q *Query) GetMetadataOnlyQueryParam() bool {
@@ -42,6 +46,10 @@ func (q *Query) IsRawMetadataQueryAllowed() bool {
 	return true
 }
 
+func (q *Query) GetPagination() *databaseModel.Pagination {
+	return &q.Pagination
+}
+
 type DAO interface {
 	Create(entity *v1.GlobalRoleBinding) error
 	Update(entity *v1.GlobalRoleBinding) error
@@ -27,6 +27,10 @@ type Query struct {
 	// NamePrefix is a prefix of the Secret.metadata.name that is used to filter the list of the Secret.
 	// NamePrefix can be empty in case you want to return the full list of Secret available.
 	NamePrefix string `query:"name"`
+	// LabelSelector is used to filter the list of the Secret based on their labels (e.g. `team=sre,tier!=dev`).
+	LabelSelector databaseModel.LabelSelector `query:"label_selector"`
+	// Pagination is used to get the list page by page and to sort it.
+	Pagination databaseModel.Pagination
 	// Project is the exact name of the project.
 	// The value can come from the path of the URL or from the query parameter
 	Project      string `param:"project" query:"project"`
@@ -45,6 +49,10 @@ func (q *Query) IsRawMetadataQueryAllowed() bool {
 	return true
 }
 
+func (q *Query) GetPagination() *databaseModel.Pagination {
+	return &q.Pagination
+}
+
 type DAO interface {
 	Create(entity *v1.Secret) error
 	Update(entity *v1.Secret) error
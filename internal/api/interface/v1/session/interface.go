@@ -0,0 +1,43 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"time"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type DAO interface {
+	Create(session *v1.Session) error
+	Update(session *v1.Session) error
+	// Get returns the session having the given ID, whoever owns it.
+	Get(id string) (*v1.Session, error)
+	// List returns the sessions of the user, the oldest first.
+	List(username string) ([]*v1.Session, error)
+	Delete(id string) error
+	// DeleteAll removes every session of the user.
+	DeleteAll(username string) error
+	// DeleteExpired removes the sessions expired before the given time.
+	DeleteExpired(before time.Time) error
+}
+
+type Service interface {
+	// List returns the sessions of the user that are not expired.
+	List(username string) ([]*v1.Session, error)
+	// Delete revokes the session. The session must belong to the user.
+	Delete(username string, id string) error
+	// DeleteAll revokes every session of the user.
+	DeleteAll(username string) error
+}
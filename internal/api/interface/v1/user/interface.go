@@ -26,8 +26,12 @@ type Query struct {
 	databaseModel.Query
 	// NamePrefix is a prefix of the User.metadata.name that is used to filter the list of the User.
 	// NamePrefix can be empty in case you want to return the full list of User available.
-	NamePrefix   string `query:"name"`
-	MetadataOnly bool   `query:"metadata_only"`
+	NamePrefix string `query:"name"`
+	// LabelSelector is used to filter the list of the User based on their labels (e.g. `team=sre,tier!=dev`).
+	LabelSelector databaseModel.LabelSelector `query:"label_selector"`
+	// Pagination is used to get the list page by page and to sort it.
+	Pagination   databaseModel.Pagination
+	MetadataOnly bool `query:"metadata_only"`
 }
 
 func (q *Query) GetMetadataOnlyQueryParam() bool {
@@ -42,6 +46,10 @@ func (q *Query) IsRawMetadataQueryAllowed() bool {
 	return true
 }
 
+func (q *Query) GetPagination() *databaseModel.Pagination {
+	return &q.Pagination
+}
+
 type DAO interface {
 	Create(entity *v1.User) error
 	Update(entity *v1.User) error
@@ -55,3 +63,17 @@ type DAO interface {
 type Service interface {
 	apiInterface.Service[*v1.User, *v1.PublicUser, *Query]
 }
+
+// TrustedUser is a user authenticated by a trusted reverse proxy, as described by the headers of the request.
+type TrustedUser struct {
+	Login string
+	Email string
+	// Groups is nil when the proxy is not configured to send the groups of the user.
+	Groups []string
+}
+
+// Synchronizer creates or updates the users authenticated by a trusted reverse proxy,
+// the same way as the users logging in with an external provider.
+type Synchronizer interface {
+	SyncTrustedUser(usr TrustedUser) error
+}
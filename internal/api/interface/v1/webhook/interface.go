@@ -0,0 +1,79 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the Webhook.metadata.name that is used to filter the list of the Webhook.
+	// NamePrefix can be empty in case you want to return the full list of Webhook available.
+	NamePrefix string `query:"name"`
+	// LabelSelector is used to filter the list of the Webhook based on their labels (e.g. `team=sre,tier!=dev`).
+	LabelSelector databaseModel.LabelSelector `query:"label_selector"`
+	// Pagination is used to get the list page by page and to sort it.
+	Pagination   databaseModel.Pagination
+	MetadataOnly bool `query:"metadata_only"`
+}
+
+func (q *Query) GetMetadataOnlyQueryParam() bool {
+	return q.MetadataOnly
+}
+
+func (q *Query) IsRawQueryAllowed() bool {
+	return true
+}
+
+func (q *Query) IsRawMetadataQueryAllowed() bool {
+	return true
+}
+
+func (q *Query) GetPagination() *databaseModel.Pagination {
+	return &q.Pagination
+}
+
+// DeliveryQuery is used to filter the deliveries of a webhook.
+type DeliveryQuery struct {
+	Status v1.WebhookDeliveryStatus
+	// Limit is the maximum number of deliveries returned, the latest first. 0 means no limit.
+	Limit int
+}
+
+type DAO interface {
+	Create(entity *v1.Webhook) error
+	Update(entity *v1.Webhook) error
+	Delete(name string) error
+	Get(name string) (*v1.Webhook, error)
+	List(q *Query) ([]*v1.Webhook, error)
+	RawList(q *Query) ([]json.RawMessage, error)
+	MetadataList(q *Query) ([]api.Entity, error)
+	RawMetadataList(q *Query) ([]json.RawMessage, error)
+	CreateDelivery(delivery *v1.WebhookDelivery) error
+	UpdateDelivery(delivery *v1.WebhookDelivery) error
+	QueryDeliveries(q *databaseModel.WebhookDeliveryQuery) ([]*v1.WebhookDelivery, error)
+	DeleteDeliveries(q *databaseModel.WebhookDeliveryQuery) error
+}
+
+type Service interface {
+	apiInterface.Service[*v1.Webhook, *v1.Webhook, *Query]
+	// ListDeliveries returns the deliveries of the webhook, the latest first.
+	ListDeliveries(parameters apiInterface.Parameters, q *DeliveryQuery) ([]*v1.WebhookDelivery, error)
+}
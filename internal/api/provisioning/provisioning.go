@@ -15,9 +15,11 @@ package provisioning
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/audit"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/dependency"
 	apiInterface "github.com/perses/perses/internal/api/interface"
@@ -25,6 +27,7 @@ import (
 	"github.com/perses/perses/internal/cli/resource"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/sirupsen/logrus"
 )
 
@@ -85,21 +88,35 @@ func (p *provisioning) applyEntity(entities []modelAPI.Entity) {
 			continue
 		}
 
+		auditor := p.serviceManager.GetAuditor()
 		// the document doesn't exist, so we have to create it.
-		_, createErr := createFun()
+		created, createErr := createFun()
 
 		if createErr == nil {
+			event := audit.NewEvent(modelV1.AuditOriginProvisioning, role.CreateAction, kind, project, name)
+			event.NewVersion = audit.GetVersion(created)
+			auditor.Record(nil, event, nil)
 			continue
 		}
 
 		if !databaseModel.IsKeyConflict(createErr) {
 			logrus.WithError(createErr).Errorf("unable to create the %q %q", kind, name)
+			auditor.Record(nil, audit.NewEvent(modelV1.AuditOriginProvisioning, role.CreateAction, kind, project, name), createErr)
 			continue
 		}
 
-		if _, updateError := updateFunc(); updateError != nil {
+		updated, updateError := updateFunc()
+		if errors.Is(updateError, apiInterface.UnchangedError) {
+			// The resource is already provisioned as it is described in the file.
+			continue
+		}
+		event := audit.NewEvent(modelV1.AuditOriginProvisioning, role.UpdateAction, kind, project, name)
+		if updateError != nil {
 			logrus.WithError(updateError).Errorf("unable to update the %q %q", kind, name)
+		} else {
+			audit.SetUpdatedVersion(event, updated)
 		}
+		auditor.Record(nil, event, updateError)
 	}
 }
 
@@ -0,0 +1,105 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// prefixFactor reduces the score of a word that only starts with the searched term.
+const prefixFactor = 0.5
+
+type posting struct {
+	document int
+	weight   float64
+}
+
+// content is an inverted index: for every word, it gives the documents containing it.
+type content struct {
+	documents []document
+	// words is the sorted list of the indexed words. It is used to find the words starting with a term.
+	words    []string
+	postings map[string][]posting
+}
+
+func newContent(documents []document) *content {
+	c := &content{
+		documents: documents,
+		postings:  make(map[string][]posting),
+	}
+	for i, doc := range documents {
+		// A word can appear in several fields of the document, only the best weight is kept.
+		weights := make(map[string]float64)
+		for _, f := range doc.fields {
+			for _, word := range tokenize(f.text) {
+				if f.weight > weights[word] {
+					weights[word] = f.weight
+				}
+			}
+		}
+		for word, weight := range weights {
+			c.postings[word] = append(c.postings[word], posting{document: i, weight: weight})
+		}
+	}
+	c.words = make([]string, 0, len(c.postings))
+	for word := range c.postings {
+		c.words = append(c.words, word)
+	}
+	sort.Strings(c.words)
+	return c
+}
+
+// score returns the score of every document containing all the terms.
+// A term matches the words it is equal to, and with a lower score the words it is a prefix of.
+func (c *content) score(terms []string) map[int]float64 {
+	var result map[int]float64
+	for _, term := range terms {
+		termScores := make(map[int]float64)
+		for i := sort.SearchStrings(c.words, term); i < len(c.words) && strings.HasPrefix(c.words[i], term); i++ {
+			word := c.words[i]
+			factor := 1.0
+			if word != term {
+				factor = prefixFactor
+			}
+			for _, p := range c.postings[word] {
+				if score := p.weight * factor; score > termScores[p.document] {
+					termScores[p.document] = score
+				}
+			}
+		}
+		if result == nil {
+			result = termScores
+			continue
+		}
+		// Only the documents matching every term are kept.
+		for doc, score := range result {
+			termScore, ok := termScores[doc]
+			if !ok {
+				delete(result, doc)
+				continue
+			}
+			result[doc] = score + termScore
+		}
+	}
+	return result
+}
+
+// tokenize splits the text into lower case words. Every character that is not a letter or a digit is a separator.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
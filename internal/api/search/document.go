@@ -0,0 +1,155 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"sort"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	dashboardModel "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+)
+
+// The weights give more importance to the names than to the descriptions and the queries.
+const (
+	weightDisplayName = 5
+	weightName        = 4
+	weightDescription = 2
+	weightParent      = 1
+	weightQuery       = 1
+)
+
+// queryFields are the fields of the query plugins containing the query expression.
+var queryFields = map[string]bool{
+	"query":      true,
+	"expr":       true,
+	"expression": true,
+}
+
+type field struct {
+	text   string
+	weight float64
+}
+
+// document is an element that can be found by the search.
+type document struct {
+	result v1.SearchResult
+	fields []field
+}
+
+func dashboardDocuments(dash *v1.Dashboard) []document {
+	project := dash.Metadata.Project
+	name := dash.Metadata.Name
+	var displayName, description string
+	if dash.Spec.Display != nil {
+		displayName = dash.Spec.Display.Name
+		description = dash.Spec.Display.Description
+	}
+	documents := []document{
+		{
+			result: v1.SearchResult{Kind: v1.SearchResultKindDashboard, Project: project, Name: name, DisplayName: displayName},
+			fields: []field{{text: displayName, weight: weightDisplayName}, {text: name, weight: weightName}, {text: description, weight: weightDescription}},
+		},
+	}
+	// The panels are sorted to always build the same index for the same dashboards.
+	panelKeys := make([]string, 0, len(dash.Spec.Panels))
+	for key := range dash.Spec.Panels {
+		panelKeys = append(panelKeys, key)
+	}
+	sort.Strings(panelKeys)
+	for _, key := range panelKeys {
+		panel := dash.Spec.Panels[key]
+		if panel == nil {
+			continue
+		}
+		fields := []field{
+			{text: panel.Spec.Display.Name, weight: weightName},
+			{text: panel.Spec.Display.Description, weight: weightDescription},
+			{text: displayName, weight: weightParent},
+		}
+		for _, query := range panel.Spec.Queries {
+			for _, expression := range queryExpressions(query.Spec.Plugin.Spec) {
+				fields = append(fields, field{text: expression, weight: weightQuery})
+			}
+		}
+		documents = append(documents, document{
+			result: v1.SearchResult{Kind: v1.SearchResultKindPanel, Project: project, Dashboard: name, Name: key, DisplayName: panel.Spec.Display.Name},
+			fields: fields,
+		})
+	}
+	for _, v := range dash.Spec.Variables {
+		if v.Spec == nil {
+			continue
+		}
+		variableName := v.Spec.GetName()
+		var display *variable.Display
+		switch spec := v.Spec.(type) {
+		case *dashboardModel.ListVariableSpec:
+			display = spec.Display
+		case *dashboardModel.TextVariableSpec:
+			display = spec.Display
+		}
+		documents = append(documents, variableDocument(project, name, variableName, display, displayName))
+	}
+	return documents
+}
+
+func projectVariableDocument(v *v1.Variable) document {
+	var display *variable.Display
+	switch spec := v.Spec.Spec.(type) {
+	case *variable.ListSpec:
+		display = spec.Display
+	case *variable.TextSpec:
+		display = spec.Display
+	}
+	return variableDocument(v.Metadata.Project, "", v.Metadata.Name, display, "")
+}
+
+func variableDocument(project string, dashboardName string, name string, display *variable.Display, parentDisplayName string) document {
+	var displayName, description string
+	if display != nil {
+		displayName = display.Name
+		description = display.Description
+	}
+	return document{
+		result: v1.SearchResult{Kind: v1.SearchResultKindVariable, Project: project, Dashboard: dashboardName, Name: name, DisplayName: displayName},
+		fields: []field{
+			{text: name, weight: weightName},
+			{text: displayName, weight: weightName},
+			{text: description, weight: weightDescription},
+			{text: parentDisplayName, weight: weightParent},
+		},
+	}
+}
+
+// queryExpressions returns the query expressions found in the spec of a query plugin.
+// As every plugin has its own spec, the values of the fields usually containing the query are collected wherever they are.
+func queryExpressions(spec any) []string {
+	var result []string
+	switch typedSpec := spec.(type) {
+	case map[string]any:
+		for key, value := range typedSpec {
+			if expression, ok := value.(string); ok && queryFields[key] {
+				result = append(result, expression)
+				continue
+			}
+			result = append(result, queryExpressions(value)...)
+		}
+	case []any:
+		for _, value := range typedSpec {
+			result = append(result, queryExpressions(value)...)
+		}
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package search provides a full-text search on the dashboards, their panels and their variables, and on the project variables.
+// The index is kept in memory. It is built the first time a search is done and rebuilt after every change made on the indexed resources.
+package search
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	// Text is the text to search. Every word must be found for an element to be returned.
+	Text string
+	// Kind restricts the search to one kind of element. Every kind is returned when empty.
+	Kind v1.SearchResultKind
+	// Limit is the maximum number of results returned.
+	Limit int
+	// IsAllowed returns true if the caller can see the given result. Every result is allowed when nil.
+	IsAllowed func(result *v1.SearchResult) bool
+}
+
+type Index interface {
+	// Invalidate marks the index as outdated. It is rebuilt the next time a search is done.
+	Invalidate()
+	// Search returns the elements matching the query, the most relevant first.
+	Search(query Query) ([]*v1.SearchResult, error)
+}
+
+func New(dashboardDAO dashboard.DAO, variableDAO variable.DAO) Index {
+	return &index{
+		dashboardDAO: dashboardDAO,
+		variableDAO:  variableDAO,
+	}
+}
+
+type index struct {
+	dashboardDAO dashboard.DAO
+	variableDAO  variable.DAO
+	mutex        sync.RWMutex
+	// content is nil when the index must be rebuilt.
+	content *content
+}
+
+func (i *index) Invalidate() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.content = nil
+}
+
+func (i *index) Search(query Query) ([]*v1.SearchResult, error) {
+	c, err := i.getContent()
+	if err != nil {
+		return nil, err
+	}
+	terms := tokenize(query.Text)
+	if len(terms) == 0 {
+		return []*v1.SearchResult{}, nil
+	}
+	scores := c.score(terms)
+	result := make([]*v1.SearchResult, 0, len(scores))
+	for doc, score := range scores {
+		element := c.documents[doc].result
+		if len(query.Kind) > 0 && element.Kind != query.Kind {
+			continue
+		}
+		if query.IsAllowed != nil && !query.IsAllowed(&element) {
+			continue
+		}
+		element.Score = score
+		result = append(result, &element)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Score != result[b].Score {
+			return result[a].Score > result[b].Score
+		}
+		if result[a].Project != result[b].Project {
+			return result[a].Project < result[b].Project
+		}
+		if result[a].Dashboard != result[b].Dashboard {
+			return result[a].Dashboard < result[b].Dashboard
+		}
+		return result[a].Name < result[b].Name
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+// getContent returns the current content of the index, building it if needed.
+func (i *index) getContent() (*content, error) {
+	i.mutex.RLock()
+	c := i.content
+	i.mutex.RUnlock()
+	if c != nil {
+		return c, nil
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	// The index may have been built while waiting for the lock.
+	if i.content != nil {
+		return i.content, nil
+	}
+	dashboards, err := i.dashboardDAO.List(&dashboard.Query{})
+	if err != nil {
+		return nil, err
+	}
+	variables, err := i.variableDAO.List(&variable.Query{})
+	if err != nil {
+		return nil, err
+	}
+	var documents []document
+	for _, dash := range dashboards {
+		documents = append(documents, dashboardDocuments(dash)...)
+	}
+	for _, v := range variables {
+		documents = append(documents, projectVariableDocument(v))
+	}
+	i.content = newContent(documents)
+	return i.content, nil
+}
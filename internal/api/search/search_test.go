@@ -0,0 +1,173 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const checkoutDashboard = `{
+  "kind": "Dashboard",
+  "metadata": {"name": "checkout", "project": "shop"},
+  "spec": {
+    "display": {"name": "Checkout service", "description": "Health of the payment flow"},
+    "variables": [
+      {"kind": "TextVariable", "spec": {"name": "region", "display": {"name": "Cloud region"}, "value": "eu"}}
+    ],
+    "panels": {
+      "latency": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "P99 latency"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "queries": [
+            {"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "histogram_quantile(0.99, rate(http_request_duration_seconds_bucket[5m]))"}}}}
+          ]
+        }
+      },
+      "errors": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "Errors", "description": "Rate of the failed requests"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}}
+        }
+      }
+    },
+    "layouts": [],
+    "duration": "1h"
+  }
+}`
+
+const regionVariable = `{
+  "kind": "Variable",
+  "metadata": {"name": "region", "project": "infra"},
+  "spec": {"kind": "TextVariable", "spec": {"value": "us"}}
+}`
+
+type fakeDashboardDAO struct {
+	dashboard.DAO
+	list []*v1.Dashboard
+}
+
+func (d *fakeDashboardDAO) List(_ *dashboard.Query) ([]*v1.Dashboard, error) {
+	return d.list, nil
+}
+
+type fakeVariableDAO struct {
+	variable.DAO
+	list []*v1.Variable
+}
+
+func (d *fakeVariableDAO) List(_ *variable.Query) ([]*v1.Variable, error) {
+	return d.list, nil
+}
+
+func newIndex(t *testing.T) (Index, *fakeDashboardDAO) {
+	dash := &v1.Dashboard{}
+	assert.NoError(t, json.Unmarshal([]byte(checkoutDashboard), dash))
+	v := &v1.Variable{}
+	assert.NoError(t, json.Unmarshal([]byte(regionVariable), v))
+	dashboardDAO := &fakeDashboardDAO{list: []*v1.Dashboard{dash}}
+	return New(dashboardDAO, &fakeVariableDAO{list: []*v1.Variable{v}}), dashboardDAO
+}
+
+func names(results []*v1.SearchResult) []string {
+	var result []string
+	for _, r := range results {
+		result = append(result, string(r.Kind)+":"+r.Project+"/"+r.Dashboard+"/"+r.Name)
+	}
+	return result
+}
+
+func TestIndex_Search(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		query    Query
+		expected []string
+	}{
+		{
+			title:    "panel title",
+			query:    Query{Text: "p99 latency"},
+			expected: []string{"Panel:shop/checkout/latency"},
+		},
+		{
+			title:    "query expression",
+			query:    Query{Text: "http_request_duration"},
+			expected: []string{"Panel:shop/checkout/latency"},
+		},
+		{
+			title:    "prefix of a word",
+			query:    Query{Text: "checkout pay"},
+			expected: []string{"Dashboard:shop//checkout"},
+		},
+		{
+			title:    "the dashboard ranks before its panels",
+			query:    Query{Text: "checkout"},
+			expected: []string{"Dashboard:shop//checkout", "Panel:shop/checkout/errors", "Panel:shop/checkout/latency", "Variable:shop/checkout/region"},
+		},
+		{
+			title:    "variables",
+			query:    Query{Text: "region"},
+			expected: []string{"Variable:infra//region", "Variable:shop/checkout/region"},
+		},
+		{
+			title:    "restricted to a kind",
+			query:    Query{Text: "checkout", Kind: v1.SearchResultKindPanel, Limit: 1},
+			expected: []string{"Panel:shop/checkout/errors"},
+		},
+		{
+			title: "filtered by the permissions",
+			query: Query{Text: "region", IsAllowed: func(result *v1.SearchResult) bool {
+				return result.Project == "infra"
+			}},
+			expected: []string{"Variable:infra//region"},
+		},
+		{
+			title: "nothing found",
+			query: Query{Text: "latency memory"},
+		},
+	}
+	index, _ := newIndex(t)
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := index.Search(test.query)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, names(result))
+		})
+	}
+}
+
+func TestIndex_Invalidate(t *testing.T) {
+	index, dashboardDAO := newIndex(t)
+	result, err := index.Search(Query{Text: "latency"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	dashboardDAO.list = nil
+	// The index is not rebuilt until it is invalidated.
+	result, err = index.Search(Query{Text: "latency"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	index.Invalidate()
+	result, err = index.Search(Query{Text: "latency"})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -20,7 +20,9 @@ import (
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/common/async"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
@@ -57,23 +59,47 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 	if err != nil {
 		return nil, err
 	}
-	if permErr := t.checkPermissionList(ctx, parameters, scope); permErr != nil {
-		return nil, permErr
-	}
 	// Get the list of the project the user has access to, depending on the current scope.
 	projects, err := t.authz.GetUserProjects(ctx, role.ReadAction, *scope)
 	if err != nil {
 		return nil, err
 	}
+	restricted, permErr := t.checkPermissionList(ctx, parameters, scope, projects)
+	if permErr != nil {
+		return nil, permErr
+	}
 
 	// If there is no project associated with the user, then we should just return an empty list.
 	if len(projects) == 0 {
 		return []api.Entity{}, nil
 	}
 
+	// When the permission is restricted to some resource names, the list is filtered afterward.
+	// So the pagination can only be applied once the list is filtered.
+	if restricted {
+		pagination := *q.GetPagination()
+		*q.GetPagination() = databaseModel.Pagination{}
+		list, listErr := t.listAuthorizedProjects(parameters, *scope, projects, q)
+		*q.GetPagination() = pagination
+		if listErr != nil {
+			return nil, listErr
+		}
+		result := make([]any, 0)
+		for _, item := range t.toAnyList(list) {
+			if t.isReadable(ctx, *scope, item) {
+				result = append(result, item)
+			}
+		}
+		return databaseModel.Paginate(q.GetPagination(), result, sortKeyOf)
+	}
+	return t.listAuthorizedProjects(parameters, *scope, projects, q)
+}
+
+// listAuthorizedProjects returns the list of the resources of the projects the user has access to.
+func (t *toolbox[T, K, V]) listAuthorizedProjects(parameters apiInterface.Parameters, scope role.Scope, projects []string, q V) (any, error) {
 	// Special case if the user is getting the list of the project, as "project" is not considered has a global scope.
 	// More explanation about why it's not a global scope available here: https://github.com/perses/perses/blob/611b7993257dcadb18d48de945ad4def18889bec/pkg/model/api/v1/role/scope.go#L137-L138
-	if *scope == role.ProjectScope {
+	if scope == role.ProjectScope {
 		return t.listProjectWhenPermissionIsActivated(parameters, projects, q)
 	}
 
@@ -88,6 +114,9 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 		return t.metadataOrFullList(parameters, q)
 	}
 
+	// The lists of the different projects are merged, so the pagination can only be applied once every list is retrieved.
+	pagination := *q.GetPagination()
+	*q.GetPagination() = databaseModel.Pagination{}
 	result := make([]any, 0, len(projects))
 	asynchronousRequests := make([]async.Future[any], 0, len(projects))
 	for _, project := range projects {
@@ -98,22 +127,51 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 		if requestErr != nil {
 			return nil, requestErr
 		}
-		switch typedList := listResult.(type) {
-		case []api.Entity:
-			for _, entity := range typedList {
-				result = append(result, entity)
-			}
-		case []K:
-			for _, entity := range typedList {
-				result = append(result, entity)
-			}
-		case []json.RawMessage:
-			for _, entity := range typedList {
-				result = append(result, entity)
-			}
+		result = append(result, t.toAnyList(listResult)...)
+	}
+	*q.GetPagination() = pagination
+	return databaseModel.Paginate(q.GetPagination(), result, sortKeyOf)
+}
+
+// toAnyList converts any of the lists returned by the service into a list of items.
+func (t *toolbox[T, K, V]) toAnyList(list any) []any {
+	var result []any
+	switch typedList := list.(type) {
+	case []api.Entity:
+		for _, entity := range typedList {
+			result = append(result, entity)
 		}
+	case []K:
+		for _, entity := range typedList {
+			result = append(result, entity)
+		}
+	case []json.RawMessage:
+		for _, entity := range typedList {
+			result = append(result, entity)
+		}
+	case []any:
+		result = typedList
 	}
-	return result, nil
+	return result
+}
+
+// isReadable returns true if the user is allowed to read the item, considering the permissions restricted to some resource names.
+func (t *toolbox[T, K, V]) isReadable(ctx echo.Context, scope role.Scope, item any) bool {
+	var name, project string
+	switch typedItem := item.(type) {
+	case json.RawMessage:
+		name = gjson.GetBytes(typedItem, "metadata.name").String()
+		project = gjson.GetBytes(typedItem, "metadata.project").String()
+	case api.Entity:
+		name = typedItem.GetMetadata().GetName()
+		project = utils.GetMetadataProject(typedItem.GetMetadata())
+	}
+	if role.IsGlobalScope(scope) {
+		project = modelV1.WildcardProject
+	} else if scope == role.ProjectScope {
+		project = name
+	}
+	return t.authz.HasResourcePermission(ctx, role.ReadAction, project, scope, name)
 }
 
 func (t *toolbox[T, K, V]) listProjectWhenPermissionIsActivated(parameters apiInterface.Parameters, projects []string, query V) (any, error) {
@@ -125,37 +183,58 @@ func (t *toolbox[T, K, V]) listProjectWhenPermissionIsActivated(parameters apiIn
 	// Last case, we want the list of the project that matches what the user has access to.
 	// So we get the list from the database, and then we keep only that one that matches the list extracted from the permission.
 	// The usage of the map is just to avoid having the o(n2) complexity by looping over two lists to make the intersection.
+	// As the list is filtered afterward, the pagination is applied once the intersection is done.
+	pagination := *query.GetPagination()
+	*query.GetPagination() = databaseModel.Pagination{}
 	projectList, listErr := t.metadataOrFullList(parameters, query)
+	*query.GetPagination() = pagination
 	if listErr != nil {
 		return nil, listErr
 	}
 
 	switch typedList := projectList.(type) {
 	case []K:
-		result := make([]K, 0, len(typedList))
-		buildMap := buildMapFromList(typedList)
-		for _, project := range projects {
-			result = append(result, buildMap[project])
-		}
-		return result, nil
+		result := intersect(projects, buildMapFromList(typedList))
+		return databaseModel.Paginate(query.GetPagination(), result, entitySortKey[K])
 	case []api.Entity:
-		result := make([]api.Entity, 0, len(typedList))
-		buildMap := buildMapFromList(typedList)
-		for _, project := range projects {
-			result = append(result, buildMap[project])
-		}
-		return result, nil
+		result := intersect(projects, buildMapFromList(typedList))
+		return databaseModel.Paginate(query.GetPagination(), result, entitySortKey[api.Entity])
 	case []json.RawMessage:
-		result := make([]json.RawMessage, 0, len(typedList))
-		buildMap := buildRawMapFromList(typedList)
-		for _, project := range projects {
-			result = append(result, buildMap[project])
-		}
-		return result, nil
+		result := intersect(projects, buildRawMapFromList(typedList))
+		return databaseModel.Paginate(query.GetPagination(), result, rawSortKey)
 	}
 	return []any{}, nil
 }
 
+// intersect returns the items of the map matching the given names.
+func intersect[T any](names []string, items map[string]T) []T {
+	result := make([]T, 0, len(names))
+	for _, name := range names {
+		if item, ok := items[name]; ok {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func entitySortKey[T api.Entity](entity T) databaseModel.SortKey {
+	return databaseModel.NewSortKeyFromMetadata(entity.GetMetadata())
+}
+
+func rawSortKey(doc json.RawMessage) databaseModel.SortKey {
+	return databaseModel.NewSortKeyFromJSON(doc)
+}
+
+func sortKeyOf(item any) databaseModel.SortKey {
+	switch typedItem := item.(type) {
+	case json.RawMessage:
+		return rawSortKey(typedItem)
+	case api.Entity:
+		return entitySortKey(typedItem)
+	}
+	return databaseModel.SortKey{}
+}
+
 func (t *toolbox[T, K, V]) metadataOrFullList(parameters apiInterface.Parameters, query V) (any, error) {
 	if query.GetMetadataOnlyQueryParam() {
 		if query.IsRawMetadataQueryAllowed() {
@@ -176,6 +255,11 @@ func (t *toolbox[T, K, V]) asyncMetadataOrFullList(parameters apiInterface.Param
 			return [][]byte{}, fmt.Errorf("unable to copy the parameters: %w", err)
 		}
 		param.Project = project
-		return t.metadataOrFullList(param, query)
+		// Each request gets its own query, as the database writes the pagination token in it.
+		q, err := deep.Copy(query)
+		if err != nil {
+			return [][]byte{}, fmt.Errorf("unable to copy the query: %w", err)
+		}
+		return t.metadataOrFullList(param, q)
 	}
 }
@@ -14,11 +14,15 @@
 package toolbox
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/audit"
 	"github.com/perses/perses/internal/api/authorization"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
@@ -29,6 +33,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+	// HeaderContinue contains the token to get the next page of a list.
+	HeaderContinue = "X-Continue-Token"
+)
+
 func ExtractParameters(ctx echo.Context, caseSensitive bool) apiInterface.Parameters {
 	project := utils.GetProjectParameter(ctx)
 	name := utils.GetNameParameter(ctx)
@@ -42,6 +53,44 @@ func ExtractParameters(ctx echo.Context, caseSensitive bool) apiInterface.Parame
 	}
 }
 
+// SetETag sets the ETag header of the response with the version of the entity.
+func SetETag(ctx echo.Context, entity api.Entity) {
+	ctx.Response().Header().Set(HeaderETag, fmt.Sprintf("%q", strconv.FormatUint(utils.GetMetadataVersion(entity.GetMetadata()), 10)))
+}
+
+// ExtractIfMatchVersion returns the version set in the header If-Match.
+// nil is returned when the header is absent or when it is "*", meaning the entity can be modified whatever its version is.
+func ExtractIfMatchVersion(ctx echo.Context) (*uint64, error) {
+	ifMatch := strings.TrimSpace(ctx.Request().Header.Get(HeaderIfMatch))
+	if len(ifMatch) == 0 || ifMatch == "*" {
+		return nil, nil
+	}
+	invalidHeaderErr := apiInterface.HandleBadRequestError(fmt.Sprintf("invalid %s header %q, it must be a single ETag as returned by the API", HeaderIfMatch, ifMatch))
+	if len(ifMatch) < 2 || !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		return nil, invalidHeaderErr
+	}
+	version, err := strconv.ParseUint(ifMatch[1:len(ifMatch)-1], 10, 64)
+	if err != nil {
+		return nil, invalidHeaderErr
+	}
+	return &version, nil
+}
+
+// extractExpectedVersion returns the version of the entity the client expects to update.
+// The version is taken from the header If-Match if present. "*" means the entity can be updated whatever its version is.
+// Otherwise, the metadata.version of the entity is used. As the version is 0 when it is not set in the body,
+// 0 means the client doesn't expect any particular version.
+func extractExpectedVersion(ctx echo.Context, entity api.Entity) (*uint64, error) {
+	if len(strings.TrimSpace(ctx.Request().Header.Get(HeaderIfMatch))) > 0 {
+		return ExtractIfMatchVersion(ctx)
+	}
+	version := utils.GetMetadataVersion(entity.GetMetadata())
+	if version == 0 {
+		return nil, nil
+	}
+	return &version, nil
+}
+
 func isJSONContentType(ctx echo.Context) bool {
 	contentType := ctx.Request().Header.Get(echo.HeaderContentType)
 	if len(contentType) == 0 {
@@ -60,10 +109,11 @@ type Toolbox[T api.Entity, K databaseModel.Query] interface {
 	List(ctx echo.Context, q K) error
 }
 
-func New[T api.Entity, K api.Entity, V databaseModel.Query](service apiInterface.Service[T, K, V], authz authorization.Authorization, kind v1.Kind, caseSensitive bool) Toolbox[T, V] {
+func New[T api.Entity, K api.Entity, V databaseModel.Query](service apiInterface.Service[T, K, V], authz authorization.Authorization, auditor audit.Auditor, kind v1.Kind, caseSensitive bool) Toolbox[T, V] {
 	return &toolbox[T, K, V]{
 		service:       service,
 		authz:         authz,
+		auditor:       auditor,
 		kind:          kind,
 		caseSensitive: caseSensitive,
 	}
@@ -73,34 +123,49 @@ type toolbox[T api.Entity, K api.Entity, V databaseModel.Query] struct {
 	Toolbox[T, V]
 	service       apiInterface.Service[T, K, V]
 	authz         authorization.Authorization
+	auditor       audit.Auditor
 	kind          v1.Kind
 	caseSensitive bool
 }
 
 // checkPermissionList will verify only the permission for the List method. As you can see, scope is hardcoded.
-// Use the generic checkPermission for any other purpose
-func (t *toolbox[T, K, V]) checkPermissionList(ctx echo.Context, parameters apiInterface.Parameters, scope *role.Scope) error {
+// Use the generic checkPermission for any other purpose.
+// The projects are the ones returned by GetUserProjects for the scope.
+// It returns true when the user is only allowed to read some resources of the list,
+// because the permission is restricted to some resource names. The list must then be filtered.
+func (t *toolbox[T, K, V]) checkPermissionList(ctx echo.Context, parameters apiInterface.Parameters, scope *role.Scope, projects []string) (bool, error) {
 	if !t.authz.IsEnabled() {
-		return nil
+		return false, nil
 	}
 	projectName := parameters.Project
 	if role.IsGlobalScope(*scope) {
-		if ok := t.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, *scope); !ok {
-			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, *scope))
+		if ok := t.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, *scope); ok {
+			return false, nil
 		}
-		return nil
+		if slices.Contains(projects, v1.WildcardProject) {
+			return true, nil
+		}
+		return false, apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, *scope))
 	}
 	if *scope == role.ProjectScope {
 		projectName = parameters.Name
 	}
 	if len(projectName) == 0 {
 		// In this particular context, the user would like to get every resource to every project he has access to.
-		return nil
+		for _, project := range projects {
+			if ok := t.authz.HasPermission(ctx, role.ReadAction, project, *scope); !ok {
+				return true, nil
+			}
+		}
+		return false, nil
 	}
-	if ok := t.authz.HasPermission(ctx, role.ReadAction, projectName, *scope); !ok {
-		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, projectName, *scope))
+	if ok := t.authz.HasPermission(ctx, role.ReadAction, projectName, *scope); ok {
+		return false, nil
 	}
-	return nil
+	if slices.Contains(projects, v1.WildcardProject) || slices.Contains(projects, projectName) {
+		return true, nil
+	}
+	return false, apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, projectName, *scope))
 }
 
 func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity, parameters apiInterface.Parameters, action role.Action) error {
@@ -112,8 +177,13 @@ func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity,
 	if err != nil {
 		return err
 	}
+	name := parameters.Name
+	if len(name) == 0 && entity != nil {
+		// Retrieving the name from the payload if it's not provided in the url
+		name = entity.GetMetadata().GetName()
+	}
 	if role.IsGlobalScope(*scope) {
-		if ok := t.authz.HasPermission(ctx, action, v1.WildcardProject, *scope); !ok {
+		if ok := t.authz.HasResourcePermission(ctx, action, v1.WildcardProject, *scope, name); !ok {
 			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", action, *scope))
 		}
 		return nil
@@ -123,7 +193,7 @@ func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity,
 	if *scope == role.ProjectScope {
 		// Create is still a "Global" only permission
 		if action == role.CreateAction {
-			if ok := t.authz.HasPermission(ctx, action, v1.WildcardProject, *scope); !ok {
+			if ok := t.authz.HasResourcePermission(ctx, action, v1.WildcardProject, *scope, name); !ok {
 				return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", action, *scope))
 			}
 			return nil
@@ -135,7 +205,7 @@ func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity,
 		// Retrieving project name from payload if project name not provided in the url
 		projectName = utils.GetMetadataProject(entity.GetMetadata())
 	}
-	if ok := t.authz.HasPermission(ctx, action, projectName, *scope); !ok {
+	if ok := t.authz.HasResourcePermission(ctx, action, projectName, *scope, name); !ok {
 		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, projectName, *scope))
 	}
 	return nil
@@ -150,9 +220,13 @@ func (t *toolbox[T, K, V]) Create(ctx echo.Context, entity T) error {
 		return err
 	}
 	newEntity, err := t.service.Create(ctx, entity)
+	event := audit.NewEvent(v1.AuditOriginAPI, role.CreateAction, t.kind, utils.GetMetadataProject(entity.GetMetadata()), entity.GetMetadata().GetName())
 	if err != nil {
+		t.auditor.Record(ctx, event, err)
 		return err
 	}
+	event.NewVersion = audit.GetVersion(newEntity)
+	t.auditor.Record(ctx, event, nil)
 	return ctx.JSON(http.StatusOK, newEntity)
 }
 
@@ -164,10 +238,25 @@ func (t *toolbox[T, K, V]) Update(ctx echo.Context, entity T) error {
 	if err := t.checkPermission(ctx, entity, parameters, role.UpdateAction); err != nil {
 		return err
 	}
+	version, err := extractExpectedVersion(ctx, entity)
+	if err != nil {
+		return err
+	}
+	parameters.Version = version
 	newEntity, err := t.service.Update(ctx, entity, parameters)
+	if errors.Is(err, apiInterface.UnchangedError) {
+		// Nothing has been modified, so there is nothing to audit.
+		SetETag(ctx, newEntity)
+		return ctx.JSON(http.StatusOK, newEntity)
+	}
+	event := audit.NewEvent(v1.AuditOriginAPI, role.UpdateAction, t.kind, parameters.Project, parameters.Name)
 	if err != nil {
+		t.auditor.Record(ctx, event, err)
 		return err
 	}
+	audit.SetUpdatedVersion(event, newEntity)
+	t.auditor.Record(ctx, event, nil)
+	SetETag(ctx, newEntity)
 	return ctx.JSON(http.StatusOK, newEntity)
 }
 
@@ -176,7 +265,16 @@ func (t *toolbox[T, K, V]) Delete(ctx echo.Context) error {
 	if err := t.checkPermission(ctx, nil, parameters, role.DeleteAction); err != nil {
 		return err
 	}
-	if err := t.service.Delete(ctx, parameters); err != nil {
+	event := audit.NewEvent(v1.AuditOriginAPI, role.DeleteAction, t.kind, parameters.Project, parameters.Name)
+	if t.auditor.IsEnabled() {
+		// The resource is read before being deleted only to know the version removed.
+		if oldEntity, getErr := t.service.Get(parameters); getErr == nil {
+			event.OldVersion = audit.GetVersion(oldEntity)
+		}
+	}
+	err := t.service.Delete(ctx, parameters)
+	t.auditor.Record(ctx, event, err)
+	if err != nil {
 		return err
 	}
 	return ctx.NoContent(http.StatusNoContent)
@@ -191,6 +289,7 @@ func (t *toolbox[T, K, V]) Get(ctx echo.Context) error {
 	if err != nil {
 		return err
 	}
+	SetETag(ctx, entity)
 	return ctx.JSON(http.StatusOK, entity)
 }
 
@@ -198,13 +297,18 @@ func (t *toolbox[T, K, V]) List(ctx echo.Context, query V) error {
 	if err := ctx.Bind(query); err != nil {
 		return apiInterface.HandleBadRequestError(err.Error())
 	}
+	if err := query.GetPagination().Validate(); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
 	parameters := ExtractParameters(ctx, t.caseSensitive)
 
 	list, listErr := t.list(ctx, parameters, query)
 	if listErr != nil {
 		return listErr
 	}
-
+	if next := query.GetPagination().Next(); len(next) > 0 {
+		ctx.Response().Header().Set(HeaderContinue, next)
+	}
 	return ctx.JSON(http.StatusOK, list)
 }
 
@@ -21,8 +21,10 @@ import (
 
 const (
 	ParamDashboard         = "dashboard"
+	ParamID                = "id"
 	ParamName              = "name"
 	ParamProject           = "project"
+	ParamVersion           = "version"
 	APIPrefix              = "/api"
 	PathAuth               = "auth"
 	PathAuthProviders      = "auth/providers"
@@ -35,9 +37,18 @@ const (
 	AuthKindNative         = "native"
 	AuthKindOIDC           = "oidc"
 	AuthKindOAuth          = "oauth"
+	AuthKindLDAP           = "ldap"
+	AuthKindTrustedHeader  = "trusted_header"
+	AuthKindAccessToken    = "access_token"
 	APIV1Prefix            = "/api/v1"
+	WellKnownPrefix        = "/.well-known"
+	PathJWKS               = "jwks.json"
+	PathAccessToken        = "tokens"
+	PathAudit              = "audit"
 	PathDashboard          = "dashboards"
 	PathDatasource         = "datasources"
+	PathDelivery           = "deliveries"
+	PathEncryption         = "encryption"
 	PathEphemeralDashboard = "ephemeraldashboards"
 	PathFolder             = "folders"
 	PathGlobalDatasource   = "globaldatasources"
@@ -46,14 +57,22 @@ const (
 	PathGlobalSecret       = "globalsecrets"
 	PathGlobalVariable     = "globalvariables"
 	PathProject            = "projects"
+	PathReencrypt          = "reencrypt"
+	PathRevision           = "revisions"
 	PathRole               = "roles"
 	PathRoleBinding        = "rolebindings"
+	PathSearch             = "search"
 	PathSecret             = "secrets"
+	PathServiceAccount     = "serviceaccounts"
+	PathSession            = "sessions"
 	PathUnsaved            = "unsaved"
 	PathUser               = "users"
 	PathVariable           = "variables"
 	PathView               = "view"
+	PathWatch              = "watch"
+	PathWebhook            = "webhooks"
 	ContextKeyAnonymous    = "anonymous"
+	ContextKeyAccessToken  = "access_token"
 )
 
 const MetricNamespace = "perses"
@@ -81,6 +100,17 @@ func IsAnonymous(ctx echo.Context) bool {
 	return value.(bool)
 }
 
+// GetMetadataVersion Retrieve version from entity metadata
+func GetMetadataVersion(metadata api.Metadata) uint64 {
+	switch m := metadata.(type) {
+	case *v1.Metadata:
+		return m.Version
+	case *v1.ProjectMetadata:
+		return m.Version
+	}
+	return 0
+}
+
 // GetMetadataProject Retrieve project from entity metadata
 func GetMetadataProject(metadata api.Metadata) string {
 	if projectMetadata, ok := metadata.(*v1.ProjectMetadata); ok {
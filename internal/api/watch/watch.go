@@ -0,0 +1,241 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watch broadcasts the changes made on the resources to the clients watching them.
+package watch
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// bufferSize is the number of events kept for a subscriber not reading them fast enough.
+// Once the buffer is full, the subscription is closed, so the client can reconnect and list the resources again.
+const bufferSize = 256
+
+// Filter selects the events a subscriber is interested in.
+type Filter struct {
+	// Kinds are the kinds of resources to watch. Every kind is watched when it is empty.
+	Kinds []v1.Kind
+	// Project is the project of the resources to watch. Every project is watched when it is empty.
+	Project string
+}
+
+func (f Filter) match(event *v1.WatchEvent) bool {
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, event.Kind) {
+		return false
+	}
+	return len(f.Project) == 0 || f.Project == event.Project
+}
+
+// Subscription receives the events matching its filter.
+type Subscription struct {
+	filter Filter
+	events chan *v1.WatchEvent
+	// queue holds the events of an unbounded subscription until they are read. It is nil for the other subscriptions.
+	queue *eventQueue
+}
+
+// Events returns the channel receiving the events. It is closed when the subscription ends.
+func (s *Subscription) Events() <-chan *v1.WatchEvent {
+	return s.events
+}
+
+// eventQueue keeps the events of an unbounded subscription, without any limit, and forwards them to its channel.
+type eventQueue struct {
+	mutex   sync.Mutex
+	pending []*v1.WatchEvent
+	// wake is signaled when an event is pushed, done is closed when the subscription ends.
+	wake chan struct{}
+	done chan struct{}
+}
+
+func newEventQueue() *eventQueue {
+	return &eventQueue{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+}
+
+func (q *eventQueue) push(event *v1.WatchEvent) {
+	q.mutex.Lock()
+	q.pending = append(q.pending, event)
+	q.mutex.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// forward sends the pending events to the channel until the subscription ends, then it closes the channel.
+func (q *eventQueue) forward(events chan<- *v1.WatchEvent) {
+	defer close(events)
+	for {
+		select {
+		case <-q.wake:
+		case <-q.done:
+			return
+		}
+		q.mutex.Lock()
+		pending := q.pending
+		q.pending = nil
+		q.mutex.Unlock()
+		for _, event := range pending {
+			select {
+			case events <- event:
+			case <-q.done:
+				return
+			}
+		}
+	}
+}
+
+type Broadcaster interface {
+	// Publish sends the event describing the change of the entity to every subscriber interested in it.
+	// It never blocks: a subscriber not reading its events fast enough is dropped.
+	Publish(eventType v1.WatchEventType, entity api.Entity)
+	Subscribe(filter Filter) *Subscription
+	// SubscribeUnbounded returns a subscription that is never dropped: the events it doesn't read fast enough are
+	// queued without limit. It is meant for the consumers that must see every change, like the webhook recorder.
+	SubscribeUnbounded(filter Filter) *Subscription
+	Unsubscribe(subscription *Subscription)
+	// String and Execute allow running the broadcaster as a task, so every subscription is closed when Perses stops.
+	// Otherwise, the HTTP server would wait for the streams to end before stopping.
+	String() string
+	Execute(ctx context.Context, cancelFunc context.CancelFunc) error
+}
+
+func New() Broadcaster {
+	return &broadcaster{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// NewEvent returns the event describing the change of the entity.
+// The project of a `Project` is the name of the project itself, so the changes of a project can be watched with the resources it contains.
+func NewEvent(eventType v1.WatchEventType, entity api.Entity) *v1.WatchEvent {
+	metadata := entity.GetMetadata()
+	kind := v1.Kind(entity.GetKind())
+	project := utils.GetMetadataProject(metadata)
+	if kind == v1.KindProject {
+		project = metadata.GetName()
+	}
+	return &v1.WatchEvent{
+		Type:    eventType,
+		Kind:    kind,
+		Project: project,
+		Name:    metadata.GetName(),
+		Version: utils.GetMetadataVersion(metadata),
+		Object:  entity,
+	}
+}
+
+type broadcaster struct {
+	mutex         sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	closed        bool
+}
+
+func (b *broadcaster) Publish(eventType v1.WatchEventType, entity api.Entity) {
+	event := NewEvent(eventType, entity)
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for subscription := range b.subscriptions {
+		if !subscription.filter.match(event) {
+			continue
+		}
+		if subscription.queue != nil {
+			subscription.queue.push(event)
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			logrus.Warn("a watcher doesn't read the events fast enough, closing its subscription")
+			b.remove(subscription)
+		}
+	}
+}
+
+func (b *broadcaster) Subscribe(filter Filter) *Subscription {
+	subscription := &Subscription{
+		filter: filter,
+		events: make(chan *v1.WatchEvent, bufferSize),
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		close(subscription.events)
+		return subscription
+	}
+	b.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+func (b *broadcaster) SubscribeUnbounded(filter Filter) *Subscription {
+	subscription := &Subscription{
+		filter: filter,
+		events: make(chan *v1.WatchEvent),
+		queue:  newEventQueue(),
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		close(subscription.events)
+		return subscription
+	}
+	b.subscriptions[subscription] = struct{}{}
+	go subscription.queue.forward(subscription.events)
+	return subscription
+}
+
+func (b *broadcaster) Unsubscribe(subscription *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.remove(subscription)
+}
+
+// remove closes the subscription. The mutex must be held by the caller.
+func (b *broadcaster) remove(subscription *Subscription) {
+	if _, ok := b.subscriptions[subscription]; !ok {
+		return
+	}
+	delete(b.subscriptions, subscription)
+	if subscription.queue != nil {
+		// The channel is closed by the queue once it stops forwarding the events.
+		close(subscription.queue.done)
+		return
+	}
+	close(subscription.events)
+}
+
+func (b *broadcaster) String() string {
+	return "watch broadcaster"
+}
+
+func (b *broadcaster) Execute(ctx context.Context, _ context.CancelFunc) error {
+	<-ctx.Done()
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.closed = true
+	for subscription := range b.subscriptions {
+		b.remove(subscription)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFolder(project string, name string, version uint64) *v1.Folder {
+	folder := &v1.Folder{Kind: v1.KindFolder, Metadata: *v1.NewProjectMetadata(project, name)}
+	folder.Metadata.Version = version
+	return folder
+}
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent(v1.WatchEventModified, newFolder("perses", "ops", 2))
+	assert.Equal(t, v1.WatchEventModified, event.Type)
+	assert.Equal(t, v1.KindFolder, event.Kind)
+	assert.Equal(t, "perses", event.Project)
+	assert.Equal(t, "ops", event.Name)
+	assert.Equal(t, uint64(2), event.Version)
+
+	event = NewEvent(v1.WatchEventAdded, &v1.Project{Kind: v1.KindProject, Metadata: v1.Metadata{Name: "perses"}})
+	assert.Equal(t, "perses", event.Project)
+}
+
+func TestBroadcaster_Filter(t *testing.T) {
+	b := New()
+	all := b.Subscribe(Filter{})
+	folders := b.Subscribe(Filter{Kinds: []v1.Kind{v1.KindFolder}, Project: "perses"})
+	dashboards := b.Subscribe(Filter{Kinds: []v1.Kind{v1.KindDashboard}})
+
+	b.Publish(v1.WatchEventAdded, newFolder("perses", "ops", 0))
+	b.Publish(v1.WatchEventAdded, newFolder("other", "ops", 0))
+
+	assert.Len(t, all.Events(), 2)
+	if assert.Len(t, folders.Events(), 1) {
+		assert.Equal(t, "perses", (<-folders.Events()).Project)
+	}
+	assert.Len(t, dashboards.Events(), 0)
+
+	b.Unsubscribe(all)
+	_, ok := <-all.Events()
+	assert.True(t, ok, "the events already sent can still be read")
+	_, ok = <-all.Events()
+	assert.True(t, ok)
+	_, ok = <-all.Events()
+	assert.False(t, ok)
+}
+
+func TestBroadcaster_SlowSubscriber(t *testing.T) {
+	b := New()
+	subscription := b.Subscribe(Filter{})
+	for i := 0; i <= bufferSize; i++ {
+		b.Publish(v1.WatchEventModified, newFolder("perses", "ops", uint64(i)))
+	}
+	count := 0
+	for range subscription.Events() {
+		count++
+	}
+	assert.Equal(t, bufferSize, count)
+}
+
+func TestBroadcaster_UnboundedSubscriber(t *testing.T) {
+	b := New()
+	subscription := b.SubscribeUnbounded(Filter{Kinds: []v1.Kind{v1.KindFolder}})
+	for i := 0; i < 2*bufferSize; i++ {
+		b.Publish(v1.WatchEventModified, newFolder("perses", "ops", uint64(i)))
+	}
+	for i := 0; i < 2*bufferSize; i++ {
+		event, ok := <-subscription.Events()
+		if !assert.True(t, ok, "the subscription must not be dropped") {
+			return
+		}
+		assert.Equal(t, uint64(i), event.Version)
+	}
+	b.Unsubscribe(subscription)
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+}
+
+func TestBroadcaster_Execute(t *testing.T) {
+	b := New()
+	subscription := b.Subscribe(Filter{})
+	unbounded := b.SubscribeUnbounded(Filter{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, b.Execute(ctx, cancel))
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+	_, ok = <-unbounded.Events()
+	assert.False(t, ok)
+	_, ok = <-b.Subscribe(Filter{}).Events()
+	assert.False(t, ok, "no subscription can be made once the broadcaster is stopped")
+	_, ok = <-b.SubscribeUnbounded(Filter{}).Events()
+	assert.False(t, ok)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook sends the changes made on the resources to the webhooks interested in them.
+//
+// The recorder listens to the changes published by the watch broadcaster and queues a delivery for every matching webhook.
+// The sender periodically sends the deliveries due and plans the retries of the failed ones.
+// As the queue is stored in the database, the pending deliveries survive a restart of Perses.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
+	"github.com/perses/perses/internal/api/watch"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+func getAction(eventType v1.WatchEventType) role.Action {
+	switch eventType {
+	case v1.WatchEventAdded:
+		return role.CreateAction
+	case v1.WatchEventDeleted:
+		return role.DeleteAction
+	default:
+		return role.UpdateAction
+	}
+}
+
+// Match returns true if the change described by the event and the action is selected by the filter.
+func Match(filter v1.WebhookFilter, event *v1.WatchEvent, action role.Action) bool {
+	if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, event.Kind) {
+		return false
+	}
+	if len(filter.Projects) > 0 && !slices.Contains(filter.Projects, event.Project) {
+		return false
+	}
+	return len(filter.Actions) == 0 || slices.Contains(filter.Actions, action)
+}
+
+// NewDelivery returns the pending delivery of the event to the webhook.
+func NewDelivery(webhookName string, event *v1.WatchEvent, now time.Time) (*v1.WebhookDelivery, error) {
+	action := getAction(event.Type)
+	id := uuid.NewString()
+	payload, err := json.Marshal(&v1.WebhookPayload{
+		Delivery:  id,
+		Webhook:   webhookName,
+		Timestamp: now,
+		Action:    action,
+		Event:     event,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1.WebhookDelivery{
+		ID:            id,
+		Webhook:       webhookName,
+		Action:        action,
+		Kind:          event.Kind,
+		Project:       event.Project,
+		Name:          event.Name,
+		Status:        v1.WebhookDeliveryPending,
+		CreatedAt:     now,
+		NextAttemptAt: &now,
+		Payload:       payload,
+	}, nil
+}
+
+// NewRecorder returns the task queuing a delivery for every change matching a webhook.
+func NewRecorder(broadcaster watch.Broadcaster, dao webhook.DAO) async.SimpleTask {
+	return &recorder{
+		broadcaster: broadcaster,
+		dao:         dao,
+	}
+}
+
+type recorder struct {
+	async.SimpleTask
+	broadcaster watch.Broadcaster
+	dao         webhook.DAO
+}
+
+func (r *recorder) String() string {
+	return "webhook recorder"
+}
+
+func (r *recorder) Execute(ctx context.Context, _ context.CancelFunc) error {
+	// The subscription is unbounded, so no change is lost while the deliveries are written in the database.
+	subscription := r.broadcaster.SubscribeUnbounded(watch.Filter{})
+	defer r.broadcaster.Unsubscribe(subscription)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				// The subscription only ends when the broadcaster stops.
+				return nil
+			}
+			r.record(event)
+		}
+	}
+}
+
+func (r *recorder) record(event *v1.WatchEvent) {
+	webhooks, err := r.dao.List(&webhook.Query{})
+	if err != nil {
+		logrus.WithError(err).Error("unable to list the webhooks")
+		return
+	}
+	action := getAction(event.Type)
+	now := time.Now().UTC()
+	for _, w := range webhooks {
+		if !Match(w.Spec.Filter, event, action) {
+			continue
+		}
+		delivery, deliveryErr := NewDelivery(w.Metadata.Name, event, now)
+		if deliveryErr != nil {
+			logrus.WithError(deliveryErr).Errorf("unable to create the delivery of the webhook %q", w.Metadata.Name)
+			continue
+		}
+		if createErr := r.dao.CreateDelivery(delivery); createErr != nil {
+			logrus.WithError(createErr).Errorf("unable to queue the delivery of the webhook %q", w.Metadata.Name)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
+	"github.com/perses/perses/internal/api/interface/v1/webhook"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	secretModel "github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// SendInterval is the period at which the sender looks for the deliveries due.
+	SendInterval = 10 * time.Second
+	// retention is how long the deliveries succeeded or failed are kept, so their status can be queried.
+	retention = 7 * 24 * time.Hour
+	// maxDeliveriesPerRun limits the number of deliveries sent at each run, so a run doesn't overlap the next one for too long.
+	maxDeliveriesPerRun = 100
+	requestTimeout      = 30 * time.Second
+	// maxErrorLength is the maximum number of bytes of the response body kept in the error of a delivery.
+	maxErrorLength = 512
+
+	HeaderDelivery  = "X-Perses-Delivery"
+	HeaderEvent     = "X-Perses-Event"
+	HeaderSignature = "X-Perses-Signature"
+)
+
+// Sign returns the value of the header HeaderSignature for the payload: the HMAC-SHA256 of the payload, hex encoded and prefixed by `sha256=`.
+func Sign(key string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(payload)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
+
+// NewSender returns the task sending the deliveries due and purging the old ones.
+func NewSender(dao webhook.DAO, globalSecretDAO globalsecret.DAO, crypto crypto.Crypto) async.SimpleTask {
+	return &sender{
+		dao:             dao,
+		globalSecretDAO: globalSecretDAO,
+		crypto:          crypto,
+	}
+}
+
+type sender struct {
+	async.SimpleTask
+	dao             webhook.DAO
+	globalSecretDAO globalsecret.DAO
+	crypto          crypto.Crypto
+}
+
+func (s *sender) String() string {
+	return "webhook sender"
+}
+
+func (s *sender) Execute(ctx context.Context, _ context.CancelFunc) error {
+	now := time.Now().UTC()
+	for _, status := range []v1.WebhookDeliveryStatus{v1.WebhookDeliverySuccess, v1.WebhookDeliveryFailed} {
+		if err := s.dao.DeleteDeliveries(&databaseModel.WebhookDeliveryQuery{Status: status, CreatedBefore: now.Add(-retention)}); err != nil {
+			logrus.WithError(err).Errorf("unable to purge the %s webhook deliveries", status)
+		}
+	}
+	deliveries, err := s.dao.QueryDeliveries(&databaseModel.WebhookDeliveryQuery{
+		Status:    v1.WebhookDeliveryPending,
+		DueBefore: now,
+		Limit:     maxDeliveriesPerRun,
+	})
+	if err != nil {
+		return err
+	}
+	webhooks := make(map[string]*v1.Webhook)
+	// The transports are shared by the deliveries using the same secret, and their connections are closed at the end of the run.
+	transports := make(map[string]*http.Transport)
+	defer func() {
+		for _, transport := range transports {
+			transport.CloseIdleConnections()
+		}
+	}()
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		w, ok := webhooks[delivery.Webhook]
+		if !ok {
+			w, err = s.dao.Get(delivery.Webhook)
+			if err != nil {
+				if !databaseModel.IsKeyNotFound(err) {
+					logrus.WithError(err).Errorf("unable to get the webhook %q", delivery.Webhook)
+					continue
+				}
+				w = nil
+			}
+			webhooks[delivery.Webhook] = w
+		}
+		s.attempt(ctx, w, delivery, transports)
+		if updateErr := s.dao.UpdateDelivery(delivery); updateErr != nil {
+			logrus.WithError(updateErr).Errorf("unable to update the delivery %q of the webhook %q", delivery.ID, delivery.Webhook)
+		}
+	}
+	return nil
+}
+
+// attempt sends the delivery and updates its status according to the result. w is nil if the webhook doesn't exist anymore.
+func (s *sender) attempt(ctx context.Context, w *v1.Webhook, delivery *v1.WebhookDelivery, transports map[string]*http.Transport) {
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.StatusCode = 0
+	delivery.Error = ""
+	if w == nil {
+		delivery.Status = v1.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = "the webhook doesn't exist anymore"
+		return
+	}
+	statusCode, err := s.send(ctx, w, delivery, transports)
+	delivery.StatusCode = statusCode
+	if err == nil {
+		delivery.Status = v1.WebhookDeliverySuccess
+		delivery.NextAttemptAt = nil
+		return
+	}
+	delivery.Error = err.Error()
+	if delivery.Attempts >= w.Spec.RetryPolicy.MaxAttempts {
+		delivery.Status = v1.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		logrus.WithError(err).Warningf("the delivery %q of the webhook %q failed after %d attempts", delivery.ID, w.Metadata.Name, delivery.Attempts)
+		return
+	}
+	next := now.Add(w.Spec.RetryPolicy.Delay(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+}
+
+// send posts the payload of the delivery to the webhook. It returns the status code of the response, if any.
+// The transport built for the secret of the webhook is kept in transports, so the next deliveries of the run reuse its connections.
+func (s *sender) send(ctx context.Context, w *v1.Webhook, delivery *v1.WebhookDelivery, transports map[string]*http.Transport) (int, error) {
+	scrt, err := s.getSecret(w.Spec.Secret)
+	if err != nil {
+		return 0, err
+	}
+	transport, ok := transports[w.Spec.Secret]
+	if !ok {
+		transport, err = prepareTransport(scrt)
+		if err != nil {
+			return 0, err
+		}
+		transports[w.Spec.Secret] = transport
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Spec.URL.String(), bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderEvent, fmt.Sprintf("%s.%s", delivery.Kind, delivery.Action))
+	if len(w.Spec.SigningSecret) > 0 {
+		key, keyErr := s.getSigningKey(w.Spec.SigningSecret)
+		if keyErr != nil {
+			return 0, keyErr
+		}
+		req.Header.Set(HeaderSignature, Sign(key, delivery.Payload))
+	}
+	if authErr := setupAuthentication(ctx, req, scrt, transport); authErr != nil {
+		return 0, authErr
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+		return resp.StatusCode, fmt.Errorf("the webhook answered with the status code %d: %s", resp.StatusCode, string(body))
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *sender) getSecret(name string) (*v1.SecretSpec, error) {
+	if len(name) == 0 {
+		return nil, nil
+	}
+	scrt, err := s.globalSecretDAO.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the secret %q: %w", name, err)
+	}
+	if decryptErr := s.crypto.Decrypt(&scrt.Spec); decryptErr != nil {
+		logrus.WithError(decryptErr).Errorf("unable to decrypt the secret %q", name)
+		return nil, fmt.Errorf("unable to decrypt the secret %q", name)
+	}
+	return &scrt.Spec, nil
+}
+
+// getSigningKey returns the key used to sign the payloads. It is the credentials of the authorization of the secret.
+func (s *sender) getSigningKey(name string) (string, error) {
+	scrt, err := s.getSecret(name)
+	if err != nil {
+		return "", err
+	}
+	if scrt.Authorization == nil {
+		return "", fmt.Errorf("the secret %q has no authorization credentials to sign the payload", name)
+	}
+	key, err := scrt.Authorization.GetCredentials()
+	if err != nil {
+		return "", err
+	}
+	if len(key) == 0 {
+		return "", fmt.Errorf("the secret %q has empty authorization credentials", name)
+	}
+	return key, nil
+}
+
+func prepareTransport(scrt *v1.SecretSpec) (*http.Transport, error) {
+	var tlsConfig *secretModel.TLSConfig
+	if scrt != nil {
+		tlsConfig = scrt.TLSConfig
+	}
+	// BuildTLSConfig returns the default configuration when tlsConfig is nil.
+	config, err := tlsConfig.BuildTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the tls config: %w", err)
+	}
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		IdleConnTimeout:     90 * time.Second,
+		ForceAttemptHTTP2:   true,
+		TLSClientConfig:     config,
+	}, nil
+}
+
+func setupAuthentication(ctx context.Context, req *http.Request, scrt *v1.SecretSpec, transport *http.Transport) error {
+	if scrt == nil {
+		return nil
+	}
+	if basicAuth := scrt.BasicAuth; basicAuth != nil {
+		password, err := basicAuth.GetPassword()
+		if err != nil {
+			return err
+		}
+		req.SetBasicAuth(basicAuth.Username, password)
+	}
+	if auth := scrt.Authorization; auth != nil {
+		credential, err := auth.GetCredentials()
+		if err != nil {
+			return err
+		}
+		req.Header.Set(echo.HeaderAuthorization, fmt.Sprintf("%s %s", auth.Type, credential))
+	}
+	if oauth := scrt.OAuth; oauth != nil {
+		token, err := getToken(ctx, oauth, transport)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(echo.HeaderAuthorization, fmt.Sprintf("Bearer %s", token.AccessToken))
+	}
+	return nil
+}
+
+// getToken exchanges the client credentials for an access token, from the OAuth 2.0 provider.
+func getToken(ctx context.Context, oauth *secretModel.OAuth, transport *http.Transport) (*oauth2.Token, error) {
+	clientSecret, err := oauth.GetClientSecret()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get client secret: %w", err)
+	}
+	conf := &clientcredentials.Config{
+		ClientID:       oauth.ClientID,
+		ClientSecret:   clientSecret,
+		TokenURL:       oauth.TokenURL,
+		Scopes:         oauth.Scopes,
+		EndpointParams: oauth.EndpointParams,
+		AuthStyle:      oauth2.AuthStyle(oauth.AuthStyle),
+	}
+	token, err := conf.Token(context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if !token.Valid() {
+		return nil, errors.New("invalid token received")
+	}
+	return token, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	databaseFile "github.com/perses/perses/internal/api/database/file"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	globalSecretImpl "github.com/perses/perses/internal/api/impl/v1/globalsecret"
+	webhookImpl "github.com/perses/perses/internal/api/impl/v1/webhook"
+	"github.com/perses/perses/internal/api/watch"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+// noCrypto leaves the secrets as they are stored.
+type noCrypto struct{}
+
+func (noCrypto) Encrypt(_ *v1.SecretSpec) error {
+	return nil
+}
+
+func (noCrypto) Decrypt(_ *v1.SecretSpec) error {
+	return nil
+}
+
+func (noCrypto) Reencrypt(_ *v1.SecretSpec) (bool, error) {
+	return false, nil
+}
+
+func newWebhook(name string, url string, filter v1.WebhookFilter) *v1.Webhook {
+	return &v1.Webhook{
+		Kind:     v1.KindWebhook,
+		Metadata: v1.Metadata{Name: name},
+		Spec: v1.WebhookSpec{
+			URL:           common.MustParseURL(url),
+			SigningSecret: "signing",
+			Filter:        filter,
+			RetryPolicy:   v1.WebhookRetryPolicy{MaxAttempts: 2, Backoff: common.Duration(time.Minute)},
+		},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	event := &v1.WatchEvent{Type: v1.WatchEventAdded, Kind: v1.KindDashboard, Project: "perses", Name: "demo"}
+	testSuite := []struct {
+		title  string
+		filter v1.WebhookFilter
+		action role.Action
+		result bool
+	}{
+		{
+			title:  "empty filter",
+			action: role.CreateAction,
+			result: true,
+		},
+		{
+			title:  "matching filter",
+			filter: v1.WebhookFilter{Kinds: []v1.Kind{v1.KindDashboard}, Projects: []string{"perses"}, Actions: []role.Action{role.CreateAction}},
+			action: role.CreateAction,
+			result: true,
+		},
+		{
+			title:  "other kind",
+			filter: v1.WebhookFilter{Kinds: []v1.Kind{v1.KindDatasource}},
+			action: role.CreateAction,
+		},
+		{
+			title:  "other project",
+			filter: v1.WebhookFilter{Projects: []string{"other"}},
+			action: role.CreateAction,
+		},
+		{
+			title:  "other action",
+			filter: v1.WebhookFilter{Actions: []role.Action{role.DeleteAction}},
+			action: role.CreateAction,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.result, Match(test.filter, event, test.action))
+		})
+	}
+}
+
+func TestSendDeliveries(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r)
+		bodies = append(bodies, body)
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The DAO is case-sensitive, otherwise the temporary folder would be lower-cased by the queries.
+	persesDAO := &databaseFile.DAO{Folder: t.TempDir(), Extension: config.JSONExtension, CaseSensitive: true}
+	dao := webhookImpl.NewDAO(persesDAO)
+	globalSecretDAO := globalSecretImpl.NewDAO(persesDAO)
+	assert.NoError(t, globalSecretDAO.Create(&v1.GlobalSecret{
+		Kind:     v1.KindGlobalSecret,
+		Metadata: v1.Metadata{Name: "signing"},
+		Spec:     v1.SecretSpec{Authorization: &secret.Authorization{Credentials: "my-key"}},
+	}))
+	assert.NoError(t, dao.Create(newWebhook("ci", server.URL, v1.WebhookFilter{Kinds: []v1.Kind{v1.KindProject}})))
+	assert.NoError(t, dao.Create(newWebhook("dashboards", server.URL, v1.WebhookFilter{Kinds: []v1.Kind{v1.KindDashboard}})))
+
+	// Record the creation of a project, only the webhook "ci" is interested in it.
+	r := &recorder{dao: dao}
+	r.record(watch.NewEvent(v1.WatchEventAdded, &v1.Project{Kind: v1.KindProject, Metadata: v1.Metadata{Name: "perses"}}))
+	deliveries, err := dao.QueryDeliveries(&databaseModel.WebhookDeliveryQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+	assert.Equal(t, "ci", delivery.Webhook)
+	assert.Equal(t, role.CreateAction, delivery.Action)
+
+	// The first attempt fails, a retry is planned.
+	snd := NewSender(dao, globalSecretDAO, noCrypto{})
+	assert.NoError(t, snd.Execute(context.Background(), nil))
+	assert.Len(t, received, 1)
+	assert.Equal(t, Sign("my-key", bodies[0]), received[0].Header.Get(HeaderSignature))
+	assert.Equal(t, delivery.ID, received[0].Header.Get(HeaderDelivery))
+	assert.Equal(t, "Project.create", received[0].Header.Get(HeaderEvent))
+	payload := map[string]any{}
+	assert.NoError(t, json.Unmarshal(bodies[0], &payload))
+	assert.Equal(t, delivery.ID, payload["delivery"])
+	assert.Equal(t, "create", payload["action"])
+	result, err := dao.QueryDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "ci"})
+	assert.NoError(t, err)
+	assert.Equal(t, v1.WebhookDeliveryPending, result[0].Status)
+	assert.Equal(t, 1, result[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, result[0].StatusCode)
+	assert.True(t, result[0].NextAttemptAt.After(time.Now()))
+
+	// The retry is not due yet, so nothing is sent.
+	assert.NoError(t, snd.Execute(context.Background(), nil))
+	assert.Len(t, received, 1)
+
+	// Once due, the retry succeeds with the same body.
+	now := time.Now().UTC()
+	result[0].NextAttemptAt = &now
+	assert.NoError(t, dao.UpdateDelivery(result[0]))
+	fail = false
+	assert.NoError(t, snd.Execute(context.Background(), nil))
+	assert.Len(t, received, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+	result, err = dao.QueryDeliveries(&databaseModel.WebhookDeliveryQuery{Webhook: "ci"})
+	assert.NoError(t, err)
+	assert.Equal(t, v1.WebhookDeliverySuccess, result[0].Status)
+	assert.Equal(t, 2, result[0].Attempts)
+	assert.Nil(t, result[0].NextAttemptAt)
+}
@@ -37,6 +37,7 @@ type option struct {
 	kind            modelV1.Kind
 	allProject      bool
 	prefix          string
+	labelSelector   string
 	resourceService service.Service
 }
 
@@ -92,7 +93,7 @@ func (o *option) Validate() error {
 }
 
 func (o *option) Execute() error {
-	resourceList, err := o.resourceService.ListResource(o.prefix)
+	resourceList, err := o.resourceService.ListResource(o.prefix, o.labelSelector)
 	if err != nil {
 		return err
 	}
@@ -126,6 +127,9 @@ percli get dashboards node
 # List all dashboards in a specific project.
 percli get dashboards -p my_project
 
+# List all dashboards owned by the sre team that are not in the dev tier.
+percli get dashboards -l 'team=sre,tier!=dev'
+
 #List all dashboards as a JSON object.
 percli get dashboards -a -ojson
 
@@ -137,6 +141,7 @@ percli get dashboards -a -ojson
 	opt.AddOutputFlags(cmd, &o.OutputOption)
 	opt.AddProjectFlags(cmd, &o.ProjectOption)
 	cmd.Flags().BoolVarP(&o.allProject, "all", "a", o.allProject, "If present, list the requested object(s) across all projects. The project in the current context is ignored even if specified with --project.")
+	cmd.Flags().StringVarP(&o.labelSelector, "selector", "l", o.labelSelector, "Selector (label query) to filter on, supports '=', '==', '!=', 'in', 'notin' and the existence of a label (e.g. -l 'team=sre,tier!=dev').")
 	cmd.MarkFlagsMutuallyExclusive("project", "all")
 	return cmd
 }
@@ -22,6 +22,7 @@ import (
 	test "github.com/perses/perses/internal/test"
 	fakeapi "github.com/perses/perses/pkg/client/fake/api"
 	fakev1 "github.com/perses/perses/pkg/client/fake/api/v1"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
 func TestGetCMD(t *testing.T) {
@@ -58,6 +59,22 @@ func TestGetCMD(t *testing.T) {
 			IsErrorExpected: false,
 			ExpectedMessage: string(test.JSONMarshalStrict(fakev1.ProjectList("per"))) + "\n",
 		},
+		{
+			// Among the fake projects, only "perses" has the label team=sre.
+			Title:           "get project with a label selector in json format",
+			Args:            []string{"project", "-l", "team=sre", "-ojson"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: string(test.JSONMarshalStrict(fakev1.ProjectList("perses"))) + "\n",
+		},
+		{
+			// "Amadeus" has another team, and "Chronosphere" has no team label.
+			Title:           "get project with a negative label selector in json format",
+			Args:            []string{"project", "-l", "team!=sre", "-ojson"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: string(test.JSONMarshalStrict([]*modelV1.Project{fakev1.ProjectList("Amadeus")[0], fakev1.ProjectList("Chronosphere")[0]})) + "\n",
+		},
 		{
 			Title:           "get globaldatasource in json format",
 			Args:            []string{"gdts", "-ojson"},
@@ -310,6 +310,9 @@ percli login https://demo.perses.dev
 
 # Log in to the given server via delegated authentication, non-interactively
 percli login https://demo.perses.dev --provider <slug_id> --client-id <client_id> --client-secret <client-secret>
+
+# Log in to the given server with an access token of a user or of a service account
+percli login https://demo.perses.dev --token perses_<id>_<secret>
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -320,7 +323,7 @@ percli login https://demo.perses.dev --provider <slug_id> --client-id <client_id
 	cmd.Flags().StringVarP(&o.password, "password", "p", "", "Password used for the authentication.")
 	cmd.Flags().StringVar(&o.clientID, "client-id", "", "Client ID used for robotic access when using external authentication provider.")
 	cmd.Flags().StringVar(&o.clientSecret, "client-secret", "", "Client Secret used for robotic access when using external authentication provider.")
-	cmd.Flags().StringVar(&o.accessToken, "token", "", "Bearer token for authentication to the API server")
+	cmd.Flags().StringVar(&o.accessToken, "token", "", "Bearer token for authentication to the API server. It can be a JWT or an access token.")
 	cmd.Flags().StringVar(&o.externalAuthProvider, "provider", "", "External authentication provider identifier. (slug_id)")
 	return cmd
 }
@@ -150,7 +150,7 @@ func (o *option) setNamesFromAll() error {
 	if svcErr != nil {
 		return svcErr
 	}
-	list, err := svc.ListResource("")
+	list, err := svc.ListResource("", "")
 	if err != nil {
 		return err
 	}
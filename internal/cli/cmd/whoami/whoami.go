@@ -16,11 +16,13 @@ package whoami
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/perses/perses/internal/cli/output"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/secret"
 	"github.com/spf13/cobra"
 )
@@ -57,6 +59,10 @@ func (o *option) Execute() error {
 			return err
 		}
 	}
+	// The access tokens are opaque, only the server knows who they belong to.
+	if strings.HasPrefix(o.authorization.Credentials, modelV1.AccessTokenPrefix) {
+		return output.HandleString(o.writer, "Authenticated with an access token")
+	}
 	login, err := o.extractLogin()
 	if err != nil {
 		return err
@@ -128,6 +128,13 @@ var resources = []resource{
 			"scrt",
 		},
 	},
+	{
+		kind:      modelV1.KindServiceAccount,
+		shortTerm: "sa",
+		aliases: []string{
+			"serviceaccounts",
+		},
+	},
 	{
 		kind:      modelV1.KindUser,
 		shortTerm: "usr",
@@ -144,6 +151,13 @@ var resources = []resource{
 			"vars",
 		},
 	},
+	{
+		kind:      modelV1.KindWebhook,
+		shortTerm: "wh",
+		aliases: []string{
+			"webhooks",
+		},
+	},
 }
 
 func HandleSuccessMessage(writer io.Writer, kind modelV1.Kind, project string, globalResourceMessage string) error {
@@ -35,8 +35,8 @@ func (e *ephemeralDashboard) UpdateResource(entity modelAPI.Entity) (modelAPI.En
 	return e.apiClient.Update(entity.(*modelV1.EphemeralDashboard))
 }
 
-func (e *ephemeralDashboard) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(e.apiClient.List(prefix))
+func (e *ephemeralDashboard) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(e.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (e *ephemeralDashboard) GetResource(name string) (modelAPI.Entity, error) {
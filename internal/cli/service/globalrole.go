@@ -74,8 +74,8 @@ func (g *globalRole) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, er
 	return g.apiClient.Update(entity.(*modelV1.GlobalRole))
 }
 
-func (g *globalRole) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(g.apiClient.List(prefix))
+func (g *globalRole) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(g.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (g *globalRole) GetResource(name string) (modelAPI.Entity, error) {
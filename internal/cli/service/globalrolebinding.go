@@ -33,8 +33,8 @@ func (g *globalRoleBinding) UpdateResource(entity modelAPI.Entity) (modelAPI.Ent
 	return g.apiClient.Update(entity.(*modelV1.GlobalRoleBinding))
 }
 
-func (g *globalRoleBinding) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(g.apiClient.List(prefix))
+func (g *globalRoleBinding) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(g.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (g *globalRoleBinding) GetResource(name string) (modelAPI.Entity, error) {
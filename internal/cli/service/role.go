@@ -33,8 +33,8 @@ func (r *role) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
 	return r.apiClient.Update(entity.(*modelV1.Role))
 }
 
-func (r *role) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(r.apiClient.List(prefix))
+func (r *role) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(r.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (r *role) GetResource(name string) (modelAPI.Entity, error) {
@@ -33,8 +33,8 @@ func (r *roleBinding) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, e
 	return r.apiClient.Update(entity.(*modelV1.RoleBinding))
 }
 
-func (r *roleBinding) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(r.apiClient.List(prefix))
+func (r *roleBinding) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(r.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (r *roleBinding) GetResource(name string) (modelAPI.Entity, error) {
@@ -49,7 +49,7 @@ func Upsert(svc Service, entity modelAPI.Entity) error {
 type Service interface {
 	CreateResource(entity modelAPI.Entity) (modelAPI.Entity, error)
 	UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error)
-	ListResource(prefix string) ([]modelAPI.Entity, error)
+	ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error)
 	GetResource(name string) (modelAPI.Entity, error)
 	DeleteResource(name string) error
 	BuildMatrix(hits []modelAPI.Entity) [][]string
@@ -110,6 +110,10 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &secret{
 			apiClient: apiClient.V1().Secret(projectName),
 		}, nil
+	case modelV1.KindServiceAccount:
+		return &serviceAccount{
+			apiClient: apiClient.V1().ServiceAccount(),
+		}, nil
 	case modelV1.KindUser:
 		return &user{
 			apiClient: apiClient.V1().User(),
@@ -118,6 +122,10 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &variable{
 			apiClient: apiClient.V1().Variable(projectName),
 		}, nil
+	case modelV1.KindWebhook:
+		return &webhook{
+			apiClient: apiClient.V1().Webhook(),
+		}, nil
 	default:
 		return nil, fmt.Errorf("resource %q not supported by the command", kind)
 	}
@@ -33,8 +33,8 @@ func (u *user) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
 	return u.apiClient.Update(entity.(*modelV1.User))
 }
 
-func (u *user) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(u.apiClient.List(prefix))
+func (u *user) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(u.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (u *user) GetResource(name string) (modelAPI.Entity, error) {
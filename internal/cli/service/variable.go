@@ -33,8 +33,8 @@ func (d *variable) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, erro
 	return d.apiClient.Update(entity.(*modelV1.Variable))
 }
 
-func (d *variable) ListResource(prefix string) ([]modelAPI.Entity, error) {
-	return convertToEntityIfNoError(d.apiClient.List(prefix))
+func (d *variable) ListResource(prefix string, labelSelector string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(d.apiClient.ListWithLabelSelector(prefix, labelSelector))
 }
 
 func (d *variable) GetResource(name string) (modelAPI.Entity, error) {
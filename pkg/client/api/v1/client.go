@@ -36,8 +36,10 @@ type ClientInterface interface {
 	Role(project string) RoleInterface
 	RoleBinding(project string) RoleBindingInterface
 	Secret(project string) SecretInterface
+	ServiceAccount() ServiceAccountInterface
 	User() UserInterface
 	Variable(project string) VariableInterface
+	Webhook() WebhookInterface
 }
 
 type client struct {
@@ -115,6 +117,10 @@ func (c *client) Secret(project string) SecretInterface {
 	return newSecret(c.restClient, project)
 }
 
+func (c *client) ServiceAccount() ServiceAccountInterface {
+	return newServiceAccount(c.restClient)
+}
+
 func (c *client) User() UserInterface {
 	return newUser(c.restClient)
 }
@@ -123,8 +129,13 @@ func (c *client) Variable(project string) VariableInterface {
 	return newVariable(c.restClient, project)
 }
 
+func (c *client) Webhook() WebhookInterface {
+	return newWebhook(c.restClient)
+}
+
 type query struct {
-	name string
+	name          string
+	labelSelector string
 }
 
 func (q *query) GetValues() url.Values {
@@ -132,5 +143,8 @@ func (q *query) GetValues() url.Values {
 	if len(q.name) > 0 {
 		values["name"] = []string{q.name}
 	}
+	if len(q.labelSelector) > 0 {
+		values["label_selector"] = []string{q.labelSelector}
+	}
 	return values
 }
@@ -33,6 +33,9 @@ type DashboardInterface interface {
 	// prefix is a prefix of the Dashboard.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Dashboard available
 	List(prefix string) ([]*v1.Dashboard, error)
+	// ListWithLabelSelector is like List, but it also filters the Dashboard based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Dashboard, error)
 }
 
 type dashboard struct {
@@ -92,11 +95,16 @@ func (c *dashboard) Get(name string) (*v1.Dashboard, error) {
 }
 
 func (c *dashboard) List(prefix string) ([]*v1.Dashboard, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *dashboard) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Dashboard, error) {
 	var result []*v1.Dashboard
 	err := c.client.Get().
 		Resource(dashboardResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
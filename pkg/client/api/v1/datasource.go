@@ -33,6 +33,9 @@ type DatasourceInterface interface {
 	// prefix is a prefix of the Datasource.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Datasource available
 	List(prefix string) ([]*v1.Datasource, error)
+	// ListWithLabelSelector is like List, but it also filters the Datasource based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Datasource, error)
 }
 
 type datasource struct {
@@ -92,11 +95,16 @@ func (c *datasource) Get(name string) (*v1.Datasource, error) {
 }
 
 func (c *datasource) List(prefix string) ([]*v1.Datasource, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *datasource) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Datasource, error) {
 	var result []*v1.Datasource
 	err := c.client.Get().
 		Resource(datasourceResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
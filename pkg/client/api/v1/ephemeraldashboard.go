@@ -33,6 +33,9 @@ type EphemeralDashboardInterface interface {
 	// prefix is a prefix of the EphemeralDashboard.metadata.name to search for.
 	// It can be empty in case you want to get the full list of EphemeralDashboard available
 	List(prefix string) ([]*v1.EphemeralDashboard, error)
+	// ListWithLabelSelector is like List, but it also filters the EphemeralDashboard based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.EphemeralDashboard, error)
 }
 
 type ephemeralDashboard struct {
@@ -92,11 +95,16 @@ func (c *ephemeralDashboard) Get(name string) (*v1.EphemeralDashboard, error) {
 }
 
 func (c *ephemeralDashboard) List(prefix string) ([]*v1.EphemeralDashboard, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *ephemeralDashboard) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.EphemeralDashboard, error) {
 	var result []*v1.EphemeralDashboard
 	err := c.client.Get().
 		Resource(ephemeralDashboardResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
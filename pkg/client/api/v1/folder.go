@@ -33,6 +33,9 @@ type FolderInterface interface {
 	// prefix is a prefix of the Folder.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Folder available
 	List(prefix string) ([]*v1.Folder, error)
+	// ListWithLabelSelector is like List, but it also filters the Folder based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Folder, error)
 }
 
 type folder struct {
@@ -92,11 +95,16 @@ func (c *folder) Get(name string) (*v1.Folder, error) {
 }
 
 func (c *folder) List(prefix string) ([]*v1.Folder, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *folder) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Folder, error) {
 	var result []*v1.Folder
 	err := c.client.Get().
 		Resource(folderResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
@@ -33,6 +33,9 @@ type GlobalDatasourceInterface interface {
 	// prefix is a prefix of the GlobalDatasource.metadata.name to search for.
 	// It can be empty in case you want to get the full list of GlobalDatasource available
 	List(prefix string) ([]*v1.GlobalDatasource, error)
+	// ListWithLabelSelector is like List, but it also filters the GlobalDatasource based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalDatasource, error)
 }
 
 type globalDatasource struct {
@@ -86,11 +89,16 @@ func (c *globalDatasource) Get(name string) (*v1.GlobalDatasource, error) {
 }
 
 func (c *globalDatasource) List(prefix string) ([]*v1.GlobalDatasource, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *globalDatasource) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalDatasource, error) {
 	var result []*v1.GlobalDatasource
 	err := c.client.Get().
 		Resource(globalDatasourceResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
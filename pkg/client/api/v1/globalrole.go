@@ -33,6 +33,9 @@ type GlobalRoleInterface interface {
 	// prefix is a prefix of the GlobalRole.metadata.name to search for.
 	// It can be empty in case you want to get the full list of GlobalRole available
 	List(prefix string) ([]*v1.GlobalRole, error)
+	// ListWithLabelSelector is like List, but it also filters the GlobalRole based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalRole, error)
 }
 
 type globalRole struct {
@@ -86,11 +89,16 @@ func (c *globalRole) Get(name string) (*v1.GlobalRole, error) {
 }
 
 func (c *globalRole) List(prefix string) ([]*v1.GlobalRole, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *globalRole) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalRole, error) {
 	var result []*v1.GlobalRole
 	err := c.client.Get().
 		Resource(globalRoleResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
@@ -33,6 +33,9 @@ type GlobalRoleBindingInterface interface {
 	// prefix is a prefix of the GlobalRoleBinding.metadata.name to search for.
 	// It can be empty in case you want to get the full list of GlobalRoleBinding available
 	List(prefix string) ([]*v1.GlobalRoleBinding, error)
+	// ListWithLabelSelector is like List, but it also filters the GlobalRoleBinding based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalRoleBinding, error)
 }
 
 type globalRoleBinding struct {
@@ -86,11 +89,16 @@ func (c *globalRoleBinding) Get(name string) (*v1.GlobalRoleBinding, error) {
 }
 
 func (c *globalRoleBinding) List(prefix string) ([]*v1.GlobalRoleBinding, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *globalRoleBinding) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalRoleBinding, error) {
 	var result []*v1.GlobalRoleBinding
 	err := c.client.Get().
 		Resource(globalRoleBindingResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
@@ -33,6 +33,9 @@ type GlobalSecretInterface interface {
 	// prefix is a prefix of the GlobalSecret.metadata.name to search for.
 	// It can be empty in case you want to get the full list of GlobalSecret available
 	List(prefix string) ([]*v1.GlobalSecret, error)
+	// ListWithLabelSelector is like List, but it also filters the GlobalSecret based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalSecret, error)
 }
 
 type globalSecret struct {
@@ -86,11 +89,16 @@ func (c *globalSecret) Get(name string) (*v1.GlobalSecret, error) {
 }
 
 func (c *globalSecret) List(prefix string) ([]*v1.GlobalSecret, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *globalSecret) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalSecret, error) {
 	var result []*v1.GlobalSecret
 	err := c.client.Get().
 		Resource(globalSecretResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
@@ -33,6 +33,9 @@ type GlobalVariableInterface interface {
 	// prefix is a prefix of the GlobalVariable.metadata.name to search for.
 	// It can be empty in case you want to get the full list of GlobalVariable available
 	List(prefix string) ([]*v1.GlobalVariable, error)
+	// ListWithLabelSelector is like List, but it also filters the GlobalVariable based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalVariable, error)
 }
 
 type globalVariable struct {
@@ -86,11 +89,16 @@ func (c *globalVariable) Get(name string) (*v1.GlobalVariable, error) {
 }
 
 func (c *globalVariable) List(prefix string) ([]*v1.GlobalVariable, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *globalVariable) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.GlobalVariable, error) {
 	var result []*v1.GlobalVariable
 	err := c.client.Get().
 		Resource(globalVariableResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
@@ -33,6 +33,9 @@ type ProjectInterface interface {
 	// prefix is a prefix of the Project.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Project available
 	List(prefix string) ([]*v1.Project, error)
+	// ListWithLabelSelector is like List, but it also filters the Project based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Project, error)
 }
 
 type project struct {
@@ -86,11 +89,16 @@ func (c *project) Get(name string) (*v1.Project, error) {
 }
 
 func (c *project) List(prefix string) ([]*v1.Project, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *project) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Project, error) {
 	var result []*v1.Project
 	err := c.client.Get().
 		Resource(projectResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
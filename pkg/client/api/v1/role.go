@@ -33,6 +33,9 @@ type RoleInterface interface {
 	// prefix is a prefix of the Role.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Role available
 	List(prefix string) ([]*v1.Role, error)
+	// ListWithLabelSelector is like List, but it also filters the Role based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Role, error)
 }
 
 type role struct {
@@ -92,11 +95,16 @@ func (c *role) Get(name string) (*v1.Role, error) {
 }
 
 func (c *role) List(prefix string) ([]*v1.Role, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *role) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Role, error) {
 	var result []*v1.Role
 	err := c.client.Get().
 		Resource(roleResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
@@ -33,6 +33,9 @@ type RoleBindingInterface interface {
 	// prefix is a prefix of the RoleBinding.metadata.name to search for.
 	// It can be empty in case you want to get the full list of RoleBinding available
 	List(prefix string) ([]*v1.RoleBinding, error)
+	// ListWithLabelSelector is like List, but it also filters the RoleBinding based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.RoleBinding, error)
 }
 
 type roleBinding struct {
@@ -92,11 +95,16 @@ func (c *roleBinding) Get(name string) (*v1.RoleBinding, error) {
 }
 
 func (c *roleBinding) List(prefix string) ([]*v1.RoleBinding, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *roleBinding) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.RoleBinding, error) {
 	var result []*v1.RoleBinding
 	err := c.client.Get().
 		Resource(roleBindingResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
@@ -33,6 +33,9 @@ type SecretInterface interface {
 	// prefix is a prefix of the Secret.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Secret available
 	List(prefix string) ([]*v1.Secret, error)
+	// ListWithLabelSelector is like List, but it also filters the Secret based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Secret, error)
 }
 
 type secret struct {
@@ -92,11 +95,16 @@ func (c *secret) Get(name string) (*v1.Secret, error) {
 }
 
 func (c *secret) List(prefix string) ([]*v1.Secret, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *secret) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Secret, error) {
 	var result []*v1.Secret
 	err := c.client.Get().
 		Resource(secretResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
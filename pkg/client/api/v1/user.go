@@ -33,6 +33,9 @@ type UserInterface interface {
 	// prefix is a prefix of the User.metadata.name to search for.
 	// It can be empty in case you want to get the full list of User available
 	List(prefix string) ([]*v1.User, error)
+	// ListWithLabelSelector is like List, but it also filters the User based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.User, error)
 }
 
 type user struct {
@@ -86,11 +89,16 @@ func (c *user) Get(name string) (*v1.User, error) {
 }
 
 func (c *user) List(prefix string) ([]*v1.User, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *user) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.User, error) {
 	var result []*v1.User
 	err := c.client.Get().
 		Resource(userResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Do().
 		Object(&result)
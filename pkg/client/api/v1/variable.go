@@ -33,6 +33,9 @@ type VariableInterface interface {
 	// prefix is a prefix of the Variable.metadata.name to search for.
 	// It can be empty in case you want to get the full list of Variable available
 	List(prefix string) ([]*v1.Variable, error)
+	// ListWithLabelSelector is like List, but it also filters the Variable based on their labels.
+	// labelSelector uses the same syntax as Kubernetes, e.g. `team=sre,tier!=dev`. It can be empty.
+	ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Variable, error)
 }
 
 type variable struct {
@@ -92,11 +95,16 @@ func (c *variable) Get(name string) (*v1.Variable, error) {
 }
 
 func (c *variable) List(prefix string) ([]*v1.Variable, error) {
+	return c.ListWithLabelSelector(prefix, "")
+}
+
+func (c *variable) ListWithLabelSelector(prefix string, labelSelector string) ([]*v1.Variable, error) {
 	var result []*v1.Variable
 	err := c.client.Get().
 		Resource(variableResource).
 		Query(&query{
-			name: prefix,
+			name:          prefix,
+			labelSelector: labelSelector,
 		}).
 		Project(c.project).
 		Do().
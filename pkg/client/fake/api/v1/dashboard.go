@@ -50,3 +50,13 @@ func (d *dashboard) Get(name string) (*modelV1.Dashboard, error) {
 func (d *dashboard) List(_ string) ([]*modelV1.Dashboard, error) {
 	return make([]*modelV1.Dashboard, 0), nil
 }
+
+func (d *dashboard) ListWithLabelSelector(prefix string, labelSelector string) ([]*modelV1.Dashboard, error) {
+	list, err := d.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return filterByLabelSelector(list, labelSelector, func(entity *modelV1.Dashboard) map[string]string {
+		return entity.Metadata.Labels
+	})
+}
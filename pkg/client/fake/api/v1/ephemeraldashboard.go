@@ -37,3 +37,13 @@ func (e *ephemeralDashboard) Get(_ string) (*modelV1.EphemeralDashboard, error)
 func (e *ephemeralDashboard) List(_ string) ([]*modelV1.EphemeralDashboard, error) {
 	return make([]*modelV1.EphemeralDashboard, 0), nil
 }
+
+func (e *ephemeralDashboard) ListWithLabelSelector(prefix string, labelSelector string) ([]*modelV1.EphemeralDashboard, error) {
+	list, err := e.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return filterByLabelSelector(list, labelSelector, func(entity *modelV1.EphemeralDashboard) map[string]string {
+		return entity.Metadata.Labels
+	})
+}
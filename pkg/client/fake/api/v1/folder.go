@@ -89,3 +89,13 @@ func (c *folder) Get(name string) (*modelV1.Folder, error) {
 func (c *folder) List(prefix string) ([]*modelV1.Folder, error) {
 	return FolderList(c.project, prefix), nil
 }
+
+func (c *folder) ListWithLabelSelector(prefix string, labelSelector string) ([]*modelV1.Folder, error) {
+	list, err := c.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return filterByLabelSelector(list, labelSelector, func(entity *modelV1.Folder) map[string]string {
+		return entity.Metadata.Labels
+	})
+}
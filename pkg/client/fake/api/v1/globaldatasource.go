@@ -121,3 +121,13 @@ func (c *globalDatasource) Get(name string) (*modelV1.GlobalDatasource, error) {
 func (c *globalDatasource) List(prefix string) ([]*modelV1.GlobalDatasource, error) {
 	return GlobalDatasourceList(prefix), nil
 }
+
+func (c *globalDatasource) ListWithLabelSelector(prefix string, labelSelector string) ([]*modelV1.GlobalDatasource, error) {
+	list, err := c.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return filterByLabelSelector(list, labelSelector, func(entity *modelV1.GlobalDatasource) map[string]string {
+		return entity.Metadata.Labels
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakev1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// filterByLabelSelector keeps the resources whose labels match the selector, as the API does with the query parameter
+// label_selector.
+func filterByLabelSelector[T any](list []T, labelSelector string, labelsOf func(T) map[string]string) ([]T, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]T, 0, len(list))
+	for _, item := range list {
+		if selector.Matches(labels.Set(labelsOf(item))) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
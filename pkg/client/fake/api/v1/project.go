@@ -25,13 +25,15 @@ func ProjectList(prefix string) []*modelV1.Project {
 		{
 			Kind: modelV1.KindProject,
 			Metadata: modelV1.Metadata{
-				Name: "perses",
+				Name:   "perses",
+				Labels: map[string]string{"team": "sre"},
 			},
 		},
 		{
 			Kind: modelV1.KindProject,
 			Metadata: modelV1.Metadata{
-				Name: "Amadeus",
+				Name:   "Amadeus",
+				Labels: map[string]string{"team": "dev"},
 			},
 		},
 		{
@@ -78,3 +80,13 @@ func (c *project) Get(name string) (*modelV1.Project, error) {
 func (c *project) List(prefix string) ([]*modelV1.Project, error) {
 	return ProjectList(prefix), nil
 }
+
+func (c *project) ListWithLabelSelector(prefix string, labelSelector string) ([]*modelV1.Project, error) {
+	list, err := c.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return filterByLabelSelector(list, labelSelector, func(entity *modelV1.Project) map[string]string {
+		return entity.Metadata.Labels
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+)
+
+type AuditFile struct {
+	// Path is the file where the events are appended, one JSON document per line.
+	Path string `json:"path" yaml:"path"`
+}
+
+func (f *AuditFile) Verify() error {
+	if len(f.Path) == 0 {
+		return fmt.Errorf("path is required for the audit file")
+	}
+	return nil
+}
+
+type AuditDatabase struct {
+	// Disable the storage of the events in the Perses database.
+	Disable bool `json:"disable" yaml:"disable"`
+}
+
+type AuditConfig struct {
+	// Enable the audit log. Every change made on a resource is then recorded.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Database is the configuration of the storage of the events in the Perses database. It is used by default.
+	Database AuditDatabase `json:"database" yaml:"database"`
+	// File, when set, appends every event in a file.
+	File *AuditFile `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+func (a *AuditConfig) Verify() error {
+	if a.Enable && a.Database.Disable && a.File == nil {
+		return fmt.Errorf("at least one audit sink must be used, you cannot disable the database without setting a file")
+	}
+	return nil
+}
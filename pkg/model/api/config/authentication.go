@@ -17,8 +17,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/netip"
 	"os"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/perses/perses/pkg/model/api/v1/common"
@@ -31,6 +33,20 @@ const (
 	DefaultProviderTimeout = time.Minute * 1
 )
 
+const (
+	// LDAPLoginPlaceholder is replaced by the login of the user in the LDAP search filters.
+	LDAPLoginPlaceholder = "{login}"
+	// LDAPDNPlaceholder is replaced by the DN of the user in the LDAP group search filter.
+	LDAPDNPlaceholder      = "{dn}"
+	DefaultLDAPUserFilter  = "(uid={login})"
+	DefaultLDAPGroupFilter = "(member={dn})"
+)
+
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmES256 = "ES256"
+)
+
 type OAuthOverride struct {
 	ClientID         secret.Hidden `json:"client_id" yaml:"client_id"`
 	ClientSecret     secret.Hidden `json:"client_secret,omitempty" yaml:"client_secret,omitempty"`
@@ -106,6 +122,10 @@ type Provider struct {
 	RedirectURI       common.URL     `json:"redirect_uri,omitempty" yaml:"redirect_uri,omitempty"`
 	Scopes            []string       `json:"scopes,omitempty" yaml:"scopes,omitempty"`
 	HTTP              HTTP           `json:"http" yaml:"http"`
+	// GroupsClaim is the path of the claim in the user info listing the groups the user belongs to.
+	// Nested claims are reached with a dot, like `realm_access.roles`.
+	// When it is empty, the groups of the user are not synced.
+	GroupsClaim string `json:"groups_claim,omitempty" yaml:"groups_claim,omitempty"`
 }
 
 func (p *Provider) Verify() error {
@@ -173,10 +193,236 @@ func (p *OAuthProvider) Verify() error {
 	return nil
 }
 
+// LDAPUserSearch describes how the entry of the user logging in is found in the directory.
+type LDAPUserSearch struct {
+	// BaseDN is the entry from which the users are searched, like `ou=people,dc=example,dc=org`.
+	BaseDN string `json:"base_dn" yaml:"base_dn"`
+	// Filter is the LDAP filter matching the entry of the user.
+	// `{login}` is replaced by the escaped login of the user. By default, it is `(uid={login})`.
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+}
+
+func (s *LDAPUserSearch) Verify() error {
+	if len(s.BaseDN) == 0 {
+		return errors.New("ldap user search's `base_dn` is mandatory")
+	}
+	if len(s.Filter) == 0 {
+		s.Filter = DefaultLDAPUserFilter
+	}
+	if !strings.Contains(s.Filter, LDAPLoginPlaceholder) {
+		return fmt.Errorf("ldap user search's `filter` must contain the placeholder %s", LDAPLoginPlaceholder)
+	}
+	return nil
+}
+
+// LDAPGroupSearch describes how the groups of the user are found in the directory.
+type LDAPGroupSearch struct {
+	// BaseDN is the entry from which the groups are searched, like `ou=groups,dc=example,dc=org`.
+	BaseDN string `json:"base_dn" yaml:"base_dn"`
+	// Filter is the LDAP filter matching the groups of the user.
+	// `{dn}` is replaced by the DN of the user, and `{login}` by its login. By default, it is `(member={dn})`.
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	// NameAttribute is the attribute of the groups used as the name of the group in the role bindings.
+	// By default, it is `cn`.
+	NameAttribute string `json:"name_attribute,omitempty" yaml:"name_attribute,omitempty"`
+}
+
+func (s *LDAPGroupSearch) Verify() error {
+	if len(s.BaseDN) == 0 {
+		return errors.New("ldap group search's `base_dn` is mandatory")
+	}
+	if len(s.Filter) == 0 {
+		s.Filter = DefaultLDAPGroupFilter
+	}
+	if len(s.NameAttribute) == 0 {
+		s.NameAttribute = "cn"
+	}
+	return nil
+}
+
+// LDAPAttributes are the attributes of the user entry used to fill the user in Perses.
+type LDAPAttributes struct {
+	// Login is the attribute used as the name of the user in Perses. By default, it is `uid`.
+	Login string `json:"login,omitempty" yaml:"login,omitempty"`
+	// Email is by default `mail`.
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	// FirstName is by default `givenName`.
+	FirstName string `json:"first_name,omitempty" yaml:"first_name,omitempty"`
+	// LastName is by default `sn`.
+	LastName string `json:"last_name,omitempty" yaml:"last_name,omitempty"`
+}
+
+func (a *LDAPAttributes) Verify() error {
+	if len(a.Login) == 0 {
+		a.Login = "uid"
+	}
+	if len(a.Email) == 0 {
+		a.Email = "mail"
+	}
+	if len(a.FirstName) == 0 {
+		a.FirstName = "givenName"
+	}
+	if len(a.LastName) == 0 {
+		a.LastName = "sn"
+	}
+	return nil
+}
+
+// LDAPProvider authenticates the users by binding to an LDAP directory with their login and password.
+type LDAPProvider struct {
+	SlugID string `json:"slug_id" yaml:"slug_id"`
+	Name   string `json:"name" yaml:"name"`
+	// URL of the directory, like `ldap://ldap.example.org:389` or `ldaps://ldap.example.org:636`.
+	URL common.URL `json:"url" yaml:"url"`
+	// StartTLS upgrades the `ldap://` connection to TLS before any bind.
+	StartTLS  bool              `json:"start_tls,omitempty" yaml:"start_tls,omitempty"`
+	TLSConfig *secret.TLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+	// Timeout of the connection and of each request to the directory. By default, it is 1 minute.
+	Timeout common.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// BindDN is the DN of the account used to search the users and their groups.
+	// When it is empty, the searches are done anonymously.
+	BindDN           string         `json:"bind_dn,omitempty" yaml:"bind_dn,omitempty"`
+	BindPassword     secret.Hidden  `json:"bind_password,omitempty" yaml:"bind_password,omitempty"`
+	BindPasswordFile string         `json:"bind_password_file,omitempty" yaml:"bind_password_file,omitempty"`
+	UserSearch       LDAPUserSearch `json:"user_search" yaml:"user_search"`
+	Attributes       LDAPAttributes `json:"attributes" yaml:"attributes"`
+	// GroupSearch syncs the groups of the user, so they can be used as subjects in the role bindings.
+	// When it is not set, the groups of the user are not synced.
+	GroupSearch *LDAPGroupSearch `json:"group_search,omitempty" yaml:"group_search,omitempty"`
+}
+
+// publicLDAPProvider is the LDAPProvider with the TLS config hidden. It is used to marshal the provider.
+type publicLDAPProvider struct {
+	SlugID           string                  `json:"slug_id" yaml:"slug_id"`
+	Name             string                  `json:"name" yaml:"name"`
+	URL              common.URL              `json:"url" yaml:"url"`
+	StartTLS         bool                    `json:"start_tls,omitempty" yaml:"start_tls,omitempty"`
+	TLSConfig        *secret.PublicTLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+	Timeout          common.Duration         `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	BindDN           string                  `json:"bind_dn,omitempty" yaml:"bind_dn,omitempty"`
+	BindPassword     secret.Hidden           `json:"bind_password,omitempty" yaml:"bind_password,omitempty"`
+	BindPasswordFile string                  `json:"bind_password_file,omitempty" yaml:"bind_password_file,omitempty"`
+	UserSearch       LDAPUserSearch          `json:"user_search" yaml:"user_search"`
+	Attributes       LDAPAttributes          `json:"attributes" yaml:"attributes"`
+	GroupSearch      *LDAPGroupSearch        `json:"group_search,omitempty" yaml:"group_search,omitempty"`
+}
+
+func (p LDAPProvider) toPublic() publicLDAPProvider {
+	return publicLDAPProvider{
+		SlugID:           p.SlugID,
+		Name:             p.Name,
+		URL:              p.URL,
+		StartTLS:         p.StartTLS,
+		TLSConfig:        secret.NewPublicTLSConfig(p.TLSConfig),
+		Timeout:          p.Timeout,
+		BindDN:           p.BindDN,
+		BindPassword:     p.BindPassword,
+		BindPasswordFile: p.BindPasswordFile,
+		UserSearch:       p.UserSearch,
+		Attributes:       p.Attributes,
+		GroupSearch:      p.GroupSearch,
+	}
+}
+
+func (p LDAPProvider) MarshalYAML() (any, error) {
+	return p.toPublic(), nil
+}
+
+func (p LDAPProvider) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.toPublic())
+}
+
+func (p *LDAPProvider) Verify() error {
+	if p.SlugID == "" {
+		return errors.New("provider's `slug_id` is mandatory")
+	}
+	if p.Name == "" {
+		return errors.New("provider's `name` is mandatory")
+	}
+	if p.URL.IsNilOrEmpty() {
+		return errors.New("provider's `url` is mandatory")
+	}
+	if p.URL.Scheme != "ldap" && p.URL.Scheme != "ldaps" {
+		return fmt.Errorf("provider's `url` scheme %q not supported, it must be ldap or ldaps", p.URL.Scheme)
+	}
+	if p.StartTLS && p.URL.Scheme == "ldaps" {
+		return errors.New("`start_tls` can't be used with an ldaps url, the connection is already using TLS")
+	}
+	if len(p.BindPassword) > 0 && len(p.BindPasswordFile) > 0 {
+		return errors.New("only one of `bind_password` or `bind_password_file` can be set")
+	}
+	if len(p.BindPasswordFile) > 0 {
+		data, err := os.ReadFile(p.BindPasswordFile)
+		if err != nil {
+			return fmt.Errorf("failed to read bind_password_file: %w", err)
+		}
+		p.BindPassword = secret.Hidden(strings.TrimSpace(string(data)))
+	}
+	if len(p.BindPassword) > 0 && len(p.BindDN) == 0 {
+		return errors.New("`bind_password` requires `bind_dn` to be set")
+	}
+	if p.Timeout == 0 {
+		p.Timeout = common.Duration(DefaultProviderTimeout)
+	}
+	return nil
+}
+
+// TrustedHeaderProvider authenticates the users with the headers set by a reverse proxy that already authenticated them,
+// like oauth2-proxy. The headers are only trusted when the request comes from one of the allowed networks.
+type TrustedHeaderProvider struct {
+	// AllowedCIDRs are the networks of the reverse proxies, like `10.0.0.0/8`.
+	// The headers of the requests coming from any other address are ignored.
+	AllowedCIDRs []string `json:"allowed_cidrs" yaml:"allowed_cidrs"`
+	// UserHeader is the header containing the login of the user. By default, it is `X-Forwarded-User`.
+	UserHeader string `json:"user_header,omitempty" yaml:"user_header,omitempty"`
+	// EmailHeader is the header containing the email of the user. By default, it is `X-Forwarded-Email`.
+	EmailHeader string `json:"email_header,omitempty" yaml:"email_header,omitempty"`
+	// GroupsHeader is the header containing the groups of the user, like `X-Forwarded-Groups`.
+	// When it is empty, the groups of the user are not synced.
+	GroupsHeader string `json:"groups_header,omitempty" yaml:"groups_header,omitempty"`
+	// GroupsSeparator separates the groups in the GroupsHeader. By default, it is `,`.
+	GroupsSeparator string `json:"groups_separator,omitempty" yaml:"groups_separator,omitempty"`
+}
+
+func (p *TrustedHeaderProvider) Verify() error {
+	if len(p.AllowedCIDRs) == 0 {
+		return errors.New("trusted header provider's `allowed_cidrs` is mandatory")
+	}
+	for _, cidr := range p.AllowedCIDRs {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return fmt.Errorf("trusted header provider's `allowed_cidrs`: %w", err)
+		}
+	}
+	if len(p.UserHeader) == 0 {
+		p.UserHeader = "X-Forwarded-User"
+	}
+	if len(p.EmailHeader) == 0 {
+		p.EmailHeader = "X-Forwarded-Email"
+	}
+	if len(p.GroupsSeparator) == 0 {
+		p.GroupsSeparator = ","
+	}
+	return nil
+}
+
+// AllowedPrefixes returns the parsed AllowedCIDRs. They must have been checked with Verify.
+func (p *TrustedHeaderProvider) AllowedPrefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(p.AllowedCIDRs))
+	for _, cidr := range p.AllowedCIDRs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		}
+	}
+	return prefixes
+}
+
 type AuthProviders struct {
 	EnableNative bool            `json:"enable_native" yaml:"enable_native"`
 	OAuth        []OAuthProvider `json:"oauth,omitempty" yaml:"oauth,omitempty"`
 	OIDC         []OIDCProvider  `json:"oidc,omitempty" yaml:"oidc,omitempty"`
+	LDAP         []LDAPProvider  `json:"ldap,omitempty" yaml:"ldap,omitempty"`
+	// TrustedHeader trusts the headers set by a reverse proxy to authenticate the users, without any login in Perses.
+	TrustedHeader *TrustedHeaderProvider `json:"trusted_header,omitempty" yaml:"trusted_header,omitempty"`
 }
 
 func (p *AuthProviders) Verify() error {
@@ -196,6 +442,49 @@ func (p *AuthProviders) Verify() error {
 			return fmt.Errorf("several OAuth providers exist with the same slug_id %q", prov.SlugID)
 		}
 	}
+	var tmpLDAPSlugIDs []string
+	for _, prov := range p.LDAP {
+		var ok bool
+		tmpLDAPSlugIDs, ok = appendIfMissing(tmpLDAPSlugIDs, prov.SlugID)
+		if !ok {
+			return fmt.Errorf("several LDAP providers exist with the same slug_id %q", prov.SlugID)
+		}
+	}
+	return nil
+}
+
+// JWTSigningKey is a private key used to sign the JWT issued by Perses.
+type JWTSigningKey struct {
+	// ID identifies the key. It is set in the `kid` header of the tokens it signs.
+	ID string `json:"id" yaml:"id"`
+	// Algorithm is RS256 for an RSA key, or ES256 for an ECDSA P-256 key.
+	Algorithm string `json:"algorithm" yaml:"algorithm"`
+	// PrivateKey is the PEM encoded private key (PKCS#1, SEC 1 or PKCS#8).
+	PrivateKey secret.Hidden `json:"private_key,omitempty" yaml:"private_key,omitempty"`
+	// PrivateKeyFile is the path to the file containing the PEM encoded private key.
+	PrivateKeyFile string `json:"private_key_file,omitempty" yaml:"private_key_file,omitempty"`
+}
+
+func (k *JWTSigningKey) Verify() error {
+	if len(k.ID) == 0 {
+		return errors.New("signing key's `id` is mandatory")
+	}
+	if k.Algorithm != SigningAlgorithmRS256 && k.Algorithm != SigningAlgorithmES256 {
+		return fmt.Errorf("signing key %q: algorithm %q not supported, it must be %s or %s", k.ID, k.Algorithm, SigningAlgorithmRS256, SigningAlgorithmES256)
+	}
+	if len(k.PrivateKey) > 0 && len(k.PrivateKeyFile) > 0 {
+		return fmt.Errorf("signing key %q: only one of `private_key` or `private_key_file` can be set", k.ID)
+	}
+	if len(k.PrivateKeyFile) > 0 {
+		data, err := os.ReadFile(k.PrivateKeyFile)
+		if err != nil {
+			return fmt.Errorf("signing key %q: failed to read private_key_file: %w", k.ID, err)
+		}
+		k.PrivateKey = secret.Hidden(data)
+	}
+	if len(k.PrivateKey) == 0 {
+		return fmt.Errorf("signing key %q: one of `private_key` or `private_key_file` must be set", k.ID)
+	}
 	return nil
 }
 
@@ -211,6 +500,12 @@ type AuthenticationConfig struct {
 	DisableSignUp bool `json:"disable_sign_up" yaml:"disable_sign_up"`
 	// Providers configure the different authentication providers
 	Providers AuthProviders `json:"providers" yaml:"providers"`
+	// SigningKeys are the keys signing the access and refresh tokens. Their public part is published at
+	// /.well-known/jwks.json, so other services can verify the tokens issued by Perses.
+	// The first key signs the new tokens. The other ones only verify the tokens signed before a rotation, and can be
+	// removed once these tokens are expired.
+	// When it is empty, the tokens are signed with HS512 and keys derived from the encryption_key.
+	SigningKeys []JWTSigningKey `json:"signing_keys,omitempty" yaml:"signing_keys,omitempty"`
 }
 
 func (a *AuthenticationConfig) Verify() error {
@@ -220,5 +515,13 @@ func (a *AuthenticationConfig) Verify() error {
 	if a.RefreshTokenTTL == 0 {
 		a.RefreshTokenTTL = common.Duration(DefaultRefreshTokenTTL)
 	}
+	var ids []string
+	for _, key := range a.SigningKeys {
+		var ok bool
+		ids, ok = appendIfMissing(ids, key.ID)
+		if !ok {
+			return fmt.Errorf("several signing keys exist with the same id %q", key.ID)
+		}
+	}
 	return nil
 }
@@ -14,10 +14,12 @@
 package config
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
 
 	"github.com/perses/common/config"
+	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,6 +29,9 @@ func TestAuthProviders_Verify(t *testing.T) {
 
 	wrongOIDC := AuthProviders{OIDC: []OIDCProvider{{Provider: Provider{SlugID: "hello"}}, {Provider: Provider{SlugID: "hello"}}}}
 	assert.ErrorContains(t, wrongOIDC.Verify(), "several OIDC providers exist with the same slug_id")
+
+	wrongLDAP := AuthProviders{LDAP: []LDAPProvider{{SlugID: "hello"}, {SlugID: "hello"}}}
+	assert.ErrorContains(t, wrongLDAP.Verify(), "several LDAP providers exist with the same slug_id")
 }
 
 // TestProvider_Verify makes sure the Verify of parent struct is well called by the config Resolver
@@ -100,3 +105,212 @@ func TestAppendIfMissing(t *testing.T) {
 	assert.Len(t, slice, 3)
 	assert.False(t, ok3)
 }
+
+func TestJWTSigningKey_Verify(t *testing.T) {
+	keyFile := t.TempDir() + "/key.pem"
+	assert.NoError(t, os.WriteFile(keyFile, []byte("pem"), 0600))
+	testSuites := []struct {
+		title string
+		key   JWTSigningKey
+		err   string
+	}{
+		{
+			title: "missing id",
+			key:   JWTSigningKey{Algorithm: SigningAlgorithmRS256, PrivateKey: "pem"},
+			err:   "`id` is mandatory",
+		},
+		{
+			title: "unsupported algorithm",
+			key:   JWTSigningKey{ID: "k1", Algorithm: "HS256", PrivateKey: "pem"},
+			err:   "not supported",
+		},
+		{
+			title: "both key and file",
+			key:   JWTSigningKey{ID: "k1", Algorithm: SigningAlgorithmES256, PrivateKey: "pem", PrivateKeyFile: keyFile},
+			err:   "only one of",
+		},
+		{
+			title: "no key",
+			key:   JWTSigningKey{ID: "k1", Algorithm: SigningAlgorithmES256},
+			err:   "must be set",
+		},
+		{
+			title: "key read from the file",
+			key:   JWTSigningKey{ID: "k1", Algorithm: SigningAlgorithmES256, PrivateKeyFile: keyFile},
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			err := test.key.Verify()
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "pem", string(test.key.PrivateKey))
+		})
+	}
+}
+
+func TestAuthenticationConfig_VerifySigningKeys(t *testing.T) {
+	cfg := AuthenticationConfig{SigningKeys: []JWTSigningKey{{ID: "k1"}, {ID: "k1"}}}
+	assert.ErrorContains(t, cfg.Verify(), "several signing keys exist with the same id \"k1\"")
+}
+
+func TestLDAPProvider_Verify(t *testing.T) {
+	testSuites := []struct {
+		title string
+		input string
+		err   string
+	}{
+		{
+			title: "missing url",
+			input: `
+slug_id: "corporate"
+name: "Corporate"
+user_search:
+  base_dn: "ou=people,dc=example,dc=org"
+`,
+			err: "`url` is mandatory",
+		},
+		{
+			title: "unsupported scheme",
+			input: `
+slug_id: "corporate"
+name: "Corporate"
+url: "http://ldap.example.org"
+user_search:
+  base_dn: "ou=people,dc=example,dc=org"
+`,
+			err: "must be ldap or ldaps",
+		},
+		{
+			title: "start_tls with ldaps",
+			input: `
+slug_id: "corporate"
+name: "Corporate"
+url: "ldaps://ldap.example.org"
+start_tls: true
+user_search:
+  base_dn: "ou=people,dc=example,dc=org"
+`,
+			err: "`start_tls` can't be used with an ldaps url",
+		},
+		{
+			title: "missing user search base_dn",
+			input: `
+slug_id: "corporate"
+name: "Corporate"
+url: "ldap://ldap.example.org"
+`,
+			err: "`base_dn` is mandatory",
+		},
+		{
+			title: "user filter without the login",
+			input: `
+slug_id: "corporate"
+name: "Corporate"
+url: "ldap://ldap.example.org"
+user_search:
+  base_dn: "ou=people,dc=example,dc=org"
+  filter: "(uid=alice)"
+`,
+			err: "must contain the placeholder {login}",
+		},
+		{
+			title: "bind_password without bind_dn",
+			input: `
+slug_id: "corporate"
+name: "Corporate"
+url: "ldap://ldap.example.org"
+bind_password: "s3cr3t"
+user_search:
+  base_dn: "ou=people,dc=example,dc=org"
+`,
+			err: "`bind_password` requires `bind_dn`",
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			err := config.NewResolver[LDAPProvider]().
+				SetConfigData([]byte(test.input)).
+				Resolve(&LDAPProvider{}).
+				Verify()
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestLDAPProvider_VerifyDefaults(t *testing.T) {
+	testYamlInput := `
+slug_id: "corporate"
+name: "Corporate"
+url: "ldap://ldap.example.org"
+start_tls: true
+tls_config:
+  key: "pr1v4te"
+bind_dn: "cn=perses,dc=example,dc=org"
+bind_password: "s3cr3t"
+user_search:
+  base_dn: "ou=people,dc=example,dc=org"
+group_search:
+  base_dn: "ou=groups,dc=example,dc=org"
+`
+	c := &LDAPProvider{}
+	err := config.NewResolver[LDAPProvider]().
+		SetConfigData([]byte(testYamlInput)).
+		Resolve(c).
+		Verify()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLDAPUserFilter, c.UserSearch.Filter)
+	assert.Equal(t, LDAPAttributes{Login: "uid", Email: "mail", FirstName: "givenName", LastName: "sn"}, c.Attributes)
+	assert.Equal(t, &LDAPGroupSearch{BaseDN: "ou=groups,dc=example,dc=org", Filter: DefaultLDAPGroupFilter, NameAttribute: "cn"}, c.GroupSearch)
+	assert.Equal(t, common.Duration(DefaultProviderTimeout), c.Timeout)
+
+	// The secrets are hidden once marshalled
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+	assert.NotContains(t, string(data), "pr1v4te")
+}
+
+func TestTrustedHeaderProvider_Verify(t *testing.T) {
+	testSuites := []struct {
+		title    string
+		provider TrustedHeaderProvider
+		result   TrustedHeaderProvider
+		err      string
+	}{
+		{
+			title:    "defaults",
+			provider: TrustedHeaderProvider{AllowedCIDRs: []string{"10.0.0.0/8", "::1/128"}},
+			result: TrustedHeaderProvider{
+				AllowedCIDRs:    []string{"10.0.0.0/8", "::1/128"},
+				UserHeader:      "X-Forwarded-User",
+				EmailHeader:     "X-Forwarded-Email",
+				GroupsSeparator: ",",
+			},
+		},
+		{
+			title:    "no allowed cidrs",
+			provider: TrustedHeaderProvider{},
+			err:      "trusted header provider's `allowed_cidrs` is mandatory",
+		},
+		{
+			title:    "invalid cidr",
+			provider: TrustedHeaderProvider{AllowedCIDRs: []string{"10.0.0.1"}},
+			err:      "trusted header provider's `allowed_cidrs`",
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			err := test.provider.Verify()
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, test.provider)
+		})
+	}
+}
@@ -83,6 +83,8 @@ type Config struct {
 	Frontend Frontend `json:"frontend,omitempty" yaml:"frontend,omitempty"`
 	// Plugin contains the config for runtime plugins.
 	Plugin Plugin `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	// Audit contains the config of the audit log.
+	Audit AuditConfig `json:"audit,omitempty" yaml:"audit,omitempty"`
 }
 
 func (c *Config) Verify() error {
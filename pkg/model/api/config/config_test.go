@@ -60,7 +60,9 @@ func TestJSONMarshalConfig(t *testing.T) {
     }
   },
   "database": {},
-  "dashboard": {},
+  "dashboard": {
+    "revision": {}
+  },
   "provisioning": {},
   "datasource": {
     "global": {
@@ -92,6 +94,12 @@ func TestJSONMarshalConfig(t *testing.T) {
   },
   "plugin": {
     "enable_dev": false
+  },
+  "audit": {
+    "enable": false,
+    "database": {
+      "disable": false
+    }
   }
 }`,
 		},
@@ -129,7 +137,11 @@ func TestJSONMarshalConfig(t *testing.T) {
       "case_sensitive": false
     }
   },
-  "dashboard": {},
+  "dashboard": {
+    "revision": {
+      "max_revisions": 20
+    }
+  },
   "provisioning": {
     "interval": "1h"
   },
@@ -165,6 +177,12 @@ func TestJSONMarshalConfig(t *testing.T) {
     "path": "plugins",
     "archive_path": "plugins-archive",
     "enable_dev": false
+  },
+  "audit": {
+    "enable": false,
+    "database": {
+      "disable": false
+    }
   }
 }`,
 		},
@@ -489,6 +507,11 @@ plugin:
 					Path:        "custom/plugins",
 					ArchivePath: "custom/plugins/archive",
 				},
+				Dashboard: DashboardConfig{
+					Revision: DashboardRevision{
+						MaxRevisions: defaultMaxRevisions,
+					},
+				},
 				Provisioning: ProvisioningConfig{
 					Folders: []string{
 						"dev/data",
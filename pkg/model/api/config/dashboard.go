@@ -114,8 +114,29 @@ func (c *CustomLintRule) evaluateAndLoadCELExpression() error {
 	return nil
 }
 
+const defaultMaxRevisions = 20
+
+type DashboardRevision struct {
+	// Disable the revision history of the dashboards. When disabled, the previous versions of a dashboard are not kept.
+	Disable bool `json:"disable,omitempty" yaml:"disable,omitempty"`
+	// MaxRevisions is the number of versions kept for each dashboard, including the current one.
+	MaxRevisions int `json:"max_revisions,omitempty" yaml:"max_revisions,omitempty"`
+}
+
+func (r *DashboardRevision) Verify() error {
+	if r.MaxRevisions == 0 {
+		r.MaxRevisions = defaultMaxRevisions
+	}
+	if r.MaxRevisions < 0 {
+		return fmt.Errorf("max_revisions cannot be negative")
+	}
+	return nil
+}
+
 type DashboardConfig struct {
 	CustomLintRules []*CustomLintRule `json:"custom_lint_rules,omitempty" yaml:"custom_lint_rules,omitempty"`
+	// Revision is the configuration of the dashboard revision history.
+	Revision DashboardRevision `json:"revision,omitempty" yaml:"revision,omitempty"`
 }
 
 func (c *DashboardConfig) Verify() error {
@@ -50,7 +50,60 @@ func (f *File) Verify() error {
 	return nil
 }
 
+type SQLDriver string
+
+const (
+	SQLDriverMySQL    SQLDriver = "mysql"
+	SQLDriverPostgres SQLDriver = "postgres"
+	// SQLDriverSQLite is not a driver that can be set in the SQL config.
+	// It is the dialect used by the DAO when the database is configured with the SQLite config.
+	SQLDriverSQLite SQLDriver = "sqlite"
+)
+
+const defaultPostgresSchema = "public"
+
+// PostgresSSLMode is the sslmode used by the PostgreSQL client. See https://www.postgresql.org/docs/current/libpq-ssl.html
+type PostgresSSLMode string
+
+const (
+	PostgresSSLModeDisable    PostgresSSLMode = "disable"
+	PostgresSSLModeAllow      PostgresSSLMode = "allow"
+	PostgresSSLModePrefer     PostgresSSLMode = "prefer"
+	PostgresSSLModeRequire    PostgresSSLMode = "require"
+	PostgresSSLModeVerifyCA   PostgresSSLMode = "verify-ca"
+	PostgresSSLModeVerifyFull PostgresSSLMode = "verify-full"
+)
+
+type SQLPostgres struct {
+	// Schema is the PostgreSQL schema in which the tables are created. Default is "public".
+	Schema string `json:"schema,omitempty" yaml:"schema,omitempty"`
+	// SSLMode to use when connecting to the database
+	SSLMode PostgresSSLMode `json:"ssl_mode,omitempty" yaml:"ssl_mode,omitempty"`
+}
+
+func (p *SQLPostgres) Verify() error {
+	if len(p.Schema) == 0 {
+		p.Schema = defaultPostgresSchema
+	}
+	switch p.SSLMode {
+	case "",
+		PostgresSSLModeDisable,
+		PostgresSSLModeAllow,
+		PostgresSSLModePrefer,
+		PostgresSSLModeRequire,
+		PostgresSSLModeVerifyCA,
+		PostgresSSLModeVerifyFull:
+	default:
+		return fmt.Errorf("unknown ssl_mode %q", p.SSLMode)
+	}
+	return nil
+}
+
 type SQL struct {
+	// Driver is the database engine to connect to. Default is mysql.
+	Driver SQLDriver `json:"driver,omitempty" yaml:"driver,omitempty"`
+	// Postgres specific configuration. Only used when driver is postgres.
+	Postgres *SQLPostgres `json:"postgres,omitempty" yaml:"postgres,omitempty"`
 	// TLS configuration
 	TLSConfig *secret.PublicTLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
 	// Username
@@ -114,6 +167,18 @@ func (s *SQL) Verify() error {
 	if len(s.DBName) == 0 {
 		return fmt.Errorf("db_name must be specified")
 	}
+	if len(s.Driver) == 0 {
+		s.Driver = SQLDriverMySQL
+	}
+	if s.Driver != SQLDriverMySQL && s.Driver != SQLDriverPostgres {
+		return fmt.Errorf("driver %q is not supported. Use %q or %q", s.Driver, SQLDriverMySQL, SQLDriverPostgres)
+	}
+	if s.Driver == SQLDriverPostgres && s.Postgres == nil {
+		s.Postgres = &SQLPostgres{Schema: defaultPostgresSchema}
+	}
+	if s.Driver != SQLDriverPostgres && s.Postgres != nil {
+		return fmt.Errorf("postgres config can only be set when the driver is %q", SQLDriverPostgres)
+	}
 	if len(s.User) > 0 && len(s.UserFile) > 0 {
 		return fmt.Errorf("user and user_file are mutually exclusive. Use one or the other not both at the same time")
 	}
@@ -151,20 +216,41 @@ func (s *SQL) Verify() error {
 	return nil
 }
 
+type SQLite struct {
+	// Path is the path to the SQLite database file. It is created if it doesn't exist.
+	Path string `json:"path" yaml:"path"`
+	// +kubebuilder:validation:Optional
+	CaseSensitive bool `json:"case_sensitive" yaml:"case_sensitive"`
+}
+
+func (s *SQLite) Verify() error {
+	if len(s.Path) == 0 {
+		return fmt.Errorf("path must be specified when using SQLite as a database")
+	}
+	return nil
+}
+
 type Database struct {
-	File *File `json:"file,omitempty" yaml:"file,omitempty"`
-	SQL  *SQL  `json:"sql,omitempty" yaml:"sql,omitempty"`
+	File   *File   `json:"file,omitempty" yaml:"file,omitempty"`
+	SQL    *SQL    `json:"sql,omitempty" yaml:"sql,omitempty"`
+	SQLite *SQLite `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
 }
 
 func (d *Database) Verify() error {
-	if d.File == nil && d.SQL == nil {
+	if d.File == nil && d.SQL == nil && d.SQLite == nil {
 		logrus.Debug("no database has been specified, therefore a file system database is used")
 		d.File = &File{
 			Folder: defaultFileDBFolder,
 		}
 	}
-	if d.File != nil && d.SQL != nil {
-		return fmt.Errorf("you cannot tel to Perses to use SQL and the filesystem at the same time")
+	count := 0
+	for _, isSet := range []bool{d.File != nil, d.SQL != nil, d.SQLite != nil} {
+		if isSet {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("you cannot tel to Perses to use more than one database at the same time. Use one of file, sql or sqlite")
 	}
 	return nil
 }
@@ -0,0 +1,107 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQL_Verify(t *testing.T) {
+	testSuite := []struct {
+		title  string
+		sql    SQL
+		result SQL
+		err    string
+	}{
+		{
+			title:  "default driver is mysql",
+			sql:    SQL{DBName: "perses"},
+			result: SQL{DBName: "perses", Driver: SQLDriverMySQL},
+		},
+		{
+			title:  "postgres with default config",
+			sql:    SQL{DBName: "perses", Driver: SQLDriverPostgres},
+			result: SQL{DBName: "perses", Driver: SQLDriverPostgres, Postgres: &SQLPostgres{Schema: "public"}},
+		},
+		{
+			title: "unknown driver",
+			sql:   SQL{DBName: "perses", Driver: "oracle"},
+			err:   `driver "oracle" is not supported`,
+		},
+		{
+			title: "sqlite is not a driver of the sql config",
+			sql:   SQL{DBName: "perses", Driver: SQLDriverSQLite},
+			err:   `driver "sqlite" is not supported`,
+		},
+		{
+			title: "postgres config with mysql",
+			sql:   SQL{DBName: "perses", Driver: SQLDriverMySQL, Postgres: &SQLPostgres{}},
+			err:   "postgres config can only be set when the driver is",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			err := test.sql.Verify()
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, test.sql)
+		})
+	}
+}
+
+func TestDatabase_Verify(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		database Database
+		result   Database
+		err      string
+	}{
+		{
+			title:    "file database by default",
+			database: Database{},
+			result:   Database{File: &File{Folder: defaultFileDBFolder}},
+		},
+		{
+			title:    "sqlite database",
+			database: Database{SQLite: &SQLite{Path: "./perses.db"}},
+			result:   Database{SQLite: &SQLite{Path: "./perses.db"}},
+		},
+		{
+			title:    "sqlite and file at the same time",
+			database: Database{File: &File{Folder: defaultFileDBFolder}, SQLite: &SQLite{Path: "./perses.db"}},
+			err:      "you cannot tel to Perses to use more than one database at the same time",
+		},
+		{
+			title:    "sqlite and sql at the same time",
+			database: Database{SQL: &SQL{DBName: "perses"}, SQLite: &SQLite{Path: "./perses.db"}},
+			err:      "you cannot tel to Perses to use more than one database at the same time",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			err := test.database.Verify()
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, test.database)
+		})
+	}
+}
@@ -13,7 +13,10 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"path/filepath"
+)
 
 type GlobalDatasourceConfig struct {
 	// Disable is used to disable the global datasource feature.
@@ -39,10 +42,29 @@ type ProjectDatasourceConfig struct {
 	Disable bool `json:"disable" yaml:"disable"`
 }
 
+type SQLiteDatasourceConfig struct {
+	// AllowedDirectories are the directories of the server holding the database files the SQL datasources using the
+	// SQLite driver can open. As long as it is empty, these datasources can't be queried.
+	AllowedDirectories []string `json:"allowed_directories,omitempty" yaml:"allowed_directories,omitempty"`
+}
+
+func (c *SQLiteDatasourceConfig) Verify() error {
+	for i, dir := range c.AllowedDirectories {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("invalid sqlite directory %q: %w", dir, err)
+		}
+		c.AllowedDirectories[i] = absDir
+	}
+	return nil
+}
+
 type DatasourceConfig struct {
 	Global  GlobalDatasourceConfig  `json:"global" yaml:"global"`
 	Project ProjectDatasourceConfig `json:"project" yaml:"project"`
 	// DisableLocal when used is preventing the possibility to add a datasource directly in the dashboard spec.
 	// It will also disable the associated proxy.
 	DisableLocal bool `json:"disable_local" yaml:"disable_local"`
+	// SQLite configures the SQL datasources using the SQLite driver.
+	SQLite *SQLiteDatasourceConfig `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
 }
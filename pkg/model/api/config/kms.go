@@ -0,0 +1,106 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+)
+
+const (
+	DefaultVaultTransitMountPath = "transit"
+	// KMSKeyID is reserved for the data encrypted with a KMS, it can't be used as an encryption_key_id.
+	KMSKeyID = "kms"
+)
+
+// VaultTransit is a HashiCorp Vault Transit secrets engine wrapping the data keys with one of its keys.
+type VaultTransit struct {
+	// Address is the URL of Vault, like https://vault.example.com:8200.
+	Address common.URL `json:"address" yaml:"address"`
+	// Token is the Vault token used to authenticate.
+	Token secret.Hidden `json:"token,omitempty" yaml:"token,omitempty"`
+	// TokenFile is the path to the file containing the Vault token. It is read again before each request,
+	// so the token can be renewed by a Vault agent.
+	TokenFile string `json:"token_file,omitempty" yaml:"token_file,omitempty"`
+	// Namespace is the Vault Enterprise namespace of the secrets engine.
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// MountPath is the path where the Transit secrets engine is mounted. By default, it is `transit`.
+	MountPath string `json:"mount_path,omitempty" yaml:"mount_path,omitempty"`
+	// KeyName is the name of the Transit key wrapping the data keys.
+	KeyName string `json:"key_name" yaml:"key_name"`
+	HTTP    HTTP   `json:"http" yaml:"http"`
+}
+
+func (v *VaultTransit) Verify() error {
+	if v.Address.IsNilOrEmpty() {
+		return errors.New("vault_transit: `address` is mandatory")
+	}
+	if len(v.KeyName) == 0 {
+		return errors.New("vault_transit: `key_name` is mandatory")
+	}
+	if len(v.Token) > 0 && len(v.TokenFile) > 0 {
+		return errors.New("vault_transit: `token` and `token_file` are mutually exclusive")
+	}
+	if len(v.Token) == 0 && len(v.TokenFile) == 0 {
+		return errors.New("vault_transit: one of `token` or `token_file` must be set")
+	}
+	if len(v.TokenFile) > 0 {
+		if _, err := os.Stat(v.TokenFile); err != nil {
+			return fmt.Errorf("vault_transit: unable to find the token_file: %w", err)
+		}
+	}
+	if len(v.MountPath) == 0 {
+		v.MountPath = DefaultVaultTransitMountPath
+	}
+	return nil
+}
+
+// FileKEK is a key encryption key stored in a file, for the sites that can't reach an external KMS.
+type FileKEK struct {
+	// KeyFile is the path to the file containing the key. Its size must be exactly 32 bytes.
+	KeyFile string `json:"key_file" yaml:"key_file"`
+}
+
+func (f *FileKEK) Verify() error {
+	if len(f.KeyFile) == 0 {
+		return errors.New("file: `key_file` is mandatory")
+	}
+	data, err := os.ReadFile(f.KeyFile)
+	if err != nil {
+		return fmt.Errorf("file: unable to read the key_file: %w", err)
+	}
+	if len(data) != 32 {
+		return errors.New("file: the key size must be 32 bytes")
+	}
+	return nil
+}
+
+// KMS configures the envelope encryption of the secrets: every secret is encrypted with its own data key, which is
+// itself encrypted (wrapped) by a key encryption key held outside the Perses configuration.
+// Exactly one provider must be set.
+type KMS struct {
+	VaultTransit *VaultTransit `json:"vault_transit,omitempty" yaml:"vault_transit,omitempty"`
+	File         *FileKEK      `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+func (k *KMS) Verify() error {
+	if (k.VaultTransit == nil) == (k.File == nil) {
+		return errors.New("kms: exactly one of `vault_transit` or `file` must be set")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMS_Verify(t *testing.T) {
+	assert.ErrorContains(t, (&KMS{}).Verify(), "exactly one of")
+	assert.ErrorContains(t, (&KMS{VaultTransit: &VaultTransit{}, File: &FileKEK{}}).Verify(), "exactly one of")
+	assert.NoError(t, (&KMS{File: &FileKEK{}}).Verify())
+}
+
+func TestVaultTransit_Verify(t *testing.T) {
+	address, err := url.Parse("https://vault.example.com:8200")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, (&VaultTransit{KeyName: "perses", Token: "token"}).Verify(), "`address` is mandatory")
+	assert.ErrorContains(t, (&VaultTransit{Address: common.URL{URL: address}, Token: "token"}).Verify(), "`key_name` is mandatory")
+	assert.ErrorContains(t, (&VaultTransit{Address: common.URL{URL: address}, KeyName: "perses"}).Verify(), "one of `token` or `token_file` must be set")
+
+	v := &VaultTransit{Address: common.URL{URL: address}, KeyName: "perses", Token: "token"}
+	assert.NoError(t, v.Verify())
+	assert.Equal(t, DefaultVaultTransitMountPath, v.MountPath)
+}
+
+func TestFileKEK_Verify(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "kek")
+	assert.NoError(t, os.WriteFile(keyFile, []byte("too short"), 0600))
+	assert.ErrorContains(t, (&FileKEK{KeyFile: keyFile}).Verify(), "the key size must be 32 bytes")
+	assert.NoError(t, os.WriteFile(keyFile, []byte("=tW$56zytgB&3jN2E%7-+qrGZE?v6LCc"), 0600))
+	assert.NoError(t, (&FileKEK{KeyFile: keyFile}).Verify())
+}
+
+func TestSecurity_VerifyKMSWithoutEncryptionKey(t *testing.T) {
+	security := Security{
+		EnableAuth:     true,
+		Authentication: AuthenticationConfig{Providers: AuthProviders{EnableNative: true}},
+		KMS:            &KMS{File: &FileKEK{}},
+	}
+	assert.ErrorContains(t, security.Verify(), "signing_keys must be set")
+	security.Authentication.SigningKeys = []JWTSigningKey{{ID: "key-1"}}
+	assert.NoError(t, security.Verify())
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 
 	"github.com/perses/perses/pkg/model/api/v1/secret"
 	"github.com/sirupsen/logrus"
@@ -29,6 +30,64 @@ const (
 	defaultEncryptionKey = "e=dz;`M'5Pjvy^Sq3FVBkTC@N9?H/gua"
 )
 
+var encryptionKeyIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]*$`)
+
+func verifyEncryptionKeyID(id string) error {
+	if !encryptionKeyIDRegexp.MatchString(id) {
+		return fmt.Errorf("encryption key id %q is not valid, it must only contain alphanumeric characters, '_', '.' or '-'", id)
+	}
+	if id == KMSKeyID {
+		return fmt.Errorf("encryption key id %q is reserved", id)
+	}
+	return nil
+}
+
+// readEncryptionKey returns the hexadecimal form of the key, read from the file when it is set.
+func readEncryptionKey(key secret.Hidden, keyFile string) (secret.Hidden, error) {
+	if len(keyFile) > 0 {
+		// Read the file and load the password contained
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return "", err
+		}
+		key = secret.Hidden(data)
+	}
+	if len(key) != 32 {
+		return "", fmt.Errorf("encryption_key size must be 32 bytes")
+	}
+	return secret.Hidden(hex.EncodeToString([]byte(key))), nil
+}
+
+// EncryptionKey is a key replaced by the current encryption_key. It is only used to decrypt the data encrypted before
+// the rotation.
+type EncryptionKey struct {
+	// ID is the encryption_key_id the key had when it was the current one. It is empty for the data encrypted before
+	// the keys got an id.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// Key is the secret key. Its size must be exactly 32 bytes.
+	Key secret.Hidden `json:"key,omitempty" yaml:"key,omitempty"`
+	// KeyFile is the path to the file containing the key.
+	KeyFile string `json:"key_file,omitempty" yaml:"key_file,omitempty"`
+}
+
+func (k *EncryptionKey) Verify() error {
+	if err := verifyEncryptionKeyID(k.ID); err != nil {
+		return err
+	}
+	if len(k.Key) > 0 && len(k.KeyFile) > 0 {
+		return fmt.Errorf("previous encryption key %q: key and key_file are mutually exclusive", k.ID)
+	}
+	if len(k.Key) == 0 && len(k.KeyFile) == 0 {
+		return fmt.Errorf("previous encryption key %q: one of key or key_file must be set", k.ID)
+	}
+	key, err := readEncryptionKey(k.Key, k.KeyFile)
+	if err != nil {
+		return fmt.Errorf("previous encryption key %q: %w", k.ID, err)
+	}
+	k.Key = key
+	return nil
+}
+
 type SameSite http.SameSite
 
 const (
@@ -152,6 +211,15 @@ type Security struct {
 	EncryptionKey secret.Hidden `json:"encryption_key,omitempty" yaml:"encryption_key,omitempty"`
 	// EncryptionKeyFile is the path to file containing the secret key
 	EncryptionKeyFile string `json:"encryption_key_file,omitempty" yaml:"encryption_key_file,omitempty"`
+	// EncryptionKeyID identifies the encryption_key. It is stored along with the data it encrypts, so the data can
+	// still be decrypted once the key is rotated. When it is empty, the data is stored as it was before the keys got an id.
+	EncryptionKeyID string `json:"encryption_key_id,omitempty" yaml:"encryption_key_id,omitempty"`
+	// PreviousEncryptionKeys are the keys replaced by the encryption_key. They are only used to decrypt the data,
+	// and can be removed once the data has been re-encrypted with the encryption_key.
+	PreviousEncryptionKeys []EncryptionKey `json:"previous_encryption_keys,omitempty" yaml:"previous_encryption_keys,omitempty"`
+	// KMS enables the envelope encryption of the secrets, with a key encryption key held by an external KMS.
+	// The encryption keys are then only used to decrypt the secrets encrypted before, until they are re-encrypted.
+	KMS *KMS `json:"kms,omitempty" yaml:"kms,omitempty"`
 	// When it is true, the authentication and authorization config are considered.
 	// And you will need a valid JWT token to contact most of the endpoints exposed by the API
 	EnableAuth bool `json:"enable_auth" yaml:"enable_auth"`
@@ -165,28 +233,39 @@ type Security struct {
 
 func (s *Security) Verify() error {
 	if len(s.EncryptionKey) == 0 && len(s.EncryptionKeyFile) == 0 {
-		logrus.Warning("encryption_key is not provided and therefore it will use a default one. For production instance you should provide the key.")
+		if s.KMS == nil {
+			logrus.Warning("encryption_key is not provided and therefore it will use a default one. For production instance you should provide the key.")
+		} else if s.EnableAuth && len(s.Authentication.SigningKeys) == 0 {
+			// Without signing keys, the tokens are signed with keys derived from the encryption key.
+			return errors.New("signing_keys must be set when the secrets are encrypted with a KMS and encryption_key is not provided")
+		}
 		s.EncryptionKey = defaultEncryptionKey
 	}
 	if len(s.EncryptionKey) > 0 && len(s.EncryptionKeyFile) > 0 {
 		return fmt.Errorf("encryption_key and encryption_key_file are mutually exclusive. Use one or the other not both at the same time")
 	}
-	if len(s.EncryptionKeyFile) > 0 {
-		// Read the file and load the password contained
-		data, err := os.ReadFile(s.EncryptionKeyFile)
-		if err != nil {
-			return err
-		}
-		s.EncryptionKey = secret.Hidden(data)
+	key, err := readEncryptionKey(s.EncryptionKey, s.EncryptionKeyFile)
+	if err != nil {
+		return err
 	}
-	if len(s.EncryptionKey) != 32 {
-		return fmt.Errorf("encryption_key size must be 32 bytes")
+	s.EncryptionKey = key
+	if idErr := verifyEncryptionKeyID(s.EncryptionKeyID); idErr != nil {
+		return idErr
+	}
+	ids := []string{s.EncryptionKeyID}
+	for _, previousKey := range s.PreviousEncryptionKeys {
+		var ok bool
+		ids, ok = appendIfMissing(ids, previousKey.ID)
+		if !ok {
+			return fmt.Errorf("several encryption keys exist with the same id %q", previousKey.ID)
+		}
 	}
-	s.EncryptionKey = secret.Hidden(hex.EncodeToString([]byte(s.EncryptionKey)))
 
 	if s.EnableAuth && !s.Authentication.Providers.EnableNative &&
 		len(s.Authentication.Providers.OIDC) == 0 &&
-		len(s.Authentication.Providers.OAuth) == 0 {
+		len(s.Authentication.Providers.OAuth) == 0 &&
+		len(s.Authentication.Providers.LDAP) == 0 &&
+		s.Authentication.Providers.TrustedHeader == nil {
 		return errors.New("impossible to enable auth if no provider is setup")
 	}
 	return nil
@@ -0,0 +1,86 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurity_VerifyEncryptionKeys(t *testing.T) {
+	key := "=tW$56zytgB&3jN2E%7-+qrGZE?v6LCc"
+	testSuites := []struct {
+		title    string
+		security Security
+		err      string
+	}{
+		{
+			title: "previous key without id",
+			security: Security{
+				EncryptionKey:          secret.Hidden(key),
+				EncryptionKeyID:        "2025",
+				PreviousEncryptionKeys: []EncryptionKey{{Key: secret.Hidden(key)}},
+			},
+		},
+		{
+			title: "invalid id",
+			security: Security{
+				EncryptionKey:   secret.Hidden(key),
+				EncryptionKeyID: "2025:01",
+			},
+			err: "encryption key id \"2025:01\" is not valid",
+		},
+		{
+			title: "previous key with the id of the current key",
+			security: Security{
+				EncryptionKey:          secret.Hidden(key),
+				EncryptionKeyID:        "2025",
+				PreviousEncryptionKeys: []EncryptionKey{{ID: "2025", Key: secret.Hidden(key)}},
+			},
+			err: "several encryption keys exist with the same id \"2025\"",
+		},
+		{
+			title: "two previous keys without id",
+			security: Security{
+				EncryptionKey:          secret.Hidden(key),
+				EncryptionKeyID:        "2025",
+				PreviousEncryptionKeys: []EncryptionKey{{Key: secret.Hidden(key)}, {Key: secret.Hidden(key)}},
+			},
+			err: "several encryption keys exist with the same id \"\"",
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			err := test.security.Verify()
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEncryptionKey_Verify(t *testing.T) {
+	key := "=tW$56zytgB&3jN2E%7-+qrGZE?v6LCc"
+	previousKey := EncryptionKey{ID: "2024", Key: secret.Hidden(key)}
+	assert.NoError(t, previousKey.Verify())
+	assert.Equal(t, hex.EncodeToString([]byte(key)), string(previousKey.Key))
+
+	assert.ErrorContains(t, (&EncryptionKey{ID: "2024", Key: "short"}).Verify(), "size must be 32 bytes")
+	assert.ErrorContains(t, (&EncryptionKey{ID: "2024"}).Verify(), "one of key or key_file must be set")
+}
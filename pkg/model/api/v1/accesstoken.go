@@ -0,0 +1,109 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+// AccessTokenPrefix starts every access token, so they can be told apart from the JWT and found by the secret scanners.
+const AccessTokenPrefix = "perses_"
+
+// AccessTokenRequest is the body of the request creating an access token.
+type AccessTokenRequest struct {
+	// Name tells what the token is used for.
+	Name string `json:"name" yaml:"name"`
+	// ExpiresAt is the time after which the token is rejected. The token never expires when it is not set.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	// Permissions restricts what can be done with the token.
+	// The token can't do more than its owner, and it can do everything its owner can do when Permissions is empty.
+	Permissions []role.Permission `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
+
+func (r *AccessTokenRequest) UnmarshalJSON(data []byte) error {
+	var tmp AccessTokenRequest
+	type plain AccessTokenRequest
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *AccessTokenRequest) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp AccessTokenRequest
+	type plain AccessTokenRequest
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *AccessTokenRequest) validate() error {
+	if len(r.Name) == 0 {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expiresAt must be in the future")
+	}
+	return nil
+}
+
+// AccessToken is a long-lived token used to call the API on behalf of a user or a service account.
+// The token itself is only returned at its creation.
+type AccessToken struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	// Subject is the owner of the token. It is a User or a ServiceAccount.
+	Subject     Subject           `json:"subject" yaml:"subject"`
+	Permissions []role.Permission `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	// LastUsedAt is refreshed at most once per minute.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" yaml:"lastUsedAt,omitempty"`
+}
+
+// IsExpired returns true when the token has an expiry and it is passed.
+func (t *AccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// CreatedAccessToken is returned once, when the access token is created. It is the only time the token is readable.
+type CreatedAccessToken struct {
+	AccessToken `json:",inline" yaml:",inline"`
+	Token       string `json:"token" yaml:"token"`
+}
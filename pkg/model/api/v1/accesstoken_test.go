@@ -0,0 +1,73 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalAccessTokenRequest(t *testing.T) {
+	testSuites := []struct {
+		title  string
+		jason  string
+		result AccessTokenRequest
+		err    string
+	}{
+		{
+			title: "scoped token",
+			jason: `{"name": "ci", "permissions": [{"actions": ["read"], "scopes": ["Dashboard"]}]}`,
+			result: AccessTokenRequest{
+				Name:        "ci",
+				Permissions: []role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.DashboardScope}}},
+			},
+		},
+		{
+			title: "missing name",
+			jason: `{}`,
+			err:   "name cannot be empty",
+		},
+		{
+			title: "expiry in the past",
+			jason: `{"name": "ci", "expiresAt": "2020-01-01T00:00:00Z"}`,
+			err:   "expiresAt must be in the future",
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			result := AccessTokenRequest{}
+			err := json.Unmarshal([]byte(test.jason), &result)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestAccessTokenIsExpired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Second)
+	after := now.Add(time.Second)
+	assert.False(t, (&AccessToken{}).IsExpired(now))
+	assert.True(t, (&AccessToken{ExpiresAt: &before}).IsExpired(now))
+	assert.True(t, (&AccessToken{ExpiresAt: &now}).IsExpired(now))
+	assert.False(t, (&AccessToken{ExpiresAt: &after}).IsExpired(now))
+}
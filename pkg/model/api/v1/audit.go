@@ -0,0 +1,65 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+// AuditOrigin is the part of Perses that performed the change.
+type AuditOrigin string
+
+const (
+	AuditOriginAPI          AuditOrigin = "api"
+	AuditOriginProvisioning AuditOrigin = "provisioning"
+	AuditOriginDiscovery    AuditOrigin = "discovery"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditProvider describes the provider used by the user to authenticate.
+type AuditProvider struct {
+	Kind string `json:"kind" yaml:"kind"`
+	ID   string `json:"id,omitempty" yaml:"id,omitempty"`
+}
+
+// AuditEvent is an entry of the audit log. An event is recorded for every change made on a resource.
+type AuditEvent struct {
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	Timestamp time.Time   `json:"timestamp" yaml:"timestamp"`
+	Origin    AuditOrigin `json:"origin" yaml:"origin"`
+	// User is the name of the user who made the change. It is empty when the change doesn't come from an authenticated user.
+	User     string         `json:"user,omitempty" yaml:"user,omitempty"`
+	Provider *AuditProvider `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Action   role.Action    `json:"action" yaml:"action"`
+	Kind     Kind           `json:"kind" yaml:"kind"`
+	Project  string         `json:"project,omitempty" yaml:"project,omitempty"`
+	Name     string         `json:"name" yaml:"name"`
+	// OldVersion is the metadata.version of the resource before the change. It is not set for a creation.
+	OldVersion *uint64 `json:"oldVersion,omitempty" yaml:"oldVersion,omitempty"`
+	// NewVersion is the metadata.version of the resource after the change. It is not set for a deletion or when the change failed.
+	NewVersion *uint64      `json:"newVersion,omitempty" yaml:"newVersion,omitempty"`
+	Outcome    AuditOutcome `json:"outcome" yaml:"outcome"`
+	// Error is the reason of the failure.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
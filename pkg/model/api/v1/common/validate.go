@@ -16,12 +16,21 @@ package common
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var idRegexp = regexp.MustCompile("^[a-zA-Z0-9_.-]+$")
 var keyMaxLength = 75
 var maxDescriptionLength = 200
 
+// The following rules are the same as the ones used by Kubernetes for the labels and the annotations.
+var qualifiedNameRegexp = regexp.MustCompile("^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$")
+var dnsSubdomainRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+var qualifiedNameMaxLength = 63
+var dnsSubdomainMaxLength = 253
+var labelValueMaxLength = 63
+var annotationsMaxSize = 256 * 1024
+
 // ValidateID checks for forbidden items in substring used inside id
 func ValidateID(name string) error {
 	if len(name) == 0 {
@@ -51,3 +60,68 @@ func ValidateDescription(description string) error {
 
 	return nil
 }
+
+// ValidateQualifiedName checks the key of a label or of an annotation.
+// Like with Kubernetes, it is a name with an optional prefix separated by a slash (e.g. `perses.dev/team`).
+// The prefix must be a DNS subdomain and the name must be at most 63 characters made of alphanumeric characters, '-', '_' or '.'.
+func ValidateQualifiedName(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) == 0 {
+			return fmt.Errorf("%q is not a correct key: the prefix cannot be empty", key)
+		}
+		if len(prefix) > dnsSubdomainMaxLength {
+			return fmt.Errorf("%q is not a correct key: the prefix cannot contain more than %d characters", key, dnsSubdomainMaxLength)
+		}
+		if !dnsSubdomainRegexp.MatchString(prefix) {
+			return fmt.Errorf("%q is not a correct key: the prefix should match the regexp: %s", key, dnsSubdomainRegexp.String())
+		}
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("%q is not a correct key: the name cannot be empty", key)
+	}
+	if len(name) > qualifiedNameMaxLength {
+		return fmt.Errorf("%q is not a correct key: the name cannot contain more than %d characters", key, qualifiedNameMaxLength)
+	}
+	if !qualifiedNameRegexp.MatchString(name) {
+		return fmt.Errorf("%q is not a correct key: the name should match the regexp: %s", key, qualifiedNameRegexp.String())
+	}
+	return nil
+}
+
+// ValidateLabels checks the keys and the values of the labels.
+// A value can be empty, otherwise it follows the same rules as the name of a key.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if err := ValidateQualifiedName(key); err != nil {
+			return err
+		}
+		if len(value) == 0 {
+			continue
+		}
+		if len(value) > labelValueMaxLength {
+			return fmt.Errorf("the value of the label %q cannot contain more than %d characters", key, labelValueMaxLength)
+		}
+		if !qualifiedNameRegexp.MatchString(value) {
+			return fmt.Errorf("%q is not a correct value for the label %q. It should match the regexp: %s", value, key, qualifiedNameRegexp.String())
+		}
+	}
+	return nil
+}
+
+// ValidateAnnotations checks the keys of the annotations and their total size. The values are free.
+func ValidateAnnotations(annotations map[string]string) error {
+	size := 0
+	for key, value := range annotations {
+		if err := ValidateQualifiedName(key); err != nil {
+			return err
+		}
+		size += len(key) + len(value)
+	}
+	if size > annotationsMaxSize {
+		return fmt.Errorf("the annotations cannot be bigger than %d bytes", annotationsMaxSize)
+	}
+	return nil
+}
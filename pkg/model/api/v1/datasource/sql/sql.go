@@ -17,7 +17,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Driver the SQL driver to use
@@ -26,8 +29,13 @@ type Driver string
 const (
 	DriverMySQL      Driver = "mysql"
 	DriverPostgreSQL Driver = "postgres"
+	DriverClickHouse Driver = "clickhouse"
+	DriverSQLServer  Driver = "sqlserver"
+	DriverSQLite     Driver = "sqlite"
 )
 
+var supportedDrivers = []Driver{DriverMySQL, DriverPostgreSQL, DriverClickHouse, DriverSQLServer, DriverSQLite}
+
 // SSLMode postgres ssl modes
 type SSLMode string
 
@@ -48,8 +56,77 @@ type MySQLConfig struct {
 	WriteTimeout     time.Duration     `json:"writeTimeout,omitempty" yaml:"writeTimeout,omitempty"`
 }
 
+const (
+	DefaultMaxOpenConns    = 10
+	DefaultMaxIdleConns    = 2
+	DefaultConnMaxIdleTime = 5 * time.Minute
+	DefaultPoolIdleTimeout = 15 * time.Minute
+)
+
+// PoolConfig configures the pool of connections Perses keeps open to the database, so the connections are reused
+// from one query to another.
+type PoolConfig struct {
+	// MaxOpenConns is the maximum number of connections open to the database. By default, it is 10.
+	MaxOpenConns int `json:"maxOpenConns,omitempty" yaml:"maxOpenConns,omitempty"`
+	// MaxIdleConns is the maximum number of idle connections kept in the pool. By default, it is 2.
+	MaxIdleConns int `json:"maxIdleConns,omitempty" yaml:"maxIdleConns,omitempty"`
+	// ConnMaxLifetime is the maximum time a connection is reused. There is no limit by default.
+	ConnMaxLifetime time.Duration `json:"connMaxLifetime,omitempty" yaml:"connMaxLifetime,omitempty"`
+	// ConnMaxIdleTime is the maximum time a connection stays idle before being closed. By default, it is 5 minutes.
+	ConnMaxIdleTime time.Duration `json:"connMaxIdleTime,omitempty" yaml:"connMaxIdleTime,omitempty"`
+	// IdleTimeout is the time after which the pool of a datasource that is not queried anymore is closed.
+	// By default, it is 15 minutes.
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty" yaml:"idleTimeout,omitempty"`
+}
+
+func (p *PoolConfig) validate() error {
+	if p.MaxOpenConns < 0 || p.MaxIdleConns < 0 {
+		return errors.New("the number of connections of the pool cannot be negative")
+	}
+	if p.ConnMaxLifetime < 0 || p.ConnMaxIdleTime < 0 || p.IdleTimeout < 0 {
+		return errors.New("the durations of the pool cannot be negative")
+	}
+	return nil
+}
+
+const (
+	DefaultStatementTimeout = time.Minute
+	DefaultMaxRows          = 100000
+	DefaultMaxBytes         = 32 << 20
+)
+
+// Guardrails limit what the queries sent through the proxy can do, and the size of their result.
+type Guardrails struct {
+	// ReadOnly executes the queries in read-only transactions, so they can't modify the database. It is true by default.
+	ReadOnly *bool `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	// StatementTimeout is the maximum duration of a query. By default, it is 1 minute.
+	StatementTimeout time.Duration `json:"statementTimeout,omitempty" yaml:"statementTimeout,omitempty"`
+	// MaxRows is the maximum number of rows returned by a query. By default, it is 100000.
+	MaxRows int `json:"maxRows,omitempty" yaml:"maxRows,omitempty"`
+	// MaxBytes is the maximum size of the result of a query, in bytes. By default, it is 32MiB.
+	MaxBytes int64 `json:"maxBytes,omitempty" yaml:"maxBytes,omitempty"`
+	// AllowedStatements are the types of statements accepted, like SELECT, WITH or SHOW.
+	// Every type of statement is accepted when it is empty.
+	AllowedStatements []string `json:"allowedStatements,omitempty" yaml:"allowedStatements,omitempty"`
+}
+
+func (g *Guardrails) validate() error {
+	if g.StatementTimeout < 0 {
+		return errors.New("the statement timeout cannot be negative")
+	}
+	if g.MaxRows < 0 || g.MaxBytes < 0 {
+		return errors.New("the maximum size of the result cannot be negative")
+	}
+	for _, statement := range g.AllowedStatements {
+		if len(statement) == 0 || strings.ContainsFunc(statement, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			return fmt.Errorf("invalid statement type %q, it must be a keyword like SELECT", statement)
+		}
+	}
+	return nil
+}
+
 type PostgresConfig struct {
-	// MaxConns is the maximum size of the pool
+	// MaxConns is the maximum size of the pool. It is used when pool.maxOpenConns is not set.
 	MaxConns int32 `json:"maxConns,omitempty" yaml:"maxConns,omitempty"`
 	// ConnectTimeout the timeout value used for socket connect operations.
 	ConnectTimeout time.Duration `json:"connectTimeout,omitempty" yaml:"connectTimeout,omitempty"`
@@ -103,11 +180,92 @@ func (p *PostgresConfig) validate() error {
 	return nil
 }
 
+// ClickHouseProtocol is the protocol used to contact ClickHouse
+type ClickHouseProtocol string
+
+const (
+	ClickHouseProtocolNative ClickHouseProtocol = "native"
+	ClickHouseProtocolHTTP   ClickHouseProtocol = "http"
+)
+
+// ClickHouseCompression is the compression of the data exchanged with ClickHouse
+type ClickHouseCompression string
+
+const (
+	ClickHouseCompressionNone ClickHouseCompression = "none"
+	ClickHouseCompressionLZ4  ClickHouseCompression = "lz4"
+	ClickHouseCompressionZSTD ClickHouseCompression = "zstd"
+)
+
+type ClickHouseConfig struct {
+	// Protocol is either native (default) or http.
+	Protocol ClickHouseProtocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	// Secure connects to ClickHouse with TLS, using the TLS config of the secret if any.
+	Secure bool `json:"secure,omitempty" yaml:"secure,omitempty"`
+	// DialTimeout is the timeout to open a connection.
+	DialTimeout time.Duration `json:"dialTimeout,omitempty" yaml:"dialTimeout,omitempty"`
+	// ReadTimeout is the timeout to read the response of the server.
+	ReadTimeout time.Duration `json:"readTimeout,omitempty" yaml:"readTimeout,omitempty"`
+	// Compression is one of none (default), lz4 or zstd.
+	Compression ClickHouseCompression `json:"compression,omitempty" yaml:"compression,omitempty"`
+	// Settings are the ClickHouse settings applied to the queries, like max_memory_usage.
+	Settings map[string]string `json:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
+func (c *ClickHouseConfig) validate() error {
+	switch c.Protocol {
+	case "", ClickHouseProtocolNative, ClickHouseProtocolHTTP:
+	default:
+		return fmt.Errorf("unknown clickhouse protocol %s", c.Protocol)
+	}
+	switch c.Compression {
+	case "", ClickHouseCompressionNone, ClickHouseCompressionLZ4, ClickHouseCompressionZSTD:
+	default:
+		return fmt.Errorf("unknown clickhouse compression %s", c.Compression)
+	}
+	return nil
+}
+
+// SQLServerEncrypt is the encryption of the connection to SQL Server
+type SQLServerEncrypt string
+
+const (
+	SQLServerEncryptDisable SQLServerEncrypt = "disable"
+	SQLServerEncryptFalse   SQLServerEncrypt = "false"
+	SQLServerEncryptTrue    SQLServerEncrypt = "true"
+	SQLServerEncryptStrict  SQLServerEncrypt = "strict"
+)
+
+type SQLServerConfig struct {
+	// Encrypt is one of disable, false (only the login is encrypted, the default), true or strict (TDS 8.0).
+	Encrypt SQLServerEncrypt `json:"encrypt,omitempty" yaml:"encrypt,omitempty"`
+	// ConnectTimeout is the timeout to open a connection.
+	ConnectTimeout time.Duration `json:"connectTimeout,omitempty" yaml:"connectTimeout,omitempty"`
+	// AppName is the application name sent to the server.
+	AppName string `json:"appName,omitempty" yaml:"appName,omitempty"`
+	// Params are additional parameters of the connection string.
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+func (c *SQLServerConfig) validate() error {
+	switch c.Encrypt {
+	case "", SQLServerEncryptDisable, SQLServerEncryptFalse, SQLServerEncryptTrue, SQLServerEncryptStrict:
+	default:
+		return fmt.Errorf("unknown sqlserver encryption %s", c.Encrypt)
+	}
+	return nil
+}
+
+type SQLiteConfig struct {
+	// BusyTimeout is the time a query waits for the database to be unlocked.
+	BusyTimeout time.Duration `json:"busyTimeout,omitempty" yaml:"busyTimeout,omitempty"`
+}
+
 type Config struct {
 	Driver Driver `json:"driver" yaml:"driver"`
-	// Host is the hostname required to contact the datasource
-	Host string `json:"host" yaml:"host"`
-	// Database is the database for the datasource
+	// Host is the hostname required to contact the datasource. It is not used by SQLite.
+	Host string `json:"host,omitempty" yaml:"host,omitempty"`
+	// Database is the database for the datasource. With SQLite, it is the path of the database file.
 	Database string `json:"database" yaml:"database"`
 	// Secret is the name of the secret that should be used for the proxy or discovery configuration
 	// It will contain any sensitive information such as username, password, token, certificate.
@@ -116,6 +274,16 @@ type Config struct {
 	MySQL *MySQLConfig `json:"mysql,omitempty" yaml:"mysql,omitempty"`
 	// Postgres specific driver config
 	Postgres *PostgresConfig `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	// ClickHouse specific driver config
+	ClickHouse *ClickHouseConfig `json:"clickhouse,omitempty" yaml:"clickhouse,omitempty"`
+	// SQLServer specific driver config
+	SQLServer *SQLServerConfig `json:"sqlserver,omitempty" yaml:"sqlserver,omitempty"`
+	// SQLite specific driver config
+	SQLite *SQLiteConfig `json:"sqlite,omitempty" yaml:"sqlite,omitempty"`
+	// Pool configures the pool of connections to the database
+	Pool *PoolConfig `json:"pool,omitempty" yaml:"pool,omitempty"`
+	// Guardrails limit what the queries can do
+	Guardrails *Guardrails `json:"guardrails,omitempty" yaml:"guardrails,omitempty"`
 }
 
 func (s *Config) UnmarshalJSON(data []byte) error {
@@ -149,11 +317,11 @@ func (s *Config) validate() error {
 		return errors.New("driver is required")
 	}
 
-	if s.Driver != DriverMySQL && s.Driver != DriverPostgreSQL {
+	if !slices.Contains(supportedDrivers, s.Driver) {
 		return fmt.Errorf("driver %s is not supported", s.Driver)
 	}
 
-	if s.Host == "" {
+	if s.Host == "" && s.Driver != DriverSQLite {
 		return errors.New("host cannot be empty")
 	}
 
@@ -161,6 +329,30 @@ func (s *Config) validate() error {
 		return errors.New("database cannot be empty")
 	}
 
+	if s.ClickHouse != nil {
+		if err := s.ClickHouse.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.SQLServer != nil {
+		if err := s.SQLServer.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Pool != nil {
+		if err := s.Pool.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Guardrails != nil {
+		if err := s.Guardrails.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -16,6 +16,7 @@ package sql
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -60,6 +61,177 @@ func TestUnmarshalJSONConfig(t *testing.T) {
     "sslMode": "notreal"
   }
 }
+`,
+			expectErr: true,
+		},
+		{
+			title: "pool config",
+			jason: `
+{
+  "driver": "mysql",
+  "host": "localhost:3306",
+  "database": "test",
+  "pool": {
+    "maxOpenConns": 20,
+    "maxIdleConns": 5
+  }
+}
+`,
+			result: Config{
+				Driver:   DriverMySQL,
+				Host:     "localhost:3306",
+				Database: "test",
+				Pool: &PoolConfig{
+					MaxOpenConns: 20,
+					MaxIdleConns: 5,
+				},
+			},
+		},
+		{
+			title: "guardrails",
+			jason: `
+{
+  "driver": "postgres",
+  "host": "localhost:5432",
+  "database": "test",
+  "guardrails": {
+    "readOnly": false,
+    "maxRows": 1000,
+    "allowedStatements": ["SELECT", "WITH"]
+  }
+}
+`,
+			result: Config{
+				Driver:   DriverPostgreSQL,
+				Host:     "localhost:5432",
+				Database: "test",
+				Guardrails: &Guardrails{
+					ReadOnly:          new(bool),
+					MaxRows:           1000,
+					AllowedStatements: []string{"SELECT", "WITH"},
+				},
+			},
+		},
+		{
+			title: "invalid statement type",
+			jason: `
+{
+  "driver": "postgres",
+  "host": "localhost:5432",
+  "database": "test",
+  "guardrails": {
+    "allowedStatements": ["SELECT *"]
+  }
+}
+`,
+			expectErr: true,
+		},
+		{
+			title: "negative pool size",
+			jason: `
+{
+  "driver": "mysql",
+  "host": "localhost:3306",
+  "database": "test",
+  "pool": {
+    "maxOpenConns": -1
+  }
+}
+`,
+			expectErr: true,
+		},
+		{
+			title: "sqlite without host",
+			jason: `
+{
+  "driver": "sqlite",
+  "database": "/var/lib/perses/sqlite/analytics.db",
+  "sqlite": {
+    "busyTimeout": 5000000000
+  }
+}
+`,
+			result: Config{
+				Driver:   DriverSQLite,
+				Database: "/var/lib/perses/sqlite/analytics.db",
+				SQLite: &SQLiteConfig{
+					BusyTimeout: 5 * time.Second,
+				},
+			},
+		},
+		{
+			title: "clickhouse config",
+			jason: `
+{
+  "driver": "clickhouse",
+  "host": "localhost:9440",
+  "database": "default",
+  "clickhouse": {
+    "secure": true,
+    "compression": "lz4",
+    "settings": {
+      "max_memory_usage": "10000000000"
+    }
+  }
+}
+`,
+			result: Config{
+				Driver:   DriverClickHouse,
+				Host:     "localhost:9440",
+				Database: "default",
+				ClickHouse: &ClickHouseConfig{
+					Secure:      true,
+					Compression: ClickHouseCompressionLZ4,
+					Settings:    map[string]string{"max_memory_usage": "10000000000"},
+				},
+			},
+		},
+		{
+			title: "clickhouse without host",
+			jason: `
+{
+  "driver": "clickhouse",
+  "database": "default"
+}
+`,
+			expectErr: true,
+		},
+		{
+			title: "unknown clickhouse compression",
+			jason: `
+{
+  "driver": "clickhouse",
+  "host": "localhost:9000",
+  "database": "default",
+  "clickhouse": {
+    "compression": "snappy"
+  }
+}
+`,
+			expectErr: true,
+		},
+		{
+			title: "unknown sqlserver encryption",
+			jason: `
+{
+  "driver": "sqlserver",
+  "host": "localhost:1433",
+  "database": "reporting",
+  "sqlserver": {
+    "encrypt": "maybe"
+  }
+}
+`,
+			expectErr: true,
+		},
+		{
+			title: "unknown driver",
+			jason: `
+{
+  "driver": "oracle",
+  "host": "localhost:1521",
+  "database": "test"
+}
 `,
 			expectErr: true,
 		},
@@ -33,12 +33,15 @@ const (
 	KindGlobalRoleBinding  Kind = "GlobalRoleBinding"
 	KindGlobalVariable     Kind = "GlobalVariable"
 	KindGlobalSecret       Kind = "GlobalSecret"
+	KindGroup              Kind = "Group"
 	KindProject            Kind = "Project"
 	KindRole               Kind = "Role"
 	KindRoleBinding        Kind = "RoleBinding"
 	KindSecret             Kind = "Secret"
+	KindServiceAccount     Kind = "ServiceAccount"
 	KindUser               Kind = "User"
 	KindVariable           Kind = "Variable"
+	KindWebhook            Kind = "Webhook"
 )
 
 var PluralKindMap = map[Kind]string{
@@ -55,8 +58,10 @@ var PluralKindMap = map[Kind]string{
 	KindRole:               "roles",
 	KindRoleBinding:        "rolebindings",
 	KindSecret:             "secrets",
+	KindServiceAccount:     "serviceaccounts",
 	KindUser:               "users",
 	KindVariable:           "variables",
+	KindWebhook:            "webhooks",
 }
 
 func (k *Kind) UnmarshalJSON(data []byte) error {
@@ -126,10 +131,14 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 		return &RoleBinding{}, nil
 	case KindSecret:
 		return &Secret{}, nil
+	case KindServiceAccount:
+		return &ServiceAccount{}, nil
 	case KindUser:
 		return &User{}, nil
 	case KindVariable:
 		return &Variable{}, nil
+	case KindWebhook:
+		return &Webhook{}, nil
 	default:
 		return nil, fmt.Errorf("%q has no associated struct", kind)
 	}
@@ -137,7 +146,7 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 
 func IsGlobal(kind Kind) bool {
 	switch kind {
-	case KindGlobalDatasource, KindGlobalRole, KindGlobalRoleBinding, KindGlobalSecret, KindGlobalVariable, KindProject, KindUser:
+	case KindGlobalDatasource, KindGlobalRole, KindGlobalRoleBinding, KindGlobalSecret, KindGlobalVariable, KindProject, KindServiceAccount, KindUser, KindWebhook:
 		return true
 	default:
 		return false
@@ -174,6 +183,9 @@ func GetKind(kind string) (*Kind, error) {
 	case strings.ToLower(string(KindGlobalVariable)):
 		result := KindGlobalVariable
 		return &result, nil
+	case strings.ToLower(string(KindGroup)):
+		result := KindGroup
+		return &result, nil
 	case strings.ToLower(string(KindProject)):
 		result := KindProject
 		return &result, nil
@@ -186,12 +198,18 @@ func GetKind(kind string) (*Kind, error) {
 	case strings.ToLower(string(KindSecret)):
 		result := KindSecret
 		return &result, nil
+	case strings.ToLower(string(KindServiceAccount)):
+		result := KindServiceAccount
+		return &result, nil
 	case strings.ToLower(string(KindUser)):
 		result := KindUser
 		return &result, nil
 	case strings.ToLower(string(KindVariable)):
 		result := KindVariable
 		return &result, nil
+	case strings.ToLower(string(KindWebhook)):
+		result := KindWebhook
+		return &result, nil
 	default:
 		return nil, fmt.Errorf("unknown kind %q used", kind)
 	}
@@ -40,6 +40,10 @@ type Metadata struct {
 	// +kubebuilder:validation:Optional
 	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
 	Version   uint64    `json:"version" yaml:"version"`
+	// Labels are used to organize the resources (e.g. by team or by tier). They can be used to filter the lists of resources.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Annotations are used to attach any other information to the resources (e.g. the tool that generated them).
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 }
 
 func (m *Metadata) CreateNow() {
@@ -105,7 +109,13 @@ func (m *Metadata) UnmarshalYAML(unmarshal func(any) error) error {
 }
 
 func (m *Metadata) validate() error {
-	return common.ValidateID(m.Name)
+	if err := common.ValidateID(m.Name); err != nil {
+		return err
+	}
+	if err := common.ValidateLabels(m.Labels); err != nil {
+		return err
+	}
+	return common.ValidateAnnotations(m.Annotations)
 }
 
 // This wrapping struct is required to allow defining a custom unmarshall on Metadata
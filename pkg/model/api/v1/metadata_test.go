@@ -135,6 +135,43 @@ version: 1
 				Version:   1,
 			},
 		},
+		{
+			title: "labels and annotations",
+			jason: `
+{
+  "name": "foo",
+  "createdAt": "1970-01-01T00:00:00.000000000Z",
+  "updatedAt": "1970-01-01T00:00:00.000000000Z",
+  "version": 1,
+  "labels": {
+    "team": "sre",
+    "perses.dev/tier": ""
+  },
+  "annotations": {
+    "perses.dev/source": "https://github.com/perses/perses"
+  }
+}
+`,
+			yamele: `
+name: "foo"
+createdAt: "1970-01-01T00:00:00.000000000Z"
+updatedAt: "1970-01-01T00:00:00.000000000Z"
+version: 1
+labels:
+  team: "sre"
+  perses.dev/tier: ""
+annotations:
+  perses.dev/source: "https://github.com/perses/perses"
+`,
+			result: Metadata{
+				Name:        "foo",
+				CreatedAt:   dummyDate,
+				UpdatedAt:   dummyDate,
+				Version:     1,
+				Labels:      map[string]string{"team": "sre", "perses.dev/tier": ""},
+				Annotations: map[string]string{"perses.dev/source": "https://github.com/perses/perses"},
+			},
+		},
 	}
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {
@@ -181,6 +218,51 @@ version: 1
 `,
 			err: fmt.Errorf("\"f o o\" is not a correct name. It should match the regexp: ^[a-zA-Z0-9_.-]+$"),
 		},
+		{
+			title: "label key cannot contain spaces",
+			jason: `
+{
+  "name": "foo",
+  "labels": {"my team": "sre"}
+}
+`,
+			yamele: `
+name: "foo"
+labels:
+  my team: "sre"
+`,
+			err: fmt.Errorf("\"my team\" is not a correct key: the name should match the regexp: ^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$"),
+		},
+		{
+			title: "label value cannot end with a dash",
+			jason: `
+{
+  "name": "foo",
+  "labels": {"team": "sre-"}
+}
+`,
+			yamele: `
+name: "foo"
+labels:
+  team: "sre-"
+`,
+			err: fmt.Errorf("\"sre-\" is not a correct value for the label \"team\". It should match the regexp: ^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$"),
+		},
+		{
+			title: "annotation key prefix must be a DNS subdomain",
+			jason: `
+{
+  "name": "foo",
+  "annotations": {"Perses_dev/source": "git"}
+}
+`,
+			yamele: `
+name: "foo"
+annotations:
+  Perses_dev/source: "git"
+`,
+			err: fmt.Errorf("\"Perses_dev/source\" is not a correct key: the prefix should match the regexp: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"),
+		},
 	}
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {
@@ -27,6 +27,7 @@ type PublicUserSpec struct {
 	LastName       string               `json:"lastName,omitempty" yaml:"lastName,omitempty"`
 	NativeProvider PublicNativeProvider `json:"nativeProvider,omitempty" yaml:"nativeProvider,omitempty"`
 	OauthProviders []OAuthProvider      `json:"oauthProviders,omitempty" yaml:"oauthProviders,omitempty"`
+	Groups         []string             `json:"groups,omitempty" yaml:"groups,omitempty"`
 }
 
 func NewPublicUserSpec(u UserSpec) PublicUserSpec {
@@ -37,6 +38,7 @@ func NewPublicUserSpec(u UserSpec) PublicUserSpec {
 			Password: secret.Hidden(u.NativeProvider.Password),
 		},
 		OauthProviders: u.OauthProviders,
+		Groups:         u.Groups,
 	}
 }
 
@@ -0,0 +1,51 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RevisionMetadata describes a version of a resource kept in the revision history.
+type RevisionMetadata struct {
+	// Version is the value of metadata.version of the resource when the revision has been recorded.
+	Version uint64 `json:"version" yaml:"version"`
+	// Author is the name of the user who made the change. It is empty when the change didn't come from a user (provisioning, anonymous access...)
+	Author string `json:"author,omitempty" yaml:"author,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+}
+
+// DashboardRevision is a version of a dashboard kept in the revision history.
+type DashboardRevision struct {
+	RevisionMetadata `json:",inline" yaml:",inline"`
+	Dashboard        *Dashboard `json:"dashboard" yaml:"dashboard"`
+}
+
+// JSONPatchOperation is an operation as described in the RFC 6902.
+type JSONPatchOperation struct {
+	Op    string          `json:"op" yaml:"op"`
+	Path  string          `json:"path" yaml:"path"`
+	Value json.RawMessage `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// RevisionDiff is the list of operations required to go from the revision `From` to the revision `To`.
+type RevisionDiff struct {
+	From  uint64               `json:"from" yaml:"from"`
+	To    uint64               `json:"to" yaml:"to"`
+	Patch []JSONPatchOperation `json:"patch" yaml:"patch"`
+}
@@ -22,10 +22,13 @@ import (
 type Action string
 
 const (
-	ReadAction     Action = "read"
-	CreateAction   Action = "create"
-	UpdateAction   Action = "update"
-	DeleteAction   Action = "delete"
+	ReadAction   Action = "read"
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	DeleteAction Action = "delete"
+	// QueryAction allows sending queries to a datasource through the proxy.
+	// It is distinct from ReadAction, which only gives access to the definition of the datasource.
+	QueryAction    Action = "query"
 	WildcardAction Action = "*"
 )
 
@@ -80,6 +83,9 @@ func GetAction(action string) (*Action, error) {
 	case strings.ToLower(string(DeleteAction)):
 		result := DeleteAction
 		return &result, nil
+	case strings.ToLower(string(QueryAction)):
+		result := QueryAction
+		return &result, nil
 	case strings.ToLower(string(WildcardAction)):
 		result := WildcardAction
 		return &result, nil
@@ -16,6 +16,7 @@ package role
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 )
 
 type Permission struct {
@@ -24,6 +25,30 @@ type Permission struct {
 	// The list of kind targeted by the permission. For example: `Datasource`, `Dashboard`, ...
 	// With Role, you can't target global kinds
 	Scopes []Scope `json:"scopes" yaml:"scopes"`
+	// ResourceNames restricts the permission to the resources whose name matches one of the glob patterns,
+	// like `team-a-*`. The syntax of the patterns is the one of https://pkg.go.dev/path#Match.
+	// For the Project scope, the pattern is matched against the name of the project.
+	// When it is empty, the permission applies to every resource of the scopes.
+	ResourceNames []string `json:"resourceNames,omitempty" yaml:"resourceNames,omitempty"`
+}
+
+// IsRestricted returns true if the permission only applies to the resources matching its ResourceNames.
+func (p *Permission) IsRestricted() bool {
+	return len(p.ResourceNames) > 0
+}
+
+// MatchResourceName returns true if the permission applies to the resource with the given name.
+func (p *Permission) MatchResourceName(name string) bool {
+	if !p.IsRestricted() {
+		return true
+	}
+	for _, pattern := range p.ResourceNames {
+		// The patterns are validated when the permission is decoded, so the error can be ignored.
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Permission) UnmarshalJSON(data []byte) error {
@@ -59,5 +84,13 @@ func (p *Permission) validate() error {
 	if len(p.Scopes) == 0 {
 		return fmt.Errorf("permission scopes cannot be empty")
 	}
+	for _, pattern := range p.ResourceNames {
+		if len(pattern) == 0 {
+			return fmt.Errorf("permission resourceNames cannot contain an empty pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid resourceNames pattern %q: %w", pattern, err)
+		}
+	}
 	return nil
 }
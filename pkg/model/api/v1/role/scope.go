@@ -22,6 +22,7 @@ import (
 type Scope string
 
 const (
+	AuditScope              Scope = "Audit"
 	DashboardScope          Scope = "Dashboard"
 	DatasourceScope         Scope = "Datasource"
 	EphemeralDashboardScope Scope = "EphemeralDashboard"
@@ -35,8 +36,10 @@ const (
 	RoleScope               Scope = "Role"
 	RoleBindingScope        Scope = "RoleBinding"
 	SecretScope             Scope = "Secret"
+	ServiceAccountScope     Scope = "ServiceAccount"
 	UserScope               Scope = "User"
 	VariableScope           Scope = "Variable"
+	WebhookScope            Scope = "Webhook"
 	WildcardScope           Scope = "*"
 )
 
@@ -79,6 +82,9 @@ func (k *Scope) validate() error {
 // GetScope parse string to Scope (not case-sensitive)
 func GetScope(scope string) (*Scope, error) {
 	switch strings.ToLower(scope) {
+	case strings.ToLower(string(AuditScope)):
+		result := AuditScope
+		return &result, nil
 	case strings.ToLower(string(DashboardScope)):
 		result := DashboardScope
 		return &result, nil
@@ -118,12 +124,18 @@ func GetScope(scope string) (*Scope, error) {
 	case strings.ToLower(string(SecretScope)):
 		result := SecretScope
 		return &result, nil
+	case strings.ToLower(string(ServiceAccountScope)):
+		result := ServiceAccountScope
+		return &result, nil
 	case strings.ToLower(string(UserScope)):
 		result := UserScope
 		return &result, nil
 	case strings.ToLower(string(VariableScope)):
 		result := VariableScope
 		return &result, nil
+	case strings.ToLower(string(WebhookScope)):
+		result := WebhookScope
+		return &result, nil
 	case strings.ToLower(string(WildcardScope)):
 		result := WildcardScope
 		return &result, nil
@@ -136,7 +148,7 @@ func IsGlobalScope(scope Scope) bool {
 	switch scope {
 	// ProjectScope is not global even if it should be. Owners of projects should be able to delete their own projects
 	// As ProjectScope is not Global, it can be added in Role scopes and allow this flow.
-	case GlobalDatasourceScope, GlobalRoleScope, GlobalRoleBindingScope, GlobalSecretScope, GlobalVariableScope, UserScope:
+	case AuditScope, GlobalDatasourceScope, GlobalRoleScope, GlobalRoleBindingScope, GlobalSecretScope, GlobalVariableScope, ServiceAccountScope, UserScope, WebhookScope:
 		return true
 	default:
 		return false
@@ -25,6 +25,8 @@ type RoleBindingInterface interface {
 	GetMetadata() modelAPI.Metadata
 }
 
+// Subject is a user, a group of users or a service account bound to a role.
+// Group is not a resource: the group membership of a user is synced from the claims of the OAuth / OIDC provider.
 type Subject struct {
 	Kind Kind   `json:"kind" yaml:"kind"`
 	Name string `json:"name" yaml:"name"`
@@ -57,7 +59,7 @@ func (s *Subject) UnmarshalYAML(unmarshal func(any) error) error {
 }
 
 func (s *Subject) validate() error {
-	if s.Kind != KindUser {
+	if s.Kind != KindUser && s.Kind != KindGroup && s.Kind != KindServiceAccount {
 		return fmt.Errorf("invalid kind: %q for a Subject kind", s.Kind)
 	}
 	if len(s.Name) == 0 {
@@ -0,0 +1,47 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import "fmt"
+
+type SearchResultKind string
+
+const (
+	SearchResultKindDashboard SearchResultKind = "Dashboard"
+	SearchResultKindPanel     SearchResultKind = "Panel"
+	SearchResultKindVariable  SearchResultKind = "Variable"
+)
+
+func (k *SearchResultKind) UnmarshalParam(param string) error {
+	switch SearchResultKind(param) {
+	case SearchResultKindDashboard, SearchResultKindPanel, SearchResultKindVariable:
+		*k = SearchResultKind(param)
+		return nil
+	}
+	return fmt.Errorf("unknown search result kind %q, valid values are %q, %q and %q", param, SearchResultKindDashboard, SearchResultKindPanel, SearchResultKindVariable)
+}
+
+// SearchResult is an element found by the full-text search.
+type SearchResult struct {
+	Kind    SearchResultKind `json:"kind" yaml:"kind"`
+	Project string           `json:"project" yaml:"project"`
+	// Dashboard is the name of the dashboard containing the panel or the variable.
+	// It is empty for a variable defined at the project level.
+	Dashboard string `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
+	// Name is the name of the dashboard, the key of the panel or the name of the variable.
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	// Score is the relevance of the result. The higher, the better.
+	Score float64 `json:"score" yaml:"score"`
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+// ServiceAccountUsernamePrefix prefixes the name of a service account when it is acting as a user.
+// As ':' is not allowed in a name, a service account can't be mistaken for a user.
+const ServiceAccountUsernamePrefix = "serviceaccount:"
+
+// ServiceAccountUsername returns the username used by the service account when it calls the API.
+func ServiceAccountUsername(name string) string {
+	return ServiceAccountUsernamePrefix + name
+}
+
+type ServiceAccountSpec struct {
+	// Description tells what the service account is used for.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ServiceAccount is a non-human identity used by the automation.
+// It calls the API with access tokens, and it gets its permissions through the role bindings.
+type ServiceAccount struct {
+	Kind     Kind               `json:"kind" yaml:"kind"`
+	Metadata Metadata           `json:"metadata" yaml:"metadata"`
+	Spec     ServiceAccountSpec `json:"spec" yaml:"spec"`
+}
+
+func (s *ServiceAccount) UnmarshalJSON(data []byte) error {
+	var tmp ServiceAccount
+	type plain ServiceAccount
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *ServiceAccount) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp ServiceAccount
+	type plain ServiceAccount
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *ServiceAccount) validate() error {
+	if s.Kind != KindServiceAccount {
+		return fmt.Errorf("invalid kind: %q for a ServiceAccount type", s.Kind)
+	}
+	return nil
+}
+
+func (s *ServiceAccount) GetMetadata() modelAPI.Metadata {
+	return &s.Metadata
+}
+
+func (s *ServiceAccount) GetKind() string {
+	return string(s.Kind)
+}
+
+func (s *ServiceAccount) GetSpec() any {
+	return s.Spec
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import "time"
+
+// Session is opened when a user logs in and lasts as long as its refresh token.
+// Both the access and the refresh tokens of the session are rejected once it is revoked.
+type Session struct {
+	ID       string `json:"id" yaml:"id"`
+	Username string `json:"username" yaml:"username"`
+	// ProviderKind is the kind of the provider the user logged in with (native, oidc, oauth).
+	ProviderKind string `json:"providerKind" yaml:"providerKind"`
+	// ProviderID is the slug ID of the OIDC or OAuth provider.
+	ProviderID string `json:"providerID,omitempty" yaml:"providerID,omitempty"`
+	// UserAgent is the User-Agent header of the login request. It tells the device used.
+	UserAgent string `json:"userAgent,omitempty" yaml:"userAgent,omitempty"`
+	// RemoteAddress is the IP address the login request came from.
+	RemoteAddress string `json:"remoteAddress,omitempty" yaml:"remoteAddress,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	// ExpiresAt is the expiry of the refresh token.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	ExpiresAt time.Time `json:"expiresAt" yaml:"expiresAt"`
+	// LastRefreshedAt is the last time the refresh token was used to get a new access token.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	LastRefreshedAt *time.Time `json:"lastRefreshedAt,omitempty" yaml:"lastRefreshedAt,omitempty"`
+}
+
+// IsExpired returns true when the refresh token of the session is expired.
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -39,6 +39,9 @@ type UserSpec struct {
 	LastName       string          `json:"lastName,omitempty" yaml:"lastName,omitempty"`
 	NativeProvider NativeProvider  `json:"nativeProvider,omitempty" yaml:"nativeProvider,omitempty"`
 	OauthProviders []OAuthProvider `json:"oauthProviders,omitempty" yaml:"oauthProviders,omitempty"`
+	// Groups is the list of groups the user belongs to. It is synced from the claims of the OAuth / OIDC provider,
+	// or from the directory of the LDAP provider the user logged in with, and it cannot be set through the API.
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
 }
 
 type User struct {
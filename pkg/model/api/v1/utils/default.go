@@ -14,6 +14,7 @@
 package utils
 
 import (
+	"slices"
 	"time"
 
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -100,6 +101,10 @@ func DefaultViewerRole(projectName string) *v1.Role {
 					Actions: []role.Action{role.ReadAction},
 					Scopes:  []role.Scope{role.WildcardScope},
 				},
+				{
+					Actions: []role.Action{role.QueryAction},
+					Scopes:  []role.Scope{role.DatasourceScope},
+				},
 			},
 		},
 	}
@@ -130,3 +135,35 @@ func DefaultOwnerRoleBinding(projectName string, username string) *v1.RoleBindin
 		},
 	}
 }
+
+// MigrateDefaultViewerRole grants the query action on the datasources to a default viewer role created before this
+// action existed, so the viewers of the project can still query its datasources through the proxy.
+// It returns true when the role has been modified.
+func MigrateDefaultViewerRole(entity *v1.Role) bool {
+	if entity.Metadata.Name != viewer {
+		return false
+	}
+	permissions := entity.Spec.Permissions
+	if !grants(permissions, role.ReadAction, role.DatasourceScope) || grants(permissions, role.QueryAction, role.DatasourceScope) {
+		return false
+	}
+	entity.Spec.Permissions = append(permissions, role.Permission{
+		Actions: []role.Action{role.QueryAction},
+		Scopes:  []role.Scope{role.DatasourceScope},
+	})
+	return true
+}
+
+// grants returns true if one of the permissions grants the action on every resource of the scope.
+func grants(permissions []role.Permission, requestAction role.Action, requestScope role.Scope) bool {
+	for _, permission := range permissions {
+		if permission.IsRestricted() {
+			continue
+		}
+		if (slices.Contains(permission.Actions, requestAction) || slices.Contains(permission.Actions, role.WildcardAction)) &&
+			(slices.Contains(permission.Scopes, requestScope) || slices.Contains(permission.Scopes, role.WildcardScope)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateDefaultViewerRole(t *testing.T) {
+	// The viewer created before the query action existed is migrated once.
+	legacyViewer := DefaultViewerRole("perses")
+	legacyViewer.Spec.Permissions = legacyViewer.Spec.Permissions[:1]
+	assert.True(t, MigrateDefaultViewerRole(legacyViewer))
+	assert.Equal(t, DefaultViewerRole("perses").Spec.Permissions, legacyViewer.Spec.Permissions)
+	assert.False(t, MigrateDefaultViewerRole(legacyViewer))
+
+	// A viewer that can't read the datasources is left untouched.
+	customViewer := DefaultViewerRole("perses")
+	customViewer.Spec.Permissions = []role.Permission{
+		{
+			Actions: []role.Action{role.ReadAction},
+			Scopes:  []role.Scope{role.DashboardScope},
+		},
+	}
+	assert.False(t, MigrateDefaultViewerRole(customViewer))
+
+	assert.False(t, MigrateDefaultViewerRole(DefaultViewerRole("perses")))
+	assert.False(t, MigrateDefaultViewerRole(DefaultEditorRole("perses")))
+}
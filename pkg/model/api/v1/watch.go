@@ -0,0 +1,40 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+type WatchEventType string
+
+const (
+	WatchEventAdded    WatchEventType = "ADDED"
+	WatchEventModified WatchEventType = "MODIFIED"
+	WatchEventDeleted  WatchEventType = "DELETED"
+)
+
+// WatchEvent describes a change made on a resource. It is sent to the clients watching the resources.
+type WatchEvent struct {
+	Type WatchEventType `json:"type" yaml:"type"`
+	Kind Kind           `json:"kind" yaml:"kind"`
+	// Project is the project of the resource. For a `Project`, it is the name of the project itself. It is empty for a global resource.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	Name    string `json:"name" yaml:"name"`
+	// Version is the metadata.version of the resource after the change. For a deletion, it is the version of the resource deleted.
+	Version uint64 `json:"version" yaml:"version"`
+	// Object is the resource after the change. For a deletion, it is the last state of the resource.
+	// The secrets and the users are sent in their public form.
+	Object modelAPI.Entity `json:"object" yaml:"object"`
+}
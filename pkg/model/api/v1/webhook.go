@@ -0,0 +1,213 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const (
+	DefaultWebhookMaxAttempts = 5
+	DefaultWebhookBackoff     = common.Duration(10 * time.Second)
+	// MaxWebhookAttempts is the upper bound of the attempts of a delivery, as the backoff is doubled after every retry.
+	MaxWebhookAttempts = 20
+	// MaxWebhookRetryDelay is the longest time to wait between two attempts, whatever the backoff and the attempts are.
+	MaxWebhookRetryDelay = 24 * time.Hour
+)
+
+// WebhookFilter selects the changes sent to the webhook. Every empty field matches everything.
+type WebhookFilter struct {
+	Kinds    []Kind   `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	Projects []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+	// Actions can only contain `create`, `update` and `delete`.
+	Actions []role.Action `json:"actions,omitempty" yaml:"actions,omitempty"`
+}
+
+func (f *WebhookFilter) validate() error {
+	for _, action := range f.Actions {
+		if action != role.CreateAction && action != role.UpdateAction && action != role.DeleteAction {
+			return fmt.Errorf("unsupported action %q in the filter, valid values are %q, %q and %q", action, role.CreateAction, role.UpdateAction, role.DeleteAction)
+		}
+	}
+	return nil
+}
+
+// WebhookRetryPolicy tells how a failed delivery is retried.
+type WebhookRetryPolicy struct {
+	// MaxAttempts is the maximum number of times a delivery is attempted, the first attempt included.
+	MaxAttempts int `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	// Backoff is the time to wait before the first retry. It is doubled after every retry, up to MaxWebhookRetryDelay.
+	Backoff common.Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+}
+
+// Delay returns the time to wait before the next attempt of a delivery that failed the given number of times.
+func (r *WebhookRetryPolicy) Delay(attempts int) time.Duration {
+	delay := time.Duration(r.Backoff)
+	for i := 1; i < attempts && delay < MaxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxWebhookRetryDelay)
+}
+
+func (r *WebhookRetryPolicy) validate() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("retryPolicy.maxAttempts cannot be negative")
+	}
+	if r.MaxAttempts > MaxWebhookAttempts {
+		return fmt.Errorf("retryPolicy.maxAttempts cannot be greater than %d", MaxWebhookAttempts)
+	}
+	if r.Backoff < 0 {
+		return fmt.Errorf("retryPolicy.backoff cannot be negative")
+	}
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if r.Backoff == 0 {
+		r.Backoff = DefaultWebhookBackoff
+	}
+	return nil
+}
+
+type WebhookSpec struct {
+	// URL is the endpoint receiving the changes.
+	URL *common.URL `json:"url" yaml:"url"`
+	// Secret is the name of the GlobalSecret used to call the URL.
+	// Its TLS configuration and its authentication (basic auth, authorization or OAuth) are applied to the requests.
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// SigningSecret is the name of the GlobalSecret containing the key used to sign the payloads with HMAC-SHA256.
+	// The key is the credentials of the authorization of the secret.
+	SigningSecret string             `json:"signingSecret,omitempty" yaml:"signingSecret,omitempty"`
+	Filter        WebhookFilter      `json:"filter,omitempty" yaml:"filter,omitempty"`
+	RetryPolicy   WebhookRetryPolicy `json:"retryPolicy,omitempty" yaml:"retryPolicy,omitempty"`
+}
+
+func (s *WebhookSpec) UnmarshalJSON(data []byte) error {
+	var tmp WebhookSpec
+	type plain WebhookSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *WebhookSpec) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp WebhookSpec
+	type plain WebhookSpec
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *WebhookSpec) validate() error {
+	if s.URL == nil || s.URL.IsNilOrEmpty() {
+		return fmt.Errorf("url cannot be empty")
+	}
+	if s.URL.Scheme != "http" && s.URL.Scheme != "https" {
+		return fmt.Errorf("the scheme of the url must be http or https")
+	}
+	if err := s.Filter.validate(); err != nil {
+		return err
+	}
+	return s.RetryPolicy.validate()
+}
+
+// Webhook is a URL notified every time a resource matching its filter is created, updated or deleted.
+type Webhook struct {
+	Kind     Kind        `json:"kind" yaml:"kind"`
+	Metadata Metadata    `json:"metadata" yaml:"metadata"`
+	Spec     WebhookSpec `json:"spec" yaml:"spec"`
+}
+
+func (w *Webhook) GetMetadata() modelAPI.Metadata {
+	return &w.Metadata
+}
+
+func (w *Webhook) GetKind() string {
+	return string(w.Kind)
+}
+
+func (w *Webhook) GetSpec() any {
+	return w.Spec
+}
+
+// WebhookPayload is the body of the request sent to a webhook.
+type WebhookPayload struct {
+	// Delivery is the ID of the delivery. It is the same for every attempt, so the receiver can ignore a payload already received.
+	Delivery string `json:"delivery" yaml:"delivery"`
+	Webhook  string `json:"webhook" yaml:"webhook"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	Timestamp time.Time   `json:"timestamp" yaml:"timestamp"`
+	Action    role.Action `json:"action" yaml:"action"`
+	// Event describes the change. It is the event sent by the watch API.
+	Event *WatchEvent `json:"event" yaml:"event"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the status of a delivery not sent yet, or failed but retried later.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	WebhookDeliverySuccess WebhookDeliveryStatus = "success"
+	// WebhookDeliveryFailed is the status of a delivery that failed on every attempt.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is the sending of a change to a webhook.
+type WebhookDelivery struct {
+	ID      string                `json:"id" yaml:"id"`
+	Webhook string                `json:"webhook" yaml:"webhook"`
+	Action  role.Action           `json:"action" yaml:"action"`
+	Kind    Kind                  `json:"kind" yaml:"kind"`
+	Project string                `json:"project,omitempty" yaml:"project,omitempty"`
+	Name    string                `json:"name" yaml:"name"`
+	Status  WebhookDeliveryStatus `json:"status" yaml:"status"`
+	// Attempts is the number of times the delivery has been attempted.
+	Attempts int `json:"attempts" yaml:"attempts"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	// NextAttemptAt is the time of the next attempt. It is only set when the delivery is pending.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" yaml:"nextAttemptAt,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty" yaml:"lastAttemptAt,omitempty"`
+	// StatusCode is the HTTP status code returned by the webhook on the last attempt.
+	StatusCode int `json:"statusCode,omitempty" yaml:"statusCode,omitempty"`
+	// Error is the reason of the failure of the last attempt.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Payload is the marshalled WebhookPayload. It is kept as is, so every attempt sends and signs the same body.
+	Payload json.RawMessage `json:"payload" yaml:"payload"`
+}
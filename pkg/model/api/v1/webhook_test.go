@@ -0,0 +1,111 @@
+// Copyright 2025 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalWebhook(t *testing.T) {
+	jason := `
+{
+  "kind": "Webhook",
+  "metadata": {
+    "name": "ci"
+  },
+  "spec": {
+    "url": "https://ci.example.com/hooks/perses",
+    "signingSecret": "ci-signing-key",
+    "filter": {
+      "kinds": ["Dashboard"],
+      "actions": ["create", "delete"]
+    }
+  }
+}
+`
+	result := Webhook{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), &result))
+	assert.Equal(t, WebhookSpec{
+		URL:           common.MustParseURL("https://ci.example.com/hooks/perses"),
+		SigningSecret: "ci-signing-key",
+		Filter: WebhookFilter{
+			Kinds:   []Kind{KindDashboard},
+			Actions: []role.Action{role.CreateAction, role.DeleteAction},
+		},
+		// The retry policy is set to its default value.
+		RetryPolicy: WebhookRetryPolicy{
+			MaxAttempts: DefaultWebhookMaxAttempts,
+			Backoff:     common.Duration(10 * time.Second),
+		},
+	}, result.Spec)
+}
+
+func TestUnmarshalWebhookError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "url cannot be empty",
+			jason: `{"kind": "Webhook", "metadata": {"name": "ci"}, "spec": {}}`,
+			err:   fmt.Errorf("url cannot be empty"),
+		},
+		{
+			title: "url must be http or https",
+			jason: `{"kind": "Webhook", "metadata": {"name": "ci"}, "spec": {"url": "ftp://ci.example.com"}}`,
+			err:   fmt.Errorf("the scheme of the url must be http or https"),
+		},
+		{
+			title: "read is not a change",
+			jason: `{"kind": "Webhook", "metadata": {"name": "ci"}, "spec": {"url": "https://ci.example.com", "filter": {"actions": ["read"]}}}`,
+			err:   fmt.Errorf("unsupported action \"read\" in the filter, valid values are \"create\", \"update\" and \"delete\""),
+		},
+		{
+			title: "negative attempts",
+			jason: `{"kind": "Webhook", "metadata": {"name": "ci"}, "spec": {"url": "https://ci.example.com", "retryPolicy": {"maxAttempts": -1}}}`,
+			err:   fmt.Errorf("retryPolicy.maxAttempts cannot be negative"),
+		},
+		{
+			title: "too many attempts",
+			jason: `{"kind": "Webhook", "metadata": {"name": "ci"}, "spec": {"url": "https://ci.example.com", "retryPolicy": {"maxAttempts": 100}}}`,
+			err:   fmt.Errorf("retryPolicy.maxAttempts cannot be greater than 20"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := Webhook{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), &result))
+		})
+	}
+}
+
+func TestWebhookRetryPolicyDelay(t *testing.T) {
+	policy := WebhookRetryPolicy{MaxAttempts: MaxWebhookAttempts, Backoff: common.Duration(10 * time.Second)}
+	assert.Equal(t, 10*time.Second, policy.Delay(1))
+	assert.Equal(t, 20*time.Second, policy.Delay(2))
+	assert.Equal(t, 80*time.Second, policy.Delay(4))
+	assert.Equal(t, MaxWebhookRetryDelay, policy.Delay(MaxWebhookAttempts))
+
+	// A long backoff doesn't overflow once doubled.
+	policy.Backoff = common.Duration(365 * 24 * time.Hour)
+	assert.Equal(t, MaxWebhookRetryDelay, policy.Delay(MaxWebhookAttempts))
+}
@@ -25,6 +25,7 @@ import {
   useIsAuthEnabled,
   useIsEphemeralDashboardEnabled,
   useIsExplorerEnabled,
+  useIsTrustedHeaderProviderEnabled,
 } from './context/Config';
 import { DarkModeContextProvider } from './context/DarkMode';
 import { NavHistoryProvider } from './context/DashboardNavHistory';
@@ -186,10 +187,17 @@ function Router(): ReactElement {
 function RequireAuth(): ReactElement | null {
   const isAuthEnabled = useIsAuthEnabled();
   const isAccessTokenExist = useIsAccessTokenExist();
+  const isTrustedHeaderProviderEnabled = useIsTrustedHeaderProviderEnabled();
   const location = useLocation();
   if (!isAuthEnabled || isAccessTokenExist) {
     return <Outlet />;
   }
+  if (isTrustedHeaderProviderEnabled) {
+    // The user is already authenticated by the reverse proxy, the session just has to be opened.
+    const redirectQueryString = buildRedirectQueryString(location.pathname + location.search);
+    window.location.href = `/api/auth/providers/trusted_header/login?${redirectQueryString}`;
+    return null;
+  }
   let to = SignInRoute;
   if (location.pathname !== '' && location.pathname !== '/') {
     to += `?${buildRedirectQueryString(location.pathname + location.search)}`;
@@ -11,11 +11,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { RoleBinding, roleBindingsEditorSchema } from '@perses-dev/core';
+import { RoleBinding, roleBindingsEditorSchema, Subject } from '@perses-dev/core';
 import { getSubmitText, getTitleAction } from '@perses-dev/plugin-system';
 import React, { ReactElement, useMemo, useState } from 'react';
 import { Controller, FormProvider, SubmitHandler, useFieldArray, useForm } from 'react-hook-form';
-import { Autocomplete, Box, Divider, IconButton, Stack, TextField, Typography } from '@mui/material';
+import { Autocomplete, Box, Divider, IconButton, MenuItem, Stack, TextField, Typography } from '@mui/material';
 import { DiscardChangesConfirmationDialog, FormActions } from '@perses-dev/components';
 import { zodResolver } from '@hookform/resolvers/zod';
 import PlusIcon from 'mdi-material-ui/Plus';
@@ -23,6 +23,12 @@ import MinusIcon from 'mdi-material-ui/Minus';
 import { useUserList } from '../../model/user-client';
 import { FormEditorProps } from '../form-drawers';
 
+const SUBJECT_LABELS: Record<Subject['kind'], string> = {
+  User: 'Username',
+  Group: 'Group',
+  ServiceAccount: 'Service account',
+};
+
 interface RoleBindingEditorFormProps extends FormEditorProps<RoleBinding> {
   roleSuggestions: string[];
 }
@@ -62,6 +68,13 @@ export function RoleBindingEditorForm({
   const usernames = useMemo(() => {
     return (users ?? []).map((user) => user.metadata.name);
   }, [users]);
+  // Groups are synced from the OAuth / OIDC providers, so the known ones are the groups of the existing users.
+  const groupNames = useMemo(() => {
+    return [...new Set((users ?? []).flatMap((user) => user.spec.groups ?? []))];
+  }, [users]);
+  const subjectOptions: Record<Subject['kind'], string[]> = useMemo(() => {
+    return { User: usernames, Group: groupNames, ServiceAccount: [] };
+  }, [usernames, groupNames]);
 
   // When user click on cancel, several possibilities:
   // - create action: ask for discard approval
@@ -159,6 +172,31 @@ export function RoleBindingEditorForm({
           {fields && fields.length > 0 ? (
             fields.map((field, index) => (
               <Stack key={field.id} direction="row" gap={1}>
+                <Controller
+                  control={form.control}
+                  name={`spec.subjects.${index}.kind`}
+                  render={({ field, fieldState }) => (
+                    <TextField
+                      select
+                      {...field}
+                      required
+                      label="Kind"
+                      sx={{ minWidth: 120 }}
+                      InputProps={{
+                        readOnly: action === 'read',
+                      }}
+                      error={!!fieldState.error}
+                      helperText={fieldState.error?.message}
+                      onChange={(event) => {
+                        field.onChange(event);
+                      }}
+                    >
+                      <MenuItem value="User">User</MenuItem>
+                      <MenuItem value="Group">Group</MenuItem>
+                      <MenuItem value="ServiceAccount">ServiceAccount</MenuItem>
+                    </TextField>
+                  )}
+                />
                 <Controller
                   control={form.control}
                   name={`spec.subjects.${index}.name`}
@@ -167,7 +205,7 @@ export function RoleBindingEditorForm({
                       {...field}
                       disablePortal
                       freeSolo
-                      options={usernames}
+                      options={subjectOptions[form.watch(`spec.subjects.${index}.kind`)]}
                       fullWidth
                       readOnly={action === 'read'}
                       onChange={(_, data) => {
@@ -176,7 +214,7 @@ export function RoleBindingEditorForm({
                       renderInput={(params) => (
                         <TextField
                           {...params}
-                          label="Username"
+                          label={SUBJECT_LABELS[form.watch(`spec.subjects.${index}.kind`)]}
                           required
                           error={!!fieldState.error}
                           helperText={fieldState.error?.message}
@@ -163,6 +163,13 @@ export function useIsNativeProviderEnabled(): boolean {
 export function useIsExternalProviderEnabled(): boolean {
   const { config } = useConfigContext();
   return (
-    !!config.security.authentication.providers.oidc?.length || !!config.security.authentication.providers.oauth?.length
+    !!config.security.authentication.providers.oidc?.length ||
+    !!config.security.authentication.providers.oauth?.length ||
+    !!config.security.authentication.providers.ldap?.length
   );
 }
+
+export function useIsTrustedHeaderProviderEnabled(): boolean {
+  const { config } = useConfigContext();
+  return !!config.security.authentication.providers.trusted_header;
+}
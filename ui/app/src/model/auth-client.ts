@@ -24,6 +24,7 @@ const authResource = 'auth';
 const jwtPayload = 'jwtPayload';
 const redirectQueryParam = 'rd';
 const cookieRefreshTime = 500;
+export const nativeProviderPath = 'native';
 
 export interface NativeAuthBody {
   login: string;
@@ -87,12 +88,18 @@ export function useAuthToken(): UseQueryResult<Payload | null> {
   });
 }
 
-export function useNativeAuthMutation(): UseMutationResult<void, Error, NativeAuthBody> {
+/**
+ * Mutation to login with a username and a password.
+ * @param providerPath path of the provider checking the password: the native provider by default, or `ldap/<slug_id>`.
+ */
+export function useNativeAuthMutation(
+  providerPath = nativeProviderPath
+): UseMutationResult<void, Error, NativeAuthBody> {
   const queryClient = useQueryClient();
   return useMutation<void, Error, NativeAuthBody>({
     mutationKey: [authResource],
     mutationFn: (body: NativeAuthBody) => {
-      return nativeAuth(body);
+      return nativeAuth(body, providerPath);
     },
     onSuccess: () => {
       return queryClient.invalidateQueries({ queryKey: [authResource] });
@@ -100,8 +107,8 @@ export function useNativeAuthMutation(): UseMutationResult<void, Error, NativeAu
   });
 }
 
-export function nativeAuth(body: NativeAuthBody): Promise<void> {
-  const url = buildURL({ resource: `${authResource}/providers/native/login`, apiURL: '/api' });
+export function nativeAuth(body: NativeAuthBody, providerPath = nativeProviderPath): Promise<void> {
+  const url = buildURL({ resource: `${authResource}/providers/${providerPath}/login`, apiURL: '/api' });
   return fetchJson<void>(url, {
     method: HTTPMethodPOST,
     headers: HTTPHeader,
@@ -46,7 +46,19 @@ export interface TLSConfig {
   max_version: string;
 }
 
+export interface DatabaseSQLPostgres {
+  schema?: string;
+  ssl_mode?: 'disable' | 'allow' | 'prefer' | 'require' | 'verify-ca' | 'verify-full';
+}
+
+export interface DatabaseSQLite {
+  path: string;
+  case_sensitive: boolean;
+}
+
 export interface DatabaseSQL {
+  driver?: 'mysql' | 'postgres';
+  postgres?: DatabaseSQLPostgres;
   tls_config?: TLSConfig;
   user?: string;
   password?: string;
@@ -78,6 +90,7 @@ export interface DatabaseSQL {
 export interface Database {
   file?: DatabaseFile;
   sql?: DatabaseSQL;
+  sqlite?: DatabaseSQLite;
 }
 
 export interface ProvisioningConfig {
@@ -110,10 +123,26 @@ export interface OauthProvider {
   user_infos_url: string;
 }
 
+export interface LDAPProvider {
+  slug_id: string;
+  name: string;
+  url: string;
+}
+
+export interface TrustedHeaderProvider {
+  allowed_cidrs: string[];
+  user_header: string;
+  email_header: string;
+  groups_header?: string;
+  groups_separator: string;
+}
+
 export interface AuthProviders {
   enable_native: boolean;
   oauth: OauthProvider[];
   oidc: OIDCProvider[];
+  ldap?: LDAPProvider[];
+  trusted_header?: TrustedHeaderProvider;
 }
 
 export interface AuthenticationConfig {
@@ -11,18 +11,27 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { Button, LinearProgress, Link, TextField, Typography } from '@mui/material';
+import { Button, LinearProgress, Link, MenuItem, TextField, Typography } from '@mui/material';
 import { ReactElement, useState } from 'react';
 import { useSnackbar } from '@perses-dev/components';
 import { Link as RouterLink, useNavigate } from 'react-router-dom';
-import { useNativeAuthMutation, useRedirectQueryParam } from '../../model/auth-client';
+import { nativeProviderPath, useNativeAuthMutation, useRedirectQueryParam } from '../../model/auth-client';
 import { SignUpRoute } from '../../model/route';
-import { useIsSignUpDisable } from '../../context/Config';
+import { useConfigContext, useIsSignUpDisable } from '../../context/Config';
 import { SignWrapper } from './SignWrapper';
 
 function SignInView(): ReactElement {
   const isSignUpDisable = useIsSignUpDisable();
-  const authMutation = useNativeAuthMutation();
+  const { config } = useConfigContext();
+  const passwordProviders = [
+    ...(config.security.authentication.providers.enable_native ? [{ path: nativeProviderPath, name: 'Perses' }] : []),
+    ...(config.security.authentication.providers.ldap ?? []).map((provider) => ({
+      path: `ldap/${provider.slug_id}`,
+      name: provider.name,
+    })),
+  ];
+  const [providerPath, setProviderPath] = useState<string>(passwordProviders[0]?.path ?? nativeProviderPath);
+  const authMutation = useNativeAuthMutation(providerPath);
   const navigate = useNavigate();
   const { successSnackbar, exceptionSnackbar } = useSnackbar();
   const [login, setLogin] = useState<string>('');
@@ -61,6 +70,15 @@ function SignInView(): ReactElement {
 
   return (
     <SignWrapper>
+      {passwordProviders.length > 1 && (
+        <TextField select label="Account" value={providerPath} onChange={(e) => setProviderPath(e.target.value)}>
+          {passwordProviders.map((provider) => (
+            <MenuItem key={provider.path} value={provider.path}>
+              {provider.name}
+            </MenuItem>
+          ))}
+        </TextField>
+      )}
       <TextField label="Username" required onChange={(e) => setLogin(e.target.value)} onKeyPress={handleKeypress} />
       <TextField
         type="password"
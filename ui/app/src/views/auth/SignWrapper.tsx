@@ -136,7 +136,10 @@ export function SignWrapper(props: { children: ReactNode }): ReactElement {
     button: computeSocialButtonFromURL(theme, provider.issuer),
   }));
   const socialProviders = [...oidcProviders, ...oauthProviders];
-  const nativeProviderIsEnabled = config.config?.security?.authentication?.providers?.enable_native;
+  // The username/password form is used by the native provider and by the LDAP providers.
+  const nativeProviderIsEnabled =
+    config.config?.security?.authentication?.providers?.enable_native ||
+    !!config.config?.security?.authentication?.providers?.ldap?.length;
   const path = useRedirectQueryParam();
 
   return (
@@ -25,8 +25,10 @@ export type Kind =
   | 'Role'
   | 'RoleBinding'
   | 'Secret'
+  | 'ServiceAccount'
   | 'User'
-  | 'Variable';
+  | 'Variable'
+  | 'Webhook';
 
 export const KINDS: Kind[] = [
   'Dashboard',
@@ -42,6 +44,8 @@ export const KINDS: Kind[] = [
   'Role',
   'RoleBinding',
   'Secret',
+  'ServiceAccount',
   'User',
   'Variable',
+  'Webhook',
 ];
@@ -22,6 +22,8 @@ export interface Metadata {
   createdAt?: string;
   updatedAt?: string;
   version?: number;
+  labels?: Record<string, string>;
+  annotations?: Record<string, string>;
 }
 
 export interface ProjectMetadata extends Metadata {
@@ -14,7 +14,7 @@
 import { Metadata, ProjectMetadata } from './resource';
 
 export interface Subject {
-  kind: 'User';
+  kind: 'User' | 'Group' | 'ServiceAccount';
   name: string;
 }
 
@@ -14,9 +14,9 @@
 import { Metadata, ProjectMetadata } from './resource';
 import { Kind } from './kind';
 
-export type Action = 'create' | 'read' | 'update' | 'delete' | '*';
-export const ACTIONS = ['*', 'create', 'read', 'update', 'delete'];
-export type Scope = Kind | '*';
+export type Action = 'create' | 'read' | 'update' | 'delete' | 'query' | '*';
+export const ACTIONS = ['*', 'create', 'read', 'update', 'delete', 'query'];
+export type Scope = Kind | 'Audit' | '*';
 export const PROJECT_SCOPES = [
   '*',
   'Dashboard',
@@ -31,17 +31,22 @@ export const PROJECT_SCOPES = [
 ];
 
 export const GLOBAL_SCOPES = [
+  'Audit',
   'GlobalDatasource',
   'GlobalRole',
   'GlobalRoleBinding',
   'GlobalSecret',
   'GlobalVariable',
+  'ServiceAccount',
   'User',
+  'Webhook',
 ];
 
 export interface Permission {
   actions: Action[];
   scopes: Scope[];
+  // Glob patterns restricting the permission to the resources with a matching name.
+  resourceNames?: string[];
 }
 
 export interface RoleSpec {
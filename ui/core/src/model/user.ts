@@ -28,6 +28,8 @@ export interface UserSpec {
   lastName?: string;
   nativeProvider?: NativeProvider;
   oauthProviders?: OAuthProvider[];
+  // synced from the OAuth / OIDC provider, it cannot be set through the API
+  groups?: string[];
 }
 
 export interface UserResource {
@@ -21,6 +21,8 @@ export const nameSchema = z
 
 export const metadataSchema = z.object({
   name: nameSchema,
+  labels: z.record(z.string(), z.string()).optional(),
+  annotations: z.record(z.string(), z.string()).optional(),
 });
 
 export const projectMetadataSchema = metadataSchema.extend({
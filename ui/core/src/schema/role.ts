@@ -17,11 +17,12 @@ import { metadataSchema, projectMetadataSchema } from './metadata';
 
 export const permissionSchema: z.ZodSchema<Permission> = z.object({
   // TODO: use SCOPE & ACTIONS constants
-  actions: z.array(z.enum(['*', 'create', 'read', 'update', 'delete'])).nonempty('Must contains at least 1 action'),
+  actions: z.array(z.enum(['*', 'create', 'read', 'update', 'delete', 'query'])).nonempty('Must contains at least 1 action'),
   scopes: z
     .array(
       z.enum([
         '*',
+        'Audit',
         'Dashboard',
         'Datasource',
         'EphemeralDashboard',
@@ -35,11 +36,14 @@ export const permissionSchema: z.ZodSchema<Permission> = z.object({
         'Role',
         'RoleBinding',
         'Secret',
+        'ServiceAccount',
         'User',
         'Variable',
+        'Webhook',
       ])
     )
     .nonempty('Must contains at least 1 scope'), // TODO: limit project role
+  resourceNames: z.array(z.string().min(1, 'Must not be empty')).optional(),
 });
 
 export const roleSpecSchema: z.ZodSchema<RoleSpec> = z.object({
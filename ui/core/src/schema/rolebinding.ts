@@ -16,7 +16,7 @@ import { RoleBinding, RoleBindingSpec, Subject } from '../model';
 import { nameSchema, metadataSchema, projectMetadataSchema } from './metadata';
 
 export const subjectSchema: z.ZodSchema<Subject> = z.object({
-  kind: z.enum(['User']),
+  kind: z.enum(['User', 'Group', 'ServiceAccount']),
   name: nameSchema,
 });
 